@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"GoJanus/pkg/bench"
+	"GoJanus/pkg/wire"
+)
+
+// runBench implements `janus bench`: load a server and report throughput, latency and errors
+func runBench(args []string) int {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	socketPath := flags.String("socket", "", "Unix socket path of the server")
+	concurrency := flags.Int("concurrency", 1, "Workers sending requests back to back")
+	duration := flags.Duration("duration", 0, "How long to send (default 10s unless --requests is set)")
+	requests := flags.Int("requests", 0, "Total requests to send; 0 sends until --duration ends")
+	mixSpec := flags.String("mix", "ping", "Weighted requests, e.g. ping=3,echo=1")
+	mixFile := flags.String("mix-file", "", "YAML or JSON list of {request, weight, args}; overrides --mix")
+	payloadSpec := flags.String("payload", "", "Comma-separated payload sizes in bytes, e.g. 64,1k,16k, used in turn")
+	payloadArg := flags.String("payload-arg", "message", "Argument that carries the payload")
+	timeout := flags.Duration("timeout", 5*time.Second, "How long to wait for each response")
+	sharedReply := flags.Bool("shared-reply", false, "Receive all responses on one shared reply socket")
+	seed := flags.Int64("seed", 0, "Seed for the request mix; 0 picks a random seed")
+	encoding := flags.String("encoding", "json", "Request encoding: json or cbor")
+	format := flags.String("format", "text", "Output format: text or json")
+	output := flags.String("output", "", "Write the report to this file instead of stdout")
+	verbose := flags.Bool("verbose", false, "Show client library logs")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *socketPath == "" || flags.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: janus bench --socket path [--concurrency n] [--duration d | --requests n] [--mix ping=3,echo=1 | --mix-file file] [--payload 64,1k] [--shared-reply] [--encoding json|cbor] [--format text|json] [--output file]")
+		return exitUsage
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Unsupported format: %s (supported: text, json)\n", *format)
+		return exitUsage
+	}
+	if _, err := wire.ParseEncoding(*encoding); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return exitUsage
+	}
+
+	config := bench.Config{
+		SocketPath:  *socketPath,
+		Concurrency: *concurrency,
+		Duration:    *duration,
+		Requests:    *requests,
+		Timeout:     *timeout,
+		PayloadArg:  *payloadArg,
+		SharedReply: *sharedReply,
+		Seed:        *seed,
+		Encoding:    wire.Encoding(*encoding),
+	}
+	var err error
+	if *mixFile != "" {
+		config.Mix, err = bench.LoadMix(*mixFile)
+	} else {
+		config.Mix, err = bench.ParseMix(*mixSpec)
+	}
+	if err == nil {
+		config.PayloadSizes, err = bench.ParseSizes(*payloadSpec)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return exitUsage
+	}
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		if _, ok := <-signals; ok {
+			cancel()
+		}
+	}()
+
+	report, err := bench.Run(ctx, config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return exitError
+	}
+	if report.Succeeded+report.Failed == 0 && report.FirstSendError != "" {
+		fmt.Fprintf(os.Stderr, "No request reached the server: %s\n", report.FirstSendError)
+		return exitError
+	}
+
+	var data []byte
+	if *format == "json" {
+		data, err = json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to serialize bench report: %v\n", err)
+			return exitError
+		}
+		data = append(data, '\n')
+	} else {
+		data = []byte(formatBench(report))
+	}
+	if err := writeOutput(*output, data); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write report: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// formatBench renders a report as text
+func formatBench(report *bench.Report) string {
+	var b strings.Builder
+	mode := "a reply socket per request"
+	if report.SharedReply {
+		mode = "a shared reply socket"
+	}
+	fmt.Fprintf(&b, "Target:      %s (concurrency %d, %s, %s)\n", report.SocketPath, report.Concurrency, mode, report.Encoding)
+	fmt.Fprintf(&b, "Duration:    %v\n", report.Duration().Round(time.Millisecond))
+	writeBenchStats(&b, report.Stats)
+	if report.FirstSendError != "" {
+		fmt.Fprintf(&b, "First send error: %s\n", report.FirstSendError)
+	}
+
+	if len(report.Requests) > 1 {
+		fmt.Fprintf(&b, "\nBy request:\n")
+		for _, name := range sortedStatsKeys(report.Requests) {
+			writeBenchRow(&b, name, report.Requests[name])
+		}
+	}
+	if len(report.PayloadStats) > 0 {
+		fmt.Fprintf(&b, "\nBy payload size:\n")
+		sizes := sortedStatsKeys(report.PayloadStats)
+		sort.SliceStable(sizes, func(i, j int) bool { return len(sizes[i]) < len(sizes[j]) })
+		for _, size := range sizes {
+			writeBenchRow(&b, size+" B", report.PayloadStats[size])
+		}
+	}
+	return b.String()
+}
+
+// writeBenchStats writes the totals, latency and error breakdown
+func writeBenchStats(b *strings.Builder, stats bench.Stats) {
+	fmt.Fprintf(b, "Requests:    %d sent, %d succeeded, %d error responses, %d timeouts, %d send errors\n",
+		stats.Sent, stats.Succeeded, stats.Failed, stats.Timeouts, stats.SendErrors)
+	fmt.Fprintf(b, "Throughput:  %.1f responses/s\n", stats.Throughput)
+	latency := stats.Latency
+	fmt.Fprintf(b, "Latency:     min %.3fms  mean %.3fms  p50 %.3fms  p90 %.3fms  p95 %.3fms  p99 %.3fms  p99.9 %.3fms  max %.3fms\n",
+		latency.MinMs, latency.MeanMs, latency.P50Ms, latency.P90Ms, latency.P95Ms, latency.P99Ms, latency.P999Ms, latency.MaxMs)
+	if len(stats.Errors) > 0 {
+		fmt.Fprintf(b, "Errors:\n")
+		for _, name := range sortedCountKeys(stats.Errors) {
+			fmt.Fprintf(b, "  %-28s %d\n", name, stats.Errors[name])
+		}
+	}
+}
+
+// writeBenchRow writes a one-line summary of a breakdown entry
+func writeBenchRow(b *strings.Builder, label string, stats *bench.Stats) {
+	fmt.Fprintf(b, "  %-20s %6d sent %6d ok %5d errors %5d unanswered  %8.1f/s  p50 %.3fms  p99 %.3fms\n",
+		label, stats.Sent, stats.Succeeded, stats.Failed, stats.Timeouts+stats.SendErrors, stats.Throughput, stats.Latency.P50Ms, stats.Latency.P99Ms)
+}
+
+func sortedStatsKeys(stats map[string]*bench.Stats) []string {
+	keys := make([]string, 0, len(stats))
+	for key := range stats {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedCountKeys orders error names by count, most frequent first
+func sortedCountKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"GoJanus/pkg/call"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+)
+
+// runCall implements `janus call`: send one request and exit with a code derived from the response
+func runCall(args []string) int {
+	flags := flag.NewFlagSet("call", flag.ContinueOnError)
+	socketPath := flags.String("socket", "", "Unix socket path of the server")
+	timeout := flags.Duration("timeout", 30*time.Second, "Timeout for each attempt")
+	retries := flags.Int("retries", 0, "Retries after timeouts and transient errors")
+	retryDelay := flags.Duration("retry-delay", 200*time.Millisecond, "Delay before the first retry, doubled for each further retry")
+	output := flags.String("output", "pretty", "Output: pretty, raw (compact response), result or none")
+	field := flags.String("field", "", "Print only this field of the response, e.g. .result.items[0].name")
+	noValidate := flags.Bool("no-validate", false, "Send without fetching the manifest or validating arguments")
+	verbose := flags.Bool("verbose", false, "Show client library logs")
+	words, err := parseInterspersed(flags, args)
+	if err != nil {
+		return exitUsage
+	}
+	if *timeout < 100*time.Millisecond {
+		fmt.Fprintln(os.Stderr, "--timeout must be at least 100ms")
+		return exitUsage
+	}
+	if *socketPath == "" || len(words) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: janus call --socket path <request> [key=value] [key:=json] [{json}] [@file|@-] [--timeout d] [--retries n] [--output pretty|raw|result|none] [--field path]")
+		return exitUsage
+	}
+	switch *output {
+	case "pretty", "raw", "result", "none":
+	default:
+		fmt.Fprintf(os.Stderr, "Unsupported output: %s (supported: pretty, raw, result, none)\n", *output)
+		return exitUsage
+	}
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	requestArgs, err := call.ParseArgs(words[1:], os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return exitUsage
+	}
+
+	config := protocol.DefaultJanusClientConfig()
+	config.EnableValidation = !*noValidate
+	// The datagram read deadline bounds each attempt too, so it follows --timeout
+	config.DatagramTimeout = *timeout
+	client, err := protocol.New(*socketPath, config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create client: %v\n", err)
+		return exitError
+	}
+	defer client.Close()
+
+	response, err := call.Send(context.Background(), client, words[0], requestArgs, call.Options{
+		Timeout:    *timeout,
+		Retries:    *retries,
+		RetryDelay: *retryDelay,
+		OnRetry: func(attempt int, err error) {
+			fmt.Fprintf(os.Stderr, "Attempt %d failed: %v; retrying\n", attempt, err)
+		},
+	})
+	code := call.Outcome(response, err)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return code
+	}
+
+	if *field != "" {
+		value, err := call.ExtractField(response, *field)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return exitUsage
+		}
+		if text, err := call.FormatField(value); err == nil {
+			fmt.Println(text)
+		}
+		return code
+	}
+	printCallResponse(response, *output)
+	return code
+}
+
+// printCallResponse writes the response in the selected output mode
+// In result mode an error response is written to stderr instead
+func printCallResponse(response *models.JanusResponse, output string) {
+	var value interface{} = response
+	var target io.Writer = os.Stdout
+	if output == "result" {
+		value = response.Result
+		if response.Error != nil {
+			value, target = response.Error, os.Stderr
+		}
+	}
+
+	var data []byte
+	var err error
+	switch output {
+	case "none":
+		return
+	case "raw":
+		data, err = json.Marshal(value)
+	default:
+		data, err = json.MarshalIndent(value, "", "  ")
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to serialize response: %v\n", err)
+		return
+	}
+	fmt.Fprintln(target, string(data))
+}
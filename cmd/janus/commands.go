@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	manifestpkg "GoJanus/pkg/manifest"
+)
+
+// subcommand runs a named CLI subcommand and returns the process exit code
+type subcommand struct {
+	summary string
+	run     func(args []string) int
+}
+
+// subcommands maps `janus <name>` to its implementation
+// Invocations without a known subcommand fall back to the --listen/--send-to flags
+var subcommands = map[string]subcommand{
+	"bench":       {summary: "Load a server and report throughput, latency and errors", run: runBench},
+	"call":        {summary: "Send one request and exit with a code derived from the response", run: runCall},
+	"conformance": {summary: "Check servers against the protocol case catalogue", run: runConformance},
+	"docs":        {summary: "Render Markdown or HTML documentation from a manifest", run: runDocs},
+	"gen":         {summary: "Generate language bindings from a manifest", run: runGen},
+	"manifest":    {summary: "Compare, lint and bundle manifests (diff, lint, bundle)", run: runManifest},
+	"mock":        {summary: "Serve synthetic responses for every request in a manifest", run: runMock},
+	"openrpc":     {summary: "Generate an OpenRPC document from a manifest", run: runOpenRPC},
+	"proxy":       {summary: "Relay and log datagrams between clients and a server", run: runProxy},
+	"replay":      {summary: "Resend captured requests and diff the responses", run: runReplay},
+	"schema":      {summary: "Convert manifests to and from JSON Schema", run: runSchema},
+	"shell":       {summary: "Interactive client with completion and history", run: runShell},
+}
+
+// dispatchSubcommand runs the subcommand named by args[0] if there is one
+func dispatchSubcommand(args []string) (int, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+
+	if args[0] == "help" {
+		printSubcommands()
+		return 0, true
+	}
+
+	cmd, exists := subcommands[args[0]]
+	if !exists {
+		return 0, false
+	}
+	return cmd.run(args[1:]), true
+}
+
+// printSubcommands lists the available subcommands
+func printSubcommands() {
+	names := make([]string, 0, len(subcommands))
+	for name := range subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Usage: janus <command> [options]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, subcommands[name].summary)
+	}
+	fmt.Fprintln(os.Stderr, "\nWithout a command, janus runs in --listen / --send-to mode (see janus --help)")
+}
+
+// loadManifestFile parses a JSON or YAML manifest file
+func loadManifestFile(path string) (*manifestpkg.Manifest, error) {
+	if path == "" {
+		return nil, fmt.Errorf("--manifest is required")
+	}
+	return manifestpkg.NewManifestParser().ParseFromFile(path)
+}
+
+// writeOutput writes data to the named file, or stdout when path is empty or "-"
+func writeOutput(path string, data []byte) error {
+	if path == "" || path == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// parseInterspersed parses flags that may appear before, between or after positional arguments
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// Exit codes shared by subcommands
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitCheckFailed = 3 // A check such as manifest diff or lint found problems
+)
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"GoJanus/pkg/conformance"
+)
+
+// runConformance implements `janus conformance`: run the protocol case catalogue against one or more servers
+func runConformance(args []string) int {
+	flags := flag.NewFlagSet("conformance", flag.ContinueOnError)
+	sockets := flags.String("socket", "", "Comma-separated Unix socket paths of the servers to check")
+	cases := flags.String("case", "", "Comma-separated case names or categories to run (glob patterns); empty runs all")
+	timeout := flags.Duration("timeout", 2*time.Second, "How long to wait for each response")
+	maxMessageSize := flags.Int("max-message-size", 64*1024, "The servers' request size limit, exceeded by oversized_payload")
+	format := flags.String("format", "text", "Output format: text or json")
+	list := flags.Bool("list", false, "List the cases and exit")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *list {
+		for _, c := range conformance.Cases() {
+			fmt.Printf("%-24s %-9s %s\n", c.Name, c.Category, c.Description)
+		}
+		return exitOK
+	}
+	socketPaths := splitList(*sockets)
+	if len(socketPaths) == 0 || flags.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: janus conformance --socket path[,path...] [--case names] [--timeout d] [--format text|json] [--list]")
+		return exitUsage
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Unsupported format: %s (supported: text, json)\n", *format)
+		return exitUsage
+	}
+	log.SetOutput(io.Discard)
+
+	options := conformance.Options{Timeout: *timeout, MaxMessageSize: *maxMessageSize, Cases: splitList(*cases)}
+	var reports []*conformance.Report
+	for _, socketPath := range socketPaths {
+		report, err := conformance.Run(socketPath, options)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return exitUsage
+		}
+		reports = append(reports, report)
+	}
+
+	if *format == "json" {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to serialize conformance reports: %v\n", err)
+			return exitError
+		}
+		fmt.Println(string(data))
+	} else {
+		printConformance(reports)
+	}
+
+	for _, report := range reports {
+		if !report.OK() {
+			return exitCheckFailed
+		}
+	}
+	return exitOK
+}
+
+// printConformance writes a matrix of cases by server, then the details of failures and skips
+func printConformance(reports []*conformance.Report) {
+	labels := conformanceLabels(reports)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CASE\tCATEGORY\t%s\n", strings.Join(labels, "\t"))
+	for i, result := range reports[0].Results {
+		row := []string{result.Name, result.Category}
+		for _, report := range reports {
+			status := string(report.Results[i].Status)
+			if report.Results[i].Status == conformance.Fail {
+				status = "FAIL"
+			}
+			row = append(row, status)
+		}
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	writer.Flush()
+
+	for i, report := range reports {
+		for _, result := range report.Results {
+			if result.Status != conformance.Pass && result.Detail != "" {
+				fmt.Printf("\n%s %s (%s): %s", labels[i], result.Name, result.Status, result.Detail)
+			}
+		}
+	}
+	fmt.Println()
+	for i, report := range reports {
+		fmt.Printf("\n%s: %d passed, %d failed, %d skipped", labels[i], report.Passed, report.Failed, report.Skipped)
+	}
+	fmt.Println()
+}
+
+// conformanceLabels names each server by its implementation, or its socket path when that is unknown or ambiguous
+func conformanceLabels(reports []*conformance.Report) []string {
+	counts := map[string]int{}
+	for _, report := range reports {
+		counts[report.Implementation]++
+	}
+	labels := make([]string, len(reports))
+	for i, report := range reports {
+		labels[i] = report.Implementation
+		if labels[i] == "" || counts[labels[i]] > 1 {
+			labels[i] = report.SocketPath
+		}
+	}
+	return labels
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"GoJanus/pkg/docs"
+)
+
+// runDocs implements `janus docs`
+func runDocs(args []string) int {
+	flags := flag.NewFlagSet("docs", flag.ContinueOnError)
+	manifestPath := flags.String("manifest", "", "Manifest file (JSON or YAML)")
+	format := flags.String("format", "", "Output format: markdown or html (default from --out extension, else markdown)")
+	outPath := flags.String("out", "", "Output file (default stdout)")
+	noBuiltins := flags.Bool("no-builtins", false, "Omit the built-in requests")
+	noErrorCodes := flags.Bool("no-error-codes", false, "Omit the error code reference")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(*outPath)) {
+		case ".html", ".htm":
+			*format = "html"
+		default:
+			*format = "markdown"
+		}
+	}
+
+	render := docs.RenderMarkdown
+	switch strings.ToLower(*format) {
+	case "markdown", "md":
+	case "html":
+		render = docs.RenderHTML
+	default:
+		fmt.Fprintf(os.Stderr, "Unsupported format: %s (supported: markdown, html)\n", *format)
+		return exitUsage
+	}
+
+	manifest, err := loadManifestFile(*manifestPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load manifest: %v\n", err)
+		return exitError
+	}
+
+	output, err := render(manifest, docs.Options{
+		IncludeBuiltins:   !*noBuiltins,
+		IncludeErrorCodes: !*noErrorCodes,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to render documentation: %v\n", err)
+		return exitError
+	}
+	return writeSchemaOutput(*outPath, output)
+}
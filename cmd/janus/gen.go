@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"GoJanus/pkg/codegen"
+)
+
+// runGen implements `janus gen <language>`
+func runGen(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: janus gen go --manifest <file> [--package name] [--import-path path] [--out file]")
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "go":
+		return runGenGo(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "Unsupported language: %s (supported: go)\n", args[0])
+		return exitUsage
+	}
+}
+
+// runGenGo implements `janus gen go`
+func runGenGo(args []string) int {
+	flags := flag.NewFlagSet("gen go", flag.ContinueOnError)
+	manifestPath := flags.String("manifest", "", "Manifest file (JSON or YAML)")
+	packageName := flags.String("package", "api", "Package name of the generated file")
+	importPath := flags.String("import-path", "GoJanus", "Import path of the GoJanus module")
+	outPath := flags.String("out", "", "Output file (default stdout)")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	manifest, err := loadManifestFile(*manifestPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load manifest: %v\n", err)
+		return exitError
+	}
+
+	source, err := codegen.GenerateGo(manifest, codegen.GoOptions{
+		PackageName: *packageName,
+		ImportPath:  *importPath,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate Go code: %v\n", err)
+		return exitError
+	}
+
+	if err := writeOutput(*outPath, source); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write output: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -17,9 +17,15 @@ import (
 	"GoJanus/pkg/models"
 	"GoJanus/pkg/protocol"
 	manifestpkg "GoJanus/pkg/manifest"
+	"GoJanus/pkg/wire"
 )
 
 func main() {
+	// Subcommands (janus gen, ...) take precedence over the legacy flag interface
+	if code, handled := dispatchSubcommand(os.Args[1:]); handled {
+		os.Exit(code)
+	}
+	
 	var (
 		socketPath = flag.String("socket", "/tmp/go-janus.sock", "Unix socket path")
 		listen     = flag.Bool("listen", false, "Listen for datagrams on socket")
@@ -33,13 +39,9 @@ func main() {
 	// Load Manifest if provided
 	var manifest *manifestpkg.Manifest
 	if *manifestPath != "" {
-		manifestData, err := os.ReadFile(*manifestPath)
-		if err != nil {
-			log.Fatalf("Failed to read Manifest: %v", err)
-		}
-		
+		// Parse from the file so relative imports resolve against its directory
 		parser := manifestpkg.NewManifestParser()
-		loadedManifest, err := parser.ParseJSON(manifestData)
+		loadedManifest, err := parser.ParseFromFile(*manifestPath)
 		manifest = loadedManifest
 		if err != nil {
 			log.Fatalf("Failed to parse Manifest: %v", err)
@@ -111,8 +113,8 @@ func listenForDatagrams(socketPath string, manifest *manifestpkg.Manifest) {
 			continue
 		}
 
-		var cmd models.JanusRequest
-		if err := json.Unmarshal(buffer[:n], &cmd); err != nil {
+		cmd, err := wire.DecodeRequest(buffer[:n])
+		if err != nil {
 			log.Printf("Failed to parse datagram: %v", err)
 			continue
 		}
@@ -125,7 +127,7 @@ func listenForDatagrams(socketPath string, manifest *manifestpkg.Manifest) {
 
 		// Send response via reply_to if manifestified
 		if cmd.ReplyTo != nil && *cmd.ReplyTo != "" {
-			sendResponse(cmd.ID, cmd.Request, cmd.Args, *cmd.ReplyTo, manifest, serverState, clientID)
+			sendResponse(cmd.ID, cmd.Request, cmd.Args, *cmd.ReplyTo, wire.DetectEncoding(buffer[:n]), manifest, serverState, clientID)
 		}
 	}
 }
@@ -412,7 +414,7 @@ func getBuiltinRequestHandler(request string, manifest *manifestpkg.Manifest, se
 	}
 }
 
-func sendResponse(cmdID, request string, args map[string]interface{}, replyTo string, manifest *manifestpkg.Manifest, serverState *ServerState, clientID string) {
+func sendResponse(cmdID, request string, args map[string]interface{}, replyTo string, encoding wire.Encoding, manifest *manifestpkg.Manifest, serverState *ServerState, clientID string) {
 	var result interface{} // Support unwrapped results (any JSON value)
 	var success = true
 	var errorMsg *models.JSONRPCError
@@ -455,9 +457,9 @@ func sendResponse(cmdID, request string, args map[string]interface{}, replyTo st
 		response = models.NewErrorResponse(cmdID, errorMsg)
 	}
 
-	responseData, err := json.Marshal(response)
+	responseData, err := wire.EncodeResponseAs(response, encoding)
 	if err != nil {
-		log.Printf("Failed to marshal response: %v", err)
+		log.Printf("Failed to encode response: %v", err)
 		return
 	}
 
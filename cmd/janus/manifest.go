@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	manifestpkg "GoJanus/pkg/manifest"
+)
+
+// runManifest implements `janus manifest <command>`
+func runManifest(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: janus manifest diff <old> <new> [--format text|json] [--allow-breaking]")
+		fmt.Fprintln(os.Stderr, "       janus manifest lint <file> [--config file] [--disable rules] [--format text|json] [--strict]")
+		fmt.Fprintln(os.Stderr, "       janus manifest bundle <file> [--lib-path dirs] [--format json|yaml] [--output file]")
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "diff":
+		return runManifestDiff(args[1:])
+	case "lint":
+		return runManifestLint(args[1:])
+	case "bundle":
+		return runManifestBundle(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown manifest command: %s (supported: diff, lint, bundle)\n", args[0])
+		return exitUsage
+	}
+}
+
+// runManifestDiff compares two manifests and fails when the change is breaking
+func runManifestDiff(args []string) int {
+	flags := flag.NewFlagSet("manifest diff", flag.ContinueOnError)
+	format := flags.String("format", "text", "Output format: text or json")
+	allowBreaking := flags.Bool("allow-breaking", false, "Exit 0 even when breaking changes are found")
+	files, err := parseInterspersed(flags, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(files) != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: janus manifest diff <old> <new> [--format text|json] [--allow-breaking]")
+		return exitUsage
+	}
+
+	oldManifest, err := loadManifestFile(files[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load %s: %v\n", files[0], err)
+		return exitError
+	}
+	newManifest, err := loadManifestFile(files[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load %s: %v\n", files[1], err)
+		return exitError
+	}
+
+	result := manifestpkg.Diff(oldManifest, newManifest)
+
+	switch *format {
+	case "json":
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to serialize diff: %v\n", err)
+			return exitError
+		}
+		fmt.Println(string(data))
+	case "text":
+		printDiff(result)
+	default:
+		fmt.Fprintf(os.Stderr, "Unsupported format: %s (supported: text, json)\n", *format)
+		return exitUsage
+	}
+
+	if result.Breaking && !*allowBreaking {
+		return exitCheckFailed
+	}
+	return exitOK
+}
+
+// printDiff writes a human-readable change list
+func printDiff(result *manifestpkg.DiffResult) {
+	fmt.Println(result.Summary())
+	for _, change := range result.Changes {
+		marker := "  "
+		if change.Severity == manifestpkg.ChangeBreaking {
+			marker = "! "
+		}
+		fmt.Printf("%s%-10s %s: %s\n", marker, change.Severity, change.Path, change.Message)
+	}
+}
+
+// runManifestLint checks a manifest against the lint rules
+func runManifestLint(args []string) int {
+	flags := flag.NewFlagSet("manifest lint", flag.ContinueOnError)
+	configPath := flags.String("config", "", "Lint configuration file (JSON or YAML) overriding rule severities")
+	disable := flags.String("disable", "", "Comma-separated rules to disable")
+	format := flags.String("format", "text", "Output format: text or json")
+	strict := flags.Bool("strict", false, "Fail on warnings as well as errors")
+	listRules := flags.Bool("list-rules", false, "List the available rules and exit")
+	files, err := parseInterspersed(flags, args)
+	if err != nil {
+		return exitUsage
+	}
+
+	if *listRules {
+		for _, rule := range manifestpkg.LintRules() {
+			fmt.Printf("%-22s %-8s %s\n", rule.Name, rule.Severity, rule.Description)
+		}
+		return exitOK
+	}
+
+	if len(files) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: janus manifest lint <file> [--config file] [--disable rules] [--format text|json] [--strict]")
+		return exitUsage
+	}
+
+	config := &manifestpkg.LintConfig{}
+	if *configPath != "" {
+		data, err := os.ReadFile(*configPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read lint config: %v\n", err)
+			return exitError
+		}
+		if config, err = manifestpkg.ParseLintConfig(data); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid lint config: %v\n", err)
+			return exitUsage
+		}
+	}
+	if *disable != "" {
+		if config.Rules == nil {
+			config.Rules = make(map[string]manifestpkg.LintSeverity)
+		}
+		for _, rule := range strings.Split(*disable, ",") {
+			config.Rules[strings.TrimSpace(rule)] = manifestpkg.LintOff
+		}
+	}
+	if err := config.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid lint config: %v\n", err)
+		return exitUsage
+	}
+
+	// Decode without validation so structural problems and unresolved imports are reported as lint issues
+	report, err := manifestpkg.NewManifestParser().LintFile(files[0], config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load %s: %v\n", files[0], err)
+		return exitError
+	}
+
+	switch *format {
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to serialize report: %v\n", err)
+			return exitError
+		}
+		fmt.Println(string(data))
+	case "text":
+		for _, issue := range report.Issues {
+			fmt.Println(issue)
+		}
+		fmt.Printf("%d error(s), %d warning(s)\n", report.Count(manifestpkg.LintError), report.Count(manifestpkg.LintWarning))
+	default:
+		fmt.Fprintf(os.Stderr, "Unsupported format: %s (supported: text, json)\n", *format)
+		return exitUsage
+	}
+
+	if report.HasErrors() || (*strict && report.Count(manifestpkg.LintWarning) > 0) {
+		return exitCheckFailed
+	}
+	return exitOK
+}
+
+// runManifestBundle resolves a manifest's imports and writes the self-contained result
+func runManifestBundle(args []string) int {
+	flags := flag.NewFlagSet("manifest bundle", flag.ContinueOnError)
+	libPath := flags.String("lib-path", "", "Library search directories, separated like PATH, searched before $"+manifestpkg.LibraryPathEnv)
+	format := flags.String("format", "json", "Output format: json or yaml")
+	output := flags.String("output", "", "Output file (default stdout)")
+	files, err := parseInterspersed(flags, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(files) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: janus manifest bundle <file> [--lib-path dirs] [--format json|yaml] [--output file]")
+		return exitUsage
+	}
+
+	parser := manifestpkg.NewManifestParser()
+	parser.LibraryPaths = filepath.SplitList(*libPath)
+	manifest, err := parser.ParseFromFile(files[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load %s: %v\n", files[0], err)
+		return exitError
+	}
+
+	var data []byte
+	switch *format {
+	case "json":
+		data, err = parser.SerializeToJSON(manifest)
+		data = append(data, '\n')
+	case "yaml":
+		data, err = parser.SerializeToYAML(manifest)
+	default:
+		fmt.Fprintf(os.Stderr, "Unsupported format: %s (supported: json, yaml)\n", *format)
+		return exitUsage
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to serialize manifest: %v\n", err)
+		return exitError
+	}
+
+	if err := writeOutput(*output, data); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write output: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
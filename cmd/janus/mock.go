@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"GoJanus/pkg/mock"
+	"GoJanus/pkg/server"
+)
+
+// runMock implements `janus mock`: a server answering the manifest with synthetic responses
+func runMock(args []string) int {
+	flags := flag.NewFlagSet("mock", flag.ContinueOnError)
+	manifestPath := flags.String("manifest", "", "Manifest file (JSON or YAML)")
+	socketPath := flags.String("socket", "", "Unix socket path to listen on")
+	scriptPath := flags.String("fixtures", "", "Script file (JSON or YAML) with fixtures, injected errors and latency")
+	seed := flags.Int64("seed", 1, "Seed for generated values; 0 picks a random seed")
+	latency := flags.Duration("latency", 0, "Latency added to every request unless the script overrides it")
+	recordPath := flags.String("record", "", "Append every request/response pair to this JSONL file")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *socketPath == "" {
+		fmt.Fprintln(os.Stderr, "Usage: janus mock --manifest file --socket path [--fixtures file] [--seed n] [--latency duration] [--record file]")
+		return exitUsage
+	}
+
+	manifest, err := loadManifestFile(*manifestPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load manifest: %v\n", err)
+		return exitError
+	}
+
+	script := &mock.Script{}
+	if *scriptPath != "" {
+		if script, err = mock.LoadScript(*scriptPath); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return exitError
+		}
+	}
+	if *latency > 0 {
+		script.Latency = mock.Duration(*latency)
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	janusServer, err := mock.NewServer(manifest, &server.ServerConfig{
+		SocketPath:        *socketPath,
+		MaxConnections:    100,
+		DefaultTimeout:    30,
+		MaxMessageSize:    65536,
+		CleanupOnStart:    true,
+		CleanupOnShutdown: true,
+		RecordPath:        *recordPath,
+	}, mock.Options{Seed: *seed, Script: script})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create mock server: %v\n", err)
+		return exitError
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		janusServer.Stop()
+	}()
+
+	fmt.Printf("Mocking %s v%s (%d requests)\n", manifest.Name, manifest.Version, len(manifest.Requests))
+	if err := janusServer.StartListening(); err != nil {
+		fmt.Fprintf(os.Stderr, "Mock server failed: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
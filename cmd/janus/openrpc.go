@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"GoJanus/pkg/openrpc"
+)
+
+// runOpenRPC implements `janus openrpc`
+func runOpenRPC(args []string) int {
+	flags := flag.NewFlagSet("openrpc", flag.ContinueOnError)
+	manifestPath := flags.String("manifest", "", "Manifest file (JSON or YAML)")
+	socketPath := flags.String("socket", "", "Server socket path to list under servers")
+	outPath := flags.String("out", "", "Output file (default stdout)")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	manifest, err := loadManifestFile(*manifestPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load manifest: %v\n", err)
+		return exitError
+	}
+
+	doc, issues, err := openrpc.Generate(manifest, openrpc.Options{SocketPath: *socketPath})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate OpenRPC document: %v\n", err)
+		return exitError
+	}
+	reportSchemaIssues(issues)
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to serialize document: %v\n", err)
+		return exitError
+	}
+	return writeSchemaOutput(*outPath, append(data, '\n'))
+}
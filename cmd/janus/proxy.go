@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"GoJanus/pkg/proxy"
+	"GoJanus/pkg/traffic"
+)
+
+// runProxy implements `janus proxy`: relay datagrams between clients and a server while logging them
+func runProxy(args []string) int {
+	flags := flag.NewFlagSet("proxy", flag.ContinueOnError)
+	listenPath := flags.String("listen", "", "Unix socket path clients send requests to")
+	upstreamPath := flags.String("upstream", "", "Unix socket path of the server")
+	replyPath := flags.String("reply", "", "Socket path the server replies to (default: <listen>.reply)")
+	requests := flags.String("request", "", "Comma-separated request name patterns to log, dump and inject faults into; empty selects all")
+	latency := flags.Duration("latency", 0, "Latency added to each selected datagram")
+	jitter := flags.Duration("jitter", 0, "Random extra latency up to this amount")
+	loss := flags.Float64("loss", 0, "Fraction of selected datagrams to drop (0-1)")
+	seed := flags.Int64("seed", 0, "Seed for jitter and loss; 0 picks a random seed")
+	dumpPath := flags.String("dump", "", "Append selected exchanges to this JSONL file (readable by janus replay)")
+	pretty := flags.Bool("pretty", false, "Log every datagram as indented JSON")
+	quiet := flags.Bool("quiet", false, "Do not log traffic")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *listenPath == "" || *upstreamPath == "" {
+		fmt.Fprintln(os.Stderr, "Usage: janus proxy --listen path --upstream path [--request patterns] [--latency d] [--jitter d] [--loss rate] [--dump file] [--pretty] [--quiet]")
+		return exitUsage
+	}
+
+	config := proxy.Config{
+		ListenPath:   *listenPath,
+		UpstreamPath: *upstreamPath,
+		ReplyPath:    *replyPath,
+		Requests:     splitList(*requests),
+		Latency:      *latency,
+		Jitter:       *jitter,
+		LossRate:     *loss,
+		Seed:         *seed,
+		Pretty:       *pretty,
+	}
+	if !*quiet {
+		config.Log = os.Stdout
+	}
+	if *dumpPath != "" {
+		recorder, err := traffic.OpenRecorder(*dumpPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return exitError
+		}
+		defer recorder.Close()
+		config.Dump = recorder
+	}
+
+	janusProxy, err := proxy.New(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid proxy configuration: %v\n", err)
+		return exitUsage
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		janusProxy.Stop()
+	}()
+
+	fmt.Printf("Proxying %s -> %s (replies on %s)\n", *listenPath, *upstreamPath, janusProxy.ReplyPath())
+	if err := janusProxy.StartListening(); err != nil {
+		fmt.Fprintf(os.Stderr, "Proxy failed: %v\n", err)
+		return exitError
+	}
+
+	stats := janusProxy.Stats()
+	fmt.Printf("%d requests, %d responses, %d dropped, %d unmatched, %d undecodable\n",
+		stats.Requests, stats.Responses, stats.Dropped, stats.Unmatched, stats.Invalid)
+	return exitOK
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/traffic"
+)
+
+// runReplay implements `janus replay`: resend captured requests and diff the responses
+func runReplay(args []string) int {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	socketPath := flags.String("socket", "", "Unix socket path of the server to replay against")
+	format := flags.String("format", "text", "Output format: text or json")
+	ignore := flags.String("ignore", strings.Join(traffic.DefaultIgnoredFields, ","),
+		"Comma-separated fields to skip; /pointer matches one path, a bare name matches the key anywhere")
+	only := flags.String("request", "", "Comma-separated request names to replay; empty replays all")
+	timeout := flags.Duration("timeout", 0, "Per-request timeout; 0 uses the captured timeout")
+	files, err := parseInterspersed(flags, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(files) != 1 || *socketPath == "" {
+		fmt.Fprintln(os.Stderr, "Usage: janus replay <capture.jsonl> --socket path [--request names] [--ignore fields] [--timeout duration] [--format text|json]")
+		return exitUsage
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Unsupported format: %s (supported: text, json)\n", *format)
+		return exitUsage
+	}
+
+	records, err := traffic.LoadRecords(files[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return exitError
+	}
+	records = filterRecords(records, splitList(*only))
+
+	// Captured requests are resent as recorded, so the client does not validate them again
+	config := protocol.DefaultJanusClientConfig()
+	config.EnableValidation = false
+	client, err := protocol.New(*socketPath, config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create client: %v\n", err)
+		return exitError
+	}
+	defer client.Close()
+
+	report := traffic.Replay(context.Background(), client, records, traffic.ReplayOptions{
+		Timeout: *timeout,
+		Ignore:  splitList(*ignore),
+	})
+
+	if *format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to serialize replay report: %v\n", err)
+			return exitError
+		}
+		fmt.Println(string(data))
+	} else {
+		printReplay(report)
+	}
+
+	if !report.OK() {
+		return exitCheckFailed
+	}
+	return exitOK
+}
+
+// filterRecords keeps records for the named requests; no names keeps all
+func filterRecords(records []*traffic.Record, names []string) []*traffic.Record {
+	if len(names) == 0 {
+		return records
+	}
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	filtered := records[:0:0]
+	for _, record := range records {
+		if wanted[record.Request.Request] {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// printReplay writes one line per replayed request and the differences of mismatches
+func printReplay(report *traffic.ReplayReport) {
+	for _, result := range report.Results {
+		duration := time.Duration(result.DurationMs * float64(time.Millisecond)).Round(time.Microsecond)
+		switch {
+		case result.Error != "":
+			fmt.Printf("! #%-4d %-24s failed: %s\n", result.Index, result.Request, result.Error)
+		case len(result.Differences) > 0:
+			fmt.Printf("! #%-4d %-24s %d differences (%v)\n", result.Index, result.Request, len(result.Differences), duration)
+			for _, difference := range result.Differences {
+				fmt.Printf("        %s\n", difference)
+			}
+		default:
+			fmt.Printf("  #%-4d %-24s matched (%v)\n", result.Index, result.Request, duration)
+		}
+	}
+	fmt.Println(report.Summary())
+}
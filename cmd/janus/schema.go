@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	manifestpkg "GoJanus/pkg/manifest"
+)
+
+// runSchema implements `janus schema <export|import>`
+func runSchema(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: janus schema export --manifest <file> [--out file]")
+		fmt.Fprintln(os.Stderr, "       janus schema import --schema <file> [--out file]")
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "export":
+		return runSchemaExport(args[1:])
+	case "import":
+		return runSchemaImport(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown schema command: %s (supported: export, import)\n", args[0])
+		return exitUsage
+	}
+}
+
+// runSchemaExport converts a manifest into a JSON Schema document
+func runSchemaExport(args []string) int {
+	flags := flag.NewFlagSet("schema export", flag.ContinueOnError)
+	manifestPath := flags.String("manifest", "", "Manifest file (JSON or YAML)")
+	outPath := flags.String("out", "", "Output file (default stdout)")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	manifest, err := loadManifestFile(*manifestPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load manifest: %v\n", err)
+		return exitError
+	}
+
+	doc, issues := manifestpkg.ToJSONSchema(manifest)
+	reportSchemaIssues(issues)
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to serialize schema: %v\n", err)
+		return exitError
+	}
+	return writeSchemaOutput(*outPath, append(data, '\n'))
+}
+
+// runSchemaImport converts a JSON Schema document into a manifest
+func runSchemaImport(args []string) int {
+	flags := flag.NewFlagSet("schema import", flag.ContinueOnError)
+	schemaPath := flags.String("schema", "", "JSON Schema file (draft 2020-12)")
+	outPath := flags.String("out", "", "Output file (default stdout)")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if *schemaPath == "" {
+		fmt.Fprintln(os.Stderr, "--schema is required")
+		return exitUsage
+	}
+
+	data, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read schema: %v\n", err)
+		return exitError
+	}
+
+	manifest, issues, err := manifestpkg.FromJSONSchema(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to import schema: %v\n", err)
+		return exitError
+	}
+	reportSchemaIssues(issues)
+
+	output, err := manifestpkg.NewManifestParser().SerializeToJSON(manifest)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to serialize manifest: %v\n", err)
+		return exitError
+	}
+	return writeSchemaOutput(*outPath, append(output, '\n'))
+}
+
+// reportSchemaIssues prints lossy conversion warnings to stderr
+func reportSchemaIssues(issues []manifestpkg.SchemaIssue) {
+	for _, issue := range issues {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", issue)
+	}
+}
+
+// writeSchemaOutput writes a conversion result and maps failures to an exit code
+func writeSchemaOutput(path string, data []byte) int {
+	if err := writeOutput(path, data); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write output: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
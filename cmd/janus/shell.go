@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/shell"
+)
+
+// runShell implements `janus shell`: an interactive client with completion and history
+func runShell(args []string) int {
+	flags := flag.NewFlagSet("shell", flag.ContinueOnError)
+	socketPath := flags.String("socket", "", "Unix socket path of the server")
+	historyPath := flags.String("history", defaultHistoryPath(), "History file; empty keeps history in memory only")
+	timeout := flags.Duration("timeout", 30*time.Second, "Per-request timeout")
+	verbose := flags.Bool("verbose", false, "Show client library logs")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *socketPath == "" {
+		fmt.Fprintln(os.Stderr, "Usage: janus shell --socket path [--history file] [--timeout duration] [--verbose]")
+		return exitUsage
+	}
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	client, err := protocol.New(*socketPath, protocol.DefaultJanusClientConfig())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create client: %v\n", err)
+		return exitError
+	}
+	defer client.Close()
+
+	manifest, err := client.LoadManifest()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to fetch manifest from %s: %v\n", *socketPath, err)
+		return exitError
+	}
+
+	history := shell.NewHistory(0)
+	if *historyPath != "" {
+		if history, err = shell.LoadHistory(*historyPath, 0); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return exitError
+		}
+	}
+
+	if manifest != nil {
+		fmt.Printf("Connected to %s v%s (%d requests). Type .help for help.\n", manifest.Name, manifest.Version, len(manifest.Requests))
+	}
+	janusShell := shell.New(client, manifest, os.Stdout, shell.Config{Timeout: *timeout, History: history})
+	if err := janusShell.Run(context.Background(), os.Stdin); err != nil {
+		fmt.Fprintf(os.Stderr, "Shell failed: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// defaultHistoryPath returns ~/.janus_history, or no path when the home directory is unknown
+func defaultHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".janus_history")
+}
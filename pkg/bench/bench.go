@@ -0,0 +1,302 @@
+// Package bench drives a Janus server with concurrent requests and reports
+// throughput, latency percentiles and an error breakdown
+package bench
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"GoJanus/pkg/wire"
+
+	"GoJanus/pkg/models"
+)
+
+// MixEntry is one request in the benchmark mix
+type MixEntry struct {
+	Request string                 `json:"request" yaml:"request"`
+	Weight  int                    `json:"weight" yaml:"weight"` // Relative share of the traffic; defaults to 1
+	Args    map[string]interface{} `json:"args,omitempty" yaml:"args,omitempty"`
+}
+
+// Config configures a benchmark run
+type Config struct {
+	SocketPath  string
+	Concurrency int           // Workers sending requests back to back; defaults to 1
+	Duration    time.Duration // How long to send; defaults to 10s unless Requests is set
+	Requests    int           // Total requests to send; 0 sends until Duration ends
+	Timeout     time.Duration // How long to wait for each response; defaults to 5s
+
+	Mix          []MixEntry // Requests to send, chosen by weight; defaults to ping
+	PayloadSizes []int      // Sizes in bytes of a filler string argument, chosen in turn; empty adds none
+	PayloadArg   string     // Argument carrying the payload; defaults to "message"
+
+	SharedReply bool          // Receive every response on one shared socket instead of a socket per request
+	Seed        int64         // Seeds the request mix; 0 picks a random seed
+	Encoding    wire.Encoding // Encoding of requests; defaults to JSON
+}
+
+// DefaultMix sends only ping
+var DefaultMix = []MixEntry{{Request: "ping", Weight: 1}}
+
+// ParseMix parses a mix such as "ping=3,echo=1"; a name without a weight has weight 1
+func ParseMix(spec string) ([]MixEntry, error) {
+	var mix []MixEntry
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		entry := MixEntry{Request: item, Weight: 1}
+		if index := strings.Index(item, "="); index >= 0 {
+			weight, err := strconv.Atoi(strings.TrimSpace(item[index+1:]))
+			if err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid weight in mix entry '%s'", item)
+			}
+			entry.Request, entry.Weight = strings.TrimSpace(item[:index]), weight
+		}
+		if entry.Request == "" {
+			return nil, fmt.Errorf("missing request name in mix entry '%s'", item)
+		}
+		mix = append(mix, entry)
+	}
+	return mix, nil
+}
+
+// LoadMix reads a YAML or JSON list of mix entries with request, weight and args
+// Like ParseMix, only a missing weight defaults to 1; an explicit 0 keeps the request out of the mix
+func LoadMix(path string) ([]MixEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mix file: %w", err)
+	}
+	var entries []struct {
+		Request string                 `yaml:"request"`
+		Weight  *int                   `yaml:"weight"`
+		Args    map[string]interface{} `yaml:"args"`
+	}
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse mix file %s: %w", path, err)
+	}
+	mix := make([]MixEntry, 0, len(entries))
+	for i, entry := range entries {
+		if entry.Request == "" {
+			return nil, fmt.Errorf("mix entry %d in %s has no request", i+1, path)
+		}
+		weight := 1
+		if entry.Weight != nil {
+			weight = *entry.Weight
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("mix entry %d in %s has a negative weight", i+1, path)
+		}
+		mix = append(mix, MixEntry{Request: entry.Request, Weight: weight, Args: entry.Args})
+	}
+	return mix, nil
+}
+
+// ParseSizes parses a comma-separated list of payload sizes such as "64,1k,16k"
+func ParseSizes(spec string) ([]int, error) {
+	var sizes []int
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			continue
+		}
+		multiplier := 1
+		if strings.HasSuffix(item, "k") {
+			item, multiplier = strings.TrimSuffix(item, "k"), 1024
+		}
+		size, err := strconv.Atoi(item)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid payload size '%s'", item)
+		}
+		sizes = append(sizes, size*multiplier)
+	}
+	return sizes, nil
+}
+
+// normalize validates the configuration and fills in defaults
+func (config Config) normalize() (Config, error) {
+	if config.SocketPath == "" {
+		return config, fmt.Errorf("socket path is required")
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
+	}
+	if config.Duration < 0 || config.Requests < 0 {
+		return config, fmt.Errorf("duration and request count cannot be negative")
+	}
+	if config.Duration == 0 && config.Requests == 0 {
+		config.Duration = 10 * time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	if config.Timeout < 100*time.Millisecond {
+		return config, fmt.Errorf("timeout must be at least 100ms")
+	}
+	if len(config.Mix) == 0 {
+		config.Mix = DefaultMix
+	}
+	total := 0
+	for _, entry := range config.Mix {
+		if entry.Weight < 0 {
+			return config, fmt.Errorf("request %s has a negative weight", entry.Request)
+		}
+		total += entry.Weight
+	}
+	if total == 0 {
+		return config, fmt.Errorf("the request mix has no weight")
+	}
+	for _, size := range config.PayloadSizes {
+		if size < 0 {
+			return config, fmt.Errorf("payload sizes cannot be negative")
+		}
+	}
+	if config.PayloadArg == "" {
+		config.PayloadArg = "message"
+	}
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+	encoding, err := wire.ParseEncoding(string(config.Encoding))
+	if err != nil {
+		return config, err
+	}
+	config.Encoding = encoding
+	return config, nil
+}
+
+// sender delivers one request and waits for its response
+type sender interface {
+	send(ctx context.Context, request string, args map[string]interface{}) (*models.JanusResponse, error)
+	close() error
+}
+
+// Run sends requests until the duration ends or the request count is reached,
+// then waits for outstanding responses and returns the report
+// Cancelling ctx stops the run early
+func Run(ctx context.Context, config Config) (*Report, error) {
+	config, err := config.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	var shared *sharedSender
+	if config.SharedReply {
+		if shared, err = newSharedSender(config); err != nil {
+			return nil, err
+		}
+		defer shared.close()
+	}
+	senders := make([]sender, config.Concurrency)
+	for i := range senders {
+		if shared != nil {
+			senders[i] = shared
+			continue
+		}
+		if senders[i], err = newClientSender(config); err != nil {
+			for _, created := range senders[:i] {
+				created.close()
+			}
+			return nil, err
+		}
+	}
+
+	stop := ctx
+	if config.Duration > 0 {
+		var cancel context.CancelFunc
+		stop, cancel = context.WithTimeout(ctx, config.Duration)
+		defer cancel()
+	}
+
+	payloads := buildPayloads(config.PayloadSizes)
+	var issued int64
+	samples := make([][]sample, config.Concurrency)
+	started := time.Now()
+	var wg sync.WaitGroup
+	for worker := 0; worker < config.Concurrency; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			if shared == nil {
+				defer senders[worker].close()
+			}
+			random := rand.New(rand.NewSource(config.Seed + int64(worker)))
+			for stop.Err() == nil {
+				n := atomic.AddInt64(&issued, 1)
+				if config.Requests > 0 && n > int64(config.Requests) {
+					return
+				}
+				entry := pick(config.Mix, random)
+				args, payload := requestArgs(entry, config.PayloadArg, payloads, int(n-1))
+
+				begin := time.Now()
+				response, err := senders[worker].send(ctx, entry.Request, args)
+				result := classify(entry.Request, payload, time.Since(begin), response, err)
+				result.finished = time.Since(started)
+				samples[worker] = append(samples[worker], result)
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	return newReport(config, started, time.Since(started), samples), nil
+}
+
+// pick chooses a mix entry by weight
+func pick(mix []MixEntry, random *rand.Rand) MixEntry {
+	if len(mix) == 1 {
+		return mix[0]
+	}
+	total := 0
+	for _, entry := range mix {
+		total += entry.Weight
+	}
+	n := random.Intn(total)
+	for _, entry := range mix {
+		if n < entry.Weight {
+			return entry
+		}
+		n -= entry.Weight
+	}
+	return mix[len(mix)-1]
+}
+
+// payload is a filler argument of a fixed size
+type payload struct {
+	size  int
+	value string
+}
+
+func buildPayloads(sizes []int) []payload {
+	payloads := make([]payload, len(sizes))
+	for i, size := range sizes {
+		payloads[i] = payload{size: size, value: strings.Repeat("x", size)}
+	}
+	return payloads
+}
+
+// requestArgs copies the entry's arguments and adds the n-th payload in turn
+// It returns the payload size, or -1 without payloads
+func requestArgs(entry MixEntry, payloadArg string, payloads []payload, n int) (map[string]interface{}, int) {
+	args := make(map[string]interface{}, len(entry.Args)+1)
+	for key, value := range entry.Args {
+		args[key] = value
+	}
+	if len(payloads) == 0 {
+		return args, -1
+	}
+	chosen := payloads[n%len(payloads)]
+	args[payloadArg] = chosen.value
+	return args, chosen.size
+}
@@ -0,0 +1,239 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"GoJanus/pkg/models"
+)
+
+// outcome classifies a sent request
+type outcome int
+
+const (
+	outcomeSucceeded outcome = iota
+	outcomeFailed            // The server answered with an error response
+	outcomeTimeout           // No response arrived in time
+	outcomeSendError         // The request could not be delivered
+)
+
+// sample is the result of one request
+type sample struct {
+	request  string
+	payload  int // Payload size in bytes, or -1 without payloads
+	duration time.Duration
+	finished time.Duration // Since the start of the run
+	outcome  outcome
+	code     models.JSONRPCErrorCode
+	err      error
+}
+
+// classify records the outcome of a request from the response or error returned by a sender
+func classify(request string, payload int, duration time.Duration, response *models.JanusResponse, err error) sample {
+	s := sample{request: request, payload: payload, duration: duration}
+	switch {
+	case err != nil:
+		if rpcErr, ok := models.AsJSONRPCError(err); ok {
+			s.outcome, s.code = outcomeFailed, rpcErr.Code
+		} else if isTimeout(err) {
+			s.outcome = outcomeTimeout
+		} else {
+			s.outcome, s.err = outcomeSendError, err
+		}
+	case response == nil:
+		s.outcome = outcomeTimeout
+	case response.Error != nil:
+		s.outcome, s.code = outcomeFailed, response.Error.Code
+	}
+	return s
+}
+
+// answered reports whether the server responded, successfully or with an error
+func (s sample) answered() bool {
+	return s.outcome == outcomeSucceeded || s.outcome == outcomeFailed
+}
+
+// isTimeout reports whether err means the response did not arrive in time
+func isTimeout(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "timeout") || strings.Contains(message, "timed out")
+}
+
+// Latency summarizes response times in milliseconds; timeouts and send errors are excluded
+type Latency struct {
+	MinMs  float64 `json:"min_ms"`
+	MeanMs float64 `json:"mean_ms"`
+	P50Ms  float64 `json:"p50_ms"`
+	P90Ms  float64 `json:"p90_ms"`
+	P95Ms  float64 `json:"p95_ms"`
+	P99Ms  float64 `json:"p99_ms"`
+	P999Ms float64 `json:"p999_ms"`
+	MaxMs  float64 `json:"max_ms"`
+}
+
+// Stats counts the outcomes of a set of requests
+type Stats struct {
+	Sent       int            `json:"sent"`
+	Succeeded  int            `json:"succeeded"`
+	Failed     int            `json:"failed"` // Error responses, broken down in Errors
+	Timeouts   int            `json:"timeouts"`
+	SendErrors int            `json:"send_errors"`
+	Throughput float64        `json:"throughput"` // Responses per second until the last response of the run
+	Latency    Latency        `json:"latency"`
+	Errors     map[string]int `json:"errors,omitempty"` // Error responses by code name, e.g. RESOURCE_NOT_FOUND
+}
+
+// Report is the result of a benchmark run
+type Report struct {
+	Started     time.Time  `json:"started"`
+	DurationMs  float64    `json:"duration_ms"`
+	SocketPath  string     `json:"socket_path"`
+	Concurrency int        `json:"concurrency"`
+	SharedReply bool       `json:"shared_reply"`
+	Encoding    string     `json:"encoding"`
+	Mix         []MixEntry `json:"mix"`
+	Payloads    []int      `json:"payload_sizes,omitempty"`
+
+	Stats
+	FirstSendError string            `json:"first_send_error,omitempty"` // Helps diagnose a run that could not reach the server
+	Requests       map[string]*Stats `json:"requests"`                   // By request name
+	PayloadStats   map[string]*Stats `json:"payloads,omitempty"`         // By payload size in bytes
+}
+
+// Duration returns the elapsed time of the run
+func (r *Report) Duration() time.Duration {
+	return time.Duration(r.DurationMs * float64(time.Millisecond))
+}
+
+// newReport aggregates the samples collected by each worker
+func newReport(config Config, started time.Time, elapsed time.Duration, samples [][]sample) *Report {
+	var all []sample
+	for _, workerSamples := range samples {
+		all = append(all, workerSamples...)
+	}
+
+	// Throughput is measured until the last response, so waiting out the final
+	// timeouts does not dilute it
+	window := time.Duration(0)
+	for _, s := range all {
+		if s.answered() && s.finished > window {
+			window = s.finished
+		}
+	}
+
+	report := &Report{
+		Started:     started,
+		DurationMs:  milliseconds(elapsed),
+		SocketPath:  config.SocketPath,
+		Concurrency: config.Concurrency,
+		SharedReply: config.SharedReply,
+		Encoding:    string(config.Encoding),
+		Mix:         config.Mix,
+		Payloads:    config.PayloadSizes,
+		Stats:       summarize(all, window),
+		Requests:    map[string]*Stats{},
+	}
+
+	byRequest := map[string][]sample{}
+	byPayload := map[int][]sample{}
+	for _, s := range all {
+		byRequest[s.request] = append(byRequest[s.request], s)
+		if s.payload >= 0 {
+			byPayload[s.payload] = append(byPayload[s.payload], s)
+		}
+		if s.err != nil && report.FirstSendError == "" {
+			report.FirstSendError = s.err.Error()
+		}
+	}
+	for request, requestSamples := range byRequest {
+		stats := summarize(requestSamples, window)
+		report.Requests[request] = &stats
+	}
+	if len(byPayload) > 0 {
+		report.PayloadStats = map[string]*Stats{}
+		for size, payloadSamples := range byPayload {
+			stats := summarize(payloadSamples, window)
+			report.PayloadStats[strconv.Itoa(size)] = &stats
+		}
+	}
+	return report
+}
+
+// summarize counts outcomes and computes latency percentiles over answered requests
+func summarize(samples []sample, window time.Duration) Stats {
+	stats := Stats{Sent: len(samples)}
+	var latencies []float64
+	for _, s := range samples {
+		switch s.outcome {
+		case outcomeSucceeded:
+			stats.Succeeded++
+		case outcomeFailed:
+			stats.Failed++
+			if stats.Errors == nil {
+				stats.Errors = map[string]int{}
+			}
+			stats.Errors[s.code.String()]++
+		case outcomeTimeout:
+			stats.Timeouts++
+			continue
+		case outcomeSendError:
+			stats.SendErrors++
+			continue
+		}
+		latencies = append(latencies, milliseconds(s.duration))
+	}
+
+	if window > 0 {
+		stats.Throughput = round(float64(len(latencies)) / window.Seconds())
+	}
+	if len(latencies) == 0 {
+		return stats
+	}
+	sort.Float64s(latencies)
+	total := 0.0
+	for _, latency := range latencies {
+		total += latency
+	}
+	stats.Latency = Latency{
+		MinMs:  latencies[0],
+		MeanMs: round(total / float64(len(latencies))),
+		P50Ms:  percentile(latencies, 50),
+		P90Ms:  percentile(latencies, 90),
+		P95Ms:  percentile(latencies, 95),
+		P99Ms:  percentile(latencies, 99),
+		P999Ms: percentile(latencies, 99.9),
+		MaxMs:  latencies[len(latencies)-1],
+	}
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return round(float64(d) / float64(time.Millisecond))
+}
+
+// round keeps three decimals, i.e. microseconds for millisecond values
+func round(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
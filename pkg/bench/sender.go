@@ -0,0 +1,151 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/wire"
+)
+
+// clientSender sends through a client, which binds a reply socket per request
+type clientSender struct {
+	client  *protocol.JanusClient
+	timeout time.Duration
+}
+
+func newClientSender(config Config) (*clientSender, error) {
+	clientConfig := protocol.DefaultJanusClientConfig()
+	clientConfig.EnableValidation = false
+	// The datagram read deadline bounds each request too, so it follows the timeout
+	clientConfig.DatagramTimeout = config.Timeout
+	clientConfig.Encoding = config.Encoding
+	client, err := protocol.New(config.SocketPath, clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	return &clientSender{client: client, timeout: config.Timeout}, nil
+}
+
+func (c *clientSender) send(ctx context.Context, request string, args map[string]interface{}) (*models.JanusResponse, error) {
+	return c.client.SendRequest(ctx, request, args, protocol.RequestOptions{Timeout: c.timeout})
+}
+
+func (c *clientSender) close() error {
+	return c.client.Close()
+}
+
+// sharedSender receives every response on one bound reply socket and routes
+// each to its waiting worker by request_id
+type sharedSender struct {
+	timeout   time.Duration
+	encoding  wire.Encoding
+	replyPath string
+	replies   *net.UnixConn
+	server    *net.UnixConn
+
+	mutex   sync.Mutex
+	pending map[string]chan *models.JanusResponse
+	done    chan struct{}
+}
+
+func newSharedSender(config Config) (*sharedSender, error) {
+	replyPath := fmt.Sprintf("%s/janus_bench_%d_%d.sock", os.TempDir(), os.Getpid(), time.Now().UnixNano())
+	replies, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: replyPath, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind reply socket %s: %w", replyPath, err)
+	}
+	server, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: config.SocketPath, Net: "unixgram"})
+	if err != nil {
+		replies.Close()
+		os.Remove(replyPath)
+		return nil, fmt.Errorf("failed to dial server socket %s: %w", config.SocketPath, err)
+	}
+
+	s := &sharedSender{
+		timeout:   config.Timeout,
+		encoding:  config.Encoding,
+		replyPath: replyPath,
+		replies:   replies,
+		server:    server,
+		pending:   make(map[string]chan *models.JanusResponse),
+		done:      make(chan struct{}),
+	}
+	go s.readLoop()
+	return s, nil
+}
+
+// readLoop delivers responses until the reply socket is closed
+// Responses arriving after their request timed out are discarded
+func (s *sharedSender) readLoop() {
+	defer close(s.done)
+	buffer := make([]byte, 64*1024)
+	for {
+		n, err := s.replies.Read(buffer)
+		if err != nil {
+			return
+		}
+		response, err := wire.DecodeResponse(buffer[:n])
+		if err != nil {
+			continue
+		}
+		s.mutex.Lock()
+		waiting := s.pending[response.RequestID]
+		delete(s.pending, response.RequestID)
+		s.mutex.Unlock()
+		if waiting != nil {
+			waiting <- response
+		}
+	}
+}
+
+func (s *sharedSender) send(ctx context.Context, request string, args map[string]interface{}) (*models.JanusResponse, error) {
+	timeoutSeconds := s.timeout.Seconds()
+	janusRequest := models.NewJanusRequest(request, args, &timeoutSeconds)
+	janusRequest.ReplyTo = &s.replyPath
+	data, err := wire.EncodeRequestAs(janusRequest, s.encoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize request: %w", err)
+	}
+
+	waiting := make(chan *models.JanusResponse, 1)
+	s.mutex.Lock()
+	s.pending[janusRequest.ID] = waiting
+	s.mutex.Unlock()
+	forget := func() {
+		s.mutex.Lock()
+		delete(s.pending, janusRequest.ID)
+		s.mutex.Unlock()
+	}
+
+	if _, err := s.server.Write(data); err != nil {
+		forget()
+		return nil, fmt.Errorf("failed to send request datagram: %w", err)
+	}
+
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+	select {
+	case response := <-waiting:
+		return response, nil
+	case <-timer.C:
+		forget()
+		return nil, fmt.Errorf("no response within %v: %w", s.timeout, context.DeadlineExceeded)
+	case <-ctx.Done():
+		forget()
+		return nil, ctx.Err()
+	}
+}
+
+func (s *sharedSender) close() error {
+	s.server.Close()
+	err := s.replies.Close()
+	<-s.done
+	os.Remove(s.replyPath)
+	return err
+}
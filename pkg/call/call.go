@@ -0,0 +1,219 @@
+// Package call sends a single request for scripts and health probes: arguments from
+// the command line, retries for transient failures and exit codes per error code
+package call
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+)
+
+// Exit codes for a call; error responses map to ExitCode(code)
+const (
+	ExitOK         = 0
+	ExitNoResponse = 4  // The request timed out or could not be delivered
+	ExitOtherError = 15 // An error code outside the JSON-RPC and Janus ranges
+)
+
+// ExitCode maps an error code to a process exit code:
+// 10-14 for the standard JSON-RPC errors, 20 + n for the server error -32000 - n
+// (SERVICE_UNAVAILABLE is 21, RESOURCE_NOT_FOUND 24) and ExitOtherError for anything else
+func ExitCode(code models.JSONRPCErrorCode) int {
+	switch code {
+	case models.ParseError:
+		return 10
+	case models.InvalidRequest:
+		return 11
+	case models.MethodNotFound:
+		return 12
+	case models.InvalidParams:
+		return 13
+	case models.InternalError:
+		return 14
+	}
+	if code <= models.ServerError && code > models.ServerError-100 {
+		return 20 + int(models.ServerError-code)
+	}
+	return ExitOtherError
+}
+
+// ParseArgs builds request arguments from command-line words, applied in order:
+//
+//	key=value     string value
+//	key:=json     JSON value, e.g. count:=3 tags:='["a", "b"]'
+//	{...}         JSON object merged into the arguments
+//	@file         JSON object read from a file and merged; @- reads stdin
+func ParseArgs(words []string, stdin io.Reader) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	for _, word := range words {
+		switch {
+		case strings.HasPrefix(word, "@"):
+			object, err := readObject(word[1:], stdin)
+			if err != nil {
+				return nil, err
+			}
+			merge(args, object)
+		case strings.HasPrefix(strings.TrimSpace(word), "{"):
+			object, err := decodeObject([]byte(word))
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSON arguments: %w", err)
+			}
+			merge(args, object)
+		default:
+			if err := setPair(args, word); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return args, nil
+}
+
+// setPair applies a key=value or key:=json word
+func setPair(args map[string]interface{}, word string) error {
+	index := strings.Index(word, "=")
+	if index <= 0 {
+		return fmt.Errorf("expected key=value, key:=json, {json} or @file, got '%s'", word)
+	}
+	key, value := word[:index], word[index+1:]
+	if !strings.HasSuffix(key, ":") {
+		args[key] = value
+		return nil
+	}
+
+	key = strings.TrimSuffix(key, ":")
+	if key == "" {
+		return fmt.Errorf("missing key in '%s'", word)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return fmt.Errorf("invalid JSON for '%s': %w", key, err)
+	}
+	args[key] = decoded
+	return nil
+}
+
+// readObject reads a JSON object from a file, or from stdin for "-"
+func readObject(path string, stdin io.Reader) (map[string]interface{}, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		if stdin == nil {
+			return nil, fmt.Errorf("stdin is not available")
+		}
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read arguments from '%s': %w", path, err)
+	}
+	object, err := decodeObject(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON arguments in '%s': %w", path, err)
+	}
+	return object, nil
+}
+
+// decodeObject decodes a JSON object, rejecting other values and trailing data
+func decodeObject(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+	if object == nil {
+		return nil, fmt.Errorf("arguments must be a JSON object")
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON object")
+	}
+	return object, nil
+}
+
+func merge(args, object map[string]interface{}) {
+	for key, value := range object {
+		args[key] = value
+	}
+}
+
+// Options configures retries for Send
+type Options struct {
+	Timeout    time.Duration // Per attempt; 0 uses the client default
+	Retries    int           // Extra attempts after a retryable failure
+	RetryDelay time.Duration // Wait before the first retry, doubled for each further retry
+
+	// Called before each retry with the failed attempt's error or error response
+	OnRetry func(attempt int, err error)
+}
+
+// Send sends a request, retrying when no response arrives or the server reports a
+// transient error (SERVICE_UNAVAILABLE, RATE_LIMIT_EXCEEDED, HANDLER_TIMEOUT,
+// SOCKET_ERROR); each attempt uses a new request id
+func Send(ctx context.Context, client *protocol.JanusClient, request string, args map[string]interface{}, options Options) (*models.JanusResponse, error) {
+	var requestOptions []protocol.RequestOptions
+	if options.Timeout > 0 {
+		requestOptions = append(requestOptions, protocol.RequestOptions{Timeout: options.Timeout})
+	}
+
+	delay := options.RetryDelay
+	for attempt := 0; ; attempt++ {
+		response, err := client.SendRequest(ctx, request, args, requestOptions...)
+		if attempt >= options.Retries || !Retryable(response, err) {
+			return response, err
+		}
+
+		if options.OnRetry != nil {
+			failure := err
+			if failure == nil {
+				failure = response.Error
+			}
+			options.OnRetry(attempt+1, failure)
+		}
+		select {
+		case <-ctx.Done():
+			return response, err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// Retryable reports whether a failed attempt may succeed when repeated
+func Retryable(response *models.JanusResponse, err error) bool {
+	if err != nil {
+		// Local validation failures will fail again; everything else is a delivery problem
+		rpcErr, ok := models.AsJSONRPCError(err)
+		return !ok || retryableCode(rpcErr.Code)
+	}
+	return response != nil && response.Error != nil && retryableCode(response.Error.Code)
+}
+
+func retryableCode(code models.JSONRPCErrorCode) bool {
+	switch code {
+	case models.ServiceUnavailable, models.RateLimitExceeded, models.HandlerTimeout, models.SocketTransportError:
+		return true
+	}
+	return false
+}
+
+// Outcome returns the exit code for the result of Send
+func Outcome(response *models.JanusResponse, err error) int {
+	if err != nil {
+		if rpcErr, ok := models.AsJSONRPCError(err); ok {
+			return ExitCode(rpcErr.Code)
+		}
+		return ExitNoResponse
+	}
+	if response.Error != nil {
+		return ExitCode(response.Error.Code)
+	}
+	return ExitOK
+}
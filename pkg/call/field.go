@@ -0,0 +1,150 @@
+package call
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExtractField selects a value with a jq-style path such as .result.items[0].name,
+// .result["key.with.dots"] or .result.items[-1]; "." selects the whole value
+// Missing keys and out-of-range indexes yield nil, like jq
+func ExtractField(value interface{}, path string) (interface{}, error) {
+	steps, err := parseFieldPath(path)
+	if err != nil {
+		return nil, err
+	}
+	current, err := normalize(value)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range steps {
+		switch node := current.(type) {
+		case nil:
+			return nil, nil
+		case map[string]interface{}:
+			if step.isIndex {
+				return nil, fmt.Errorf("cannot index object with %d", step.index)
+			}
+			current = node[step.key]
+		case []interface{}:
+			if !step.isIndex {
+				return nil, fmt.Errorf("cannot index array with \"%s\"", step.key)
+			}
+			index := step.index
+			if index < 0 {
+				index += len(node)
+			}
+			if index < 0 || index >= len(node) {
+				return nil, nil
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("cannot index %T at %s", node, path)
+		}
+	}
+	return current, nil
+}
+
+// FormatField renders an extracted value: strings verbatim, everything else as JSON
+func FormatField(value interface{}) (string, error) {
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// fieldStep is one object key or array index in a field path
+type fieldStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseFieldPath splits a path like .a.b[0]["c d"] into steps
+func parseFieldPath(path string) ([]fieldStep, error) {
+	if !strings.HasPrefix(path, ".") {
+		return nil, fmt.Errorf("field path must start with '.', got '%s'", path)
+	}
+	var steps []fieldStep
+	rest := path
+	for rest != "" && rest != "." {
+		switch {
+		case strings.HasPrefix(rest, "["):
+			end := closingBracket(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '[' in field path '%s'", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			if strings.HasPrefix(inner, `"`) {
+				key, err := strconv.Unquote(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid key %s in field path '%s'", inner, path)
+				}
+				steps = append(steps, fieldStep{key: key})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index '%s' in field path '%s'", inner, path)
+				}
+				steps = append(steps, fieldStep{index: index, isIndex: true})
+			}
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			if strings.HasPrefix(rest, "[") {
+				continue
+			}
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty key in field path '%s'", path)
+			}
+			steps = append(steps, fieldStep{key: rest[:end]})
+			rest = rest[end:]
+		default:
+			return nil, fmt.Errorf("unexpected '%s' in field path '%s'", rest, path)
+		}
+	}
+	return steps, nil
+}
+
+// closingBracket returns the index of the ']' ending the bracket at the start of text,
+// skipping brackets inside a quoted key
+func closingBracket(text string) int {
+	inQuote, escaped := false, false
+	for i := 1; i < len(text); i++ {
+		switch c := text[i]; {
+		case escaped:
+			escaped = false
+		case inQuote && c == '\\':
+			escaped = true
+		case c == '"':
+			inQuote = !inQuote
+		case !inQuote && c == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// normalize round-trips a value through JSON so typed structs become maps and slices
+func normalize(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
@@ -0,0 +1,474 @@
+// Package codegen generates language bindings from Janus manifests
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"GoJanus/pkg/manifest"
+)
+
+// GoOptions configures Go code generation
+type GoOptions struct {
+	PackageName string // Package clause of the generated file (default "api")
+	ImportPath  string // Import path of the GoJanus module (default "GoJanus")
+}
+
+// DefaultGoOptions returns default Go generation options
+func DefaultGoOptions() GoOptions {
+	return GoOptions{
+		PackageName: "api",
+		ImportPath:  "GoJanus",
+	}
+}
+
+// GenerateGo generates Go model types, request argument/response types, a typed client
+// wrapper over protocol.JanusClient and a server interface with JanusServer registration glue
+func GenerateGo(m *manifest.Manifest, opts GoOptions) ([]byte, error) {
+	if m == nil {
+		return nil, fmt.Errorf("manifest cannot be nil")
+	}
+
+	defaults := DefaultGoOptions()
+	if opts.PackageName == "" {
+		opts.PackageName = defaults.PackageName
+	}
+	if opts.ImportPath == "" {
+		opts.ImportPath = defaults.ImportPath
+	}
+
+	g := &goGenerator{
+		manifest:   m,
+		opts:       opts,
+		modelNames: make(map[string]string),
+		usedNames:  make(map[string]bool),
+	}
+
+	source, err := g.generate()
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(source)
+	if err != nil {
+		return nil, fmt.Errorf("generated code failed to format: %w", err)
+	}
+	return formatted, nil
+}
+
+// goGenerator holds state for a single generation run
+type goGenerator struct {
+	manifest   *manifest.Manifest
+	opts       GoOptions
+	modelNames map[string]string // manifest model name -> Go type name
+	usedNames  map[string]bool
+	decls      bytes.Buffer // type declarations emitted while resolving field types
+	fixed      fixedNames
+	model      string // manifest model whose fields are being emitted, empty outside models
+}
+
+// fixedNames holds the identifiers every generated file declares
+type fixedNames struct {
+	manifestName, manifestVersion  string
+	client, newClient, janusClient string
+	server, registerServer         string
+}
+
+// reserveFixedNames claims the fixed identifiers before any manifest-derived name
+func (g *goGenerator) reserveFixedNames() {
+	g.fixed = fixedNames{
+		manifestName:    g.reserveName("ManifestName"),
+		manifestVersion: g.reserveName("ManifestVersion"),
+		client:          g.reserveName("Client"),
+		newClient:       g.reserveName("NewClient"),
+		janusClient:     g.reserveName("JanusClient"),
+		server:          g.reserveName("Server"),
+		registerServer:  g.reserveName("RegisterServer"),
+	}
+}
+
+// generate emits the complete source file
+func (g *goGenerator) generate() ([]byte, error) {
+	var out bytes.Buffer
+
+	requestNames := sortedKeys(g.manifest.Requests)
+	modelNames := sortedKeys(g.manifest.Models)
+
+	// Reserve the fixed identifiers, then model type names so references resolve regardless of order
+	g.reserveFixedNames()
+	for _, name := range modelNames {
+		g.modelNames[name] = g.reserveName(exportedName(name))
+	}
+
+	fmt.Fprintf(&out, "// Code generated by janus gen go. DO NOT EDIT.\n")
+	fmt.Fprintf(&out, "// Source manifest: %s %s\n\n", g.manifest.Name, g.manifest.Version)
+	fmt.Fprintf(&out, "package %s\n\n", g.opts.PackageName)
+
+	if len(requestNames) > 0 {
+		fmt.Fprintf(&out, "import (\n\t\"context\"\n\n")
+		fmt.Fprintf(&out, "\t%q\n\t%q\n\t%q\n)\n\n",
+			g.opts.ImportPath+"/pkg/models",
+			g.opts.ImportPath+"/pkg/protocol",
+			g.opts.ImportPath+"/pkg/server")
+	}
+
+	fmt.Fprintf(&out, "// Manifest identity the bindings were generated from\n")
+	fmt.Fprintf(&out, "const (\n\t%s = %q\n\t%s = %q\n)\n\n",
+		g.fixed.manifestName, g.manifest.Name, g.fixed.manifestVersion, g.manifest.Version)
+
+	// Models
+	for _, name := range modelNames {
+		model := g.manifest.Models[name]
+		if model == nil {
+			continue
+		}
+		typeName := g.modelNames[name]
+		if model.Type == "object" || len(model.Properties) > 0 {
+			required := make(map[string]bool, len(model.Required))
+			for _, prop := range model.Required {
+				required[prop] = true
+			}
+			g.model = name
+			fields := g.structFields(typeName, model.Properties, required)
+			g.model = ""
+			writeComment(&g.decls, typeName, model.Description)
+			fmt.Fprintf(&g.decls, "type %s struct {\n%s}\n\n", typeName, fields)
+		} else {
+			writeComment(&g.decls, typeName, model.Description)
+			fmt.Fprintf(&g.decls, "type %s %s\n\n", typeName, scalarGoType(model.Type))
+		}
+	}
+
+	// Request argument and response types
+	type requestTypes struct {
+		name, method, args, response string
+		description                  string
+	}
+	requests := make([]requestTypes, 0, len(requestNames))
+	for _, name := range requestNames {
+		request := g.manifest.Requests[name]
+		if request == nil {
+			continue
+		}
+		method := g.reserveName(exportedName(name))
+		argsType := g.reserveName(method + "Args")
+		responseType := g.reserveName(method + "Response")
+
+		argFields := g.structFields(argsType, request.Args, nil)
+		fmt.Fprintf(&g.decls, "// %s holds the arguments of the %q request\n", argsType, name)
+		fmt.Fprintf(&g.decls, "type %s struct {\n%s}\n\n", argsType, argFields)
+
+		g.responseDecl(responseType, name, request.Response)
+
+		requests = append(requests, requestTypes{
+			name:        name,
+			method:      method,
+			args:        argsType,
+			response:    responseType,
+			description: request.Description,
+		})
+	}
+
+	out.Write(g.decls.Bytes())
+
+	if len(requests) == 0 {
+		return out.Bytes(), nil
+	}
+
+	// Typed client wrapper
+	names := g.fixed
+	fmt.Fprintf(&out, "// %s is a typed client for %s\n", names.client, g.manifest.Name)
+	fmt.Fprintf(&out, "type %s struct {\n\tclient *protocol.JanusClient\n}\n\n", names.client)
+	fmt.Fprintf(&out, "// %s wraps a protocol.JanusClient with typed request methods\n", names.newClient)
+	fmt.Fprintf(&out, "func %s(client *protocol.JanusClient) *%s {\n\treturn &%s{client: client}\n}\n\n",
+		names.newClient, names.client, names.client)
+	fmt.Fprintf(&out, "// %s returns the underlying protocol client\n", names.janusClient)
+	fmt.Fprintf(&out, "func (c *%s) %s() *protocol.JanusClient {\n\treturn c.client\n}\n\n", names.client, names.janusClient)
+	for _, request := range requests {
+		writeComment(&out, request.method, fmt.Sprintf("sends the %q request\n%s", request.name, request.description))
+		fmt.Fprintf(&out, "func (c *%s) %s(ctx context.Context, args %s, options ...protocol.RequestOptions) (%s, error) {\n",
+			names.client, request.method, request.args, request.response)
+		fmt.Fprintf(&out, "\treturn protocol.Call[%s, %s](ctx, c.client, %q, args, options...)\n}\n\n",
+			request.args, request.response, request.name)
+	}
+
+	// Server interface and registration glue
+	fmt.Fprintf(&out, "// %s is implemented by services providing %s\n", names.server, g.manifest.Name)
+	fmt.Fprintf(&out, "type %s interface {\n", names.server)
+	for _, request := range requests {
+		writeComment(&out, request.method, fmt.Sprintf("handles the %q request\n%s", request.name, request.description))
+		fmt.Fprintf(&out, "\t%s(cmd *models.JanusRequest, args %s) (%s, error)\n", request.method, request.args, request.response)
+	}
+	fmt.Fprintf(&out, "}\n\n")
+
+	fmt.Fprintf(&out, "// %s registers a handler for every request in the manifest on srv\n", names.registerServer)
+	fmt.Fprintf(&out, "func %s(srv *server.JanusServer, impl %s) error {\n", names.registerServer, names.server)
+	for _, request := range requests {
+		fmt.Fprintf(&out, "\tif err := srv.RegisterHandler(%q, server.NewTypedHandler[%s, %s](impl.%s)); err != nil {\n\t\treturn err\n\t}\n",
+			request.name, request.args, request.response, request.method)
+	}
+	fmt.Fprintf(&out, "\treturn nil\n}\n")
+
+	return out.Bytes(), nil
+}
+
+// responseDecl emits the response type for a request
+func (g *goGenerator) responseDecl(typeName, requestName string, response *manifest.ResponseManifest) {
+	var decl string
+	switch {
+	case response == nil:
+		decl = "= interface{}"
+	case response.ModelRef != "":
+		decl = "= " + g.modelType(response.ModelRef)
+	case response.Type == "object" && len(response.Properties) > 0:
+		decl = "struct {\n" + g.structFields(typeName, response.Properties, nil) + "}"
+	case response.Type == "array":
+		itemType := "interface{}"
+		if response.Items != nil {
+			itemType = g.fieldType(typeName+"Item", response.Items)
+		}
+		decl = "= []" + itemType
+	default:
+		decl = "= " + scalarGoType(response.Type)
+	}
+
+	fmt.Fprintf(&g.decls, "// %s is the result of the %q request\n", typeName, requestName)
+	fmt.Fprintf(&g.decls, "type %s %s\n\n", typeName, decl)
+}
+
+// structFields emits struct fields for a property set
+// required lists property names required by the enclosing model in addition to per-property flags
+func (g *goGenerator) structFields(owner string, properties map[string]*manifest.ArgumentManifest, required map[string]bool) string {
+	var fields bytes.Buffer
+	used := make(map[string]bool)
+
+	for _, propName := range sortedKeys(properties) {
+		prop := properties[propName]
+		if prop == nil {
+			continue
+		}
+
+		fieldName := exportedName(propName)
+		for base, i := fieldName, 2; used[fieldName]; i++ {
+			fieldName = fmt.Sprintf("%s%d", base, i)
+		}
+		used[fieldName] = true
+
+		fieldType := g.fieldType(owner+fieldName, prop)
+		isRequired := prop.Required || required[propName]
+		tag := propName
+		if !isRequired {
+			tag += ",omitempty"
+			if needsPointer(fieldType) {
+				fieldType = "*" + fieldType
+			}
+		} else if prop.Nullable && needsPointer(fieldType) {
+			// Required but nullable: the key is always sent, possibly as null
+			fieldType = "*" + fieldType
+		} else if prop.ModelRef != "" && g.model != "" && g.modelReaches(prop.ModelRef, g.model) {
+			// A value field would make the model contain itself
+			fieldType = "*" + fieldType
+		}
+
+		if prop.Description != "" {
+			writeIndentedComment(&fields, prop.Description)
+		}
+		fmt.Fprintf(&fields, "\t%s %s `json:%q`\n", fieldName, fieldType, tag)
+	}
+
+	return fields.String()
+}
+
+// modelReaches reports whether model from embeds model to by value, directly or through other models
+// Array items are not followed since slices already break the cycle
+func (g *goGenerator) modelReaches(from, to string) bool {
+	visited := make(map[string]bool)
+	var reaches func(name string) bool
+	var propertiesReach func(properties map[string]*manifest.ArgumentManifest) bool
+	reaches = func(name string) bool {
+		if name == to {
+			return true
+		}
+		if visited[name] {
+			return false
+		}
+		visited[name] = true
+		model := g.manifest.Models[name]
+		return model != nil && propertiesReach(model.Properties)
+	}
+	propertiesReach = func(properties map[string]*manifest.ArgumentManifest) bool {
+		for _, prop := range properties {
+			if prop == nil {
+				continue
+			}
+			if prop.ModelRef != "" && reaches(prop.ModelRef) {
+				return true
+			}
+			if prop.ModelRef == "" && propertiesReach(prop.Properties) {
+				return true
+			}
+		}
+		return false
+	}
+	return reaches(from)
+}
+
+// fieldType resolves the Go type of an argument, emitting nested declarations as needed
+func (g *goGenerator) fieldType(nestedName string, arg *manifest.ArgumentManifest) string {
+	if arg.ModelRef != "" {
+		return g.modelType(arg.ModelRef)
+	}
+
+	switch arg.Type {
+	case "string":
+		if len(arg.Enum) > 0 {
+			return g.enumType(nestedName, arg)
+		}
+		return "string"
+	case "array":
+		if arg.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + g.fieldType(nestedName+"Item", arg.Items)
+	case "object":
+		if len(arg.Properties) == 0 {
+			return "map[string]interface{}"
+		}
+		typeName := g.reserveName(nestedName)
+		fields := g.structFields(typeName, arg.Properties, nil)
+		writeComment(&g.decls, typeName, arg.Description)
+		fmt.Fprintf(&g.decls, "type %s struct {\n%s}\n\n", typeName, fields)
+		return typeName
+	default:
+		return scalarGoType(arg.Type)
+	}
+}
+
+// enumType emits a named string type with one constant per enum value
+func (g *goGenerator) enumType(name string, arg *manifest.ArgumentManifest) string {
+	typeName := g.reserveName(name)
+	writeComment(&g.decls, typeName, arg.Description)
+	fmt.Fprintf(&g.decls, "type %s string\n\n", typeName)
+	fmt.Fprintf(&g.decls, "// Allowed %s values\nconst (\n", typeName)
+	for _, value := range arg.Enum {
+		constName := g.reserveName(typeName + exportedName(value))
+		fmt.Fprintf(&g.decls, "\t%s %s = %q\n", constName, typeName, value)
+	}
+	fmt.Fprintf(&g.decls, ")\n\n")
+	return typeName
+}
+
+// modelType returns the Go type for a model reference
+func (g *goGenerator) modelType(modelRef string) string {
+	if typeName, exists := g.modelNames[modelRef]; exists {
+		return typeName
+	}
+	// Unresolved reference: fall back to a generic object
+	return "map[string]interface{}"
+}
+
+// reserveName returns a unique Go identifier based on name
+func (g *goGenerator) reserveName(name string) string {
+	candidate := name
+	for i := 2; g.usedNames[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	g.usedNames[candidate] = true
+	return candidate
+}
+
+// scalarGoType maps manifest scalar types to Go types
+func scalarGoType(manifestType string) string {
+	switch manifestType {
+	case "string":
+		return "string"
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]interface{}"
+	case "object":
+		return "map[string]interface{}"
+	default:
+		return "interface{}"
+	}
+}
+
+// needsPointer reports whether an optional field of this type needs a pointer to express absence
+func needsPointer(goType string) bool {
+	return !strings.HasPrefix(goType, "[]") &&
+		!strings.HasPrefix(goType, "map[") &&
+		goType != "interface{}"
+}
+
+// Common initialisms kept upper-case in Go identifiers
+var commonInitialisms = map[string]bool{
+	"API": true, "ID": true, "IP": true, "JSON": true, "HTTP": true, "HTTPS": true,
+	"URL": true, "URI": true, "UUID": true, "UID": true, "TCP": true, "UDP": true,
+	"SQL": true, "RPC": true, "TTL": true, "CPU": true, "XML": true, "HTML": true,
+}
+
+// exportedName converts snake_case, kebab-case and dotted names into an exported Go identifier
+func exportedName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var builder strings.Builder
+	for _, word := range words {
+		upper := strings.ToUpper(word)
+		if commonInitialisms[upper] {
+			builder.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		builder.WriteString(string(runes))
+	}
+
+	result := builder.String()
+	if result == "" {
+		return "X"
+	}
+	if unicode.IsDigit([]rune(result)[0]) {
+		result = "X" + result
+	}
+	return result
+}
+
+// writeComment writes a doc comment for a declaration
+func writeComment(buf *bytes.Buffer, name, description string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+	lines := strings.Split(description, "\n")
+	fmt.Fprintf(buf, "// %s %s\n", name, strings.TrimSpace(lines[0]))
+	for _, line := range lines[1:] {
+		fmt.Fprintf(buf, "// %s\n", strings.TrimSpace(line))
+	}
+}
+
+// writeIndentedComment writes a field comment
+func writeIndentedComment(buf *bytes.Buffer, description string) {
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		fmt.Fprintf(buf, "\t// %s\n", strings.TrimSpace(line))
+	}
+}
+
+// sortedKeys returns map keys in sorted order for deterministic output
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,432 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+)
+
+// responseFields are the only fields a response may carry
+var responseFields = map[string]bool{
+	"result": true, "error": true, "success": true, "request_id": true, "id": true, "timestamp": true,
+}
+
+// unknownMethod is a request name no server should implement
+const unknownMethod = "conformance_unknown_method"
+
+var catalogue = []Case{
+	{Name: "response_fields", Category: "format", Description: "A success response has exactly the protocol fields with the right types", run: checkResponseFields},
+	{Name: "response_correlation", Category: "format", Description: "request_id echoes the request id and every response has a fresh id", run: checkCorrelation},
+	{Name: "error_fields", Category: "format", Description: "An error response has success false, no result and an error with an integer code and a message", run: checkErrorFields},
+	{Name: "timestamp_format", Category: "format", Description: "Response timestamps are RFC 3339 UTC with milliseconds, e.g. 2025-01-31T12:00:00.000Z", run: checkTimestamps},
+	{Name: "concurrent_correlation", Category: "format", Description: "Concurrent requests sharing a reply socket are each answered once with their own request_id", run: checkConcurrentCorrelation},
+
+	{Name: "ping", Category: "builtins", Description: "ping returns message \"pong\"", run: checkPing},
+	{Name: "echo", Category: "builtins", Description: "echo returns the message argument unchanged", run: checkEcho},
+	{Name: "get_info", Category: "builtins", Description: "get_info returns an object", run: checkGetInfo},
+	{Name: "validate", Category: "builtins", Description: "validate returns valid true", run: checkValidate},
+	{Name: "manifest", Category: "builtins", Description: "manifest returns a manifest with a version", run: checkManifest},
+
+	{Name: "unknown_method", Category: "errors", Description: "An unknown request fails with METHOD_NOT_FOUND (-32601)", run: checkUnknownMethod},
+	{Name: "bad_json", Category: "errors", Description: "A datagram that is not JSON is answered with PARSE_ERROR (-32700) at the sender's address", run: checkBadJSON},
+	{Name: "invalid_request", Category: "errors", Description: "A JSON object without a request name fails with INVALID_REQUEST (-32600)", run: checkInvalidRequest},
+	{Name: "oversized_payload", Category: "errors", Description: "A request above the message size limit is rejected with an error response", run: checkOversized},
+	{Name: "missing_reply_to", Category: "errors", Description: "A request without reply_to gets no response and the server keeps serving", run: checkMissingReplyTo},
+	{Name: "validation_error", Category: "errors", Description: "Omitting a required manifest argument fails with INVALID_PARAMS (-32602)", run: checkValidationError},
+}
+
+func pass(format string, args ...interface{}) (Status, string) {
+	return Pass, fmt.Sprintf(format, args...)
+}
+
+func fail(format string, args ...interface{}) (Status, string) {
+	return Fail, fmt.Sprintf(format, args...)
+}
+
+func failErr(err error) (Status, string) {
+	return Fail, err.Error()
+}
+
+// errorCode returns the error code of an error response
+func errorCode(response map[string]interface{}) (models.JSONRPCErrorCode, bool) {
+	errorObject, ok := response["error"].(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	code, ok := errorObject["code"].(float64)
+	return models.JSONRPCErrorCode(code), ok && code == float64(int(code))
+}
+
+// expectError checks that a response is an error with the given code
+func expectError(response map[string]interface{}, expected models.JSONRPCErrorCode) (Status, string) {
+	if response["success"] != false {
+		return fail("expected %s (%d), got success", expected, expected)
+	}
+	code, ok := errorCode(response)
+	if !ok {
+		return fail("expected %s (%d), got error %v", expected, expected, response["error"])
+	}
+	if code != expected {
+		return fail("expected %s (%d), got %s (%d)", expected, expected, code, code)
+	}
+	return pass("")
+}
+
+// describe names the error of a response for details
+func describe(response map[string]interface{}) string {
+	if code, ok := errorCode(response); ok {
+		return fmt.Sprintf("%s (%d)", code, code)
+	}
+	return fmt.Sprintf("%v", response["error"])
+}
+
+func checkResponseFields(t *target) (Status, string) {
+	_, response, err := t.call("ping", nil)
+	if err != nil {
+		return failErr(err)
+	}
+	for field := range response {
+		if !responseFields[field] {
+			return fail("unexpected field %q", field)
+		}
+	}
+	for _, field := range []string{"id", "request_id", "success", "timestamp"} {
+		if _, ok := response[field]; !ok {
+			return fail("missing field %q", field)
+		}
+	}
+	for _, field := range []string{"id", "request_id", "timestamp"} {
+		if _, ok := response[field].(string); !ok {
+			return fail("%s must be a string, got %T", field, response[field])
+		}
+	}
+	if response["success"] != true {
+		return fail("success must be true for ping, got %v", response["success"])
+	}
+	if response["result"] == nil {
+		return fail("a success response must carry a result")
+	}
+	if response["error"] != nil {
+		return fail("a success response must not carry an error, got %v", response["error"])
+	}
+	return pass("")
+}
+
+func checkCorrelation(t *target) (Status, string) {
+	seen := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		id, response, err := t.call("ping", nil)
+		if err != nil {
+			return failErr(err)
+		}
+		if response["request_id"] != id {
+			return fail("request_id %v does not match request id %s", response["request_id"], id)
+		}
+		responseID, _ := response["id"].(string)
+		if responseID == "" || responseID == id {
+			return fail("response id must be a new non-empty string, got %q", responseID)
+		}
+		if seen[responseID] {
+			return fail("response id %s was reused", responseID)
+		}
+		seen[responseID] = true
+	}
+	return pass("")
+}
+
+func checkErrorFields(t *target) (Status, string) {
+	_, response, err := t.call(unknownMethod, nil)
+	if err != nil {
+		return failErr(err)
+	}
+	if response["success"] != false {
+		return fail("success must be false, got %v", response["success"])
+	}
+	if response["result"] != nil {
+		return fail("an error response must not carry a result, got %v", response["result"])
+	}
+	errorObject, ok := response["error"].(map[string]interface{})
+	if !ok {
+		return fail("error must be an object, got %T", response["error"])
+	}
+	if _, ok := errorCode(response); !ok {
+		return fail("error.code must be an integer, got %v", errorObject["code"])
+	}
+	if message, _ := errorObject["message"].(string); message == "" {
+		return fail("error.message must be a non-empty string, got %v", errorObject["message"])
+	}
+	if data, present := errorObject["data"]; present && data != nil {
+		if _, ok := data.(map[string]interface{}); !ok {
+			return fail("error.data must be an object when present, got %T", data)
+		}
+	}
+	return pass("")
+}
+
+func checkTimestamps(t *target) (Status, string) {
+	for _, request := range []string{"ping", unknownMethod} {
+		_, response, err := t.call(request, nil)
+		if err != nil {
+			return failErr(err)
+		}
+		timestamp, _ := response["timestamp"].(string)
+		parsed, err := time.Parse(timestampLayout, timestamp)
+		if err != nil || parsed.Format(timestampLayout) != timestamp {
+			return fail("%s response timestamp %q is not in the format %s", request, timestamp, timestampLayout)
+		}
+		if skew := time.Since(parsed); skew > 5*time.Minute || skew < -5*time.Minute {
+			return fail("%s response timestamp %s is %v away from the local clock", request, timestamp, skew.Round(time.Second))
+		}
+	}
+	return pass("")
+}
+
+func checkConcurrentCorrelation(t *target) (Status, string) {
+	const count = 8
+	e, err := t.open()
+	if err != nil {
+		return failErr(err)
+	}
+	defer e.close()
+
+	pending := map[string]bool{}
+	for i := 0; i < count; i++ {
+		id, err := e.sendRequest("echo", map[string]interface{}{"message": fmt.Sprintf("m%d", i)}, &e.path)
+		if err != nil {
+			return failErr(err)
+		}
+		pending[id] = true
+	}
+	for received := 0; received < count; received++ {
+		response, err := e.receive(t.timeout)
+		if err != nil {
+			return failErr(err)
+		}
+		if response == nil {
+			return fail("received %d of %d responses", received, count)
+		}
+		id, _ := response["request_id"].(string)
+		if !pending[id] {
+			return fail("response for unknown or already answered request %q", id)
+		}
+		delete(pending, id)
+	}
+	return pass("")
+}
+
+func checkPing(t *target) (Status, string) {
+	result, err := t.result("ping", nil)
+	if err != nil {
+		return failErr(err)
+	}
+	if fields, _ := result.(map[string]interface{}); fields["message"] != "pong" {
+		return fail("expected message \"pong\", got %v", result)
+	}
+	return pass("")
+}
+
+func checkEcho(t *target) (Status, string) {
+	message := "conformance ✓ \"quoted\" \\ \n"
+	result, err := t.result("echo", map[string]interface{}{"message": message})
+	if err != nil {
+		return failErr(err)
+	}
+	if fields, _ := result.(map[string]interface{}); fields["echo"] != message {
+		return fail("expected echo %q, got %v", message, result)
+	}
+	return pass("")
+}
+
+func checkGetInfo(t *target) (Status, string) {
+	result, err := t.result("get_info", nil)
+	if err != nil {
+		return failErr(err)
+	}
+	if _, ok := result.(map[string]interface{}); !ok {
+		return fail("expected an object, got %T", result)
+	}
+	return pass("")
+}
+
+func checkValidate(t *target) (Status, string) {
+	result, err := t.result("validate", map[string]interface{}{"message": "{}"})
+	if err != nil {
+		return failErr(err)
+	}
+	if fields, _ := result.(map[string]interface{}); fields["valid"] != true {
+		return fail("expected valid true, got %v", result)
+	}
+	return pass("")
+}
+
+func checkManifest(t *target) (Status, string) {
+	result, err := t.result("manifest", nil)
+	if err != nil {
+		return failErr(err)
+	}
+	fields, ok := result.(map[string]interface{})
+	if !ok {
+		return fail("expected a manifest object, got %T", result)
+	}
+	if version, _ := fields["version"].(string); version == "" {
+		return fail("manifest has no version")
+	}
+	if requests, present := fields["requests"]; present && requests != nil {
+		if _, ok := requests.(map[string]interface{}); !ok {
+			return fail("manifest requests must be an object, got %T", requests)
+		}
+	}
+	return pass("version %v", fields["version"])
+}
+
+func checkUnknownMethod(t *target) (Status, string) {
+	_, response, err := t.call(unknownMethod, nil)
+	if err != nil {
+		return failErr(err)
+	}
+	return expectError(response, models.MethodNotFound)
+}
+
+// checkRejected sends a raw datagram built for a bound endpoint and expects an error response
+func checkRejected(t *target, datagram func(e *endpoint) []byte, expected models.JSONRPCErrorCode) (Status, string) {
+	e, err := t.open()
+	if err != nil {
+		return failErr(err)
+	}
+	defer e.close()
+
+	if err := e.send(datagram(e)); err != nil {
+		return failErr(err)
+	}
+	response, err := e.receive(t.timeout)
+	if err != nil {
+		return failErr(err)
+	}
+	if response == nil {
+		return fail("no response within %v; expected %s (%d)", t.timeout, expected, expected)
+	}
+	if status, detail := expectError(response, expected); status != Pass {
+		return status, detail
+	}
+	if err := t.alive(); err != nil {
+		return failErr(err)
+	}
+	return pass("")
+}
+
+func checkBadJSON(t *target) (Status, string) {
+	return checkRejected(t, func(*endpoint) []byte {
+		return []byte(`{"id": "conformance", "request": "ping"`)
+	}, models.ParseError)
+}
+
+func checkInvalidRequest(t *target) (Status, string) {
+	return checkRejected(t, func(e *endpoint) []byte {
+		message := newRequest("ping", nil)
+		delete(message, "request")
+		delete(message, "method")
+		message["reply_to"] = e.path
+		data, _ := json.Marshal(message)
+		return data
+	}, models.InvalidRequest)
+}
+
+func checkOversized(t *target) (Status, string) {
+	e, err := t.open()
+	if err != nil {
+		return failErr(err)
+	}
+	defer e.close()
+
+	message := strings.Repeat("x", t.maxMessageSize)
+	if _, err := e.sendRequest("echo", map[string]interface{}{"message": message}, &e.path); err != nil {
+		return failErr(err)
+	}
+	response, err := e.receive(t.timeout)
+	if err != nil {
+		return failErr(err)
+	}
+	if response == nil {
+		return fail("a request above %d bytes was dropped without a response", t.maxMessageSize)
+	}
+	if response["success"] != false {
+		return fail("a request above %d bytes was accepted", t.maxMessageSize)
+	}
+	if err := t.alive(); err != nil {
+		return failErr(err)
+	}
+	return pass("rejected with %s", describe(response))
+}
+
+func checkMissingReplyTo(t *target) (Status, string) {
+	e, err := t.open()
+	if err != nil {
+		return failErr(err)
+	}
+	defer e.close()
+
+	if _, err := e.sendRequest("ping", nil, nil); err != nil {
+		return failErr(err)
+	}
+	wait := t.timeout
+	if wait > 500*time.Millisecond {
+		wait = 500 * time.Millisecond
+	}
+	response, err := e.receive(wait)
+	if err != nil {
+		return failErr(err)
+	}
+	if response != nil {
+		return fail("expected no response, got %v", response)
+	}
+	if err := t.alive(); err != nil {
+		return failErr(err)
+	}
+	return pass("")
+}
+
+func checkValidationError(t *target) (Status, string) {
+	result, err := t.result("manifest", nil)
+	if err != nil {
+		return failErr(err)
+	}
+	fields, _ := result.(map[string]interface{})
+	requests, _ := fields["requests"].(map[string]interface{})
+
+	names := make([]string, 0, len(requests))
+	for name := range requests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if manifest.IsBuiltinRequest(name) || !hasRequiredArg(requests[name]) {
+			continue
+		}
+		_, response, err := t.call(name, map[string]interface{}{})
+		if err != nil {
+			return failErr(err)
+		}
+		if status, detail := expectError(response, models.InvalidParams); status != Pass {
+			return status, fmt.Sprintf("%s without arguments: %s", name, detail)
+		}
+		return pass("checked %s", name)
+	}
+	return Skip, "the manifest has no request with a required argument"
+}
+
+// hasRequiredArg reports whether a manifest request definition has a required argument
+func hasRequiredArg(definition interface{}) bool {
+	fields, _ := definition.(map[string]interface{})
+	args, _ := fields["args"].(map[string]interface{})
+	for _, arg := range args {
+		if argFields, _ := arg.(map[string]interface{}); argFields["required"] == true {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+// Package conformance checks any Janus server, whatever its implementation language,
+// against a catalogue of protocol cases and reports a pass/fail matrix
+package conformance
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// Status is the outcome of a case
+type Status string
+
+const (
+	Pass Status = "pass"
+	Fail Status = "fail"
+	Skip Status = "skip" // The server offers nothing the case can check, e.g. no manifest arguments
+)
+
+// Case is one protocol check
+type Case struct {
+	Name        string
+	Category    string
+	Description string
+	run         func(t *target) (Status, string)
+}
+
+// Result is the outcome of a case against one server
+type Result struct {
+	Name       string  `json:"name"`
+	Category   string  `json:"category"`
+	Status     Status  `json:"status"`
+	Detail     string  `json:"detail,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// Report is the outcome of every selected case against one server
+type Report struct {
+	SocketPath     string    `json:"socket_path"`
+	Implementation string    `json:"implementation,omitempty"` // From get_info, when the server reports it
+	Started        time.Time `json:"started"`
+	Results        []Result  `json:"results"`
+	Passed         int       `json:"passed"`
+	Failed         int       `json:"failed"`
+	Skipped        int       `json:"skipped"`
+}
+
+// OK reports whether no case failed
+func (r *Report) OK() bool {
+	return r.Failed == 0
+}
+
+// Options configures a conformance run
+type Options struct {
+	Timeout        time.Duration // How long to wait for each response; defaults to 2s
+	MaxMessageSize int           // The server's request size limit, exceeded by the oversized case; defaults to 64KB
+	Cases          []string      // Case names or categories to run (path.Match patterns); empty runs all
+}
+
+// Cases returns the catalogue in run order
+func Cases() []Case {
+	return catalogue
+}
+
+// Select returns the cases whose name or category matches one of the patterns
+func Select(patterns []string) ([]Case, error) {
+	if len(patterns) == 0 {
+		return catalogue, nil
+	}
+	var selected []Case
+	for _, c := range catalogue {
+		for _, pattern := range patterns {
+			nameMatch, err := path.Match(pattern, c.Name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid case pattern '%s': %w", pattern, err)
+			}
+			categoryMatch, _ := path.Match(pattern, c.Category)
+			if nameMatch || categoryMatch {
+				selected = append(selected, c)
+				break
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no conformance case matches %v", patterns)
+	}
+	return selected, nil
+}
+
+// Run checks the server at socketPath with the selected cases
+func Run(socketPath string, options Options) (*Report, error) {
+	if socketPath == "" {
+		return nil, fmt.Errorf("socket path is required")
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = 2 * time.Second
+	}
+	if options.MaxMessageSize <= 0 {
+		options.MaxMessageSize = 64 * 1024
+	}
+	cases, err := Select(options.Cases)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &target{socketPath: socketPath, timeout: options.Timeout, maxMessageSize: options.MaxMessageSize}
+	report := &Report{SocketPath: socketPath, Started: time.Now()}
+	if info, err := t.result("get_info", nil); err == nil {
+		if fields, ok := info.(map[string]interface{}); ok {
+			report.Implementation, _ = fields["implementation"].(string)
+		}
+	}
+
+	for _, c := range cases {
+		started := time.Now()
+		status, detail := c.run(t)
+		report.Results = append(report.Results, Result{
+			Name:       c.Name,
+			Category:   c.Category,
+			Status:     status,
+			Detail:     detail,
+			DurationMs: float64(time.Since(started).Microseconds()) / 1000,
+		})
+		switch status {
+		case Pass:
+			report.Passed++
+		case Fail:
+			report.Failed++
+		default:
+			report.Skipped++
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,146 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// timestampLayout is the protocol timestamp format: RFC 3339 in UTC with milliseconds
+const timestampLayout = "2006-01-02T15:04:05.000Z"
+
+var socketCounter int64
+
+// target exchanges raw datagrams with the server under test
+type target struct {
+	socketPath     string
+	timeout        time.Duration
+	maxMessageSize int
+}
+
+// endpoint is a bound socket used both to send requests and to receive their responses,
+// so servers that answer the sender's address are covered too
+type endpoint struct {
+	path   string
+	conn   *net.UnixConn
+	server *net.UnixAddr
+}
+
+// open binds a fresh endpoint
+func (t *target) open() (*endpoint, error) {
+	path := fmt.Sprintf("%s/janus_conformance_%d_%d.sock", os.TempDir(), os.Getpid(), atomic.AddInt64(&socketCounter, 1))
+	os.Remove(path)
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind reply socket: %w", err)
+	}
+	conn.SetWriteBuffer(4 * t.maxMessageSize)
+	return &endpoint{path: path, conn: conn, server: &net.UnixAddr{Name: t.socketPath, Net: "unixgram"}}, nil
+}
+
+func (e *endpoint) close() {
+	e.conn.Close()
+	os.Remove(e.path)
+}
+
+// send writes a raw datagram to the server
+func (e *endpoint) send(data []byte) error {
+	if _, err := e.conn.WriteToUnix(data, e.server); err != nil {
+		return fmt.Errorf("failed to send datagram: %w", err)
+	}
+	return nil
+}
+
+// sendRequest sends a well-formed request and returns its id
+// A nil replyTo omits reply_to
+func (e *endpoint) sendRequest(request string, args map[string]interface{}, replyTo *string) (string, error) {
+	message := newRequest(request, args)
+	if replyTo != nil {
+		message["reply_to"] = *replyTo
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+	return message["id"].(string), e.send(data)
+}
+
+// receive waits for the next datagram and decodes it as a JSON object
+// It returns nil without an error when nothing arrives in time
+func (e *endpoint) receive(timeout time.Duration) (map[string]interface{}, error) {
+	buffer := make([]byte, 256*1024)
+	e.conn.SetReadDeadline(time.Now().Add(timeout))
+	n, err := e.conn.Read(buffer)
+	if err != nil {
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	var response map[string]interface{}
+	if err := json.Unmarshal(buffer[:n], &response); err != nil {
+		return nil, fmt.Errorf("response is not a JSON object: %v", err)
+	}
+	return response, nil
+}
+
+// call sends a request with reply_to set to the endpoint and waits for the response
+func (t *target) call(request string, args map[string]interface{}) (string, map[string]interface{}, error) {
+	e, err := t.open()
+	if err != nil {
+		return "", nil, err
+	}
+	defer e.close()
+
+	id, err := e.sendRequest(request, args, &e.path)
+	if err != nil {
+		return "", nil, err
+	}
+	response, err := e.receive(t.timeout)
+	if err != nil {
+		return id, nil, err
+	}
+	if response == nil {
+		return id, nil, fmt.Errorf("no response to %s within %v", request, t.timeout)
+	}
+	return id, response, nil
+}
+
+// result calls a request and returns its result, failing on error responses
+func (t *target) result(request string, args map[string]interface{}) (interface{}, error) {
+	_, response, err := t.call(request, args)
+	if err != nil {
+		return nil, err
+	}
+	if response["success"] != true {
+		return nil, fmt.Errorf("%s failed: %v", request, response["error"])
+	}
+	return response["result"], nil
+}
+
+// alive checks that the server still answers ping
+func (t *target) alive() error {
+	if _, err := t.result("ping", nil); err != nil {
+		return fmt.Errorf("server stopped answering: %w", err)
+	}
+	return nil
+}
+
+// newRequest builds a request in the protocol format
+func newRequest(request string, args map[string]interface{}) map[string]interface{} {
+	message := map[string]interface{}{
+		"id":        uuid.New().String(),
+		"method":    request,
+		"request":   request,
+		"timestamp": time.Now().UTC().Format(timestampLayout),
+	}
+	if args != nil {
+		message["args"] = args
+	}
+	return message
+}
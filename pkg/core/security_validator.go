@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 	"unicode/utf8"
+
+	"GoJanus/pkg/wire"
 )
 
 // SecurityValidator implements all 25+ security mechanisms from Swift manifest
@@ -158,6 +160,16 @@ func (sv *SecurityValidator) ValidateMessageData(data []byte) error {
 		return fmt.Errorf("message data size %d exceeds maximum %d", len(data), sv.maxArgsDataSize)
 	}
 	
+	// Binary CBOR messages legitimately carry NUL bytes in their encoding and byte strings,
+	// so only their text strings are checked
+	if wire.IsCBOR(data) {
+		value, err := wire.UnmarshalCBOR(data)
+		if err != nil {
+			return fmt.Errorf("invalid CBOR message data: %v", err)
+		}
+		return validateCBORText(value)
+	}
+	
 	// Null byte detection
 	for i, b := range data {
 		if b == 0 {
@@ -173,6 +185,33 @@ func (sv *SecurityValidator) ValidateMessageData(data []byte) error {
 	return nil
 }
 
+// validateCBORText rejects NUL bytes in the decoded text strings and map keys of a CBOR message
+// UnmarshalCBOR already rejects text strings that are not valid UTF-8
+func validateCBORText(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		if i := strings.IndexByte(v, 0); i >= 0 {
+			return fmt.Errorf("null byte detected in text string at position %d", i)
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := validateCBORText(item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			if err := validateCBORText(key); err != nil {
+				return err
+			}
+			if err := validateCBORText(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // ValidateJSONStructure performs basic JSON structure validation
 // Matches Swift JSON validation requirements
 func (sv *SecurityValidator) ValidateJSONStructure(data []byte) error {
@@ -0,0 +1,409 @@
+// Package docs renders Janus manifests as human-readable Markdown and HTML documentation
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+)
+
+// Options controls documentation output
+type Options struct {
+	IncludeBuiltins   bool // Document the built-in requests every server answers
+	IncludeErrorCodes bool // Append the full JSON-RPC error code reference
+}
+
+// DefaultOptions returns options that document everything
+func DefaultOptions() Options {
+	return Options{IncludeBuiltins: true, IncludeErrorCodes: true}
+}
+
+// page is the renderer-independent view of a manifest
+type page struct {
+	Title       string
+	Version     string
+	Description string
+	Requests    []requestDoc
+	Builtins    []requestDoc
+	Models      []modelDoc
+	ErrorCodes  []errorDoc
+}
+
+// requestDoc documents one request
+type requestDoc struct {
+	Name        string
+	Anchor      string
+	Description string
+	Args        []fieldDoc
+	Response    *responseDoc
+	Errors      []errorDoc
+}
+
+// responseDoc documents a response shape
+type responseDoc struct {
+	Type        string
+	ModelRef    string
+	Description string
+	Fields      []fieldDoc
+}
+
+// modelDoc documents a model definition
+type modelDoc struct {
+	Name        string
+	Anchor      string
+	Type        string
+	Description string
+	Fields      []fieldDoc
+}
+
+// fieldDoc documents an argument or property; nested fields use dotted names
+type fieldDoc struct {
+	Name        string
+	Type        string
+	ModelRef    string
+	Required    bool
+	Description string
+	Constraints []string
+}
+
+// errorDoc documents an error code
+type errorDoc struct {
+	Code    int
+	Name    string
+	Message string
+}
+
+// buildPage converts a manifest into the page model
+func buildPage(m *manifest.Manifest, opts Options) (*page, error) {
+	if m == nil {
+		return nil, fmt.Errorf("manifest cannot be nil")
+	}
+
+	b := &pageBuilder{manifest: m}
+	p := &page{
+		Title:       m.Name,
+		Version:     m.Version,
+		Description: m.Description,
+	}
+	if p.Title == "" {
+		p.Title = "API Reference"
+	}
+
+	p.Requests = b.requests(m.Requests)
+	if opts.IncludeBuiltins {
+		p.Builtins = b.requests(manifest.BuiltinRequests())
+	}
+
+	for _, name := range sortedKeys(m.Models) {
+		model := m.Models[name]
+		if model == nil {
+			continue
+		}
+		required := make(map[string]bool, len(model.Required))
+		for _, prop := range model.Required {
+			required[prop] = true
+		}
+		p.Models = append(p.Models, modelDoc{
+			Name:        name,
+			Anchor:      modelAnchor(name),
+			Type:        model.Type,
+			Description: model.Description,
+			Fields:      b.fields("", model.Properties, required, map[string]bool{name: true}),
+		})
+	}
+
+	if opts.IncludeErrorCodes {
+		for _, code := range models.KnownJSONRPCErrorCodes() {
+			p.ErrorCodes = append(p.ErrorCodes, newErrorDoc(code))
+		}
+	}
+
+	return p, nil
+}
+
+// pageBuilder expands model references while building the page
+type pageBuilder struct {
+	manifest *manifest.Manifest
+}
+
+// requests documents a request set in name order
+func (b *pageBuilder) requests(requests map[string]*manifest.RequestManifest) []requestDoc {
+	var docs []requestDoc
+	for _, name := range sortedKeys(requests) {
+		request := requests[name]
+		if request == nil {
+			continue
+		}
+
+		doc := requestDoc{
+			Name:        name,
+			Anchor:      requestAnchor(name),
+			Description: request.Description,
+			Args:        b.fields("", request.Args, nil, map[string]bool{}),
+		}
+
+		if request.Response != nil {
+			doc.Response = b.response(request.Response)
+		}
+
+		for _, codeName := range request.ErrorCodes {
+			if code, ok := models.ParseJSONRPCErrorCode(codeName); ok {
+				doc.Errors = append(doc.Errors, newErrorDoc(code))
+			} else {
+				doc.Errors = append(doc.Errors, errorDoc{Name: codeName, Message: "Application-defined error"})
+			}
+		}
+
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// response documents a response, expanding referenced models
+func (b *pageBuilder) response(response *manifest.ResponseManifest) *responseDoc {
+	doc := &responseDoc{
+		Type:        response.Type,
+		ModelRef:    response.ModelRef,
+		Description: response.Description,
+	}
+
+	switch {
+	case response.ModelRef != "":
+		doc.Type = "object"
+		doc.Fields = b.modelFields("", response.ModelRef, map[string]bool{})
+	case response.Items != nil:
+		doc.Type = "array<" + itemTypeName(response.Items) + ">"
+		if response.Items.ModelRef != "" {
+			doc.ModelRef = response.Items.ModelRef
+		}
+		doc.Fields = b.nested("[]", response.Items, map[string]bool{})
+	default:
+		doc.Fields = b.fields("", response.Properties, nil, map[string]bool{})
+	}
+	return doc
+}
+
+// fields documents a property set; visited guards against recursive models
+func (b *pageBuilder) fields(prefix string, properties map[string]*manifest.ArgumentManifest, required map[string]bool, visited map[string]bool) []fieldDoc {
+	var docs []fieldDoc
+	for _, name := range sortedKeys(properties) {
+		arg := properties[name]
+		if arg == nil {
+			continue
+		}
+
+		field := fieldDoc{
+			Name:        prefix + name,
+			Type:        arg.Type,
+			ModelRef:    arg.ModelRef,
+			Required:    arg.Required || required[name],
+			Description: arg.Description,
+			Constraints: constraints(arg),
+		}
+		if arg.Type == "" {
+			field.Type = compositionTypeName(arg)
+		}
+		if arg.Items != nil {
+			field.Type = "array<" + itemTypeName(arg.Items) + ">"
+			if arg.Items.ModelRef != "" {
+				field.ModelRef = arg.Items.ModelRef
+			}
+		}
+
+		docs = append(docs, field)
+		docs = append(docs, b.nested(prefix+name, arg, visited)...)
+	}
+	return docs
+}
+
+// nested documents the children of an argument: properties, items or a referenced model
+func (b *pageBuilder) nested(name string, arg *manifest.ArgumentManifest, visited map[string]bool) []fieldDoc {
+	var docs []fieldDoc
+	if arg.ModelRef != "" {
+		docs = append(docs, b.modelFields(name+".", arg.ModelRef, visited)...)
+	}
+	if len(arg.Properties) > 0 {
+		docs = append(docs, b.fields(name+".", arg.Properties, nil, visited)...)
+	}
+	if arg.Items != nil {
+		docs = append(docs, b.nested(name+"[]", arg.Items, visited)...)
+	}
+	return docs
+}
+
+// modelFields expands a referenced model's properties under prefix
+func (b *pageBuilder) modelFields(prefix, modelRef string, visited map[string]bool) []fieldDoc {
+	model, exists := b.manifest.Models[modelRef]
+	if !exists || model == nil || visited[modelRef] {
+		return nil
+	}
+
+	inner := make(map[string]bool, len(visited)+1)
+	for name := range visited {
+		inner[name] = true
+	}
+	inner[modelRef] = true
+
+	required := make(map[string]bool, len(model.Required))
+	for _, prop := range model.Required {
+		required[prop] = true
+	}
+	return b.fields(prefix, model.Properties, required, inner)
+}
+
+// constraints lists the validation rules of an argument in readable form
+func constraints(arg *manifest.ArgumentManifest) []string {
+	var rules []string
+	if arg.Pattern != "" {
+		rules = append(rules, "pattern: "+arg.Pattern)
+	}
+	if arg.MinLength != nil {
+		rules = append(rules, fmt.Sprintf("min length: %d", *arg.MinLength))
+	}
+	if arg.MaxLength != nil {
+		rules = append(rules, fmt.Sprintf("max length: %d", *arg.MaxLength))
+	}
+	if arg.Minimum != nil {
+		rules = append(rules, fmt.Sprintf("minimum: %v", *arg.Minimum))
+	}
+	if arg.Maximum != nil {
+		rules = append(rules, fmt.Sprintf("maximum: %v", *arg.Maximum))
+	}
+	if arg.MinItems != nil {
+		rules = append(rules, fmt.Sprintf("min items: %d", *arg.MinItems))
+	}
+	if arg.MaxItems != nil {
+		rules = append(rules, fmt.Sprintf("max items: %d", *arg.MaxItems))
+	}
+	if arg.UniqueItems {
+		rules = append(rules, "unique items")
+	}
+	if len(arg.RequiredProperties) > 0 {
+		rules = append(rules, "required: "+strings.Join(arg.RequiredProperties, ", "))
+	}
+	if arg.AdditionalProperties != nil && !*arg.AdditionalProperties {
+		rules = append(rules, "no additional properties")
+	}
+	if len(arg.Enum) > 0 {
+		rules = append(rules, "one of: "+strings.Join(arg.Enum, ", "))
+	}
+	if arg.Default != nil {
+		rules = append(rules, "default: "+formatValue(arg.Default))
+	}
+	if arg.Format != "" {
+		rules = append(rules, "format: "+arg.Format)
+	}
+	if arg.Const != nil {
+		rules = append(rules, "const: "+formatValue(arg.Const))
+	}
+	if arg.Nullable {
+		rules = append(rules, "nullable")
+	}
+	if arg.Discriminator != nil {
+		rules = append(rules, "discriminator: "+arg.Discriminator.PropertyName)
+	}
+	return rules
+}
+
+// compositionTypeName names an untyped argument after its composition, e.g. oneOf(Cat | Dog)
+func compositionTypeName(arg *manifest.ArgumentManifest) string {
+	var parts []string
+	for _, group := range []struct {
+		keyword      string
+		alternatives []*manifest.ArgumentManifest
+	}{{"allOf", arg.AllOf}, {"anyOf", arg.AnyOf}, {"oneOf", arg.OneOf}} {
+		if len(group.alternatives) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(group.alternatives))
+		for _, alternative := range group.alternatives {
+			names = append(names, alternativeTypeName(alternative))
+		}
+		parts = append(parts, group.keyword+"("+strings.Join(names, " | ")+")")
+	}
+	if len(parts) == 0 {
+		if arg.Const != nil {
+			return "const"
+		}
+		return arg.ModelRef
+	}
+	return strings.Join(parts, " ")
+}
+
+// alternativeTypeName names one composition alternative
+func alternativeTypeName(arg *manifest.ArgumentManifest) string {
+	switch {
+	case arg == nil:
+		return "?"
+	case arg.ModelRef != "":
+		return arg.ModelRef
+	case arg.Items != nil:
+		return "array<" + itemTypeName(arg.Items) + ">"
+	case arg.Type != "":
+		return arg.Type
+	case arg.Const != nil:
+		return formatValue(arg.Const)
+	}
+	return compositionTypeName(arg)
+}
+
+// itemTypeName names the element type of an array
+func itemTypeName(items *manifest.ArgumentManifest) string {
+	if items.ModelRef != "" {
+		return items.ModelRef
+	}
+	if items.Items != nil {
+		return "array<" + itemTypeName(items.Items) + ">"
+	}
+	return items.Type
+}
+
+// formatValue renders a default value as JSON
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func newErrorDoc(code models.JSONRPCErrorCode) errorDoc {
+	return errorDoc{Code: int(code), Name: code.String(), Message: code.Message()}
+}
+
+// requestAnchor returns the link target of a request section
+func requestAnchor(name string) string {
+	return "request-" + slug(name)
+}
+
+// modelAnchor returns the link target of a model section
+func modelAnchor(name string) string {
+	return "model-" + slug(name)
+}
+
+// slug lowercases a name and replaces everything but letters and digits with '-'
+func slug(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// sortedKeys returns map keys in sorted order for deterministic output
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,97 @@
+package docs
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+
+	"GoJanus/pkg/manifest"
+)
+
+// RenderHTML renders a manifest as a standalone HTML page
+func RenderHTML(m *manifest.Manifest, opts Options) ([]byte, error) {
+	p, err := buildPage(m, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, p); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var htmlTemplate = template.Must(template.New("page").Funcs(template.FuncMap{
+	"modelAnchor": modelAnchor,
+	"isArray":     func(typeName string) bool { return strings.HasPrefix(typeName, "array<") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 960px; margin: 2rem auto; padding: 0 1rem; color: #1f2328; }
+code { background: #f6f8fa; padding: 0 .25em; border-radius: 4px; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1rem; }
+th, td { border: 1px solid #d0d7de; padding: .4em .6em; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+section { border-top: 1px solid #d0d7de; padding-top: .5rem; }
+.required { color: #cf222e; font-weight: 600; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{with .Version}}<p>Version: <code>{{.}}</code></p>{{end}}
+{{with .Description}}<p>{{.}}</p>{{end}}
+<nav>
+<ul>
+{{range .Requests}}<li><a href="#{{.Anchor}}"><code>{{.Name}}</code></a></li>
+{{end}}{{range .Builtins}}<li><a href="#{{.Anchor}}"><code>{{.Name}}</code></a></li>
+{{end}}{{range .Models}}<li><a href="#{{.Anchor}}">{{.Name}}</a></li>
+{{end}}{{if .ErrorCodes}}<li><a href="#error-codes">Error Codes</a></li>
+{{end}}</ul>
+</nav>
+{{if .Requests}}<h2>Requests</h2>
+{{range .Requests}}{{template "request" .}}{{end}}{{end}}
+{{if .Builtins}}<h2>Built-in Requests</h2>
+<p>Every Janus server answers these requests; they cannot be overridden.</p>
+{{range .Builtins}}{{template "request" .}}{{end}}{{end}}
+{{if .Models}}<h2>Models</h2>
+{{range .Models}}<section id="{{.Anchor}}">
+<h3>{{.Name}}</h3>
+{{with .Description}}<p>{{.}}</p>{{end}}
+<p>Type: <code>{{.Type}}</code></p>
+{{template "fields" .Fields}}
+</section>
+{{end}}{{end}}
+{{if .ErrorCodes}}<h2 id="error-codes">Error Codes</h2>
+{{template "errors" .ErrorCodes}}{{end}}
+</body>
+</html>
+{{define "request"}}<section id="{{.Anchor}}">
+<h3><code>{{.Name}}</code></h3>
+{{with .Description}}<p>{{.}}</p>{{end}}
+<h4>Arguments</h4>
+{{if .Args}}{{template "fields" .Args}}{{else}}<p>None.</p>{{end}}
+{{with .Response}}<h4>Response</h4>
+<p>Type: {{template "type" .}}{{with .Description}} — {{.}}{{end}}</p>
+{{if .Fields}}{{template "fields" .Fields}}{{end}}{{end}}
+{{if .Errors}}<h4>Errors</h4>
+{{template "errors" .Errors}}{{end}}
+</section>
+{{end}}
+{{define "type"}}{{if .ModelRef}}{{if isArray .Type}}<code>array&lt;</code><a href="#{{modelAnchor .ModelRef}}">{{.ModelRef}}</a><code>&gt;</code>{{else}}<a href="#{{modelAnchor .ModelRef}}">{{.ModelRef}}</a>{{end}}{{else}}<code>{{.Type}}</code>{{end}}{{end}}
+{{define "fields"}}{{if .}}<table>
+<thead><tr><th>Name</th><th>Type</th><th>Required</th><th>Description</th><th>Constraints</th></tr></thead>
+<tbody>
+{{range .}}<tr><td><code>{{.Name}}</code></td><td>{{template "type" .}}</td><td>{{if .Required}}<span class="required">yes</span>{{else}}no{{end}}</td><td>{{.Description}}</td><td>{{range $i, $c := .Constraints}}{{if $i}}<br>{{end}}<code>{{$c}}</code>{{end}}</td></tr>
+{{end}}</tbody>
+</table>{{end}}{{end}}
+{{define "errors"}}<table>
+<thead><tr><th>Code</th><th>Name</th><th>Message</th></tr></thead>
+<tbody>
+{{range .}}<tr><td>{{if .Code}}{{.Code}}{{end}}</td><td><code>{{.Name}}</code></td><td>{{.Message}}</td></tr>
+{{end}}</tbody>
+</table>{{end}}
+`))
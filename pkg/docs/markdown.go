@@ -0,0 +1,171 @@
+package docs
+
+import (
+	"fmt"
+	"strings"
+
+	"GoJanus/pkg/manifest"
+)
+
+// RenderMarkdown renders a manifest as a Markdown API reference
+func RenderMarkdown(m *manifest.Manifest, opts Options) ([]byte, error) {
+	p, err := buildPage(m, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", p.Title)
+	if p.Version != "" {
+		fmt.Fprintf(&b, "Version: `%s`\n\n", p.Version)
+	}
+	if p.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", p.Description)
+	}
+
+	writeMarkdownContents(&b, p)
+
+	if len(p.Requests) > 0 {
+		b.WriteString("## Requests\n\n")
+		for _, request := range p.Requests {
+			writeMarkdownRequest(&b, request)
+		}
+	}
+
+	if len(p.Builtins) > 0 {
+		b.WriteString("## Built-in Requests\n\n")
+		b.WriteString("Every Janus server answers these requests; they cannot be overridden.\n\n")
+		for _, request := range p.Builtins {
+			writeMarkdownRequest(&b, request)
+		}
+	}
+
+	if len(p.Models) > 0 {
+		b.WriteString("## Models\n\n")
+		for _, model := range p.Models {
+			fmt.Fprintf(&b, "<a id=\"%s\"></a>\n\n### %s\n\n", model.Anchor, model.Name)
+			if model.Description != "" {
+				fmt.Fprintf(&b, "%s\n\n", model.Description)
+			}
+			fmt.Fprintf(&b, "Type: `%s`\n\n", model.Type)
+			writeMarkdownFields(&b, model.Fields, "Property")
+		}
+	}
+
+	if len(p.ErrorCodes) > 0 {
+		b.WriteString("## Error Codes\n\n")
+		writeMarkdownErrors(&b, p.ErrorCodes)
+	}
+
+	return []byte(b.String()), nil
+}
+
+// writeMarkdownContents writes a linked table of contents
+func writeMarkdownContents(b *strings.Builder, p *page) {
+	if len(p.Requests)+len(p.Builtins)+len(p.Models) == 0 {
+		return
+	}
+
+	b.WriteString("## Contents\n\n")
+	for _, request := range append(append([]requestDoc(nil), p.Requests...), p.Builtins...) {
+		fmt.Fprintf(b, "- [`%s`](#%s)\n", request.Name, request.Anchor)
+	}
+	for _, model := range p.Models {
+		fmt.Fprintf(b, "- [%s](#%s)\n", model.Name, model.Anchor)
+	}
+	if len(p.ErrorCodes) > 0 {
+		b.WriteString("- [Error Codes](#error-codes)\n")
+	}
+	b.WriteString("\n")
+}
+
+// writeMarkdownRequest writes one request section
+func writeMarkdownRequest(b *strings.Builder, request requestDoc) {
+	fmt.Fprintf(b, "<a id=\"%s\"></a>\n\n### `%s`\n\n", request.Anchor, request.Name)
+	if request.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", request.Description)
+	}
+
+	b.WriteString("**Arguments**\n\n")
+	if len(request.Args) == 0 {
+		b.WriteString("None.\n\n")
+	} else {
+		writeMarkdownFields(b, request.Args, "Name")
+	}
+
+	if request.Response != nil {
+		b.WriteString("**Response**\n\n")
+		fmt.Fprintf(b, "Type: %s", markdownType(request.Response.Type, request.Response.ModelRef))
+		if request.Response.Description != "" {
+			fmt.Fprintf(b, " — %s", markdownCell(request.Response.Description))
+		}
+		b.WriteString("\n\n")
+		if len(request.Response.Fields) > 0 {
+			writeMarkdownFields(b, request.Response.Fields, "Field")
+		}
+	}
+
+	if len(request.Errors) > 0 {
+		b.WriteString("**Errors**\n\n")
+		writeMarkdownErrors(b, request.Errors)
+	}
+}
+
+// writeMarkdownFields writes a field table
+func writeMarkdownFields(b *strings.Builder, fields []fieldDoc, nameHeader string) {
+	if len(fields) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "| %s | Type | Required | Description | Constraints |\n", nameHeader)
+	b.WriteString("|---|---|---|---|---|\n")
+	for _, field := range fields {
+		required := "no"
+		if field.Required {
+			required = "yes"
+		}
+		constraints := make([]string, len(field.Constraints))
+		for i, constraint := range field.Constraints {
+			constraints[i] = "`" + markdownCell(constraint) + "`"
+		}
+		fmt.Fprintf(b, "| `%s` | %s | %s | %s | %s |\n",
+			field.Name,
+			markdownType(field.Type, field.ModelRef),
+			required,
+			markdownCell(field.Description),
+			strings.Join(constraints, "<br>"))
+	}
+	b.WriteString("\n")
+}
+
+// writeMarkdownErrors writes an error code table
+func writeMarkdownErrors(b *strings.Builder, errors []errorDoc) {
+	b.WriteString("| Code | Name | Message |\n")
+	b.WriteString("|---|---|---|\n")
+	for _, e := range errors {
+		code := ""
+		if e.Code != 0 {
+			code = fmt.Sprintf("%d", e.Code)
+		}
+		fmt.Fprintf(b, "| %s | `%s` | %s |\n", code, e.Name, markdownCell(e.Message))
+	}
+	b.WriteString("\n")
+}
+
+// markdownType renders a type, linking referenced models
+func markdownType(typeName, modelRef string) string {
+	if modelRef == "" {
+		return "`" + markdownCell(typeName) + "`"
+	}
+	link := fmt.Sprintf("[%s](#%s)", modelRef, modelAnchor(modelRef))
+	if strings.HasPrefix(typeName, "array<") {
+		return "`array<`" + link + "`>`"
+	}
+	return link
+}
+
+// markdownCell escapes text for use inside a table cell
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", " ")
+}
@@ -0,0 +1,115 @@
+package manifest
+
+// builtinRequestNames lists the requests every Janus server answers itself
+var builtinRequestNames = []string{"ping", "echo", "get_info", "manifest", "validate", "slow_process"}
+
+// BuiltinRequestNames returns the names of the built-in requests
+func BuiltinRequestNames() []string {
+	return append([]string(nil), builtinRequestNames...)
+}
+
+// IsBuiltinRequest reports whether a request name is reserved for a built-in request
+func IsBuiltinRequest(name string) bool {
+	for _, builtin := range builtinRequestNames {
+		if name == builtin {
+			return true
+		}
+	}
+	return false
+}
+
+// BuiltinRequests returns manifest definitions describing the built-in requests
+// A fresh copy is returned on every call so callers may modify it
+func BuiltinRequests() map[string]*RequestManifest {
+	timestamp := func() *ArgumentManifest {
+		return &ArgumentManifest{Name: "timestamp", Type: "number", Description: "Server time in Unix seconds"}
+	}
+
+	return map[string]*RequestManifest{
+		"ping": {
+			Name:        "ping",
+			Description: "Connectivity check",
+			Response: &ResponseManifest{
+				Type:        "object",
+				Description: "Pong reply",
+				Properties: map[string]*ArgumentManifest{
+					"message":   {Name: "message", Type: "string", Description: "Always \"pong\""},
+					"timestamp": timestamp(),
+				},
+			},
+		},
+		"echo": {
+			Name:        "echo",
+			Description: "Returns the message it receives",
+			Args: map[string]*ArgumentManifest{
+				"message": {Name: "message", Type: "string", Description: "Message to echo back"},
+			},
+			Response: &ResponseManifest{
+				Type:        "object",
+				Description: "Echoed message",
+				Properties: map[string]*ArgumentManifest{
+					"echo":      {Name: "echo", Type: "string", Description: "The received message"},
+					"timestamp": timestamp(),
+				},
+			},
+		},
+		"get_info": {
+			Name:        "get_info",
+			Description: "Server implementation details",
+			Response: &ResponseManifest{
+				Type:        "object",
+				Description: "Server information",
+				Properties: map[string]*ArgumentManifest{
+					"implementation":   {Name: "implementation", Type: "string", Description: "Implementation language"},
+					"version":          {Name: "version", Type: "string", Description: "Implementation version"},
+					"architecture":     {Name: "architecture", Type: "string", Description: "Socket architecture"},
+					"protocol_version": {Name: "protocol_version", Type: "string", Description: "Janus wire protocol version"},
+					"manifest_version": {Name: "manifest_version", Type: "string", Description: "Highest served manifest version"},
+					"manifest_hash":    {Name: "manifest_hash", Type: "string", Description: "Content hash of the highest served manifest"},
+					"manifest_versions": {
+						Name:        "manifest_versions",
+						Type:        "array",
+						Description: "All served manifest versions in ascending order",
+						Items:       &ArgumentManifest{Type: "string"},
+					},
+					"timestamp": timestamp(),
+				},
+			},
+		},
+		"manifest": {
+			Name:        "manifest",
+			Description: "Returns the server's manifest",
+			Args: map[string]*ArgumentManifest{
+				"version_range": {Name: "version_range", Type: "string", Description: "Semver range selecting one of the served manifest versions"},
+				"if_none_match": {Name: "if_none_match", Type: "string", Description: "Cached content hash; when present the manifest is wrapped with its hash, or replaced by not_modified if unchanged"},
+			},
+			Response: &ResponseManifest{Type: "object", Description: "The highest served manifest within the range"},
+		},
+		"validate": {
+			Name:        "validate",
+			Description: "Checks that the request payload is valid JSON",
+			Response: &ResponseManifest{
+				Type:        "object",
+				Description: "Validation result",
+				Properties: map[string]*ArgumentManifest{
+					"valid":     {Name: "valid", Type: "boolean", Description: "Whether the payload is valid"},
+					"message":   {Name: "message", Type: "string", Description: "Validation message"},
+					"timestamp": timestamp(),
+				},
+			},
+		},
+		"slow_process": {
+			Name:        "slow_process",
+			Description: "Sleeps for two seconds before replying, for timeout testing",
+			Response: &ResponseManifest{
+				Type:        "object",
+				Description: "Processing result",
+				Properties: map[string]*ArgumentManifest{
+					"processed": {Name: "processed", Type: "boolean", Description: "Always true"},
+					"delay":     {Name: "delay", Type: "integer", Description: "Delay in seconds"},
+					"timestamp": timestamp(),
+				},
+			},
+		},
+	}
+}
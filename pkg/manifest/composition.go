@@ -0,0 +1,394 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// hasComposition reports whether the argument uses allOf, anyOf or oneOf
+func (arg *ArgumentManifest) hasComposition() bool {
+	return len(arg.AllOf) > 0 || len(arg.AnyOf) > 0 || len(arg.OneOf) > 0
+}
+
+// compositionErrors enforces allOf, anyOf and oneOf, selecting oneOf variants by discriminator when set
+func (manifest *Manifest) compositionErrors(name, path string, value interface{}, arg *ArgumentManifest) ValidationErrors {
+	var errors ValidationErrors
+	fail := func(constraint, expected, message string) {
+		errors = append(errors, &ValidationError{
+			Field:      name,
+			Path:       path,
+			Message:    message,
+			Value:      value,
+			Expected:   expected,
+			Actual:     value,
+			Constraint: constraint,
+		})
+	}
+
+	if len(arg.AllOf) > 0 {
+		errors = append(errors, manifest.allOfErrors(name, path, value, arg.AllOf)...)
+	}
+
+	if len(arg.AnyOf) > 0 {
+		var failures []string
+		for i, alternative := range arg.AnyOf {
+			altErrors := manifest.argumentErrors(name, path, value, alternative)
+			if len(altErrors) == 0 {
+				failures = nil
+				break
+			}
+			failures = append(failures, fmt.Sprintf("anyOf[%d]: %s", i, validationMessage(altErrors[0])))
+		}
+		if failures != nil {
+			fail("anyOf", fmt.Sprintf("one of %d alternatives", len(arg.AnyOf)),
+				fmt.Sprintf("value matches none of the anyOf alternatives (%s)", strings.Join(failures, "; ")))
+		}
+	}
+
+	if len(arg.OneOf) > 0 {
+		if arg.Discriminator != nil {
+			index, err := selectDiscriminatedVariant(name, path, value, arg)
+			if err != nil {
+				return append(errors, err)
+			}
+			return append(errors, manifest.argumentErrors(name, path, value, arg.OneOf[index])...)
+		}
+
+		var matched []string
+		var failures []string
+		for i, alternative := range arg.OneOf {
+			if altErrors := manifest.argumentErrors(name, path, value, alternative); len(altErrors) > 0 {
+				failures = append(failures, fmt.Sprintf("oneOf[%d]: %s", i, validationMessage(altErrors[0])))
+			} else {
+				matched = append(matched, fmt.Sprintf("oneOf[%d]", i))
+			}
+		}
+		switch {
+		case len(matched) == 0:
+			fail("oneOf", "exactly one matching alternative",
+				fmt.Sprintf("value matches none of the oneOf alternatives (%s)", strings.Join(failures, "; ")))
+		case len(matched) > 1:
+			fail("oneOf", "exactly one matching alternative",
+				fmt.Sprintf("value matches %s, expected exactly one oneOf alternative", strings.Join(matched, " and ")))
+		}
+	}
+
+	return errors
+}
+
+// allOfErrors checks every allOf member as an open object, then rejects only the properties
+// that no member declares when any member is closed
+func (manifest *Manifest) allOfErrors(name, path string, value interface{}, members []*ArgumentManifest) ValidationErrors {
+	object, isObject := value.(map[string]interface{})
+	propertyPointers := make(map[string]bool, len(object))
+	for propName := range object {
+		propertyPointers[childPointer(path, propName)] = true
+	}
+
+	var errors ValidationErrors
+	for _, member := range members {
+		for _, err := range manifest.argumentErrors(name, path, value, member) {
+			if err.Constraint == "additionalProperties" && propertyPointers[err.Path] {
+				continue
+			}
+			errors = append(errors, err)
+		}
+	}
+	if !isObject {
+		return errors
+	}
+
+	declared, closed := manifest.allOfProperties(members, true)
+	if !closed {
+		return errors
+	}
+	for _, propName := range sortedMapKeys(object) {
+		if !declared[propName] {
+			errors = append(errors, &ValidationError{
+				Field:      fmt.Sprintf("%s.%s", name, propName),
+				Path:       childPointer(path, propName),
+				Message:    "unknown property",
+				Value:      object[propName],
+				Actual:     object[propName],
+				Constraint: "additionalProperties",
+			})
+		}
+	}
+	return errors
+}
+
+// allOfProperties collects the properties declared across allOf members and whether any member is closed
+// modelsClosed is the policy for models that leave additionalProperties unset
+func (manifest *Manifest) allOfProperties(members []*ArgumentManifest, modelsClosed bool) (map[string]bool, bool) {
+	declared := make(map[string]bool)
+	closed := false
+	for _, member := range members {
+		if member == nil {
+			continue
+		}
+		if member.ModelRef != "" {
+			if model, exists := manifest.Models[member.ModelRef]; exists && model.Type == "object" {
+				for propName := range model.Properties {
+					declared[propName] = true
+				}
+				closed = closed || isClosed(model.AdditionalProperties, modelsClosed)
+			}
+		}
+		if member.describesObject() {
+			for propName := range member.Properties {
+				declared[propName] = true
+			}
+			closed = closed || isClosed(member.AdditionalProperties, false)
+		}
+		if len(member.AllOf) > 0 {
+			nested, nestedClosed := manifest.allOfProperties(member.AllOf, modelsClosed)
+			for propName := range nested {
+				declared[propName] = true
+			}
+			closed = closed || nestedClosed
+		}
+	}
+	return declared, closed
+}
+
+// validateComposition enforces allOf, anyOf and oneOf on a response value
+func (rv *ResponseValidator) validateComposition(value interface{}, arg *ArgumentManifest, fieldPath string, errors *[]*ValidationError) {
+	if len(arg.AllOf) > 0 {
+		rv.validateAllOf(value, arg.AllOf, fieldPath, errors)
+	}
+
+	if len(arg.AnyOf) > 0 {
+		var failures []string
+		for i, alternative := range arg.AnyOf {
+			var altErrors []*ValidationError
+			rv.validateValue(value, alternative, fieldPath, &altErrors)
+			if len(altErrors) == 0 {
+				failures = nil
+				break
+			}
+			failures = append(failures, fmt.Sprintf("anyOf[%d]: %s", i, altErrors[0].Message))
+		}
+		if failures != nil {
+			*errors = append(*errors, &ValidationError{
+				Field:    fieldPath,
+				Message:  "Value matches none of the anyOf alternatives",
+				Expected: fmt.Sprintf("one of %d alternatives", len(arg.AnyOf)),
+				Actual:   rv.getActualType(value),
+				Context:  strings.Join(failures, "; "),
+			})
+		}
+	}
+
+	if len(arg.OneOf) > 0 {
+		if arg.Discriminator != nil {
+			index, err := selectDiscriminatedVariant(fieldPath, "", value, arg)
+			if err != nil {
+				*errors = append(*errors, err)
+				return
+			}
+			rv.validateValue(value, arg.OneOf[index], fieldPath, errors)
+			return
+		}
+
+		matches := 0
+		var failures []string
+		for i, alternative := range arg.OneOf {
+			var altErrors []*ValidationError
+			rv.validateValue(value, alternative, fieldPath, &altErrors)
+			if len(altErrors) == 0 {
+				matches++
+			} else {
+				failures = append(failures, fmt.Sprintf("oneOf[%d]: %s", i, altErrors[0].Message))
+			}
+		}
+		if matches != 1 {
+			message := "Value matches none of the oneOf alternatives"
+			if matches > 1 {
+				message = fmt.Sprintf("Value matches %d oneOf alternatives", matches)
+			}
+			*errors = append(*errors, &ValidationError{
+				Field:    fieldPath,
+				Message:  message,
+				Expected: "exactly one matching alternative",
+				Actual:   fmt.Sprintf("%d matching alternatives", matches),
+				Context:  strings.Join(failures, "; "),
+			})
+		}
+	}
+}
+
+// validateAllOf checks every allOf member of a response value as an open object, then rejects only
+// the properties that no member declares when any member is closed
+func (rv *ResponseValidator) validateAllOf(value interface{}, members []*ArgumentManifest, fieldPath string, errors *[]*ValidationError) {
+	object, isObject := value.(map[string]interface{})
+	for _, member := range members {
+		var memberErrors []*ValidationError
+		rv.validateValue(value, member, fieldPath, &memberErrors)
+		for _, err := range memberErrors {
+			if propName, ok := err.Actual.(string); ok && err.Message == additionalPropertyMessage && err.Field == joinFieldPath(fieldPath, propName) {
+				continue
+			}
+			*errors = append(*errors, err)
+		}
+	}
+	if !isObject {
+		return
+	}
+
+	declared, closed := rv.manifest.allOfProperties(members, false)
+	if !closed {
+		return
+	}
+	for _, propName := range sortedMapKeys(object) {
+		if !declared[propName] {
+			*errors = append(*errors, &ValidationError{
+				Field:    joinFieldPath(fieldPath, propName),
+				Message:  additionalPropertyMessage,
+				Expected: "declared property",
+				Actual:   propName,
+			})
+		}
+	}
+}
+
+// selectDiscriminatedVariant returns the index of the oneOf variant named by the discriminator property
+// pointer is the JSON pointer of the value, or empty when errors carry no pointer
+func selectDiscriminatedVariant(name, pointer string, value interface{}, arg *ArgumentManifest) (int, *ValidationError) {
+	property := arg.Discriminator.PropertyName
+	propertyPath := property
+	if name != "" {
+		propertyPath = name + "." + property
+	}
+	propertyPointer := ""
+	if pointer != "" {
+		propertyPointer = childPointer(pointer, property)
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return -1, &ValidationError{
+			Field:      name,
+			Path:       pointer,
+			Message:    fmt.Sprintf("expected object with discriminator property '%s'", property),
+			Value:      value,
+			Expected:   "object",
+			Constraint: "discriminator",
+		}
+	}
+
+	selector, ok := object[property].(string)
+	if !ok {
+		return -1, &ValidationError{
+			Field:      propertyPath,
+			Path:       propertyPointer,
+			Message:    "discriminator property must be a string",
+			Value:      object[property],
+			Expected:   "string",
+			Constraint: "discriminator",
+		}
+	}
+
+	modelName := selector
+	if mapped, exists := arg.Discriminator.Mapping[selector]; exists {
+		modelName = mapped
+	}
+	for i, variant := range arg.OneOf {
+		if variant != nil && variant.ModelRef == modelName {
+			return i, nil
+		}
+	}
+
+	return -1, &ValidationError{
+		Field:      propertyPath,
+		Path:       propertyPointer,
+		Message:    fmt.Sprintf("discriminator value '%s' does not select a oneOf variant", selector),
+		Value:      selector,
+		Expected:   strings.Join(discriminatorValues(arg), ", "),
+		Actual:     selector,
+		Constraint: "discriminator",
+	}
+}
+
+// discriminatorValues lists the property values that select a variant
+func discriminatorValues(arg *ArgumentManifest) []string {
+	var values []string
+	if len(arg.Discriminator.Mapping) > 0 {
+		for value := range arg.Discriminator.Mapping {
+			values = append(values, value)
+		}
+	} else {
+		for _, variant := range arg.OneOf {
+			if variant != nil && variant.ModelRef != "" {
+				values = append(values, variant.ModelRef)
+			}
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// validateCompositionManifest checks composition, discriminator, const and format definitions
+func (manifest *Manifest) validateCompositionManifest(context string, arg *ArgumentManifest) error {
+	keywords := []string{"allOf", "anyOf", "oneOf"}
+	for k, alternatives := range [][]*ArgumentManifest{arg.AllOf, arg.AnyOf, arg.OneOf} {
+		for i, alternative := range alternatives {
+			altContext := fmt.Sprintf("%s.%s[%d]", context, keywords[k], i)
+			if alternative == nil {
+				return fmt.Errorf("alternative definition is required for '%s'", altContext)
+			}
+			if err := manifest.validateArgumentManifest(altContext, alternative); err != nil {
+				return err
+			}
+		}
+	}
+
+	if arg.Discriminator != nil {
+		if len(arg.OneOf) == 0 {
+			return fmt.Errorf("discriminator requires oneOf for '%s'", context)
+		}
+		if arg.Discriminator.PropertyName == "" {
+			return fmt.Errorf("discriminator propertyName is required for '%s'", context)
+		}
+		variants := make(map[string]bool)
+		for i, variant := range arg.OneOf {
+			if variant.ModelRef == "" {
+				return fmt.Errorf("discriminated variant '%s.oneOf[%d]' must use modelRef", context, i)
+			}
+			variants[variant.ModelRef] = true
+		}
+		for value, modelName := range arg.Discriminator.Mapping {
+			if !variants[modelName] {
+				return fmt.Errorf("discriminator mapping '%s' for '%s' names model '%s' that is not a oneOf variant", value, context, modelName)
+			}
+		}
+	}
+
+	if arg.Format != "" {
+		if arg.Type != "" && arg.Type != "string" {
+			return fmt.Errorf("format applies to strings only, '%s' has type '%s'", context, arg.Type)
+		}
+		if !IsSupportedFormat(arg.Format) {
+			return fmt.Errorf("unknown format '%s' for '%s', must be one of: %s", arg.Format, context, strings.Join(SupportedFormats(), ", "))
+		}
+	}
+
+	if arg.Const != nil && arg.Type != "" {
+		if err := manifest.validateArgumentType(context, arg.Const, arg); err != nil {
+			return fmt.Errorf("const value for '%s' does not match type '%s'", context, arg.Type)
+		}
+	}
+
+	return nil
+}
+
+// validationMessage extracts the message of a validation error
+func validationMessage(err error) string {
+	if validationErr, ok := err.(*ValidationError); ok {
+		if validationErr.Field != "" {
+			return validationErr.Field + ": " + validationErr.Message
+		}
+		return validationErr.Message
+	}
+	return err.Error()
+}
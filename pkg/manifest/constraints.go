@@ -0,0 +1,126 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MaxValidationDepth bounds how deeply nested a validated value may be
+// Self-referencing models accept arbitrarily deep values; this keeps validation bounded
+const MaxValidationDepth = 64
+
+// describesObject reports whether the argument constrains object properties
+func (arg *ArgumentManifest) describesObject() bool {
+	return len(arg.Properties) > 0 || len(arg.RequiredProperties) > 0 || arg.AdditionalProperties != nil
+}
+
+// requiredPropertySet merges a required list with the properties' own required flags
+func requiredPropertySet(properties map[string]*ArgumentManifest, required map[string]bool) map[string]bool {
+	merged := make(map[string]bool, len(required))
+	for name := range required {
+		merged[name] = true
+	}
+	for name, prop := range properties {
+		if prop != nil && prop.Required {
+			merged[name] = true
+		}
+	}
+	return merged
+}
+
+// duplicateItems returns the indexes of the first two equal items
+func duplicateItems(items []interface{}) (int, int, bool) {
+	seen := make(map[string]int, len(items))
+	for i, item := range items {
+		data, err := json.Marshal(normalizeValue(item))
+		if err != nil {
+			continue
+		}
+		if first, exists := seen[string(data)]; exists {
+			return first, i, true
+		}
+		seen[string(data)] = i
+	}
+	return 0, 0, false
+}
+
+// validateCollectionManifest checks array and object constraint definitions
+func validateCollectionManifest(context string, arg *ArgumentManifest) error {
+	if arg.MinItems != nil && *arg.MinItems < 0 {
+		return fmt.Errorf("minItems cannot be negative for '%s'", context)
+	}
+	if arg.MaxItems != nil && *arg.MaxItems < 0 {
+		return fmt.Errorf("maxItems cannot be negative for '%s'", context)
+	}
+	if arg.MinItems != nil && arg.MaxItems != nil && *arg.MinItems > *arg.MaxItems {
+		return fmt.Errorf("minItems cannot be greater than maxItems for '%s'", context)
+	}
+	if (arg.MinItems != nil || arg.MaxItems != nil || arg.UniqueItems) && arg.Type != "" && arg.Type != "array" {
+		return fmt.Errorf("array constraints apply to arrays only, '%s' has type '%s'", context, arg.Type)
+	}
+
+	if (len(arg.RequiredProperties) > 0 || arg.AdditionalProperties != nil) && arg.Type != "" && arg.Type != "object" {
+		return fmt.Errorf("object constraints apply to objects only, '%s' has type '%s'", context, arg.Type)
+	}
+	if len(arg.Properties) > 0 {
+		for _, name := range arg.RequiredProperties {
+			if _, exists := arg.Properties[name]; !exists {
+				return fmt.Errorf("required property '%s' is not defined for '%s'", name, context)
+			}
+		}
+	}
+
+	for name, prop := range arg.Properties {
+		if prop == nil {
+			return fmt.Errorf("property definition is required for '%s.%s'", context, name)
+		}
+	}
+	return nil
+}
+
+// validateArrayConstraints enforces minItems, maxItems and uniqueItems on a response array
+func (rv *ResponseValidator) validateArrayConstraints(value []interface{}, arg *ArgumentManifest, fieldPath string, errors *[]*ValidationError) {
+	if arg.MinItems != nil && len(value) < *arg.MinItems {
+		*errors = append(*errors, &ValidationError{
+			Field:    fieldPath,
+			Message:  fmt.Sprintf("Array has too few items (%d < %d)", len(value), *arg.MinItems),
+			Expected: fmt.Sprintf("at least %d items", *arg.MinItems),
+			Actual:   fmt.Sprintf("%d items", len(value)),
+		})
+	}
+	if arg.MaxItems != nil && len(value) > *arg.MaxItems {
+		*errors = append(*errors, &ValidationError{
+			Field:    fieldPath,
+			Message:  fmt.Sprintf("Array has too many items (%d > %d)", len(value), *arg.MaxItems),
+			Expected: fmt.Sprintf("at most %d items", *arg.MaxItems),
+			Actual:   fmt.Sprintf("%d items", len(value)),
+		})
+	}
+	if arg.UniqueItems {
+		if first, second, found := duplicateItems(value); found {
+			*errors = append(*errors, &ValidationError{
+				Field:    fieldPath,
+				Message:  "Array items are not unique",
+				Expected: "unique items",
+				Actual:   fmt.Sprintf("items %d and %d are equal", first, second),
+			})
+		}
+	}
+}
+
+// joinFieldPath appends a property name to a dotted field path
+func joinFieldPath(fieldPath, name string) string {
+	if fieldPath == "" {
+		return name
+	}
+	return fieldPath + "." + name
+}
+
+// fieldDepth returns the nesting depth of a dotted field path such as items[3].name
+func fieldDepth(fieldPath string) int {
+	if fieldPath == "" {
+		return 0
+	}
+	return 1 + strings.Count(fieldPath, ".") + strings.Count(fieldPath, "[")
+}
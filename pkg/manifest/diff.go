@@ -0,0 +1,561 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeSeverity classifies a manifest change by its effect on existing clients
+type ChangeSeverity string
+
+const (
+	// ChangeBreaking may cause existing clients to fail
+	ChangeBreaking ChangeSeverity = "breaking"
+	// ChangeCompatible keeps existing clients working
+	ChangeCompatible ChangeSeverity = "compatible"
+)
+
+// Change describes one difference between two manifests
+type Change struct {
+	Path     string         `json:"path"` // Dotted location, e.g. requests.get_user.args.user_id.maxLength
+	Kind     string         `json:"kind"` // Machine-readable change kind, e.g. "request_removed"
+	Severity ChangeSeverity `json:"severity"`
+	Message  string         `json:"message"`
+	Old      interface{}    `json:"old,omitempty"`
+	New      interface{}    `json:"new,omitempty"`
+}
+
+// DiffResult lists the changes between two manifests
+type DiffResult struct {
+	OldVersion string   `json:"oldVersion"`
+	NewVersion string   `json:"newVersion"`
+	Breaking   bool     `json:"breaking"`
+	Changes    []Change `json:"changes"`
+}
+
+// BreakingChanges returns only the breaking changes
+func (result *DiffResult) BreakingChanges() []Change {
+	var changes []Change
+	for _, change := range result.Changes {
+		if change.Severity == ChangeBreaking {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// Summary returns a one-line description, e.g. "Manifest 1.0.0 -> 1.1.0: 3 change(s), 1 breaking"
+func (result *DiffResult) Summary() string {
+	return fmt.Sprintf("Manifest %s -> %s: %d change(s), %d breaking",
+		result.OldVersion, result.NewVersion, len(result.Changes), len(result.BreakingChanges()))
+}
+
+// dataFlow tells which side produces a value, which decides whether tightening or loosening breaks clients
+type dataFlow int
+
+const (
+	flowInput  dataFlow = 1 << iota // Sent by clients (request arguments)
+	flowOutput                      // Sent by servers (responses)
+)
+
+// Diff compares two manifests and classifies every change as breaking or compatible
+// Arguments break when they accept less; responses break when they may return something new
+func Diff(oldManifest, newManifest *Manifest) *DiffResult {
+	d := &differ{
+		oldManifest: oldManifest,
+		newManifest: newManifest,
+		modelFlows:  modelFlows(oldManifest),
+	}
+	for name, flow := range modelFlows(newManifest) {
+		d.modelFlows[name] |= flow
+	}
+
+	result := &DiffResult{
+		OldVersion: oldManifest.Version,
+		NewVersion: newManifest.Version,
+	}
+
+	d.diffRequests()
+	d.diffModels()
+
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		return d.changes[i].Path < d.changes[j].Path
+	})
+	result.Changes = d.changes
+	if result.Changes == nil {
+		result.Changes = []Change{}
+	}
+	result.Breaking = len(result.BreakingChanges()) > 0
+	return result
+}
+
+// differ accumulates changes
+type differ struct {
+	oldManifest *Manifest
+	newManifest *Manifest
+	modelFlows  map[string]dataFlow
+	changes     []Change
+}
+
+func (d *differ) add(path, kind string, severity ChangeSeverity, message string, oldValue, newValue interface{}) {
+	d.changes = append(d.changes, Change{
+		Path:     path,
+		Kind:     kind,
+		Severity: severity,
+		Message:  message,
+		Old:      oldValue,
+		New:      newValue,
+	})
+}
+
+// severityFor returns breaking when the change breaks any flow the value takes part in
+// breaksInput and breaksOutput say whether the change breaks each flow
+func severityFor(flow dataFlow, breaksInput, breaksOutput bool) ChangeSeverity {
+	if (flow&flowInput != 0 && breaksInput) || (flow&flowOutput != 0 && breaksOutput) {
+		return ChangeBreaking
+	}
+	return ChangeCompatible
+}
+
+// diffRequests compares the request sets
+func (d *differ) diffRequests() {
+	for _, name := range unionKeys(d.oldManifest.Requests, d.newManifest.Requests) {
+		oldRequest := d.oldManifest.Requests[name]
+		newRequest := d.newManifest.Requests[name]
+		path := "requests." + name
+
+		switch {
+		case newRequest == nil:
+			d.add(path, "request_removed", ChangeBreaking, fmt.Sprintf("request '%s' was removed", name), nil, nil)
+		case oldRequest == nil:
+			d.add(path, "request_added", ChangeCompatible, fmt.Sprintf("request '%s' was added", name), nil, nil)
+		default:
+			d.diffArgumentSet(path+".args", oldRequest.Args, newRequest.Args, nil, nil, flowInput)
+			d.diffResponse(path+".response", oldRequest.Response, newRequest.Response)
+			d.diffErrorCodes(path+".errorCodes", oldRequest.ErrorCodes, newRequest.ErrorCodes)
+		}
+	}
+}
+
+// diffModels compares the model sets
+func (d *differ) diffModels() {
+	for _, name := range unionKeys(d.oldManifest.Models, d.newManifest.Models) {
+		oldModel := d.oldManifest.Models[name]
+		newModel := d.newManifest.Models[name]
+		path := "models." + name
+
+		flow := d.modelFlows[name]
+		if flow == 0 {
+			// Unreferenced models may be used by clients directly: treat them as both
+			flow = flowInput | flowOutput
+		}
+
+		switch {
+		case newModel == nil:
+			d.add(path, "model_removed", ChangeBreaking, fmt.Sprintf("model '%s' was removed", name), nil, nil)
+		case oldModel == nil:
+			d.add(path, "model_added", ChangeCompatible, fmt.Sprintf("model '%s' was added", name), nil, nil)
+		default:
+			if oldModel.Type != newModel.Type {
+				d.add(path+".type", "type_changed", ChangeBreaking,
+					fmt.Sprintf("model '%s' type changed from %s to %s", name, oldModel.Type, newModel.Type), oldModel.Type, newModel.Type)
+			}
+			d.diffArgumentSet(path+".properties", oldModel.Properties, newModel.Properties,
+				stringSet(oldModel.Required), stringSet(newModel.Required), flow)
+			// Unset models are closed for arguments only, so compare explicit settings
+			if !reflect.DeepEqual(oldModel.AdditionalProperties, newModel.AdditionalProperties) {
+				d.diffAdditionalProperties(path+".additionalProperties", isClosed(oldModel.AdditionalProperties, flow&flowOutput == 0),
+					isClosed(newModel.AdditionalProperties, flow&flowOutput == 0), flow)
+			}
+		}
+	}
+}
+
+// diffResponse compares response shapes
+func (d *differ) diffResponse(path string, oldResponse, newResponse *ResponseManifest) {
+	switch {
+	case oldResponse == nil && newResponse == nil:
+		return
+	case newResponse == nil:
+		d.add(path, "response_removed", ChangeBreaking, "response definition was removed", nil, nil)
+		return
+	case oldResponse == nil:
+		d.add(path, "response_added", ChangeCompatible, "response definition was added", nil, nil)
+		return
+	}
+
+	if oldResponse.Type != newResponse.Type {
+		d.add(path+".type", "type_changed", ChangeBreaking,
+			fmt.Sprintf("response type changed from %s to %s", oldResponse.Type, newResponse.Type), oldResponse.Type, newResponse.Type)
+	}
+	if oldResponse.ModelRef != newResponse.ModelRef {
+		d.add(path+".modelRef", "model_ref_changed", ChangeBreaking,
+			fmt.Sprintf("response model changed from '%s' to '%s'", oldResponse.ModelRef, newResponse.ModelRef), oldResponse.ModelRef, newResponse.ModelRef)
+	}
+	d.diffArgumentSet(path+".properties", oldResponse.Properties, newResponse.Properties, nil, nil, flowOutput)
+	d.diffItems(path+".items", oldResponse.Items, newResponse.Items, flowOutput)
+}
+
+// diffErrorCodes reports added and removed error codes; neither breaks clients
+func (d *differ) diffErrorCodes(path string, oldCodes, newCodes []string) {
+	oldSet, newSet := stringSet(oldCodes), stringSet(newCodes)
+	for _, code := range sortedMapKeys(newSet) {
+		if !oldSet[code] {
+			d.add(path, "error_code_added", ChangeCompatible, fmt.Sprintf("error code %s was added", code), nil, code)
+		}
+	}
+	for _, code := range sortedMapKeys(oldSet) {
+		if !newSet[code] {
+			d.add(path, "error_code_removed", ChangeCompatible, fmt.Sprintf("error code %s was removed", code), code, nil)
+		}
+	}
+}
+
+// diffArgumentSet compares arguments or properties
+// oldRequired and newRequired carry model-level required lists in addition to the per-argument flag
+func (d *differ) diffArgumentSet(path string, oldArgs, newArgs map[string]*ArgumentManifest, oldRequired, newRequired map[string]bool, flow dataFlow) {
+	for _, name := range unionKeys(oldArgs, newArgs) {
+		oldArg := oldArgs[name]
+		newArg := newArgs[name]
+		argPath := path + "." + name
+
+		switch {
+		case newArg == nil:
+			// Removed input arguments are rejected as unknown; removed output properties disappear
+			d.add(argPath, "property_removed", ChangeBreaking, fmt.Sprintf("'%s' was removed", name), nil, nil)
+		case oldArg == nil:
+			required := newArg.Required || newRequired[name]
+			kind := "property_added"
+			if required {
+				kind = "required_property_added"
+			}
+			d.add(argPath, kind, severityFor(flow, required, false), fmt.Sprintf("'%s' was added", name), nil, nil)
+		default:
+			wasRequired := oldArg.Required || oldRequired[name]
+			isRequired := newArg.Required || newRequired[name]
+			if wasRequired != isRequired {
+				kind := "became_optional"
+				if isRequired {
+					kind = "became_required"
+				}
+				d.add(argPath+".required", kind, severityFor(flow, isRequired, !isRequired),
+					fmt.Sprintf("'%s' required changed from %t to %t", name, wasRequired, isRequired), wasRequired, isRequired)
+			}
+			d.diffArgument(argPath, oldArg, newArg, flow)
+		}
+	}
+}
+
+// diffArgument compares a single argument or property
+func (d *differ) diffArgument(path string, oldArg, newArg *ArgumentManifest, flow dataFlow) {
+	if oldArg.Type != newArg.Type {
+		// integer -> number accepts more input; number -> integer returns less output
+		widened := oldArg.Type == "integer" && newArg.Type == "number"
+		narrowed := oldArg.Type == "number" && newArg.Type == "integer"
+		d.add(path+".type", "type_changed", severityFor(flow, !widened, !narrowed),
+			fmt.Sprintf("type changed from %s to %s", oldArg.Type, newArg.Type), oldArg.Type, newArg.Type)
+	}
+
+	if oldArg.ModelRef != newArg.ModelRef {
+		d.add(path+".modelRef", "model_ref_changed", ChangeBreaking,
+			fmt.Sprintf("model reference changed from '%s' to '%s'", oldArg.ModelRef, newArg.ModelRef), oldArg.ModelRef, newArg.ModelRef)
+	}
+
+	if oldArg.Pattern != newArg.Pattern {
+		// Any new or different pattern may reject values the old one accepted
+		tightened := newArg.Pattern != ""
+		loosened := oldArg.Pattern != ""
+		d.add(path+".pattern", "pattern_changed", severityFor(flow, tightened, loosened),
+			fmt.Sprintf("pattern changed from %q to %q", oldArg.Pattern, newArg.Pattern), oldArg.Pattern, newArg.Pattern)
+	}
+
+	d.diffLowerBound(path+".minLength", intPtrToFloat(oldArg.MinLength), intPtrToFloat(newArg.MinLength), flow)
+	d.diffUpperBound(path+".maxLength", intPtrToFloat(oldArg.MaxLength), intPtrToFloat(newArg.MaxLength), flow)
+	d.diffLowerBound(path+".minimum", oldArg.Minimum, newArg.Minimum, flow)
+	d.diffUpperBound(path+".maximum", oldArg.Maximum, newArg.Maximum, flow)
+	d.diffEnum(path+".enum", oldArg.Enum, newArg.Enum, flow)
+
+	if !reflect.DeepEqual(oldArg.Default, newArg.Default) {
+		d.add(path+".default", "default_changed", ChangeCompatible, "default value changed", oldArg.Default, newArg.Default)
+	}
+
+	if oldArg.Nullable != newArg.Nullable {
+		// Dropping nullable rejects null input; adding it may return null output
+		d.add(path+".nullable", "nullable_changed", severityFor(flow, oldArg.Nullable, newArg.Nullable),
+			fmt.Sprintf("nullable changed from %t to %t", oldArg.Nullable, newArg.Nullable), oldArg.Nullable, newArg.Nullable)
+	}
+
+	if !valuesEqual(oldArg.Const, newArg.Const) {
+		tightened := newArg.Const != nil
+		loosened := oldArg.Const != nil
+		d.add(path+".const", "const_changed", severityFor(flow, tightened, loosened),
+			fmt.Sprintf("const changed from %v to %v", oldArg.Const, newArg.Const), oldArg.Const, newArg.Const)
+	}
+
+	if oldArg.Format != newArg.Format {
+		tightened := newArg.Format != ""
+		loosened := oldArg.Format != ""
+		d.add(path+".format", "format_changed", severityFor(flow, tightened, loosened),
+			fmt.Sprintf("format changed from %q to %q", oldArg.Format, newArg.Format), oldArg.Format, newArg.Format)
+	}
+
+	// Extra allOf alternatives constrain further; extra anyOf/oneOf alternatives accept more
+	d.diffAlternatives(path+".allOf", oldArg.AllOf, newArg.AllOf, true, flow)
+	d.diffAlternatives(path+".anyOf", oldArg.AnyOf, newArg.AnyOf, false, flow)
+	d.diffAlternatives(path+".oneOf", oldArg.OneOf, newArg.OneOf, false, flow)
+
+	if !reflect.DeepEqual(oldArg.Discriminator, newArg.Discriminator) {
+		d.add(path+".discriminator", "discriminator_changed", ChangeBreaking, "discriminator changed", oldArg.Discriminator, newArg.Discriminator)
+	}
+
+	d.diffLowerBound(path+".minItems", intPtrToFloat(oldArg.MinItems), intPtrToFloat(newArg.MinItems), flow)
+	d.diffUpperBound(path+".maxItems", intPtrToFloat(oldArg.MaxItems), intPtrToFloat(newArg.MaxItems), flow)
+	if oldArg.UniqueItems != newArg.UniqueItems {
+		d.add(path+".uniqueItems", "unique_items_changed", severityFor(flow, newArg.UniqueItems, oldArg.UniqueItems),
+			fmt.Sprintf("uniqueItems changed from %t to %t", oldArg.UniqueItems, newArg.UniqueItems), oldArg.UniqueItems, newArg.UniqueItems)
+	}
+	d.diffAdditionalProperties(path+".additionalProperties", isClosed(oldArg.AdditionalProperties, false), isClosed(newArg.AdditionalProperties, false), flow)
+
+	d.diffArgumentSet(path+".properties", oldArg.Properties, newArg.Properties,
+		stringSet(oldArg.RequiredProperties), stringSet(newArg.RequiredProperties), flow)
+	d.diffItems(path+".items", oldArg.Items, newArg.Items, flow)
+}
+
+// diffAdditionalProperties compares whether an object accepts undeclared properties
+func (d *differ) diffAdditionalProperties(path string, wasClosed, isClosed bool, flow dataFlow) {
+	if wasClosed == isClosed {
+		return
+	}
+	d.add(path, "additional_properties_changed", severityFor(flow, isClosed, wasClosed),
+		fmt.Sprintf("additional properties changed from %s to %s", openness(wasClosed), openness(isClosed)), !wasClosed, !isClosed)
+}
+
+// isClosed resolves an additionalProperties setting, using closedByDefault when unset
+func isClosed(additionalProperties *bool, closedByDefault bool) bool {
+	if additionalProperties == nil {
+		return closedByDefault
+	}
+	return !*additionalProperties
+}
+
+// openness describes an object's additional property policy
+func openness(closed bool) string {
+	if closed {
+		return "rejected"
+	}
+	return "allowed"
+}
+
+// diffAlternatives compares composition alternatives by content
+// additionsTighten is true for allOf, where each alternative is an extra constraint
+func (d *differ) diffAlternatives(path string, oldAlternatives, newAlternatives []*ArgumentManifest, additionsTighten bool, flow dataFlow) {
+	oldSet := alternativeSet(oldAlternatives)
+	newSet := alternativeSet(newAlternatives)
+
+	for i, alternative := range newAlternatives {
+		if key := alternativeKey(alternative); !oldSet[key] {
+			d.add(fmt.Sprintf("%s[%d]", path, i), "alternative_added", severityFor(flow, additionsTighten, !additionsTighten),
+				"alternative was added", nil, alternative)
+		}
+	}
+	for i, alternative := range oldAlternatives {
+		if key := alternativeKey(alternative); !newSet[key] {
+			d.add(fmt.Sprintf("%s[%d]", path, i), "alternative_removed", severityFor(flow, !additionsTighten, additionsTighten),
+				"alternative was removed", alternative, nil)
+		}
+	}
+}
+
+// alternativeSet indexes alternatives by content
+func alternativeSet(alternatives []*ArgumentManifest) map[string]bool {
+	set := make(map[string]bool, len(alternatives))
+	for _, alternative := range alternatives {
+		set[alternativeKey(alternative)] = true
+	}
+	return set
+}
+
+// alternativeKey identifies an alternative by its JSON encoding
+func alternativeKey(alternative *ArgumentManifest) string {
+	data, _ := json.Marshal(alternative)
+	return string(data)
+}
+
+// diffItems compares array item definitions
+func (d *differ) diffItems(path string, oldItems, newItems *ArgumentManifest, flow dataFlow) {
+	switch {
+	case oldItems == nil && newItems == nil:
+	case oldItems == nil:
+		d.add(path, "items_added", severityFor(flow, true, false), "item constraints were added", nil, nil)
+	case newItems == nil:
+		d.add(path, "items_removed", severityFor(flow, false, true), "item constraints were removed", nil, nil)
+	default:
+		d.diffArgument(path, oldItems, newItems, flow)
+	}
+}
+
+// diffLowerBound compares minimum-style constraints: raising them tightens
+func (d *differ) diffLowerBound(path string, oldValue, newValue *float64, flow dataFlow) {
+	if floatPtrEqual(oldValue, newValue) {
+		return
+	}
+	tightened := newValue != nil && (oldValue == nil || *newValue > *oldValue)
+	d.diffBound(path, oldValue, newValue, tightened, flow)
+}
+
+// diffUpperBound compares maximum-style constraints: lowering them tightens
+func (d *differ) diffUpperBound(path string, oldValue, newValue *float64, flow dataFlow) {
+	if floatPtrEqual(oldValue, newValue) {
+		return
+	}
+	tightened := newValue != nil && (oldValue == nil || *newValue < *oldValue)
+	d.diffBound(path, oldValue, newValue, tightened, flow)
+}
+
+func (d *differ) diffBound(path string, oldValue, newValue *float64, tightened bool, flow dataFlow) {
+	kind := "constraint_loosened"
+	if tightened {
+		kind = "constraint_tightened"
+	}
+	keyword := path[strings.LastIndex(path, ".")+1:]
+	d.add(path, kind, severityFor(flow, tightened, !tightened),
+		fmt.Sprintf("%s changed from %s to %s", keyword, formatBound(oldValue), formatBound(newValue)),
+		floatPtrValue(oldValue), floatPtrValue(newValue))
+}
+
+// diffEnum compares enumerations: removing values narrows, adding values widens
+func (d *differ) diffEnum(path string, oldEnum, newEnum []string, flow dataFlow) {
+	if reflect.DeepEqual(stringSet(oldEnum), stringSet(newEnum)) {
+		return
+	}
+
+	// An empty enum allows any value
+	oldSet, newSet := stringSet(oldEnum), stringSet(newEnum)
+	narrowed := len(newEnum) > 0 && (len(oldEnum) == 0 || !isSubset(oldSet, newSet))
+	widened := len(oldEnum) > 0 && (len(newEnum) == 0 || !isSubset(newSet, oldSet))
+
+	kind := "enum_widened"
+	if narrowed {
+		kind = "enum_narrowed"
+	}
+	d.add(path, kind, severityFor(flow, narrowed, widened),
+		fmt.Sprintf("enum changed from %v to %v", oldEnum, newEnum), oldEnum, newEnum)
+}
+
+// modelFlows records whether each model is reachable from arguments, responses or both
+func modelFlows(m *Manifest) map[string]dataFlow {
+	flows := make(map[string]dataFlow)
+
+	var visitArg func(arg *ArgumentManifest, flow dataFlow)
+	var visitModel func(name string, flow dataFlow)
+
+	visitModel = func(name string, flow dataFlow) {
+		if flows[name]&flow == flow {
+			return
+		}
+		flows[name] |= flow
+		if model := m.Models[name]; model != nil {
+			for _, prop := range model.Properties {
+				visitArg(prop, flow)
+			}
+		}
+	}
+	visitArg = func(arg *ArgumentManifest, flow dataFlow) {
+		if arg == nil {
+			return
+		}
+		if arg.ModelRef != "" {
+			visitModel(arg.ModelRef, flow)
+		}
+		for _, prop := range arg.Properties {
+			visitArg(prop, flow)
+		}
+		visitArg(arg.Items, flow)
+		for _, alternatives := range [][]*ArgumentManifest{arg.AllOf, arg.AnyOf, arg.OneOf} {
+			for _, alternative := range alternatives {
+				visitArg(alternative, flow)
+			}
+		}
+	}
+
+	for _, request := range m.Requests {
+		if request == nil {
+			continue
+		}
+		for _, arg := range request.Args {
+			visitArg(arg, flowInput)
+		}
+		if response := request.Response; response != nil {
+			if response.ModelRef != "" {
+				visitModel(response.ModelRef, flowOutput)
+			}
+			for _, prop := range response.Properties {
+				visitArg(prop, flowOutput)
+			}
+			visitArg(response.Items, flowOutput)
+		}
+	}
+	return flows
+}
+
+// unionKeys returns the sorted union of two maps' keys
+func unionKeys[V any](a, b map[string]V) []string {
+	set := make(map[string]bool, len(a)+len(b))
+	for key := range a {
+		set[key] = true
+	}
+	for key := range b {
+		set[key] = true
+	}
+	return sortedMapKeys(set)
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+// isSubset reports whether every element of a is in b
+func isSubset(a, b map[string]bool) bool {
+	for value := range a {
+		if !b[value] {
+			return false
+		}
+	}
+	return true
+}
+
+func intPtrToFloat(value *int) *float64 {
+	if value == nil {
+		return nil
+	}
+	f := float64(*value)
+	return &f
+}
+
+func floatPtrEqual(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func floatPtrValue(value *float64) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func formatBound(value *float64) string {
+	if value == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%v", *value)
+}
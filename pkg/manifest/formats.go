@@ -0,0 +1,130 @@
+package manifest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// stringFormats maps each supported format name to its checker
+var stringFormats = map[string]func(string) bool{
+	"binary": func(value string) bool {
+		_, err := base64.StdEncoding.DecodeString(value)
+		return err == nil
+	},
+	"uuid": func(value string) bool {
+		return uuidPattern.MatchString(value)
+	},
+	"date-time": func(value string) bool {
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	},
+	"date": func(value string) bool {
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	},
+	"email": func(value string) bool {
+		// Bare addresses only; display names like "Ann <ann@example.com>" are rejected
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	},
+	"uri": func(value string) bool {
+		parsed, err := url.Parse(value)
+		return err == nil && parsed.Scheme != "" && !strings.ContainsAny(value, " \t\n")
+	},
+	"ipv4": func(value string) bool {
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() != nil && strings.Count(value, ".") == 3
+	},
+	"ipv6": func(value string) bool {
+		ip := net.ParseIP(value)
+		return ip != nil && strings.Contains(value, ":")
+	},
+}
+
+// SupportedFormats returns the string formats understood by the validators
+func SupportedFormats() []string {
+	formats := make([]string, 0, len(stringFormats))
+	for format := range stringFormats {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// IsSupportedFormat reports whether format is a known string format
+func IsSupportedFormat(format string) bool {
+	_, exists := stringFormats[format]
+	return exists
+}
+
+// matchesFormat reports whether value satisfies format; unknown formats never match
+func matchesFormat(format, value string) bool {
+	check, exists := stringFormats[format]
+	return exists && check(value)
+}
+
+// binaryValue returns a byte string, e.g. decoded from CBOR, in its base64 JSON form when arg has the binary format
+// It is then validated exactly like the same value sent as JSON
+func binaryValue(value interface{}, arg *ArgumentManifest) interface{} {
+	if data, ok := value.([]byte); ok && arg.Format == "binary" {
+		return base64.StdEncoding.EncodeToString(data)
+	}
+	return value
+}
+
+// valuesEqual compares two decoded JSON or YAML values structurally
+// Numbers compare by value whatever their Go type, so const 1 matches 1.0
+func valuesEqual(a, b interface{}) bool {
+	left, leftErr := json.Marshal(normalizeValue(a))
+	right, rightErr := json.Marshal(normalizeValue(b))
+	return leftErr == nil && rightErr == nil && string(left) == string(right)
+}
+
+// normalizeValue converts YAML-decoded maps to JSON-compatible ones and numbers to float64
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[key] = normalizeValue(item)
+		}
+		return normalized
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			keyStr, _ := json.Marshal(key)
+			normalized[strings.Trim(string(keyStr), `"`)] = normalizeValue(item)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeValue(item)
+		}
+		return normalized
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+	return value
+}
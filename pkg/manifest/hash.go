@@ -0,0 +1,24 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// ContentHash returns a stable digest of the manifest's JSON form, e.g. "sha256:9f86d0..."
+// Map keys are serialized in sorted order, so equal manifests always hash equally
+func ContentHash(manifest *Manifest) (string, error) {
+	if manifest == nil {
+		return "", fmt.Errorf("manifest cannot be nil")
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize manifest for hashing: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,277 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// LibraryPathEnv lists extra library search directories, separated like PATH
+const LibraryPathEnv = "JANUS_MANIFEST_PATH"
+
+// ManifestImport pulls the models of another manifest into a namespace
+// Imported models are referenced as <as>.<Model>, e.g. common.User
+type ManifestImport struct {
+	Path    string `json:"path,omitempty" yaml:"path,omitempty"`       // File relative to the importing manifest
+	Library string `json:"library,omitempty" yaml:"library,omitempty"` // Library name looked up in the search paths
+	As      string `json:"as,omitempty" yaml:"as,omitempty"`           // Namespace; defaults to the library or file name
+}
+
+var importNamespacePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// libraryExtensions are tried in order when looking up a named library
+var libraryExtensions = []string{".json", ".yaml", ".yml"}
+
+// Namespace returns the name imported models are qualified with
+func (imp *ManifestImport) Namespace() string {
+	if imp.As != "" {
+		return imp.As
+	}
+	if imp.Library != "" {
+		return imp.Library
+	}
+	base := filepath.Base(imp.Path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// validate checks that the import names exactly one source and a usable namespace
+func (imp *ManifestImport) validate() error {
+	if (imp.Path == "") == (imp.Library == "") {
+		return fmt.Errorf("import must set exactly one of path or library")
+	}
+	if namespace := imp.Namespace(); !importNamespacePattern.MatchString(namespace) {
+		return fmt.Errorf("import namespace '%s' must be an identifier, set 'as'", namespace)
+	}
+	return nil
+}
+
+// LibrarySearchPathsFromEnv returns the directories listed in JANUS_MANIFEST_PATH
+func LibrarySearchPathsFromEnv() []string {
+	var paths []string
+	for _, path := range filepath.SplitList(os.Getenv(LibraryPathEnv)) {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// ImportResolver resolves manifest imports into namespaced models
+// Each file is loaded once; an import chain that returns to a file being resolved is a cycle
+type ImportResolver struct {
+	SearchPaths []string // Directories searched for named libraries, in order
+
+	parser  *ManifestParser
+	loaded  map[string]*Manifest
+	loading []string
+	read    map[string]bool // Every file load attempted to read, including ones that failed
+}
+
+// NewImportResolver creates a resolver that looks up libraries in the given directories
+func NewImportResolver(searchPaths ...string) *ImportResolver {
+	return &ImportResolver{
+		SearchPaths: searchPaths,
+		parser:      NewManifestParser(),
+		loaded:      make(map[string]*Manifest),
+		read:        make(map[string]bool),
+	}
+}
+
+// Files returns the absolute paths of every imported file the resolver read, sorted
+// Files that failed to decode are included so callers can watch them for fixes
+func (r *ImportResolver) Files() []string {
+	return sortedMapKeys(r.read)
+}
+
+// ResolveFile decodes a manifest file and resolves its imports without validating it
+func (r *ImportResolver) ResolveFile(filePath string) (*Manifest, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path '%s': %w", filePath, err)
+	}
+	return r.load(absPath)
+}
+
+// Resolve replaces the manifest's imports with namespaced copies of the imported models
+// Relative import paths are resolved against baseDir
+func (r *ImportResolver) Resolve(manifest *Manifest, baseDir string) error {
+	namespaces := make(map[string]bool, len(manifest.Imports))
+	for i, imp := range manifest.Imports {
+		if imp == nil {
+			return fmt.Errorf("import %d definition is required", i)
+		}
+		if err := imp.validate(); err != nil {
+			return fmt.Errorf("import %d: %w", i, err)
+		}
+		namespace := imp.Namespace()
+		if namespaces[namespace] {
+			return fmt.Errorf("import namespace '%s' is used more than once", namespace)
+		}
+		namespaces[namespace] = true
+
+		path, err := r.locate(imp, baseDir)
+		if err != nil {
+			return err
+		}
+		library, err := r.load(path)
+		if err != nil {
+			return err
+		}
+
+		models, err := qualifyModels(library.Models, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to import '%s': %w", path, err)
+		}
+		if manifest.Models == nil && len(models) > 0 {
+			manifest.Models = make(map[string]*ModelDefinition, len(models))
+		}
+		for _, name := range sortedMapKeys(models) {
+			if _, exists := manifest.Models[name]; exists {
+				return fmt.Errorf("imported model '%s' is already defined", name)
+			}
+			manifest.Models[name] = models[name]
+		}
+	}
+
+	manifest.Imports = nil
+	return nil
+}
+
+// locate returns the absolute path of an imported file
+func (r *ImportResolver) locate(imp *ManifestImport, baseDir string) (string, error) {
+	if imp.Path != "" {
+		path := imp.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		return filepath.Abs(path)
+	}
+
+	for _, dir := range r.SearchPaths {
+		candidates := []string{filepath.Join(dir, imp.Library)}
+		if filepath.Ext(imp.Library) == "" {
+			candidates = candidates[:0]
+			for _, ext := range libraryExtensions {
+				candidates = append(candidates, filepath.Join(dir, imp.Library+ext))
+			}
+		}
+		for _, candidate := range candidates {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return filepath.Abs(candidate)
+			}
+		}
+	}
+	return "", fmt.Errorf("library '%s' not found in search paths %v", imp.Library, r.SearchPaths)
+}
+
+// load decodes a file and resolves its own imports, detecting cycles
+func (r *ImportResolver) load(path string) (*Manifest, error) {
+	for i, loading := range r.loading {
+		if loading == path {
+			chain := append(append([]string{}, r.loading[i:]...), path)
+			return nil, fmt.Errorf("import cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+	if manifest, exists := r.loaded[path]; exists {
+		return manifest, nil
+	}
+
+	r.read[path] = true
+	manifest, err := r.parser.DecodeFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.resolveFrom(manifest, path); err != nil {
+		return nil, fmt.Errorf("failed to resolve imports of '%s': %w", path, err)
+	}
+
+	r.loaded[path] = manifest
+	return manifest, nil
+}
+
+// resolveFrom resolves the imports of a manifest decoded from path
+func (r *ImportResolver) resolveFrom(manifest *Manifest, path string) error {
+	r.loading = append(r.loading, path)
+	defer func() { r.loading = r.loading[:len(r.loading)-1] }()
+	return r.Resolve(manifest, filepath.Dir(path))
+}
+
+// qualifyModels copies a library's models under namespace, rewriting references between them
+func qualifyModels(models map[string]*ModelDefinition, namespace string) (map[string]*ModelDefinition, error) {
+	if len(models) == 0 {
+		return nil, nil
+	}
+
+	// Deep copy so a library imported twice is not shared between namespaces
+	data, err := json.Marshal(models)
+	if err != nil {
+		return nil, err
+	}
+	var copied map[string]*ModelDefinition
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+
+	qualify := func(ref string) (string, error) {
+		if _, exists := models[ref]; !exists {
+			return "", fmt.Errorf("model reference '%s' is not defined in the library", ref)
+		}
+		return namespace + "." + ref, nil
+	}
+
+	qualified := make(map[string]*ModelDefinition, len(copied))
+	for _, name := range sortedMapKeys(copied) {
+		model := copied[name]
+		if model == nil {
+			continue
+		}
+		for _, propName := range sortedMapKeys(model.Properties) {
+			if err := rewriteModelRefs(model.Properties[propName], qualify); err != nil {
+				return nil, fmt.Errorf("model '%s': %w", name, err)
+			}
+		}
+		qualified[namespace+"."+name] = model
+	}
+	return qualified, nil
+}
+
+// rewriteModelRefs replaces every model reference reachable from an argument
+func rewriteModelRefs(arg *ArgumentManifest, rename func(string) (string, error)) error {
+	if arg == nil {
+		return nil
+	}
+
+	if arg.ModelRef != "" {
+		ref, err := rename(arg.ModelRef)
+		if err != nil {
+			return err
+		}
+		arg.ModelRef = ref
+	}
+	if arg.Discriminator != nil {
+		for _, value := range sortedMapKeys(arg.Discriminator.Mapping) {
+			ref, err := rename(arg.Discriminator.Mapping[value])
+			if err != nil {
+				return err
+			}
+			arg.Discriminator.Mapping[value] = ref
+		}
+	}
+
+	nested := []*ArgumentManifest{arg.Items}
+	for _, name := range sortedMapKeys(arg.Properties) {
+		nested = append(nested, arg.Properties[name])
+	}
+	nested = append(nested, arg.AllOf...)
+	nested = append(nested, arg.AnyOf...)
+	nested = append(nested, arg.OneOf...)
+	for _, child := range nested {
+		if err := rewriteModelRefs(child, rename); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,883 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONSchemaDialect is the JSON Schema draft produced and accepted by the converters
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaDefsPrefix is the reference prefix for models stored in $defs
+const jsonSchemaDefsPrefix = "#/$defs/"
+
+// JSONSchema is a JSON Schema (draft 2020-12) node restricted to the keywords
+// that have an equivalent in ArgumentManifest, ResponseManifest or ModelDefinition
+type JSONSchema struct {
+	Ref         string                 `json:"$ref,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        interface{}            `json:"type,omitempty"` // string, or array of strings on import
+	Default     interface{}            `json:"default,omitempty"`
+	Enum        []interface{}          `json:"enum,omitempty"`
+	Pattern     string                 `json:"pattern,omitempty"`
+	MinLength   *int                   `json:"minLength,omitempty"`
+	MaxLength   *int                   `json:"maxLength,omitempty"`
+	Minimum     *float64               `json:"minimum,omitempty"`
+	Maximum     *float64               `json:"maximum,omitempty"`
+	MinItems    *int                   `json:"minItems,omitempty"`
+	MaxItems    *int                   `json:"maxItems,omitempty"`
+	UniqueItems bool                   `json:"uniqueItems,omitempty"`
+	Items       *JSONSchema            `json:"items,omitempty"`
+	Properties  map[string]*JSONSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Const       interface{}            `json:"const,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	AllOf       []*JSONSchema          `json:"allOf,omitempty"`
+	AnyOf       []*JSONSchema          `json:"anyOf,omitempty"`
+	OneOf       []*JSONSchema          `json:"oneOf,omitempty"`
+
+	// OpenAPI-style discriminator; JSON Schema validators treat it as an annotation
+	Discriminator *JSONSchemaDiscriminator `json:"discriminator,omitempty"`
+
+	// Boolean on export; schema-valued additionalProperties are reported and dropped on import
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+}
+
+// JSONSchemaDiscriminator selects a oneOf variant by property value; mapping values are $refs
+type JSONSchemaDiscriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
+// JSONSchemaRequest carries a request definition inside the x-janus-requests extension
+type JSONSchemaRequest struct {
+	Description string      `json:"description,omitempty"`
+	Args        *JSONSchema `json:"args,omitempty"` // object schema, one property per argument
+	Response    *JSONSchema `json:"response,omitempty"`
+	ErrorCodes  []string    `json:"errorCodes,omitempty"`
+}
+
+// JSONSchemaDocument is the JSON Schema representation of a Manifest
+// Models live in $defs so standard tooling can reference them; requests use the
+// x-janus-requests extension keyword, which validators ignore
+type JSONSchemaDocument struct {
+	Schema      string                        `json:"$schema"`
+	ID          string                        `json:"$id,omitempty"`
+	Title       string                        `json:"title,omitempty"`
+	Description string                        `json:"description,omitempty"`
+	Version     string                        `json:"x-janus-version,omitempty"`
+	Defs        map[string]*JSONSchema        `json:"$defs,omitempty"`
+	Requests    map[string]*JSONSchemaRequest `json:"x-janus-requests,omitempty"`
+
+	// Root-level schema keywords, used when importing a plain schema without $defs
+	Type                 interface{}            `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+}
+
+// SchemaIssue reports a construct that could not be converted without loss
+type SchemaIssue struct {
+	Path    string `json:"path"`              // JSON pointer to the construct in the source document
+	Keyword string `json:"keyword,omitempty"` // Offending keyword, if any
+	Message string `json:"message"`
+}
+
+func (issue SchemaIssue) String() string {
+	if issue.Keyword != "" {
+		return fmt.Sprintf("%s: %s (%s)", issue.Path, issue.Message, issue.Keyword)
+	}
+	return fmt.Sprintf("%s: %s", issue.Path, issue.Message)
+}
+
+// schemaConverter accumulates issues during a conversion
+type schemaConverter struct {
+	refPrefix string
+	issues    []SchemaIssue
+}
+
+func (c *schemaConverter) report(path, keyword, message string) {
+	c.issues = append(c.issues, SchemaIssue{Path: path, Keyword: keyword, Message: message})
+}
+
+// MARK: - Export
+
+// ToJSONSchema converts a Manifest into a JSON Schema draft 2020-12 document
+// modelRef becomes $ref into $defs; lossy constructs are returned as issues
+func ToJSONSchema(manifest *Manifest) (*JSONSchemaDocument, []SchemaIssue) {
+	converter := &schemaConverter{refPrefix: jsonSchemaDefsPrefix}
+
+	doc := &JSONSchemaDocument{
+		Schema:      JSONSchemaDialect,
+		Title:       manifest.Name,
+		Description: manifest.Description,
+		Version:     manifest.Version,
+	}
+
+	if len(manifest.Models) > 0 {
+		doc.Defs = make(map[string]*JSONSchema, len(manifest.Models))
+		for _, name := range sortedMapKeys(manifest.Models) {
+			doc.Defs[name] = converter.modelToSchema(name, manifest.Models[name], "/$defs/"+escapePointer(name))
+		}
+	}
+
+	if len(manifest.Requests) > 0 {
+		doc.Requests = make(map[string]*JSONSchemaRequest, len(manifest.Requests))
+		for _, name := range sortedMapKeys(manifest.Requests) {
+			request := manifest.Requests[name]
+			if request == nil {
+				continue
+			}
+			path := "/x-janus-requests/" + escapePointer(name)
+			doc.Requests[name] = &JSONSchemaRequest{
+				Description: request.Description,
+				Args:        converter.argsToSchema(request.Args, path+"/args"),
+				Response:    converter.responseToSchema(request.Response, path+"/response"),
+				ErrorCodes:  request.ErrorCodes,
+			}
+		}
+	}
+
+	return doc, converter.issues
+}
+
+// JSONSchemaExporter converts individual manifest definitions into JSON Schema nodes
+// Used by formats that embed JSON Schema under their own layout, such as OpenRPC
+type JSONSchemaExporter struct {
+	converter schemaConverter
+}
+
+// NewJSONSchemaExporter creates an exporter whose model references start with refPrefix
+// (e.g. "#/components/schemas/")
+func NewJSONSchemaExporter(refPrefix string) *JSONSchemaExporter {
+	return &JSONSchemaExporter{converter: schemaConverter{refPrefix: refPrefix}}
+}
+
+// ModelSchema converts a model definition; path locates it for issue reporting
+func (e *JSONSchemaExporter) ModelSchema(name string, model *ModelDefinition, path string) *JSONSchema {
+	return e.converter.modelToSchema(name, model, path)
+}
+
+// ArgumentSchema converts an argument manifest
+func (e *JSONSchemaExporter) ArgumentSchema(arg *ArgumentManifest, path string) *JSONSchema {
+	return e.converter.argumentToSchema(arg, path)
+}
+
+// ResponseSchema converts a response manifest
+func (e *JSONSchemaExporter) ResponseSchema(response *ResponseManifest, path string) *JSONSchema {
+	return e.converter.responseToSchema(response, path)
+}
+
+// Issues returns the lossy constructs reported so far
+func (e *JSONSchemaExporter) Issues() []SchemaIssue {
+	return e.converter.issues
+}
+
+// modelToSchema converts a model definition
+func (c *schemaConverter) modelToSchema(name string, model *ModelDefinition, path string) *JSONSchema {
+	if model == nil {
+		return &JSONSchema{}
+	}
+
+	schema := &JSONSchema{
+		Description: model.Description,
+		Type:        model.Type,
+	}
+	if model.Name != "" && model.Name != name {
+		schema.Title = model.Name
+	}
+
+	required := make(map[string]bool)
+	for _, prop := range model.Required {
+		required[prop] = true
+	}
+	schema.Properties, schema.Required = c.propertiesToSchema(model.Properties, required, path)
+	if model.AdditionalProperties != nil {
+		schema.AdditionalProperties = *model.AdditionalProperties
+	}
+	return schema
+}
+
+// argsToSchema converts request arguments into an object schema
+func (c *schemaConverter) argsToSchema(args map[string]*ArgumentManifest, path string) *JSONSchema {
+	schema := &JSONSchema{Type: "object"}
+	schema.Properties, schema.Required = c.propertiesToSchema(args, nil, path)
+	return schema
+}
+
+// responseToSchema converts a response manifest
+func (c *schemaConverter) responseToSchema(response *ResponseManifest, path string) *JSONSchema {
+	if response == nil {
+		return nil
+	}
+
+	schema := &JSONSchema{Description: response.Description}
+	if response.ModelRef != "" {
+		schema.Ref = c.modelRef(response.ModelRef)
+		return schema
+	}
+
+	schema.Type = response.Type
+	schema.Properties, schema.Required = c.propertiesToSchema(response.Properties, nil, path)
+	if response.Items != nil {
+		schema.Items = c.argumentToSchema(response.Items, path+"/items")
+	}
+	return schema
+}
+
+// propertiesToSchema converts a property set and collects required names
+func (c *schemaConverter) propertiesToSchema(properties map[string]*ArgumentManifest, required map[string]bool, path string) (map[string]*JSONSchema, []string) {
+	if len(properties) == 0 {
+		return nil, nil
+	}
+
+	schemas := make(map[string]*JSONSchema, len(properties))
+	var requiredList []string
+	for _, name := range sortedMapKeys(properties) {
+		prop := properties[name]
+		if prop == nil {
+			continue
+		}
+		schemas[name] = c.argumentToSchema(prop, path+"/properties/"+escapePointer(name))
+		if prop.Required || required[name] {
+			requiredList = append(requiredList, name)
+		}
+	}
+	return schemas, requiredList
+}
+
+// argumentToSchema converts a single argument manifest
+func (c *schemaConverter) argumentToSchema(arg *ArgumentManifest, path string) *JSONSchema {
+	schema := &JSONSchema{
+		Description: arg.Description,
+		Default:     arg.Default,
+		Pattern:     arg.Pattern,
+		MinLength:   arg.MinLength,
+		MaxLength:   arg.MaxLength,
+		Minimum:     arg.Minimum,
+		Maximum:     arg.Maximum,
+		MinItems:    arg.MinItems,
+		MaxItems:    arg.MaxItems,
+		UniqueItems: arg.UniqueItems,
+	}
+
+	if arg.ModelRef != "" {
+		schema.Ref = c.modelRef(arg.ModelRef)
+	} else if arg.Type != "" {
+		schema.Type = arg.Type
+	}
+
+	if len(arg.Enum) > 0 {
+		schema.Enum = c.enumToSchema(arg.Enum, arg.Type, path)
+	}
+
+	if arg.Items != nil {
+		schema.Items = c.argumentToSchema(arg.Items, path+"/items")
+	}
+	schema.Properties, schema.Required = c.propertiesToSchema(arg.Properties, stringSet(arg.RequiredProperties), path)
+	if len(arg.Properties) == 0 {
+		schema.Required = arg.RequiredProperties
+	}
+	if arg.AdditionalProperties != nil {
+		schema.AdditionalProperties = *arg.AdditionalProperties
+	}
+
+	schema.Const = arg.Const
+	schema.Format = arg.Format
+	schema.AllOf = c.alternativesToSchema(arg.AllOf, path+"/allOf")
+	schema.AnyOf = c.alternativesToSchema(arg.AnyOf, path+"/anyOf")
+	schema.OneOf = c.alternativesToSchema(arg.OneOf, path+"/oneOf")
+	if arg.Discriminator != nil {
+		schema.Discriminator = &JSONSchemaDiscriminator{PropertyName: arg.Discriminator.PropertyName}
+		for _, value := range sortedMapKeys(arg.Discriminator.Mapping) {
+			if schema.Discriminator.Mapping == nil {
+				schema.Discriminator.Mapping = make(map[string]string)
+			}
+			schema.Discriminator.Mapping[value] = c.modelRef(arg.Discriminator.Mapping[value])
+		}
+	}
+
+	if arg.Nullable {
+		return nullableSchema(schema)
+	}
+	return schema
+}
+
+// alternativesToSchema converts composition alternatives
+func (c *schemaConverter) alternativesToSchema(alternatives []*ArgumentManifest, path string) []*JSONSchema {
+	if len(alternatives) == 0 {
+		return nil
+	}
+	schemas := make([]*JSONSchema, len(alternatives))
+	for i, alternative := range alternatives {
+		schemas[i] = c.argumentToSchema(alternative, fmt.Sprintf("%s/%d", path, i))
+	}
+	return schemas
+}
+
+// nullableSchema also accepts null: a plain type becomes [type, "null"], anything else
+// is wrapped in anyOf with a null alternative
+func nullableSchema(schema *JSONSchema) *JSONSchema {
+	if t, ok := schema.Type.(string); ok && schema.Ref == "" && len(schema.AllOf)+len(schema.AnyOf)+len(schema.OneOf) == 0 {
+		schema.Type = []string{t, "null"}
+		return schema
+	}
+
+	description, defaultValue := schema.Description, schema.Default
+	schema.Description, schema.Default = "", nil
+	return &JSONSchema{
+		Description: description,
+		Default:     defaultValue,
+		AnyOf:       []*JSONSchema{schema, {Type: "null"}},
+	}
+}
+
+// enumToSchema converts Janus string enums into typed JSON Schema enum values
+// Janus compares the string form of a value, so numeric and boolean enums are converted back to their JSON types
+func (c *schemaConverter) enumToSchema(values []string, argType, path string) []interface{} {
+	enum := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		switch argType {
+		case "number", "integer":
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				enum = append(enum, number)
+				continue
+			}
+			c.report(path, "enum", fmt.Sprintf("enum value %q is not a %s, kept as string", value, argType))
+		case "boolean":
+			if boolean, err := strconv.ParseBool(value); err == nil {
+				enum = append(enum, boolean)
+				continue
+			}
+			c.report(path, "enum", fmt.Sprintf("enum value %q is not a boolean, kept as string", value))
+		}
+		enum = append(enum, value)
+	}
+	return enum
+}
+
+// MARK: - Import
+
+// Keywords understood on import; anything else is reported and dropped
+var (
+	supportedSchemaKeywords = map[string]bool{
+		"$ref": true, "title": true, "description": true, "type": true, "default": true,
+		"enum": true, "pattern": true, "minLength": true, "maxLength": true,
+		"minimum": true, "maximum": true, "items": true, "properties": true, "required": true,
+		"const": true, "format": true, "allOf": true, "anyOf": true, "oneOf": true, "discriminator": true,
+		"minItems": true, "maxItems": true, "uniqueItems": true, "additionalProperties": true,
+	}
+	ignoredSchemaKeywords = map[string]bool{
+		"$comment": true, "examples": true,
+	}
+	documentSchemaKeywords = map[string]bool{
+		"$schema": true, "$id": true, "$defs": true, "x-janus-version": true, "x-janus-requests": true,
+	}
+)
+
+// FromJSONSchema converts a JSON Schema draft 2020-12 document into a Manifest
+// Documents produced by ToJSONSchema round-trip; plain schemas without $defs are
+// imported as a single model named after their title. Dropped constructs are returned as issues
+func FromJSONSchema(data []byte) (*Manifest, []SchemaIssue, error) {
+	var doc JSONSchemaDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse JSON Schema: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse JSON Schema: %w", err)
+	}
+
+	converter := &schemaConverter{refPrefix: jsonSchemaDefsPrefix}
+	converter.checkKeywords(raw, "", true)
+
+	if doc.Schema != "" && doc.Schema != JSONSchemaDialect {
+		converter.report("/$schema", "$schema", fmt.Sprintf("dialect %s treated as draft 2020-12", doc.Schema))
+	}
+
+	manifest := &Manifest{
+		Name:        doc.Title,
+		Version:     doc.Version,
+		Description: doc.Description,
+	}
+
+	if len(doc.Defs) > 0 {
+		manifest.Models = make(map[string]*ModelDefinition, len(doc.Defs))
+		for _, name := range sortedMapKeys(doc.Defs) {
+			manifest.Models[name] = converter.schemaToModel(name, doc.Defs[name], "/$defs/"+escapePointer(name))
+		}
+	}
+
+	// A plain schema describes a single value: import it as a model
+	if len(doc.Defs) == 0 && len(doc.Requests) == 0 && (doc.Type != nil || len(doc.Properties) > 0) {
+		name := doc.Title
+		if name == "" {
+			name = "Root"
+		}
+		root := &JSONSchema{Description: doc.Description, Type: doc.Type, Properties: doc.Properties, Required: doc.Required,
+			AdditionalProperties: doc.AdditionalProperties}
+		manifest.Models = map[string]*ModelDefinition{name: converter.schemaToModel(name, root, "")}
+	}
+
+	if len(doc.Requests) > 0 {
+		manifest.Requests = make(map[string]*RequestManifest, len(doc.Requests))
+		for _, name := range sortedMapKeys(doc.Requests) {
+			request := doc.Requests[name]
+			if request == nil {
+				continue
+			}
+			path := "/x-janus-requests/" + escapePointer(name)
+			requestManifest := &RequestManifest{
+				Name:        name,
+				Description: request.Description,
+				ErrorCodes:  request.ErrorCodes,
+			}
+			if request.Args != nil {
+				requestManifest.Args = converter.schemaToProperties(request.Args.Properties, request.Args.Required, path+"/args")
+			}
+			if request.Response != nil {
+				requestManifest.Response = converter.schemaToResponse(request.Response, path+"/response")
+			}
+			manifest.Requests[name] = requestManifest
+		}
+	}
+
+	return manifest, converter.issues, nil
+}
+
+// checkKeywords reports keywords that have no manifest equivalent
+func (c *schemaConverter) checkKeywords(node map[string]interface{}, path string, root bool) {
+	for _, keyword := range sortedMapKeys(node) {
+		value := node[keyword]
+		switch {
+		case root && documentSchemaKeywords[keyword]:
+			c.checkDocumentKeyword(keyword, value, path)
+		case supportedSchemaKeywords[keyword]:
+			c.checkNestedKeyword(keyword, value, path)
+		case ignoredSchemaKeywords[keyword]:
+		default:
+			c.report(path, keyword, "keyword not supported by Janus manifests, dropped")
+		}
+	}
+}
+
+// checkDocumentKeyword descends into $defs and x-janus-requests
+func (c *schemaConverter) checkDocumentKeyword(keyword string, value interface{}, path string) {
+	entries, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	switch keyword {
+	case "$defs":
+		for _, name := range sortedMapKeys(entries) {
+			if def, ok := entries[name].(map[string]interface{}); ok {
+				c.checkKeywords(def, path+"/$defs/"+escapePointer(name), false)
+			}
+		}
+	case "x-janus-requests":
+		for _, name := range sortedMapKeys(entries) {
+			request, ok := entries[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			requestPath := path + "/x-janus-requests/" + escapePointer(name)
+			for _, field := range []string{"args", "response"} {
+				if schema, ok := request[field].(map[string]interface{}); ok {
+					c.checkKeywords(schema, requestPath+"/"+field, false)
+				}
+			}
+		}
+	}
+}
+
+// checkNestedKeyword descends into items and properties
+func (c *schemaConverter) checkNestedKeyword(keyword string, value interface{}, path string) {
+	switch keyword {
+	case "items":
+		if items, ok := value.(map[string]interface{}); ok {
+			c.checkKeywords(items, path+"/items", false)
+		} else {
+			c.report(path+"/items", "items", "only a single items schema is supported")
+		}
+	case "properties":
+		if properties, ok := value.(map[string]interface{}); ok {
+			for _, name := range sortedMapKeys(properties) {
+				if prop, ok := properties[name].(map[string]interface{}); ok {
+					c.checkKeywords(prop, path+"/properties/"+escapePointer(name), false)
+				}
+			}
+		}
+	case "additionalProperties":
+		if _, ok := value.(bool); !ok {
+			c.report(path, keyword, "only boolean additionalProperties is supported, dropped")
+		}
+	case "allOf", "anyOf", "oneOf":
+		if alternatives, ok := value.([]interface{}); ok {
+			for i, alternative := range alternatives {
+				if node, ok := alternative.(map[string]interface{}); ok {
+					c.checkKeywords(node, fmt.Sprintf("%s/%s/%d", path, keyword, i), false)
+				}
+			}
+		}
+	}
+}
+
+// schemaToModel converts a $defs entry into a model definition
+func (c *schemaConverter) schemaToModel(name string, schema *JSONSchema, path string) *ModelDefinition {
+	model := &ModelDefinition{Name: name}
+	if schema == nil {
+		c.report(path, "", "empty model schema")
+		return model
+	}
+
+	if schema.Title != "" {
+		model.Name = schema.Title
+	}
+	model.Description = schema.Description
+	model.Type = c.schemaType(schema, path)
+	if model.Type == "" && len(schema.Properties) > 0 {
+		model.Type = "object"
+	}
+	if schema.Ref != "" {
+		c.report(path, "$ref", "models cannot alias other models, reference dropped")
+	}
+
+	model.Properties = c.schemaToProperties(schema.Properties, nil, path)
+	for _, prop := range schema.Required {
+		if _, exists := schema.Properties[prop]; !exists {
+			c.report(path, "required", fmt.Sprintf("required property '%s' is not defined, dropped", prop))
+			continue
+		}
+		model.Required = append(model.Required, prop)
+	}
+	model.AdditionalProperties = additionalPropertiesFlag(schema.AdditionalProperties)
+	return model
+}
+
+// additionalPropertiesFlag returns a boolean additionalProperties value; schemas were reported by checkKeywords
+func additionalPropertiesFlag(value interface{}) *bool {
+	if flag, ok := value.(bool); ok {
+		return &flag
+	}
+	return nil
+}
+
+// schemaToResponse converts a response schema
+func (c *schemaConverter) schemaToResponse(schema *JSONSchema, path string) *ResponseManifest {
+	response := &ResponseManifest{Description: schema.Description}
+
+	if schema.Ref != "" {
+		response.ModelRef = c.refToModel(schema.Ref, path)
+		response.Type = "object"
+		return response
+	}
+
+	response.Type = c.schemaType(schema, path)
+	response.Properties = c.schemaToProperties(schema.Properties, schema.Required, path)
+	if schema.Items != nil {
+		response.Items = c.schemaToArgument("", schema.Items, path+"/items")
+	}
+	return response
+}
+
+// schemaToProperties converts properties, marking names listed in required
+func (c *schemaConverter) schemaToProperties(properties map[string]*JSONSchema, required []string, path string) map[string]*ArgumentManifest {
+	if len(properties) == 0 {
+		return nil
+	}
+
+	requiredSet := make(map[string]bool, len(required))
+	for _, name := range required {
+		requiredSet[name] = true
+		if _, exists := properties[name]; !exists {
+			c.report(path, "required", fmt.Sprintf("required property '%s' is not defined, dropped", name))
+		}
+	}
+
+	args := make(map[string]*ArgumentManifest, len(properties))
+	for _, name := range sortedMapKeys(properties) {
+		arg := c.schemaToArgument(name, properties[name], path+"/properties/"+escapePointer(name))
+		arg.Required = requiredSet[name]
+		args[name] = arg
+	}
+	return args
+}
+
+// schemaToArgument converts a schema node into an argument manifest
+func (c *schemaConverter) schemaToArgument(name string, schema *JSONSchema, path string) *ArgumentManifest {
+	arg := &ArgumentManifest{Name: name}
+	if schema == nil {
+		return arg
+	}
+
+	// A nullable argument exported as anyOf [X, null]
+	if inner, ok := unwrapNullable(schema); ok {
+		arg = c.schemaToArgument(name, inner, path+"/anyOf/0")
+		arg.Nullable = true
+		if schema.Description != "" {
+			arg.Description = schema.Description
+		}
+		if schema.Default != nil {
+			arg.Default = schema.Default
+		}
+		return arg
+	}
+
+	if nullable, rest := splitNullType(schema.Type); nullable {
+		arg.Nullable = true
+		stripped := *schema
+		stripped.Type = rest
+		schema = &stripped
+	}
+
+	arg.Description = schema.Description
+	arg.Default = schema.Default
+	arg.Pattern = schema.Pattern
+	arg.MinLength = schema.MinLength
+	arg.MaxLength = schema.MaxLength
+	arg.Minimum = schema.Minimum
+	arg.Maximum = schema.Maximum
+	arg.MinItems = schema.MinItems
+	arg.MaxItems = schema.MaxItems
+	arg.UniqueItems = schema.UniqueItems
+
+	if schema.Ref != "" {
+		arg.ModelRef = c.refToModel(schema.Ref, path)
+		arg.Type = "object"
+	} else {
+		arg.Type = c.schemaType(schema, path)
+	}
+
+	if len(schema.Enum) > 0 {
+		arg.Enum = c.schemaToEnum(schema.Enum, path)
+		if arg.Type == "" {
+			arg.Type = "string"
+		}
+	}
+
+	if schema.Items != nil {
+		arg.Items = c.schemaToArgument("", schema.Items, path+"/items")
+	}
+	arg.Properties = c.schemaToProperties(schema.Properties, schema.Required, path)
+	if len(schema.Properties) == 0 {
+		// Without declared properties the required list has no flags to land on
+		arg.RequiredProperties = schema.Required
+	}
+	arg.AdditionalProperties = additionalPropertiesFlag(schema.AdditionalProperties)
+	if arg.Type == "" && arg.describesObject() {
+		arg.Type = "object"
+	}
+
+	arg.Const = schema.Const
+	arg.Format = schema.Format
+	arg.AllOf = c.schemaToAlternatives(schema.AllOf, path+"/allOf", arg)
+	arg.AnyOf = c.schemaToAlternatives(schema.AnyOf, path+"/anyOf", arg)
+	arg.OneOf = c.schemaToAlternatives(schema.OneOf, path+"/oneOf", arg)
+	if schema.Discriminator != nil {
+		arg.Discriminator = &Discriminator{PropertyName: schema.Discriminator.PropertyName}
+		for _, value := range sortedMapKeys(schema.Discriminator.Mapping) {
+			if modelName := c.refToModel(schema.Discriminator.Mapping[value], path+"/discriminator/mapping/"+escapePointer(value)); modelName != "" {
+				if arg.Discriminator.Mapping == nil {
+					arg.Discriminator.Mapping = make(map[string]string)
+				}
+				arg.Discriminator.Mapping[value] = modelName
+			}
+		}
+	}
+
+	if arg.Type == "" && (arg.Const != nil || arg.hasComposition()) {
+		return arg
+	}
+	if arg.Type == "" {
+		c.report(path, "type", "schema without a type accepts any value, which Janus cannot express; using object")
+		arg.Type = "object"
+	}
+
+	return arg
+}
+
+// schemaToAlternatives converts composition alternatives; a bare null alternative marks arg nullable
+func (c *schemaConverter) schemaToAlternatives(schemas []*JSONSchema, path string, arg *ArgumentManifest) []*ArgumentManifest {
+	var alternatives []*ArgumentManifest
+	for i, schema := range schemas {
+		if isNullSchema(schema) {
+			arg.Nullable = true
+			continue
+		}
+		alternatives = append(alternatives, c.schemaToArgument("", schema, fmt.Sprintf("%s/%d", path, i)))
+	}
+	return alternatives
+}
+
+// unwrapNullable returns X for a schema of the form {anyOf: [X, {type: null}]}
+func unwrapNullable(schema *JSONSchema) (*JSONSchema, bool) {
+	if len(schema.AnyOf) != 2 || !isNullSchema(schema.AnyOf[1]) || isNullSchema(schema.AnyOf[0]) {
+		return nil, false
+	}
+	if schema.Type != nil || schema.Ref != "" || len(schema.AllOf) > 0 || len(schema.OneOf) > 0 ||
+		len(schema.Properties) > 0 || schema.Items != nil || schema.Const != nil || len(schema.Enum) > 0 {
+		return nil, false
+	}
+	return schema.AnyOf[0], true
+}
+
+// isNullSchema reports whether schema is exactly {type: null}
+func isNullSchema(schema *JSONSchema) bool {
+	if schema == nil || schema.Type != "null" {
+		return false
+	}
+	bare := *schema
+	bare.Type = nil
+	bare.Description = ""
+	data, _ := json.Marshal(&bare)
+	return string(data) == "{}"
+}
+
+// splitNullType removes "null" from a type keyword, returning whether it was present
+func splitNullType(t interface{}) (bool, interface{}) {
+	switch value := t.(type) {
+	case string:
+		if value == "null" {
+			return true, nil
+		}
+	case []interface{}:
+		var rest []interface{}
+		nullable := false
+		for _, item := range value {
+			if item == "null" {
+				nullable = true
+			} else {
+				rest = append(rest, item)
+			}
+		}
+		if !nullable {
+			return false, t
+		}
+		if len(rest) == 1 {
+			return true, rest[0]
+		}
+		if len(rest) == 0 {
+			return true, nil
+		}
+		return true, rest
+	case []string:
+		items := make([]interface{}, len(value))
+		for i, item := range value {
+			items[i] = item
+		}
+		return splitNullType(items)
+	}
+	return false, t
+}
+
+// schemaType extracts a single manifest type from the type keyword
+func (c *schemaConverter) schemaType(schema *JSONSchema, path string) string {
+	switch t := schema.Type.(type) {
+	case nil:
+		return ""
+	case string:
+		if t == "null" {
+			c.report(path, "type", "null type not supported, using object")
+			return "object"
+		}
+		return t
+	case []interface{}:
+		var types []string
+		for _, value := range t {
+			if s, ok := value.(string); ok && s != "null" {
+				types = append(types, s)
+			}
+		}
+		if len(types) == 0 {
+			c.report(path, "type", "type list has no usable type, using object")
+			return "object"
+		}
+		c.report(path, "type", fmt.Sprintf("type list %v reduced to %s", t, types[0]))
+		return types[0]
+	default:
+		c.report(path, "type", fmt.Sprintf("invalid type keyword %v", t))
+		return ""
+	}
+}
+
+// modelRef builds the $ref pointing at a model, escaping the name as a JSON pointer token in a URI fragment
+func (c *schemaConverter) modelRef(name string) string {
+	return c.refPrefix + escapeFragment(escapePointer(name))
+}
+
+// refToModel converts a $ref into a model name
+func (c *schemaConverter) refToModel(ref, path string) string {
+	if strings.HasPrefix(ref, c.refPrefix) {
+		return unescapePointer(unescapeFragment(strings.TrimPrefix(ref, c.refPrefix)))
+	}
+	c.report(path, "$ref", fmt.Sprintf("reference %s does not point into %s, dropped", ref, c.refPrefix))
+	return ""
+}
+
+// schemaToEnum converts enum values into Janus string enums
+func (c *schemaConverter) schemaToEnum(values []interface{}, path string) []string {
+	enum := make([]string, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			enum = append(enum, v)
+		case float64, bool:
+			enum = append(enum, fmt.Sprintf("%v", v))
+		default:
+			c.report(path, "enum", fmt.Sprintf("enum value %v cannot be represented, dropped", value))
+		}
+	}
+	return enum
+}
+
+// MARK: - Helpers
+
+// escapePointer escapes a JSON pointer reference token (RFC 6901)
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// unescapePointer reverses escapePointer
+func unescapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+// escapeFragment percent-encodes characters not allowed in a URI fragment (RFC 3986), such as
+// spaces, "%", "#" and non-ASCII bytes
+func escapeFragment(token string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(token); i++ {
+		b := token[i]
+		if b < 0x80 && (b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || strings.IndexByte("-._~!$&'()*+,;=:@/?", b) >= 0) {
+			escaped.WriteByte(b)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+	return escaped.String()
+}
+
+// unescapeFragment reverses escapeFragment, keeping tokens with malformed escapes as they are
+func unescapeFragment(token string) string {
+	if unescaped, err := url.PathUnescape(token); err == nil {
+		return unescaped
+	}
+	return token
+}
+
+// sortedMapKeys returns map keys in sorted order for deterministic output
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
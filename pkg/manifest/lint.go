@@ -0,0 +1,498 @@
+package manifest
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"GoJanus/pkg/core"
+	"gopkg.in/yaml.v3"
+)
+
+// LintSeverity is the level at which a lint rule reports
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+	LintInfo    LintSeverity = "info"
+	LintOff     LintSeverity = "off" // Disables a rule in LintConfig
+)
+
+// LintIssue is a single finding
+type LintIssue struct {
+	Rule     string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	Path     string       `json:"path"` // Dotted location, e.g. requests.get_user.args.user_id
+	Message  string       `json:"message"`
+}
+
+func (issue LintIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", issue.Severity, issue.Path, issue.Message, issue.Rule)
+}
+
+// LintReport holds the findings of a lint run
+type LintReport struct {
+	Issues []LintIssue `json:"issues"`
+}
+
+// Count returns the number of issues at the given severity
+func (report *LintReport) Count(severity LintSeverity) int {
+	count := 0
+	for _, issue := range report.Issues {
+		if issue.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// HasErrors reports whether any error-level issue was found
+func (report *LintReport) HasErrors() bool {
+	return report.Count(LintError) > 0
+}
+
+// LintConfig overrides rule severities; rules set to "off" are skipped
+type LintConfig struct {
+	Rules map[string]LintSeverity `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// ParseLintConfig parses a JSON or YAML lint configuration and checks rule names
+func ParseLintConfig(data []byte) (*LintConfig, error) {
+	var config LintConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse lint config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate checks that every configured rule and severity exists
+func (config *LintConfig) Validate() error {
+	for name, severity := range config.Rules {
+		if findLintRule(name) == nil {
+			return fmt.Errorf("unknown lint rule '%s'", name)
+		}
+		switch severity {
+		case LintError, LintWarning, LintInfo, LintOff:
+		default:
+			return fmt.Errorf("invalid severity '%s' for lint rule '%s'", severity, name)
+		}
+	}
+	return nil
+}
+
+// severity returns the effective severity of a rule
+func (config *LintConfig) severity(rule *LintRule) LintSeverity {
+	if config != nil {
+		if severity, exists := config.Rules[rule.Name]; exists {
+			return severity
+		}
+	}
+	return rule.Severity
+}
+
+// LintRule describes a lint rule
+type LintRule struct {
+	Name        string       `json:"name"`
+	Severity    LintSeverity `json:"severity"` // Default severity
+	Description string       `json:"description"`
+	check       func(l *linter)
+}
+
+// lintRules is the rule set in reporting order
+var lintRules = []*LintRule{
+	{Name: "missing-metadata", Severity: LintError, Description: "manifest name and version are required", check: checkMissingMetadata},
+	{Name: "invalid-type", Severity: LintError, Description: "arguments, properties and models must declare a supported type", check: checkInvalidTypes},
+	{Name: "unresolved-model-ref", Severity: LintError, Description: "modelRef must name a model defined in the manifest", check: checkUnresolvedModelRefs},
+	{Name: "unresolved-import", Severity: LintError, Description: "imports must resolve to a readable manifest file or library", check: checkUnresolvedImports},
+	{Name: "invalid-pattern", Severity: LintError, Description: "pattern must be a valid regular expression", check: checkInvalidPatterns},
+	{Name: "inconsistent-bounds", Severity: LintError, Description: "minLength, minimum and minItems must not exceed their maximum counterparts", check: checkInconsistentBounds},
+	{Name: "invalid-format", Severity: LintError, Description: "format must be a supported string format on a string argument", check: checkInvalidFormats},
+	{Name: "invalid-discriminator", Severity: LintError, Description: "discriminators must select among oneOf variants that use modelRef", check: checkInvalidDiscriminators},
+	{Name: "invalid-default", Severity: LintError, Description: "default values must satisfy their own constraints", check: checkInvalidDefaults},
+	{Name: "invalid-request-name", Severity: LintError, Description: "request names must pass the security validator", check: checkRequestNames},
+	{Name: "builtin-redefined", Severity: LintError, Description: "built-in requests cannot be redefined", check: checkBuiltinRedefined},
+	{Name: "unused-model", Severity: LintWarning, Description: "models should be reachable from a request, directly or through other models", check: checkUnusedModels},
+	{Name: "missing-description", Severity: LintWarning, Description: "requests, arguments, properties and models should be described", check: checkMissingDescriptions},
+}
+
+// LintRules returns the available lint rules
+func LintRules() []LintRule {
+	rules := make([]LintRule, len(lintRules))
+	for i, rule := range lintRules {
+		rules[i] = *rule
+	}
+	return rules
+}
+
+func findLintRule(name string) *LintRule {
+	for _, rule := range lintRules {
+		if rule.Name == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Lint runs every enabled rule over the manifest
+// A nil config uses the default severities
+func Lint(manifest *Manifest, config *LintConfig) *LintReport {
+	return lint(manifest, config, nil)
+}
+
+// LintFile decodes a manifest file, resolves its imports and lints the result
+// Imports that fail to resolve are reported by the unresolved-import rule instead of failing the run
+func (parser *ManifestParser) LintFile(filePath string, config *LintConfig) (*LintReport, error) {
+	manifest, err := parser.DecodeFromFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path '%s': %w", filePath, err)
+	}
+	return lint(manifest, config, parser.resolveImportsForLint(manifest, absPath)), nil
+}
+
+// resolveImportsForLint resolves each import on its own, keeping the models of those that succeed
+// and returning the failures by import index
+func (parser *ManifestParser) resolveImportsForLint(manifest *Manifest, absPath string) map[int]error {
+	resolver := parser.importResolver()
+	failures := make(map[int]error)
+	namespaces := make(map[string]bool, len(manifest.Imports))
+	for i, imp := range manifest.Imports {
+		if imp == nil {
+			failures[i] = fmt.Errorf("import definition is required")
+			continue
+		}
+		if err := imp.validate(); err != nil {
+			failures[i] = err
+			continue
+		}
+		namespace := imp.Namespace()
+		if namespaces[namespace] {
+			failures[i] = fmt.Errorf("import namespace '%s' is used more than once", namespace)
+			continue
+		}
+		namespaces[namespace] = true
+
+		single := &Manifest{Imports: []*ManifestImport{imp}, Models: manifest.Models}
+		if err := resolver.resolveFrom(single, absPath); err != nil {
+			failures[i] = err
+			continue
+		}
+		manifest.Models = single.Models
+	}
+	manifest.Imports = nil
+	return failures
+}
+
+// lint runs the rules with the import failures found while loading the manifest
+func lint(manifest *Manifest, config *LintConfig, importFailures map[int]error) *LintReport {
+	l := &linter{manifest: manifest, importFailures: importFailures}
+	for _, rule := range lintRules {
+		severity := config.severity(rule)
+		if severity == LintOff {
+			continue
+		}
+		l.rule = rule.Name
+		l.severity = severity
+		rule.check(l)
+	}
+
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		return l.issues[i].Path < l.issues[j].Path
+	})
+	if l.issues == nil {
+		l.issues = []LintIssue{}
+	}
+	return &LintReport{Issues: l.issues}
+}
+
+// linter carries the state of a lint run
+type linter struct {
+	manifest       *Manifest
+	importFailures map[int]error // Imports LintFile could not resolve, by index
+	rule           string
+	severity       LintSeverity
+	issues         []LintIssue
+}
+
+func (l *linter) report(path, format string, args ...interface{}) {
+	l.issues = append(l.issues, LintIssue{
+		Rule:     l.rule,
+		Severity: l.severity,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// eachArgument visits every argument and property in the manifest, including nested ones
+func (l *linter) eachArgument(visit func(path string, arg *ArgumentManifest)) {
+	var walk func(path string, arg *ArgumentManifest)
+	walk = func(path string, arg *ArgumentManifest) {
+		if arg == nil {
+			return
+		}
+		visit(path, arg)
+		for _, name := range sortedMapKeys(arg.Properties) {
+			walk(path+".properties."+name, arg.Properties[name])
+		}
+		walk(path+".items", arg.Items)
+		for i, alternative := range arg.AllOf {
+			walk(fmt.Sprintf("%s.allOf[%d]", path, i), alternative)
+		}
+		for i, alternative := range arg.AnyOf {
+			walk(fmt.Sprintf("%s.anyOf[%d]", path, i), alternative)
+		}
+		for i, alternative := range arg.OneOf {
+			walk(fmt.Sprintf("%s.oneOf[%d]", path, i), alternative)
+		}
+	}
+
+	for _, requestName := range sortedMapKeys(l.manifest.Requests) {
+		request := l.manifest.Requests[requestName]
+		if request == nil {
+			continue
+		}
+		path := "requests." + requestName
+		for _, name := range sortedMapKeys(request.Args) {
+			walk(path+".args."+name, request.Args[name])
+		}
+		if response := request.Response; response != nil {
+			for _, name := range sortedMapKeys(response.Properties) {
+				walk(path+".response.properties."+name, response.Properties[name])
+			}
+			walk(path+".response.items", response.Items)
+		}
+	}
+
+	for _, modelName := range sortedMapKeys(l.manifest.Models) {
+		model := l.manifest.Models[modelName]
+		if model == nil {
+			continue
+		}
+		for _, name := range sortedMapKeys(model.Properties) {
+			walk("models."+modelName+".properties."+name, model.Properties[name])
+		}
+	}
+}
+
+// MARK: - Rules
+
+// validArgumentTypes lists the types accepted by Validate
+var validArgumentTypes = map[string]bool{
+	"string": true, "number": true, "integer": true, "boolean": true, "array": true, "object": true,
+}
+
+func checkMissingMetadata(l *linter) {
+	if l.manifest.Name == "" {
+		l.report("name", "manifest name is required")
+	}
+	if l.manifest.Version == "" {
+		l.report("version", "manifest version is required")
+	}
+}
+
+func checkInvalidTypes(l *linter) {
+	l.eachArgument(func(path string, arg *ArgumentManifest) {
+		// Arguments described by modelRef, const or composition may omit the type
+		if arg.Type == "" && (arg.ModelRef != "" || arg.Const != nil || arg.hasComposition()) {
+			return
+		}
+		if !validArgumentTypes[arg.Type] {
+			l.report(path+".type", "type '%s' is not one of string, number, integer, boolean, array, object", arg.Type)
+		}
+	})
+	for _, name := range sortedMapKeys(l.manifest.Models) {
+		if model := l.manifest.Models[name]; model != nil && !validArgumentTypes[model.Type] {
+			l.report("models."+name+".type", "type '%s' is not one of string, number, integer, boolean, array, object", model.Type)
+		}
+	}
+}
+
+func checkUnresolvedModelRefs(l *linter) {
+	resolve := func(path, modelRef string) {
+		if modelRef == "" {
+			return
+		}
+		if _, exists := l.manifest.Models[modelRef]; !exists {
+			l.report(path+".modelRef", "model '%s' is not defined", modelRef)
+		}
+	}
+
+	l.eachArgument(func(path string, arg *ArgumentManifest) {
+		resolve(path, arg.ModelRef)
+	})
+	for _, name := range sortedMapKeys(l.manifest.Requests) {
+		if request := l.manifest.Requests[name]; request != nil && request.Response != nil {
+			resolve("requests."+name+".response", request.Response.ModelRef)
+		}
+	}
+}
+
+func checkUnresolvedImports(l *linter) {
+	indexes := make([]int, 0, len(l.importFailures))
+	for i := range l.importFailures {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	for _, i := range indexes {
+		l.report(fmt.Sprintf("imports[%d]", i), "import cannot be resolved: %v", l.importFailures[i])
+	}
+}
+
+func checkInvalidPatterns(l *linter) {
+	l.eachArgument(func(path string, arg *ArgumentManifest) {
+		if arg.Pattern == "" {
+			return
+		}
+		if _, err := regexp.Compile(arg.Pattern); err != nil {
+			l.report(path+".pattern", "pattern does not compile: %v", err)
+		}
+	})
+}
+
+func checkInconsistentBounds(l *linter) {
+	l.eachArgument(func(path string, arg *ArgumentManifest) {
+		if arg.MinLength != nil && arg.MaxLength != nil && *arg.MinLength > *arg.MaxLength {
+			l.report(path, "minLength %d exceeds maxLength %d", *arg.MinLength, *arg.MaxLength)
+		}
+		if arg.Minimum != nil && arg.Maximum != nil && *arg.Minimum > *arg.Maximum {
+			l.report(path, "minimum %v exceeds maximum %v", *arg.Minimum, *arg.Maximum)
+		}
+		if arg.MinLength != nil && *arg.MinLength < 0 {
+			l.report(path, "minLength %d is negative", *arg.MinLength)
+		}
+		if arg.MaxLength != nil && *arg.MaxLength < 0 {
+			l.report(path, "maxLength %d is negative", *arg.MaxLength)
+		}
+		if arg.MinItems != nil && arg.MaxItems != nil && *arg.MinItems > *arg.MaxItems {
+			l.report(path, "minItems %d exceeds maxItems %d", *arg.MinItems, *arg.MaxItems)
+		}
+		if arg.MinItems != nil && *arg.MinItems < 0 {
+			l.report(path, "minItems %d is negative", *arg.MinItems)
+		}
+		if arg.MaxItems != nil && *arg.MaxItems < 0 {
+			l.report(path, "maxItems %d is negative", *arg.MaxItems)
+		}
+	})
+}
+
+func checkInvalidFormats(l *linter) {
+	l.eachArgument(func(path string, arg *ArgumentManifest) {
+		if arg.Format == "" {
+			return
+		}
+		if !IsSupportedFormat(arg.Format) {
+			l.report(path+".format", "format '%s' is not one of %s", arg.Format, strings.Join(SupportedFormats(), ", "))
+		} else if arg.Type != "" && arg.Type != "string" {
+			l.report(path+".format", "format '%s' applies to strings, not %s", arg.Format, arg.Type)
+		}
+	})
+}
+
+func checkInvalidDiscriminators(l *linter) {
+	l.eachArgument(func(path string, arg *ArgumentManifest) {
+		if arg.Discriminator == nil {
+			return
+		}
+		path += ".discriminator"
+		if len(arg.OneOf) == 0 {
+			l.report(path, "discriminator requires oneOf")
+			return
+		}
+		if arg.Discriminator.PropertyName == "" {
+			l.report(path+".propertyName", "propertyName is required")
+		}
+		variants := make(map[string]bool)
+		for i, variant := range arg.OneOf {
+			if variant == nil || variant.ModelRef == "" {
+				l.report(fmt.Sprintf("%s.oneOf[%d]", strings.TrimSuffix(path, ".discriminator"), i), "discriminated variants must use modelRef")
+				continue
+			}
+			variants[variant.ModelRef] = true
+		}
+		for _, value := range sortedMapKeys(arg.Discriminator.Mapping) {
+			if target := arg.Discriminator.Mapping[value]; !variants[target] {
+				l.report(path+".mapping."+value, "model '%s' is not a oneOf variant", target)
+			}
+		}
+	})
+}
+
+func checkInvalidDefaults(l *linter) {
+	l.eachArgument(func(path string, arg *ArgumentManifest) {
+		if arg.Default == nil {
+			return
+		}
+		if err := l.manifest.validateArgument(arg.Name, arg.Default, arg); err != nil {
+			message := err.Error()
+			if validationErr, ok := err.(*ValidationError); ok {
+				message = validationErr.Message
+			}
+			l.report(path+".default", "default value %v violates its own constraints: %s", arg.Default, message)
+		}
+	})
+}
+
+func checkRequestNames(l *linter) {
+	validator := core.NewSecurityValidator()
+	for _, name := range sortedMapKeys(l.manifest.Requests) {
+		if err := validator.ValidateRequestName(name); err != nil {
+			l.report("requests."+name, "invalid request name: %v", err)
+		}
+	}
+}
+
+func checkBuiltinRedefined(l *linter) {
+	for _, name := range sortedMapKeys(l.manifest.Requests) {
+		if IsBuiltinRequest(name) {
+			l.report("requests."+name, "'%s' is a built-in request and cannot be redefined", name)
+		}
+	}
+}
+
+func checkUnusedModels(l *linter) {
+	// Reachability follows model references from requests, so a model used only by unused models is unused too
+	used := make(map[string]bool)
+	for name, flow := range modelFlows(l.manifest) {
+		if flow != 0 {
+			used[name] = true
+		}
+	}
+
+	for _, name := range sortedMapKeys(l.manifest.Models) {
+		// Imported libraries are shared wholesale, so their models need not all be used
+		if !used[name] && !strings.Contains(name, ".") {
+			l.report("models."+name, "model '%s' is not reachable from any request", name)
+		}
+	}
+}
+
+func checkMissingDescriptions(l *linter) {
+	if l.manifest.Description == "" {
+		l.report("description", "manifest has no description")
+	}
+	for _, name := range sortedMapKeys(l.manifest.Requests) {
+		if request := l.manifest.Requests[name]; request != nil && request.Description == "" {
+			l.report("requests."+name, "request '%s' has no description", name)
+		}
+	}
+	for _, name := range sortedMapKeys(l.manifest.Models) {
+		if model := l.manifest.Models[name]; model != nil && model.Description == "" {
+			l.report("models."+name, "model '%s' has no description", name)
+		}
+	}
+	l.eachArgument(func(path string, arg *ArgumentManifest) {
+		// Array items and composition alternatives are described by their parent
+		if arg.Description == "" && !strings.HasSuffix(path, ".items") && !strings.HasSuffix(path, "]") {
+			l.report(path, "no description")
+		}
+	})
+}
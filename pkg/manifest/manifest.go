@@ -4,77 +4,163 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	
+	"GoJanus/pkg/models"
 )
 
 // Manifest represents the complete Manifest
 // Matches Swift Manifest structure exactly for cross-language compatibility
 type Manifest struct {
-	Version     string                        `json:"version"`
-	Name        string                        `json:"name"`
-	Description string                        `json:"description"`
-	Models      map[string]*ModelDefinition   `json:"models,omitempty"`
+	Version     string                        `json:"version" yaml:"version"`
+	Name        string                        `json:"name" yaml:"name"`
+	Description string                        `json:"description" yaml:"description"`
+	Imports     []*ManifestImport             `json:"imports,omitempty" yaml:"imports,omitempty"` // Resolved into namespaced models on parse
+	Requests    map[string]*RequestManifest   `json:"requests,omitempty" yaml:"requests,omitempty"`
+	Models      map[string]*ModelDefinition   `json:"models,omitempty" yaml:"models,omitempty"`
 }
 
 
-// RequestManifest represents a request manifest within a channel
+// RequestManifest represents a request manifest
 // Matches Swift RequestManifest structure
 type RequestManifest struct {
-	Name        string                    `json:"name"`
-	Description string                    `json:"description"`
-	Args        map[string]*ArgumentManifest  `json:"args,omitempty"`
-	Response    *ResponseManifest             `json:"response,omitempty"`
-	ErrorCodes  []string                  `json:"errorCodes,omitempty"`
+	Name        string                    `json:"name" yaml:"name"`
+	Description string                    `json:"description" yaml:"description"`
+	Args        map[string]*ArgumentManifest  `json:"args,omitempty" yaml:"args,omitempty"`
+	Response    *ResponseManifest             `json:"response,omitempty" yaml:"response,omitempty"`
+	ErrorCodes  []string                  `json:"errorCodes,omitempty" yaml:"errorCodes,omitempty"`
 }
 
 // ArgumentManifest represents an argument manifest for a request
 // Matches Swift ArgumentManifest structure with ResponseValidator extensions
 type ArgumentManifest struct {
-	Name        string                   `json:"name"`
-	Type        string                   `json:"type"`
-	Description string                   `json:"description"`
-	Required    bool                     `json:"required"`
-	Default     interface{}              `json:"default,omitempty"`
-	Pattern     string                   `json:"pattern,omitempty"`
-	MinLength   *int                     `json:"minLength,omitempty"`
-	MaxLength   *int                     `json:"maxLength,omitempty"`
-	Minimum     *float64                 `json:"minimum,omitempty"`
-	Maximum     *float64                 `json:"maximum,omitempty"`
-	Enum        []string                 `json:"enum,omitempty"`
-	ModelRef    string                   `json:"modelRef,omitempty"`
-	Items       *ArgumentManifest            `json:"items,omitempty"`       // For array types
-	Properties  map[string]*ArgumentManifest `json:"properties,omitempty"` // For object types
+	Name        string                   `json:"name" yaml:"name"`
+	Type        string                   `json:"type" yaml:"type"`
+	Description string                   `json:"description" yaml:"description"`
+	Required    bool                     `json:"required" yaml:"required"`
+	Default     interface{}              `json:"default,omitempty" yaml:"default,omitempty"`
+	Pattern     string                   `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	MinLength   *int                     `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength   *int                     `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Minimum     *float64                 `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum     *float64                 `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	Enum        []string                 `json:"enum,omitempty" yaml:"enum,omitempty"`
+	ModelRef    string                   `json:"modelRef,omitempty" yaml:"modelRef,omitempty"`
+	Items       *ArgumentManifest            `json:"items,omitempty" yaml:"items,omitempty"`       // For array types
+	Properties  map[string]*ArgumentManifest `json:"properties,omitempty" yaml:"properties,omitempty"` // For object types
+	
+	// Array and object constraints
+	MinItems             *int     `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems             *int     `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	UniqueItems          bool     `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	AdditionalProperties *bool    `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"` // false closes the object; unset leaves it open
+	RequiredProperties   []string `json:"requiredProperties,omitempty" yaml:"requiredProperties,omitempty"`     // In addition to each property's required flag
+	
+	// Composition: the value must match all, at least one, or exactly one of the alternatives
+	// Type may be omitted when composition, modelRef or const describe the value
+	AllOf         []*ArgumentManifest `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	AnyOf         []*ArgumentManifest `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+	OneOf         []*ArgumentManifest `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	Discriminator *Discriminator      `json:"discriminator,omitempty" yaml:"discriminator,omitempty"` // Selects the oneOf variant by property value
+	Nullable      bool                `json:"nullable,omitempty" yaml:"nullable,omitempty"`           // null is accepted in addition to Type
+	Const         interface{}         `json:"const,omitempty" yaml:"const,omitempty"`                 // The only accepted value
+	Format        string              `json:"format,omitempty" yaml:"format,omitempty"`               // Well-known string format, see SupportedFormats
+}
+
+// Discriminator names the property whose value selects a oneOf variant
+// Without a mapping the property value must equal the variant's modelRef
+type Discriminator struct {
+	PropertyName string            `json:"propertyName" yaml:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"` // Property value -> model name
 }
 
 // ResponseManifest represents a response manifest for a request
 // Matches Swift ResponseManifest structure with ResponseValidator extensions
 type ResponseManifest struct {
-	Type        string                   `json:"type"`
-	Description string                   `json:"description"`
-	Properties  map[string]*ArgumentManifest `json:"properties,omitempty"`
-	ModelRef    string                   `json:"modelRef,omitempty"`
-	Items       *ArgumentManifest            `json:"items,omitempty"` // For array response types
+	Type        string                   `json:"type" yaml:"type"`
+	Description string                   `json:"description" yaml:"description"`
+	Properties  map[string]*ArgumentManifest `json:"properties,omitempty" yaml:"properties,omitempty"`
+	ModelRef    string                   `json:"modelRef,omitempty" yaml:"modelRef,omitempty"`
+	Items       *ArgumentManifest            `json:"items,omitempty" yaml:"items,omitempty"` // For array response types
 }
 
 // ModelDefinition represents a reusable data model
 // Matches Swift ModelDefinition structure
 type ModelDefinition struct {
-	Name        string                   `json:"name"`
-	Type        string                   `json:"type"`
-	Description string                   `json:"description"`
-	Properties  map[string]*ArgumentManifest `json:"properties,omitempty"`
-	Required    []string                 `json:"required,omitempty"`
+	Name        string                   `json:"name" yaml:"name"`
+	Type        string                   `json:"type" yaml:"type"`
+	Description string                   `json:"description" yaml:"description"`
+	Properties  map[string]*ArgumentManifest `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required    []string                 `json:"required,omitempty" yaml:"required,omitempty"`
+	
+	// Unset keeps models closed for arguments and open for responses; true or false applies to both
+	AdditionalProperties *bool `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
 }
 
 // ValidationError represents a validation error with context
 type ValidationError struct {
-	Field    string      `json:"field"`
-	Message  string      `json:"message"`
-	Value    interface{} `json:"value,omitempty"`    // Legacy field for backward compatibility
-	Expected string      `json:"expected,omitempty"` // Expected value/type for ResponseValidator
-	Actual   interface{} `json:"actual,omitempty"`   // Actual value for ResponseValidator
-	Context  string      `json:"context,omitempty"`  // Additional context for ResponseValidator
+	Field      string      `json:"field"`
+	Message    string      `json:"message"`
+	Value      interface{} `json:"value,omitempty"`      // Legacy field for backward compatibility
+	Expected   string      `json:"expected,omitempty"`   // Expected value/type
+	Actual     interface{} `json:"actual,omitempty"`     // Actual value
+	Context    string      `json:"context,omitempty"`    // Additional context for ResponseValidator
+	Path       string      `json:"path,omitempty"`       // JSON pointer to the value, e.g. /items/3/name
+	Constraint string      `json:"constraint,omitempty"` // Violated keyword, e.g. minLength or required
+}
+
+// ValidationErrors collects every error found while validating a set of values
+type ValidationErrors []*ValidationError
+
+func (errors ValidationErrors) Error() string {
+	if len(errors) == 1 {
+		return errors[0].Error()
+	}
+	messages := make([]string, len(errors))
+	for i, err := range errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(errors), strings.Join(messages, "; "))
+}
+
+// JSONRPCError packages the errors as InvalidParams with the full list in Context["errors"]
+func (errors ValidationErrors) JSONRPCError() *models.JSONRPCError {
+	return models.NewJSONRPCErrorWithContext(models.InvalidParams, errors.Error(), map[string]interface{}{
+		"errors": errors,
+	})
+}
+
+// ValidationErrorsFrom returns the validation errors carried in Context["errors"] of a JSON-RPC error
+// The list is ValidationErrors for errors raised locally and []interface{} once it has crossed the wire
+func ValidationErrorsFrom(rpcErr *models.JSONRPCError) ValidationErrors {
+	if rpcErr == nil || rpcErr.Data == nil {
+		return nil
+	}
+	
+	switch list := rpcErr.Data.Context["errors"].(type) {
+	case ValidationErrors:
+		return list
+	case []*ValidationError:
+		return list
+	case []interface{}:
+		data, err := json.Marshal(list)
+		if err != nil {
+			return nil
+		}
+		var errors ValidationErrors
+		if err := json.Unmarshal(data, &errors); err != nil {
+			return nil
+		}
+		return errors
+	}
+	return nil
+}
+
+// childPointer appends an escaped reference token to a JSON pointer
+func childPointer(pointer, token string) string {
+	return pointer + "/" + escapePointer(token)
 }
 
 func (ve *ValidationError) Error() string {
@@ -86,85 +172,140 @@ func (ve *ValidationError) Error() string {
 }
 
 // HasRequest checks if a request exists in the manifest
-// Channels have been removed from the protocol
+// Requests are defined at the top level now that channels have been removed
 func (manifest *Manifest) HasRequest(requestName string) bool {
-	// Since channels are removed, this always returns false for now
-	// The server will handle request validation
-	return false
+	_, exists := manifest.Requests[requestName]
+	return exists
 }
 
 // GetRequest retrieves a request manifest
-// Channels have been removed from the protocol
 func (manifest *Manifest) GetRequest(requestName string) (*RequestManifest, error) {
-	// Since channels are removed, this always returns an error
-	// The server will handle request validation
-	return nil, fmt.Errorf("channels have been removed from protocol")
+	requestManifest, exists := manifest.Requests[requestName]
+	if !exists {
+		return nil, fmt.Errorf("request '%s' not found in manifest", requestName)
+	}
+	return requestManifest, nil
 }
 
 // ValidateRequestArgs validates request arguments against the manifest
-// Matches Swift comprehensive argument validation
+// Matches Swift comprehensive argument validation; returns the first error, see ValidateRequestArgsAll
 func (manifest *Manifest) ValidateRequestArgs(requestManifest *RequestManifest, args map[string]interface{}) error {
+	if errors := manifest.ValidateRequestArgsAll(requestManifest, args); len(errors) > 0 {
+		return errors[0]
+	}
+	return nil
+}
+
+// ValidateRequestArgsAll validates request arguments and returns every error, ordered by path
+func (manifest *Manifest) ValidateRequestArgsAll(requestManifest *RequestManifest, args map[string]interface{}) ValidationErrors {
+	var errors ValidationErrors
 	if requestManifest.Args == nil {
 		if len(args) > 0 {
-			return &ValidationError{
-				Field:   "arguments",
-				Message: "request does not accept arguments",
-				Value:   args,
-			}
-		}
-		return nil
+			errors = append(errors, &ValidationError{
+				Field:      "arguments",
+				Message:    "request does not accept arguments",
+				Value:      args,
+				Constraint: "additionalProperties",
+			})
+		}
+		return errors
 	}
 	
 	// Check required arguments
-	for argName, argManifest := range requestManifest.Args {
-		if argManifest.Required {
+	for _, argName := range sortedMapKeys(requestManifest.Args) {
+		if argManifest := requestManifest.Args[argName]; argManifest != nil && argManifest.Required {
 			if _, exists := args[argName]; !exists {
-				return &ValidationError{
-					Field:   argName,
-					Message: "required argument missing",
-					Value:   nil,
-				}
+				errors = append(errors, &ValidationError{
+					Field:      argName,
+					Path:       childPointer("", argName),
+					Message:    "required argument missing",
+					Constraint: "required",
+				})
 			}
 		}
 	}
 	
 	// Validate provided arguments
-	for argName, argValue := range args {
+	for _, argName := range sortedMapKeys(args) {
+		argValue := args[argName]
 		argManifest, exists := requestManifest.Args[argName]
 		if !exists {
-			return &ValidationError{
-				Field:   argName,
-				Message: "unknown argument",
-				Value:   argValue,
-			}
+			errors = append(errors, &ValidationError{
+				Field:      argName,
+				Path:       childPointer("", argName),
+				Message:    "unknown argument",
+				Value:      argValue,
+				Actual:     argValue,
+				Constraint: "additionalProperties",
+			})
+			continue
 		}
 		
-		if err := manifest.validateArgument(argName, argValue, argManifest); err != nil {
-			return err
-		}
+		errors = append(errors, manifest.argumentErrors(argName, childPointer("", argName), argValue, argManifest)...)
 	}
 	
-	return nil
+	sort.SliceStable(errors, func(i, j int) bool {
+		return errors[i].Path < errors[j].Path
+	})
+	return errors
 }
 
-// validateArgument validates a single argument against its manifest
-// Implements all Swift validation rules
+// validateArgument validates a single argument against its manifest, returning the first error
 func (manifest *Manifest) validateArgument(name string, value interface{}, argManifest *ArgumentManifest) error {
+	path := ""
+	if name != "" {
+		path = childPointer("", name)
+	}
+	if errors := manifest.argumentErrors(name, path, value, argManifest); len(errors) > 0 {
+		return errors[0]
+	}
+	return nil
+}
+
+// argumentErrors validates a value against its manifest and returns every error
+// Implements all Swift validation rules; name is the dotted field and path the JSON pointer
+func (manifest *Manifest) argumentErrors(name, path string, value interface{}, argManifest *ArgumentManifest) ValidationErrors {
+	var errors ValidationErrors
+	fail := func(constraint, expected, message string) {
+		errors = append(errors, &ValidationError{
+			Field:      name,
+			Path:       path,
+			Message:    message,
+			Value:      value,
+			Expected:   expected,
+			Actual:     value,
+			Constraint: constraint,
+		})
+	}
+	
+	value = binaryValue(value, argManifest)
+	
 	// Handle null values
 	if value == nil {
-		if argManifest.Required {
-			return &ValidationError{
-				Field:   name,
-				Message: "required argument cannot be null",
-				Value:   value,
-			}
+		if argManifest.Required && !argManifest.Nullable {
+			fail("required", "non-null value", "required argument cannot be null")
 		}
-		return nil
+		return errors
 	}
 	
-	// Type validation
-	if err := manifest.validateArgumentType(name, value, argManifest); err != nil {
-		return err
+	// Guard against runaway nesting, e.g. through self-referencing models
+	if strings.Count(path, "/") > MaxValidationDepth {
+		fail("maxDepth", fmt.Sprintf("at most %d levels", MaxValidationDepth), fmt.Sprintf("value is nested deeper than %d levels", MaxValidationDepth))
+		return errors
+	}
+	
+	// Const validation
+	if argManifest.Const != nil && !valuesEqual(value, argManifest.Const) {
+		fail("const", fmt.Sprintf("%v", argManifest.Const), fmt.Sprintf("value must equal constant %v", argManifest.Const))
+	}
+	
+	// Type validation (arguments described only by modelRef, const or composition may omit it)
+	if argManifest.Type != "" {
+		if err := manifest.validateArgumentType(name, value, argManifest); err != nil {
+			// A value of the wrong type cannot be checked against the remaining constraints
+			fail("type", argManifest.Type, err.(*ValidationError).Message)
+			return errors
+		}
 	}
 	
 	// Pattern validation for strings
@@ -172,18 +313,9 @@ func (manifest *Manifest) validateArgument(name string, value interface{}, argMa
 		if strValue, ok := value.(string); ok {
 			matched, err := regexp.MatchString(argManifest.Pattern, strValue)
 			if err != nil {
-				return &ValidationError{
-					Field:   name,
-					Message: fmt.Sprintf("invalid pattern regex: %s", err.Error()),
-					Value:   value,
-				}
-			}
-			if !matched {
-				return &ValidationError{
-					Field:   name,
-					Message: fmt.Sprintf("value does not match pattern: %s", argManifest.Pattern),
-					Value:   value,
-				}
+				fail("pattern", "valid regex pattern", fmt.Sprintf("invalid pattern regex: %s", err.Error()))
+			} else if !matched {
+				fail("pattern", argManifest.Pattern, fmt.Sprintf("value does not match pattern: %s", argManifest.Pattern))
 			}
 		}
 	}
@@ -192,38 +324,33 @@ func (manifest *Manifest) validateArgument(name string, value interface{}, argMa
 	if argManifest.Type == "string" {
 		if strValue, ok := value.(string); ok {
 			if argManifest.MinLength != nil && len(strValue) < *argManifest.MinLength {
-				return &ValidationError{
-					Field:   name,
-					Message: fmt.Sprintf("string length %d is less than minimum %d", len(strValue), *argManifest.MinLength),
-					Value:   value,
-				}
+				fail("minLength", fmt.Sprintf("minimum length %d", *argManifest.MinLength),
+					fmt.Sprintf("string length %d is less than minimum %d", len(strValue), *argManifest.MinLength))
 			}
 			if argManifest.MaxLength != nil && len(strValue) > *argManifest.MaxLength {
-				return &ValidationError{
-					Field:   name,
-					Message: fmt.Sprintf("string length %d exceeds maximum %d", len(strValue), *argManifest.MaxLength),
-					Value:   value,
-				}
+				fail("maxLength", fmt.Sprintf("maximum length %d", *argManifest.MaxLength),
+					fmt.Sprintf("string length %d exceeds maximum %d", len(strValue), *argManifest.MaxLength))
 			}
 		}
 	}
 	
+	// Format validation for strings
+	if argManifest.Format != "" {
+		if strValue, ok := value.(string); ok && !matchesFormat(argManifest.Format, strValue) {
+			fail("format", argManifest.Format, fmt.Sprintf("value is not a valid %s", argManifest.Format))
+		}
+	}
+	
 	// Numeric range validation
 	if argManifest.Type == "number" || argManifest.Type == "integer" {
 		if numValue, err := manifest.getNumericValue(value); err == nil {
 			if argManifest.Minimum != nil && numValue < *argManifest.Minimum {
-				return &ValidationError{
-					Field:   name,
-					Message: fmt.Sprintf("value %f is less than minimum %f", numValue, *argManifest.Minimum),
-					Value:   value,
-				}
+				fail("minimum", fmt.Sprintf("minimum %g", *argManifest.Minimum),
+					fmt.Sprintf("value %f is less than minimum %f", numValue, *argManifest.Minimum))
 			}
 			if argManifest.Maximum != nil && numValue > *argManifest.Maximum {
-				return &ValidationError{
-					Field:   name,
-					Message: fmt.Sprintf("value %f exceeds maximum %f", numValue, *argManifest.Maximum),
-					Value:   value,
-				}
+				fail("maximum", fmt.Sprintf("maximum %g", *argManifest.Maximum),
+					fmt.Sprintf("value %f exceeds maximum %f", numValue, *argManifest.Maximum))
 			}
 		}
 	}
@@ -239,22 +366,49 @@ func (manifest *Manifest) validateArgument(name string, value interface{}, argMa
 			}
 		}
 		if !valid {
-			return &ValidationError{
-				Field:   name,
-				Message: fmt.Sprintf("value not in allowed enum values: %v", argManifest.Enum),
-				Value:   value,
-			}
+			fail("enum", strings.Join(argManifest.Enum, ", "), fmt.Sprintf("value not in allowed enum values: %v", argManifest.Enum))
 		}
 	}
 	
 	// Model reference validation
 	if argManifest.ModelRef != "" {
-		if err := manifest.validateModelReference(name, value, argManifest.ModelRef); err != nil {
-			return err
+		errors = append(errors, manifest.modelErrors(name, path, value, argManifest.ModelRef)...)
+	}
+	
+	// Array constraints and items
+	if items, ok := value.([]interface{}); ok {
+		if argManifest.MinItems != nil && len(items) < *argManifest.MinItems {
+			fail("minItems", fmt.Sprintf("at least %d items", *argManifest.MinItems),
+				fmt.Sprintf("array has %d items, fewer than minimum %d", len(items), *argManifest.MinItems))
+		}
+		if argManifest.MaxItems != nil && len(items) > *argManifest.MaxItems {
+			fail("maxItems", fmt.Sprintf("at most %d items", *argManifest.MaxItems),
+				fmt.Sprintf("array has %d items, more than maximum %d", len(items), *argManifest.MaxItems))
+		}
+		if argManifest.UniqueItems {
+			if first, second, found := duplicateItems(items); found {
+				fail("uniqueItems", "unique items", fmt.Sprintf("items %d and %d are equal", first, second))
+			}
+		}
+		if argManifest.Items != nil {
+			for i, item := range items {
+				errors = append(errors, manifest.argumentErrors(fmt.Sprintf("%s[%d]", name, i), childPointer(path, strconv.Itoa(i)), item, argManifest.Items)...)
+			}
 		}
 	}
 	
-	return nil
+	// Inline object properties
+	if object, ok := value.(map[string]interface{}); ok && argManifest.describesObject() {
+		closed := argManifest.AdditionalProperties != nil && !*argManifest.AdditionalProperties
+		errors = append(errors, manifest.propertyErrors(name, path, object, argManifest.Properties, stringSet(argManifest.RequiredProperties), closed)...)
+	}
+	
+	// Composition validation
+	if argManifest.hasComposition() {
+		errors = append(errors, manifest.compositionErrors(name, path, value, argManifest)...)
+	}
+	
+	return errors
 }
 
 // validateArgumentType validates the type of an argument
@@ -320,58 +474,84 @@ func (manifest *Manifest) validateArgumentType(name string, value interface{}, a
 	return nil
 }
 
-// validateModelReference validates a value against a model definition
+// modelErrors validates a value against a model definition
 // Matches Swift model reference validation
-func (manifest *Manifest) validateModelReference(name string, value interface{}, modelRef string) error {
+func (manifest *Manifest) modelErrors(name, path string, value interface{}, modelRef string) ValidationErrors {
 	model, exists := manifest.Models[modelRef]
 	if !exists {
-		return &ValidationError{
-			Field:   name,
-			Message: fmt.Sprintf("model reference '%s' not found", modelRef),
-			Value:   value,
-		}
+		return ValidationErrors{{
+			Field:      name,
+			Path:       path,
+			Message:    fmt.Sprintf("model reference '%s' not found", modelRef),
+			Value:      value,
+			Constraint: "modelRef",
+		}}
 	}
 	
 	// For object types, validate properties
 	if model.Type == "object" {
 		valueMap, ok := value.(map[string]interface{})
 		if !ok {
-			return &ValidationError{
-				Field:   name,
-				Message: "expected object for model reference",
-				Value:   value,
-			}
+			return ValidationErrors{{
+				Field:      name,
+				Path:       path,
+				Message:    "expected object for model reference",
+				Value:      value,
+				Expected:   "object",
+				Actual:     value,
+				Constraint: "type",
+			}}
 		}
 		
-		// Check required properties
-		for _, requiredProp := range model.Required {
-			if _, exists := valueMap[requiredProp]; !exists {
-				return &ValidationError{
-					Field:   fmt.Sprintf("%s.%s", name, requiredProp),
-					Message: "required property missing in model",
-					Value:   value,
-				}
-			}
+		closed := model.AdditionalProperties == nil || !*model.AdditionalProperties
+		return manifest.propertyErrors(name, path, valueMap, model.Properties, stringSet(model.Required), closed)
+	}
+	
+	return nil
+}
+
+// propertyErrors validates the properties of an object
+// required lists properties required by the enclosing definition in addition to each property's
+// own required flag; closed rejects undeclared properties
+func (manifest *Manifest) propertyErrors(name, path string, object map[string]interface{}, properties map[string]*ArgumentManifest, required map[string]bool, closed bool) ValidationErrors {
+	var errors ValidationErrors
+	
+	// Check required properties
+	required = requiredPropertySet(properties, required)
+	for _, requiredProp := range sortedMapKeys(required) {
+		if _, exists := object[requiredProp]; !exists {
+			errors = append(errors, &ValidationError{
+				Field:      fmt.Sprintf("%s.%s", name, requiredProp),
+				Path:       childPointer(path, requiredProp),
+				Message:    "required property missing",
+				Value:      object,
+				Constraint: "required",
+			})
 		}
-		
-		// Validate properties
-		for propName, propValue := range valueMap {
-			propManifest, exists := model.Properties[propName]
-			if !exists {
-				return &ValidationError{
-					Field:   fmt.Sprintf("%s.%s", name, propName),
-					Message: "unknown property in model",
-					Value:   propValue,
-				}
-			}
-			
-			if err := manifest.validateArgument(fmt.Sprintf("%s.%s", name, propName), propValue, propManifest); err != nil {
-				return err
+	}
+	
+	// Validate properties
+	for _, propName := range sortedMapKeys(object) {
+		propValue := object[propName]
+		propManifest, exists := properties[propName]
+		if !exists {
+			if closed {
+				errors = append(errors, &ValidationError{
+					Field:      fmt.Sprintf("%s.%s", name, propName),
+					Path:       childPointer(path, propName),
+					Message:    "unknown property",
+					Value:      propValue,
+					Actual:     propValue,
+					Constraint: "additionalProperties",
+				})
 			}
+			continue
 		}
+		
+		errors = append(errors, manifest.argumentErrors(fmt.Sprintf("%s.%s", name, propName), childPointer(path, propName), propValue, propManifest)...)
 	}
 	
-	return nil
+	return errors
 }
 
 // Helper methods for type checking
@@ -488,6 +668,27 @@ func (manifest *Manifest) Validate() error {
 		return fmt.Errorf("Manifest name is required")
 	}
 	
+	// ParseFromFile resolves imports; other manifests must be resolved explicitly
+	if len(manifest.Imports) > 0 {
+		return fmt.Errorf("Manifest imports must be resolved before validation")
+	}
+	
+	// Validate request definitions
+	for requestName, requestManifest := range manifest.Requests {
+		if requestName == "" {
+			return fmt.Errorf("request name cannot be empty")
+		}
+		
+		if requestManifest == nil {
+			return fmt.Errorf("request '%s' definition is required", requestName)
+		}
+		
+		for argName, argManifest := range requestManifest.Args {
+			if err := manifest.validateArgumentManifest(fmt.Sprintf("request.%s.%s", requestName, argName), argManifest); err != nil {
+				return err
+			}
+		}
+	}
 	
 	// Validate model definitions
 	for modelName, model := range manifest.Models {
@@ -495,6 +696,10 @@ func (manifest *Manifest) Validate() error {
 			return fmt.Errorf("model name cannot be empty")
 		}
 		
+		if model == nil {
+			return fmt.Errorf("model '%s' definition is required", modelName)
+		}
+		
 		if model.Name == "" {
 			return fmt.Errorf("model '%s' name is required", modelName)
 		}
@@ -516,8 +721,19 @@ func (manifest *Manifest) Validate() error {
 
 // validateArgumentManifest validates an argument manifest itself
 func (manifest *Manifest) validateArgumentManifest(context string, argManifest *ArgumentManifest) error {
+	if argManifest == nil {
+		return fmt.Errorf("argument definition is required for '%s'", context)
+	}
+	
+	if err := validateCollectionManifest(context, argManifest); err != nil {
+		return err
+	}
+	
 	if argManifest.Type == "" {
-		return fmt.Errorf("argument type is required for '%s'", context)
+		if argManifest.ModelRef == "" && argManifest.Const == nil && !argManifest.hasComposition() {
+			return fmt.Errorf("argument type is required for '%s'", context)
+		}
+		return manifest.validateCompositionManifest(context, argManifest)
 	}
 	
 	validTypes := []string{"string", "number", "integer", "boolean", "array", "object"}
@@ -554,5 +770,17 @@ func (manifest *Manifest) validateArgumentManifest(context string, argManifest *
 		}
 	}
 	
-	return nil
+	// Validate nested definitions
+	if argManifest.Items != nil {
+		if err := manifest.validateArgumentManifest(context+"[]", argManifest.Items); err != nil {
+			return err
+		}
+	}
+	for _, propName := range sortedMapKeys(argManifest.Properties) {
+		if err := manifest.validateArgumentManifest(context+"."+propName, argManifest.Properties[propName]); err != nil {
+			return err
+		}
+	}
+	
+	return manifest.validateCompositionManifest(context, argManifest)
 }
\ No newline at end of file
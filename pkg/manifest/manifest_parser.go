@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -12,7 +13,10 @@ import (
 
 // ManifestParser handles parsing of Manifests from JSON and YAML
 // Matches Swift ManifestParser functionality exactly for cross-language compatibility
-type ManifestParser struct{}
+type ManifestParser struct {
+	// LibraryPaths are searched for named imports before JANUS_MANIFEST_PATH
+	LibraryPaths []string
+}
 
 // NewManifestParser creates a new parser instance
 func NewManifestParser() *ManifestParser {
@@ -22,6 +26,16 @@ func NewManifestParser() *ManifestParser {
 // ParseJSON parses an Manifest from JSON data
 // Matches Swift: static func parseJSON(_ data: Data) throws -> Manifest
 func (parser *ManifestParser) ParseJSON(data []byte) (*Manifest, error) {
+	manifest, err := parser.decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	
+	return parser.validated(manifest)
+}
+
+// decodeJSON strictly decodes JSON data without validating the result
+func (parser *ManifestParser) decodeJSON(data []byte) (*Manifest, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("JSON data cannot be empty")
 	}
@@ -36,12 +50,16 @@ func (parser *ManifestParser) ParseJSON(data []byte) (*Manifest, error) {
 		return nil, fmt.Errorf("failed to parse JSON Manifest: %w", err)
 	}
 	
-	// Validate the parsed manifest
+	return &manifest, nil
+}
+
+// validated validates a decoded manifest
+func (parser *ManifestParser) validated(manifest *Manifest) (*Manifest, error) {
 	if err := manifest.Validate(); err != nil {
 		return nil, fmt.Errorf("Manifest validation failed: %w", err)
 	}
 	
-	return &manifest, nil
+	return manifest, nil
 }
 
 // ParseJSONString parses an Manifest from a JSON string
@@ -57,6 +75,16 @@ func (parser *ManifestParser) ParseJSONString(jsonString string) (*Manifest, err
 // ParseYAML parses an Manifest from YAML data
 // Matches Swift: static func parseYAML(_ data: Data) throws -> Manifest
 func (parser *ManifestParser) ParseYAML(data []byte) (*Manifest, error) {
+	manifest, err := parser.decodeYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	
+	return parser.validated(manifest)
+}
+
+// decodeYAML strictly decodes YAML data without validating the result
+func (parser *ManifestParser) decodeYAML(data []byte) (*Manifest, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("YAML data cannot be empty")
 	}
@@ -71,11 +99,6 @@ func (parser *ManifestParser) ParseYAML(data []byte) (*Manifest, error) {
 		return nil, fmt.Errorf("failed to parse YAML Manifest: %w", err)
 	}
 	
-	// Validate the parsed manifest
-	if err := manifest.Validate(); err != nil {
-		return nil, fmt.Errorf("Manifest validation failed: %w", err)
-	}
-	
 	return &manifest, nil
 }
 
@@ -92,6 +115,66 @@ func (parser *ManifestParser) ParseYAMLString(yamlString string) (*Manifest, err
 // ParseFromFile parses an Manifest from a file
 // Matches Swift: static func parseFromFile(at url: URL) throws -> Manifest
 func (parser *ManifestParser) ParseFromFile(filePath string) (*Manifest, error) {
+	manifest, err := parser.DecodeFromFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := parser.ResolveFileImports(manifest, filePath); err != nil {
+		return nil, err
+	}
+	
+	return parser.validated(manifest)
+}
+
+// ResolveImports merges the manifest's imports into namespaced models
+// Only file-based parsing resolves imports automatically, so manifests received as data
+// (e.g. from a server) never read local files; call this to resolve them explicitly
+func (parser *ManifestParser) ResolveImports(manifest *Manifest, baseDir string) error {
+	if len(manifest.Imports) == 0 {
+		return nil
+	}
+	
+	if err := parser.importResolver().Resolve(manifest, baseDir); err != nil {
+		return fmt.Errorf("failed to resolve imports: %w", err)
+	}
+	return nil
+}
+
+// ResolveFileImports resolves the imports of a manifest decoded from filePath
+// The file itself takes part in cycle detection
+func (parser *ManifestParser) ResolveFileImports(manifest *Manifest, filePath string) error {
+	_, err := parser.ResolveFileImportsTracked(manifest, filePath)
+	return err
+}
+
+// ResolveFileImportsTracked resolves imports like ResolveFileImports and returns the absolute
+// paths of every imported file it read, even when resolution fails, so watchers can follow them
+func (parser *ManifestParser) ResolveFileImportsTracked(manifest *Manifest, filePath string) ([]string, error) {
+	if len(manifest.Imports) == 0 {
+		return nil, nil
+	}
+	
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path '%s': %w", filePath, err)
+	}
+	resolver := parser.importResolver()
+	if err := resolver.resolveFrom(manifest, absPath); err != nil {
+		return resolver.Files(), fmt.Errorf("failed to resolve imports: %w", err)
+	}
+	return resolver.Files(), nil
+}
+
+// importResolver creates a resolver searching LibraryPaths, then JANUS_MANIFEST_PATH
+func (parser *ManifestParser) importResolver() *ImportResolver {
+	searchPaths := append(append([]string{}, parser.LibraryPaths...), LibrarySearchPathsFromEnv()...)
+	return NewImportResolver(searchPaths...)
+}
+
+// DecodeFromFile reads a manifest file without validating it
+// Used by tools such as the linter that report problems instead of rejecting the file
+func (parser *ManifestParser) DecodeFromFile(filePath string) (*Manifest, error) {
 	if filePath == "" {
 		return nil, fmt.Errorf("file path cannot be empty")
 	}
@@ -109,37 +192,43 @@ func (parser *ManifestParser) ParseFromFile(filePath string) (*Manifest, error)
 		return nil, fmt.Errorf("failed to read file '%s': %w", filePath, err)
 	}
 	
+	return parser.DecodeFileContent(filePath, data)
+}
+
+// DecodeFileContent decodes content already read from filePath without validating it
+// The format is chosen by the file extension, falling back to content detection
+func (parser *ManifestParser) DecodeFileContent(filePath string, data []byte) (*Manifest, error) {
 	// Determine format based on file extension
 	if strings.HasSuffix(strings.ToLower(filePath), ".yaml") || strings.HasSuffix(strings.ToLower(filePath), ".yml") {
-		return parser.ParseYAML(data)
+		return parser.decodeYAML(data)
 	} else if strings.HasSuffix(strings.ToLower(filePath), ".json") {
-		return parser.ParseJSON(data)
+		return parser.decodeJSON(data)
 	} else {
 		// Try to auto-detect format based on content
-		return parser.parseAutoDetect(data)
+		return parser.decodeAutoDetect(data)
 	}
 }
 
-// parseAutoDetect attempts to parse data by auto-detecting the format
-func (parser *ManifestParser) parseAutoDetect(data []byte) (*Manifest, error) {
+// decodeAutoDetect attempts to decode data by auto-detecting the format
+func (parser *ManifestParser) decodeAutoDetect(data []byte) (*Manifest, error) {
 	trimmed := strings.TrimSpace(string(data))
 	
 	// Try JSON first (starts with '{')
 	if strings.HasPrefix(trimmed, "{") {
-		manifest, err := parser.ParseJSON(data)
+		manifest, err := parser.decodeJSON(data)
 		if err == nil {
 			return manifest, nil
 		}
 	}
 	
 	// Try YAML
-	manifest, err := parser.ParseYAML(data)
+	manifest, err := parser.decodeYAML(data)
 	if err == nil {
 		return manifest, nil
 	}
 	
 	// If both fail, return JSON error as it's more common
-	return parser.ParseJSON(data)
+	return parser.decodeJSON(data)
 }
 
 // ValidateManifest validates a parsed Manifest
@@ -236,14 +325,26 @@ func (parser *ManifestParser) ParseMultipleFiles(filePaths []string) (*Manifest,
 }
 
 // MergeManifests merges two Manifests (public method)
-// The additional manifest's channels and models are added to the base manifest
+// The additional manifest's requests and models are added to the base manifest
 func (parser *ManifestParser) MergeManifests(base, additional *Manifest) error {
 	return parser.mergeManifests(base, additional)
 }
 
 // mergeManifests merges two Manifests (private implementation)
-// Only models are merged now that channels have been removed from the protocol
+// Requests and models are merged now that channels have been removed from the protocol
 func (parser *ManifestParser) mergeManifests(base, additional *Manifest) error {
+	// Merge requests
+	if base.Requests == nil && len(additional.Requests) > 0 {
+		base.Requests = make(map[string]*RequestManifest)
+	}
+	
+	for requestName, requestManifest := range additional.Requests {
+		if _, exists := base.Requests[requestName]; exists {
+			return fmt.Errorf("request '%s' already exists in base manifest", requestName)
+		}
+		base.Requests[requestName] = requestManifest
+	}
+	
 	// Merge models
 	if base.Models == nil {
 		base.Models = make(map[string]*ModelDefinition)
@@ -54,23 +54,75 @@ func (rv *ResponseValidator) ValidateResponse(response map[string]interface{}, r
 func (rv *ResponseValidator) ValidateRequestResponse(response map[string]interface{}, requestName string) *ValidationResult {
 	startTime := time.Now()
 	
-	// Channels have been removed from the protocol
-	// Return a validation result indicating that validation cannot be performed
-	return &ValidationResult{
-		Valid: false,
-		Errors: []*ValidationError{{
-			Field:    "protocol",
-			Message:  "Channels have been removed from the protocol",
-			Expected: "channel-free protocol",
-			Actual:   "validation not available",
-		}},
-		ValidationTime:  float64(time.Since(startTime).Nanoseconds()) / 1e6,
-		FieldsValidated: 0,
+	requestManifest, exists := rv.manifest.Requests[requestName]
+	if !exists || requestManifest == nil {
+		return &ValidationResult{
+			Valid: false,
+			Errors: []*ValidationError{{
+				Field:    "request",
+				Message:  fmt.Sprintf("Request '%s' not found in manifest", requestName),
+				Expected: "defined request",
+				Actual:   requestName,
+			}},
+			ValidationTime:  float64(time.Since(startTime).Nanoseconds()) / 1e6,
+			FieldsValidated: 0,
+		}
 	}
+	
+	if requestManifest.Response == nil {
+		return &ValidationResult{
+			Valid: false,
+			Errors: []*ValidationError{{
+				Field:    "response",
+				Message:  fmt.Sprintf("No response manifest defined for request '%s'", requestName),
+				Expected: "response manifest",
+				Actual:   "undefined",
+			}},
+			ValidationTime:  float64(time.Since(startTime).Nanoseconds()) / 1e6,
+			FieldsValidated: 0,
+		}
+	}
+	
+	return rv.ValidateResponse(response, requestManifest.Response)
 }
 
 // validateValue validates a value against an argument or response manifest
 func (rv *ResponseValidator) validateValue(value interface{}, manifest interface{}, fieldPath string, errors *[]*ValidationError) {
+	// Guard against runaway nesting, e.g. through self-referencing models
+	if fieldDepth(fieldPath) > MaxValidationDepth {
+		*errors = append(*errors, &ValidationError{
+			Field:    fieldPath,
+			Message:  fmt.Sprintf("Value is nested deeper than %d levels", MaxValidationDepth),
+			Expected: fmt.Sprintf("at most %d levels", MaxValidationDepth),
+			Actual:   "deeper nesting",
+		})
+		return
+	}
+	
+	// Handle nullable, const and composition
+	if argManifest, ok := manifest.(*ArgumentManifest); ok {
+		value = binaryValue(value, argManifest)
+		if value == nil && argManifest.Nullable {
+			return
+		}
+		
+		initialErrorCount := len(*errors)
+		if argManifest.Const != nil && !valuesEqual(value, argManifest.Const) {
+			*errors = append(*errors, &ValidationError{
+				Field:    fieldPath,
+				Message:  "Value does not equal the required constant",
+				Expected: fmt.Sprintf("%v", argManifest.Const),
+				Actual:   value,
+			})
+		}
+		if argManifest.hasComposition() {
+			rv.validateComposition(value, argManifest, fieldPath, errors)
+		}
+		if len(*errors) > initialErrorCount || (argManifest.Type == "" && argManifest.ModelRef == "") {
+			return // Nothing further to check, or the value already failed
+		}
+	}
+	
 	// Handle model references
 	if responseManifest, ok := manifest.(*ResponseManifest); ok && responseManifest.ModelRef != "" {
 		model := rv.resolveModelReference(responseManifest.ModelRef)
@@ -302,6 +354,16 @@ func (rv *ResponseValidator) validateString(value string, manifest interface{},
 			})
 		}
 	}
+	
+	// Format validation
+	if argManifest.Format != "" && !matchesFormat(argManifest.Format, value) {
+		*errors = append(*errors, &ValidationError{
+			Field:    fieldPath,
+			Message:  fmt.Sprintf("String is not a valid %s", argManifest.Format),
+			Expected: fmt.Sprintf("format %s", argManifest.Format),
+			Actual:   value,
+		})
+	}
 }
 
 // validateNumber validates numeric value constraints
@@ -340,6 +402,7 @@ func (rv *ResponseValidator) validateArray(value []interface{}, manifest interfa
 		itemManifest = s.Items
 	case *ArgumentManifest:
 		itemManifest = s.Items
+		rv.validateArrayConstraints(value, s, fieldPath, errors)
 	}
 	
 	if itemManifest == nil {
@@ -353,36 +416,65 @@ func (rv *ResponseValidator) validateArray(value []interface{}, manifest interfa
 	}
 }
 
+// additionalPropertyMessage reports an undeclared property of a closed object
+const additionalPropertyMessage = "Additional property is not allowed"
+
 // validateObject validates object properties
 func (rv *ResponseValidator) validateObject(value map[string]interface{}, manifest interface{}, fieldPath string, errors *[]*ValidationError) {
 	var properties map[string]*ArgumentManifest
+	var required map[string]bool
+	closed := false
 	
 	switch s := manifest.(type) {
 	case *ResponseManifest:
 		properties = s.Properties
 	case *ArgumentManifest:
 		properties = s.Properties
+		required = stringSet(s.RequiredProperties)
+		closed = s.AdditionalProperties != nil && !*s.AdditionalProperties
 	case *ModelDefinition:
 		properties = s.Properties
+		required = stringSet(s.Required)
+		closed = s.AdditionalProperties != nil && !*s.AdditionalProperties
+	}
+	
+	// Required properties without a definition only need to be present
+	for _, propName := range sortedMapKeys(required) {
+		if _, defined := properties[propName]; !defined {
+			if _, exists := value[propName]; !exists {
+				*errors = append(*errors, &ValidationError{
+					Field:    joinFieldPath(fieldPath, propName),
+					Message:  "Required field is missing",
+					Expected: "present field",
+					Actual:   nil,
+				})
+			}
+		}
 	}
 	
-	if properties == nil {
-		return // No property manifest, skip property validation
+	// Undeclared properties of closed objects
+	if closed {
+		for _, propName := range sortedMapKeys(value) {
+			if _, defined := properties[propName]; !defined {
+				*errors = append(*errors, &ValidationError{
+					Field:    joinFieldPath(fieldPath, propName),
+					Message:  additionalPropertyMessage,
+					Expected: "declared property",
+					Actual:   propName,
+				})
+			}
+		}
 	}
 	
 	// Validate each property
-	for propName, propManifest := range properties {
-		var propFieldPath string
-		if fieldPath == "" {
-			propFieldPath = propName
-		} else {
-			propFieldPath = fmt.Sprintf("%s.%s", fieldPath, propName)
-		}
-		
+	for _, propName := range sortedMapKeys(properties) {
+		propManifest := properties[propName]
+		propFieldPath := joinFieldPath(fieldPath, propName)
 		propValue, exists := value[propName]
+		isRequired := propManifest.Required || required[propName]
 		
 		// Check required fields
-		if propManifest.Required && (!exists || propValue == nil) {
+		if isRequired && (!exists || (propValue == nil && !propManifest.Nullable)) {
 			*errors = append(*errors, &ValidationError{
 				Field:    propFieldPath,
 				Message:  "Required field is missing or null",
@@ -393,7 +485,7 @@ func (rv *ResponseValidator) validateObject(value map[string]interface{}, manife
 		}
 		
 		// Skip validation for optional missing fields
-		if !exists && !propManifest.Required {
+		if !exists {
 			continue
 		}
 		
@@ -7,146 +7,143 @@ import (
 )
 
 func TestResponseValidator(t *testing.T) {
-	// Create test Manifest matching TypeScript test structure
-	testManifest := &Manifest{
-		Version:     "1.0.0",
-		Name:        "Test API",
-		Description: "Test Manifest for response validation",
-		Channels: map[string]*ChannelManifest{
-			"test": {
-				Name:        "test",
-				Description: "Test channel",
-				Requests: map[string]*RequestManifest{
-					"ping": {
-						Name:        "ping",
-						Description: "Basic ping request",
-						Response: &ResponseManifest{
-							Type:        "object",
-							Description: "Ping response",
-							Properties: map[string]*ArgumentManifest{
-								"status": {
-									Type:        "string",
-									Required:    true,
-									Description: "Status message",
-								},
-								"echo": {
-									Type:        "string",
-									Required:    true,
-									Description: "Echo message",
-								},
-								"timestamp": {
-									Type:        "number",
-									Required:    true,
-									Description: "Response timestamp",
-								},
-								"server_id": {
-									Type:        "string",
-									Required:    true,
-									Description: "Server identifier",
-								},
-								"request_count": {
-									Type:        "number",
-									Required:    false,
-									Description: "Request count",
-								},
-								"metadata": {
-									Type:        "object",
-									Required:    false,
-									Description: "Optional metadata",
-								},
-							},
-						},
+	// Requests under test, keyed by request name
+	requests := map[string]*RequestManifest{
+		"ping": {
+			Name:        "ping",
+			Description: "Basic ping request",
+			Response: &ResponseManifest{
+				Type:        "object",
+				Description: "Ping response",
+				Properties: map[string]*ArgumentManifest{
+					"status": {
+						Type:        "string",
+						Required:    true,
+						Description: "Status message",
 					},
-					"get_info": {
-						Name:        "get_info",
-						Description: "Get server information",
-						Response: &ResponseManifest{
-							Type:        "object",
-							Description: "Server information",
-							Properties: map[string]*ArgumentManifest{
-								"implementation": {
-									Type:        "string",
-									Required:    true,
-									Description: "Implementation language",
-								},
-								"version": {
-									Type:        "string",
-									Required:    true,
-									Pattern:     `^\d+\.\d+\.\d+$`,
-									Description: "Version string",
-								},
-								"protocol": {
-									Type:        "string",
-									Required:    true,
-									Enum:        []string{"SOCK_DGRAM"},
-									Description: "Protocol type",
-								},
-							},
-						},
+					"echo": {
+						Type:        "string",
+						Required:    true,
+						Description: "Echo message",
+					},
+					"timestamp": {
+						Type:        "number",
+						Required:    true,
+						Description: "Response timestamp",
+					},
+					"server_id": {
+						Type:        "string",
+						Required:    true,
+						Description: "Server identifier",
+					},
+					"request_count": {
+						Type:        "number",
+						Required:    false,
+						Description: "Request count",
 					},
-					"range_test": {
-						Name:        "range_test",
-						Description: "Numeric range validation test",
-						Response: &ResponseManifest{
-							Type:        "object",
-							Description: "Range test response",
-							Properties: map[string]*ArgumentManifest{
-								"score": {
-									Type:        "number",
-									Required:    true,
-									Minimum:     ptrFloat64(0),
-									Maximum:     ptrFloat64(100),
-									Description: "Test score",
-								},
-								"grade": {
-									Type:        "string",
-									Required:    true,
-									Enum:        []string{"A", "B", "C", "D", "F"},
-									Description: "Letter grade",
-								},
-								"count": {
-									Type:        "integer",
-									Required:    true,
-									Minimum:     ptrFloat64(1),
-									Description: "Item count",
-								},
-							},
+					"metadata": {
+						Type:        "object",
+						Required:    false,
+						Description: "Optional metadata",
+					},
+				},
+			},
+		},
+		"get_info": {
+			Name:        "get_info",
+			Description: "Get server information",
+			Response: &ResponseManifest{
+				Type:        "object",
+				Description: "Server information",
+				Properties: map[string]*ArgumentManifest{
+					"implementation": {
+						Type:        "string",
+						Required:    true,
+						Description: "Implementation language",
+					},
+					"version": {
+						Type:        "string",
+						Required:    true,
+						Pattern:     `^\d+\.\d+\.\d+$`,
+						Description: "Version string",
+					},
+					"protocol": {
+						Type:        "string",
+						Required:    true,
+						Enum:        []string{"SOCK_DGRAM"},
+						Description: "Protocol type",
+					},
+				},
+			},
+		},
+		"range_test": {
+			Name:        "range_test",
+			Description: "Numeric range validation test",
+			Response: &ResponseManifest{
+				Type:        "object",
+				Description: "Range test response",
+				Properties: map[string]*ArgumentManifest{
+					"score": {
+						Type:        "number",
+						Required:    true,
+						Minimum:     ptrFloat64(0),
+						Maximum:     ptrFloat64(100),
+						Description: "Test score",
+					},
+					"grade": {
+						Type:        "string",
+						Required:    true,
+						Enum:        []string{"A", "B", "C", "D", "F"},
+						Description: "Letter grade",
+					},
+					"count": {
+						Type:        "integer",
+						Required:    true,
+						Minimum:     ptrFloat64(1),
+						Description: "Item count",
+					},
+				},
+			},
+		},
+		"array_test": {
+			Name:        "array_test",
+			Description: "Array validation test",
+			Response: &ResponseManifest{
+				Type:        "object",
+				Description: "Array test response",
+				Properties: map[string]*ArgumentManifest{
+					"items": {
+						Type:        "array",
+						Required:    true,
+						Description: "Array of strings",
+						Items: &ArgumentManifest{
+							Type:        "string",
+							MinLength:   ptrInt(1),
+							MaxLength:   ptrInt(50),
+							Description: "String item",
 						},
 					},
-					"array_test": {
-						Name:        "array_test",
-						Description: "Array validation test",
-						Response: &ResponseManifest{
-							Type:        "object",
-							Description: "Array test response",
-							Properties: map[string]*ArgumentManifest{
-								"items": {
-									Type:        "array",
-									Required:    true,
-									Description: "Array of strings",
-									Items: &ArgumentManifest{
-										Type:        "string",
-										MinLength:   ptrInt(1),
-										MaxLength:   ptrInt(50),
-										Description: "String item",
-									},
-								},
-								"numbers": {
-									Type:        "array",
-									Required:    false,
-									Description: "Array of numbers",
-									Items: &ArgumentManifest{
-										Type:        "number",
-										Minimum:     ptrFloat64(0),
-										Description: "Number item",
-									},
-								},
-							},
+					"numbers": {
+						Type:        "array",
+						Required:    false,
+						Description: "Array of numbers",
+						Items: &ArgumentManifest{
+							Type:        "number",
+							Minimum:     ptrFloat64(0),
+							Description: "Number item",
 						},
 					},
 				},
 			},
 		},
+	}
+
+	// Create test Manifest matching TypeScript test structure
+	testManifest := &Manifest{
+		Version:     "1.0.0",
+		Name:        "Test API",
+		Description: "Test Manifest for response validation",
+		Requests:    requests,
 		Models: map[string]*ModelDefinition{
 			"UserInfo": {
 				Name:        "UserInfo",
@@ -188,7 +185,7 @@ func TestResponseValidator(t *testing.T) {
 				"server_id":  "server-001",
 			}
 
-			result := validator.ValidateRequestResponse(response, "test", "ping")
+			result := validator.ValidateRequestResponse(response, "ping")
 
 			if !result.Valid {
 				t.Errorf("Expected valid response, got invalid with errors: %+v", result.Errors)
@@ -214,7 +211,7 @@ func TestResponseValidator(t *testing.T) {
 				"metadata":      map[string]interface{}{"custom": "data"},
 			}
 
-			result := validator.ValidateRequestResponse(response, "test", "ping")
+			result := validator.ValidateRequestResponse(response, "ping")
 
 			if !result.Valid {
 				t.Errorf("Expected valid response, got invalid with errors: %+v", result.Errors)
@@ -231,7 +228,7 @@ func TestResponseValidator(t *testing.T) {
 				// Missing timestamp and server_id
 			}
 
-			result := validator.ValidateRequestResponse(response, "test", "ping")
+			result := validator.ValidateRequestResponse(response, "ping")
 
 			if result.Valid {
 				t.Errorf("Expected invalid response")
@@ -267,7 +264,7 @@ func TestResponseValidator(t *testing.T) {
 				"server_id": nil,         // Should be string, null not allowed for required field
 			}
 
-			result := validator.ValidateRequestResponse(response, "test", "ping")
+			result := validator.ValidateRequestResponse(response, "ping")
 
 			if result.Valid {
 				t.Errorf("Expected invalid response")
@@ -286,7 +283,7 @@ func TestResponseValidator(t *testing.T) {
 				"protocol":       "SOCK_DGRAM",
 			}
 
-			result := validator.ValidateRequestResponse(validResponse, "test", "get_info")
+			result := validator.ValidateRequestResponse(validResponse, "get_info")
 			if !result.Valid {
 				t.Errorf("Expected valid response, got errors: %+v", result.Errors)
 			}
@@ -297,7 +294,7 @@ func TestResponseValidator(t *testing.T) {
 				"protocol":       "SOCK_DGRAM",
 			}
 
-			invalidResult := validator.ValidateRequestResponse(invalidResponse, "test", "get_info")
+			invalidResult := validator.ValidateRequestResponse(invalidResponse, "get_info")
 			if invalidResult.Valid {
 				t.Errorf("Expected invalid response")
 			}
@@ -320,7 +317,7 @@ func TestResponseValidator(t *testing.T) {
 				"protocol":       "SOCK_DGRAM",
 			}
 
-			result := validator.ValidateRequestResponse(validResponse, "test", "get_info")
+			result := validator.ValidateRequestResponse(validResponse, "get_info")
 			if !result.Valid {
 				t.Errorf("Expected valid response, got errors: %+v", result.Errors)
 			}
@@ -331,7 +328,7 @@ func TestResponseValidator(t *testing.T) {
 				"protocol":       "SOCK_STREAM", // Invalid enum value
 			}
 
-			invalidResult := validator.ValidateRequestResponse(invalidResponse, "test", "get_info")
+			invalidResult := validator.ValidateRequestResponse(invalidResponse, "get_info")
 			if invalidResult.Valid {
 				t.Errorf("Expected invalid response")
 			}
@@ -354,7 +351,7 @@ func TestResponseValidator(t *testing.T) {
 				"count": 10.0,
 			}
 
-			result := validator.ValidateRequestResponse(validResponse, "test", "range_test")
+			result := validator.ValidateRequestResponse(validResponse, "range_test")
 			if !result.Valid {
 				t.Errorf("Expected valid response, got errors: %+v", result.Errors)
 			}
@@ -365,7 +362,7 @@ func TestResponseValidator(t *testing.T) {
 				"count": 0.0,    // < minimum of 1
 			}
 
-			invalidResult := validator.ValidateRequestResponse(invalidResponse, "test", "range_test")
+			invalidResult := validator.ValidateRequestResponse(invalidResponse, "range_test")
 			if invalidResult.Valid {
 				t.Errorf("Expected invalid response")
 			}
@@ -405,7 +402,7 @@ func TestResponseValidator(t *testing.T) {
 				"count": 10.0, // integer is fine (as float)
 			}
 
-			result := validator.ValidateRequestResponse(validResponse, "test", "range_test")
+			result := validator.ValidateRequestResponse(validResponse, "range_test")
 			if !result.Valid {
 				t.Errorf("Expected valid response, got errors: %+v", result.Errors)
 			}
@@ -416,7 +413,7 @@ func TestResponseValidator(t *testing.T) {
 				"count": 10.5, // Should be integer, not float
 			}
 
-			invalidResult := validator.ValidateRequestResponse(invalidResponse, "test", "range_test")
+			invalidResult := validator.ValidateRequestResponse(invalidResponse, "range_test")
 			if invalidResult.Valid {
 				t.Errorf("Expected invalid response")
 			}
@@ -438,7 +435,7 @@ func TestResponseValidator(t *testing.T) {
 				"numbers": []interface{}{1.0, 2.0, 3.5},
 			}
 
-			result := validator.ValidateRequestResponse(validResponse, "test", "array_test")
+			result := validator.ValidateRequestResponse(validResponse, "array_test")
 			if !result.Valid {
 				t.Errorf("Expected valid response, got errors: %+v", result.Errors)
 			}
@@ -448,7 +445,7 @@ func TestResponseValidator(t *testing.T) {
 				"numbers": []interface{}{1.0, -5.0, "not a number"},        // Negative number and wrong type
 			}
 
-			invalidResult := validator.ValidateRequestResponse(invalidResponse, "test", "array_test")
+			invalidResult := validator.ValidateRequestResponse(invalidResponse, "array_test")
 			if invalidResult.Valid {
 				t.Errorf("Expected invalid response")
 			}
@@ -476,29 +473,10 @@ func TestResponseValidator(t *testing.T) {
 	})
 
 	t.Run("Error Handling", func(t *testing.T) {
-		t.Run("should handle missing channel", func(t *testing.T) {
-			response := map[string]interface{}{"status": "ok"}
-
-			result := validator.ValidateRequestResponse(response, "nonexistent", "ping")
-
-			if result.Valid {
-				t.Errorf("Expected invalid response")
-			}
-			if len(result.Errors) != 1 {
-				t.Errorf("Expected 1 error, got %d", len(result.Errors))
-			}
-			if result.Errors[0].Field != "channelId" {
-				t.Errorf("Expected channelId field error, got %s", result.Errors[0].Field)
-			}
-			if !containsString(result.Errors[0].Message, "Channel 'nonexistent' not found") {
-				t.Errorf("Expected channel not found message, got %s", result.Errors[0].Message)
-			}
-		})
-
 		t.Run("should handle missing request", func(t *testing.T) {
 			response := map[string]interface{}{"status": "ok"}
 
-			result := validator.ValidateRequestResponse(response, "test", "nonexistent")
+			result := validator.ValidateRequestResponse(response, "nonexistent")
 
 			if result.Valid {
 				t.Errorf("Expected invalid response")
@@ -516,7 +494,7 @@ func TestResponseValidator(t *testing.T) {
 
 		t.Run("should handle missing response manifest", func(t *testing.T) {
 			// Add request without response manifest
-			testManifest.Channels["test"].Requests["no_response"] = &RequestManifest{
+			requests["no_response"] = &RequestManifest{
 				Name:        "no_response",
 				Description: "Request without response manifest",
 				// No Response field
@@ -525,7 +503,7 @@ func TestResponseValidator(t *testing.T) {
 			validator := NewResponseValidator(testManifest)
 			response := map[string]interface{}{"status": "ok"}
 
-			result := validator.ValidateRequestResponse(response, "test", "no_response")
+			result := validator.ValidateRequestResponse(response, "no_response")
 
 			if result.Valid {
 				t.Errorf("Expected invalid response")
@@ -553,7 +531,7 @@ func TestResponseValidator(t *testing.T) {
 				"metadata":      map[string]interface{}{"custom": "data", "nested": map[string]interface{}{"deep": "value"}},
 			}
 
-			result := validator.ValidateRequestResponse(response, "test", "ping")
+			result := validator.ValidateRequestResponse(response, "ping")
 
 			if !result.Valid {
 				t.Errorf("Expected valid response, got errors: %+v", result.Errors)
@@ -576,7 +554,7 @@ func TestResponseValidator(t *testing.T) {
 				"numbers": largeNumbers,
 			}
 
-			result := validator.ValidateRequestResponse(largeResponse, "test", "array_test")
+			result := validator.ValidateRequestResponse(largeResponse, "array_test")
 
 			if !result.Valid {
 				t.Errorf("Expected valid response, got errors: %+v", result.Errors)
@@ -632,7 +610,7 @@ func TestResponseValidator(t *testing.T) {
 	t.Run("Model References", func(t *testing.T) {
 		t.Run("should handle model references", func(t *testing.T) {
 			// Add request that uses model reference
-			testManifest.Channels["test"].Requests["user_info"] = &RequestManifest{
+			requests["user_info"] = &RequestManifest{
 				Name:        "user_info",
 				Description: "Get user information",
 				Response: &ResponseManifest{
@@ -650,7 +628,7 @@ func TestResponseValidator(t *testing.T) {
 				"age":  30.0,
 			}
 
-			result := validator.ValidateRequestResponse(validResponse, "test", "user_info")
+			result := validator.ValidateRequestResponse(validResponse, "user_info")
 			if !result.Valid {
 				t.Errorf("Expected valid response, got errors: %+v", result.Errors)
 			}
@@ -661,7 +639,7 @@ func TestResponseValidator(t *testing.T) {
 				"age":  200.0,  // Too old
 			}
 
-			invalidResult := validator.ValidateRequestResponse(invalidResponse, "test", "user_info")
+			invalidResult := validator.ValidateRequestResponse(invalidResponse, "user_info")
 			if invalidResult.Valid {
 				t.Errorf("Expected invalid response")
 			}
@@ -685,7 +663,7 @@ func TestResponseValidator(t *testing.T) {
 		})
 
 		t.Run("should handle missing model reference", func(t *testing.T) {
-			testManifest.Channels["test"].Requests["bad_ref"] = &RequestManifest{
+			requests["bad_ref"] = &RequestManifest{
 				Name:        "bad_ref",
 				Description: "Request with bad model reference",
 				Response: &ResponseManifest{
@@ -698,7 +676,7 @@ func TestResponseValidator(t *testing.T) {
 			validator := NewResponseValidator(testManifest)
 
 			response := map[string]interface{}{"data": "test"}
-			result := validator.ValidateRequestResponse(response, "test", "bad_ref")
+			result := validator.ValidateRequestResponse(response, "bad_ref")
 
 			if result.Valid {
 				t.Errorf("Expected invalid response")
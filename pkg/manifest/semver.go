@@ -0,0 +1,365 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SemanticVersion is a parsed semantic version (https://semver.org)
+type SemanticVersion struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string // Dot-separated identifiers after '-', without build metadata
+}
+
+// ParseSemanticVersion parses "MAJOR.MINOR.PATCH[-prerelease][+build]"; a leading 'v' is accepted
+func ParseSemanticVersion(version string) (SemanticVersion, error) {
+	text := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexByte(text, '+'); i >= 0 {
+		text = text[:i]
+	}
+
+	var parsed SemanticVersion
+	if i := strings.IndexByte(text, '-'); i >= 0 {
+		parsed.Prerelease = text[i+1:]
+		text = text[:i]
+		if parsed.Prerelease == "" {
+			return SemanticVersion{}, fmt.Errorf("invalid version '%s': empty prerelease", version)
+		}
+	}
+
+	parts := strings.Split(text, ".")
+	if len(parts) != 3 {
+		return SemanticVersion{}, fmt.Errorf("invalid version '%s': expected MAJOR.MINOR.PATCH", version)
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := parseVersionNumber(part)
+		if err != nil {
+			return SemanticVersion{}, fmt.Errorf("invalid version '%s': %w", version, err)
+		}
+		numbers[i] = n
+	}
+
+	parsed.Major, parsed.Minor, parsed.Patch = numbers[0], numbers[1], numbers[2]
+	return parsed, nil
+}
+
+// String formats the version without build metadata
+func (v SemanticVersion) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 as v is lower than, equal to or higher than other
+// Prerelease versions sort before the release, identifiers compared per semver rules
+func (v SemanticVersion) Compare(other SemanticVersion) int {
+	if c := compareInts(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInts(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareInts(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	}
+
+	left, right := strings.Split(v.Prerelease, "."), strings.Split(other.Prerelease, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		if c := comparePrereleaseIdentifier(left[i], right[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(left), len(right))
+}
+
+// VersionRange is a set of version constraints
+// Alternatives are separated by "||"; each alternative is a space- or comma-separated
+// list of comparators that must all hold: "=1.2.3", ">=1.2.0", "<2.0.0", "^1.2.0",
+// "~1.2.0", "1.2.x", "1.x" or "*"
+type VersionRange struct {
+	source       string
+	alternatives [][]versionComparator
+}
+
+// versionComparator is a single "operator version" constraint
+type versionComparator struct {
+	op      string
+	version SemanticVersion
+}
+
+// ParseVersionRange parses a version range expression
+func ParseVersionRange(expression string) (*VersionRange, error) {
+	r := &VersionRange{source: strings.TrimSpace(expression)}
+	if r.source == "" {
+		return nil, fmt.Errorf("version range cannot be empty")
+	}
+
+	for _, alternative := range strings.Split(r.source, "||") {
+		fields := strings.FieldsFunc(alternative, func(c rune) bool { return c == ' ' || c == ',' })
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("invalid version range '%s': empty alternative", expression)
+		}
+
+		// Allow "> = 1.0.0"-style spacing between operator and version
+		var comparators []versionComparator
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			if isRangeOperator(field) && i+1 < len(fields) {
+				field += fields[i+1]
+				i++
+			}
+			expanded, err := parseComparator(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version range '%s': %w", expression, err)
+			}
+			comparators = append(comparators, expanded...)
+		}
+		r.alternatives = append(r.alternatives, comparators)
+	}
+	return r, nil
+}
+
+// String returns the original expression
+func (r *VersionRange) String() string {
+	return r.source
+}
+
+// Contains reports whether the version satisfies the range
+// Prerelease versions only match comparators that name a prerelease of the same MAJOR.MINOR.PATCH
+func (r *VersionRange) Contains(version SemanticVersion) bool {
+	for _, comparators := range r.alternatives {
+		if allComparatorsHold(comparators, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsString parses and checks a version string
+func (r *VersionRange) ContainsString(version string) (bool, error) {
+	parsed, err := ParseSemanticVersion(version)
+	if err != nil {
+		return false, err
+	}
+	return r.Contains(parsed), nil
+}
+
+// HighestMatch returns the highest of the given versions that satisfies the range
+func (r *VersionRange) HighestMatch(versions []string) (string, bool) {
+	candidates := make([]string, 0, len(versions))
+	for _, version := range versions {
+		if ok, err := r.ContainsString(version); err == nil && ok {
+			candidates = append(candidates, version)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	SortVersions(candidates)
+	return candidates[len(candidates)-1], true
+}
+
+// SortVersions sorts version strings in ascending semver order; unparseable versions sort first
+func SortVersions(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		left, leftErr := ParseSemanticVersion(versions[i])
+		right, rightErr := ParseSemanticVersion(versions[j])
+		switch {
+		case leftErr != nil && rightErr != nil:
+			return versions[i] < versions[j]
+		case leftErr != nil:
+			return true
+		case rightErr != nil:
+			return false
+		}
+		return left.Compare(right) < 0
+	})
+}
+
+func allComparatorsHold(comparators []versionComparator, version SemanticVersion) bool {
+	if version.Prerelease != "" && !prereleaseAllowed(comparators, version) {
+		return false
+	}
+
+	for _, c := range comparators {
+		cmp := version.Compare(c.version)
+		var ok bool
+		switch c.op {
+		case "=":
+			ok = cmp == 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// prereleaseAllowed reports whether a comparator opts in to prereleases of this version's release
+func prereleaseAllowed(comparators []versionComparator, version SemanticVersion) bool {
+	for _, c := range comparators {
+		if c.version.Prerelease != "" && c.version.Major == version.Major &&
+			c.version.Minor == version.Minor && c.version.Patch == version.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+func isRangeOperator(field string) bool {
+	switch field {
+	case "=", ">", ">=", "<", "<=", "^", "~":
+		return true
+	}
+	return false
+}
+
+// parseComparator expands one constraint into primitive comparators
+func parseComparator(field string) ([]versionComparator, error) {
+	if field == "*" || field == "x" || field == "X" {
+		return []versionComparator{{op: ">=", version: SemanticVersion{}}}, nil
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(field, op) {
+			version, err := ParseSemanticVersion(field[len(op):])
+			if err != nil {
+				return nil, err
+			}
+			return []versionComparator{{op: op, version: version}}, nil
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(field, "^"):
+		lower, err := ParseSemanticVersion(field[1:])
+		if err != nil {
+			return nil, err
+		}
+		// ^ allows changes that do not modify the left-most non-zero component
+		var upper SemanticVersion
+		switch {
+		case lower.Major > 0:
+			upper = SemanticVersion{Major: lower.Major + 1}
+		case lower.Minor > 0:
+			upper = SemanticVersion{Minor: lower.Minor + 1}
+		default:
+			upper = SemanticVersion{Patch: lower.Patch + 1}
+		}
+		return []versionComparator{{op: ">=", version: lower}, {op: "<", version: upper}}, nil
+
+	case strings.HasPrefix(field, "~"):
+		lower, err := ParseSemanticVersion(field[1:])
+		if err != nil {
+			return nil, err
+		}
+		upper := SemanticVersion{Major: lower.Major, Minor: lower.Minor + 1}
+		return []versionComparator{{op: ">=", version: lower}, {op: "<", version: upper}}, nil
+	}
+
+	return parsePartialVersion(field)
+}
+
+// parsePartialVersion handles "1", "1.x", "1.2", "1.2.x" and exact "1.2.3"
+func parsePartialVersion(field string) ([]versionComparator, error) {
+	parts := strings.Split(strings.TrimPrefix(field, "v"), ".")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid version constraint '%s'", field)
+	}
+
+	var numbers []int
+	for _, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		n, err := parseVersionNumber(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint '%s': %w", field, err)
+		}
+		numbers = append(numbers, n)
+	}
+
+	switch len(numbers) {
+	case 0:
+		return []versionComparator{{op: ">=", version: SemanticVersion{}}}, nil
+	case 1:
+		return []versionComparator{
+			{op: ">=", version: SemanticVersion{Major: numbers[0]}},
+			{op: "<", version: SemanticVersion{Major: numbers[0] + 1}},
+		}, nil
+	case 2:
+		return []versionComparator{
+			{op: ">=", version: SemanticVersion{Major: numbers[0], Minor: numbers[1]}},
+			{op: "<", version: SemanticVersion{Major: numbers[0], Minor: numbers[1] + 1}},
+		}, nil
+	default:
+		version, err := ParseSemanticVersion(field)
+		if err != nil {
+			return nil, err
+		}
+		return []versionComparator{{op: "=", version: version}}, nil
+	}
+}
+
+func parseVersionNumber(part string) (int, error) {
+	if part == "" {
+		return 0, fmt.Errorf("empty version component")
+	}
+	if len(part) > 1 && part[0] == '0' {
+		return 0, fmt.Errorf("version component '%s' has a leading zero", part)
+	}
+	n, err := strconv.Atoi(part)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("version component '%s' is not a non-negative integer", part)
+	}
+	return n, nil
+}
+
+func comparePrereleaseIdentifier(left, right string) int {
+	leftNum, leftErr := strconv.Atoi(left)
+	rightNum, rightErr := strconv.Atoi(right)
+	switch {
+	case leftErr == nil && rightErr == nil:
+		return compareInts(leftNum, rightNum)
+	case leftErr == nil:
+		return -1 // Numeric identifiers have lower precedence
+	case rightErr == nil:
+		return 1
+	}
+	return strings.Compare(left, right)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
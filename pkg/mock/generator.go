@@ -0,0 +1,495 @@
+package mock
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"GoJanus/pkg/manifest"
+)
+
+const (
+	// optionalDepth is the nesting depth below which optional properties are generated
+	optionalDepth = 3
+
+	// maxDepth stops required self-references from recursing forever
+	maxDepth = 16
+
+	// patternAttempts bounds retries when a generated string misses its length limits
+	patternAttempts = 10
+)
+
+// sampleEpoch anchors generated dates so output is stable for a given seed
+var sampleEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Generator synthesizes values that satisfy a manifest's argument, model and response definitions
+// Output is deterministic for a given seed; a Generator is safe for concurrent use
+type Generator struct {
+	manifest *manifest.Manifest
+	mutex    sync.Mutex
+	rand     *rand.Rand
+}
+
+// NewGenerator creates a generator for the manifest's definitions
+func NewGenerator(m *manifest.Manifest, seed int64) *Generator {
+	return &Generator{
+		manifest: m,
+		rand:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Response generates a value conforming to a response definition
+func (g *Generator) Response(response *manifest.ResponseManifest) interface{} {
+	if response == nil {
+		return map[string]interface{}{}
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if response.ModelRef != "" {
+		return g.model(response.ModelRef, 0)
+	}
+	return g.value(&manifest.ArgumentManifest{
+		Type:       response.Type,
+		Properties: response.Properties,
+		Items:      response.Items,
+	}, 0)
+}
+
+// Argument generates a value conforming to an argument definition
+func (g *Generator) Argument(arg *manifest.ArgumentManifest) interface{} {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.value(arg, 0)
+}
+
+// Model generates a value conforming to the named model
+func (g *Generator) Model(name string) interface{} {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.model(name, 0)
+}
+
+// Float64 returns a pseudo-random number in [0, 1) from the generator's source
+func (g *Generator) Float64() float64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.rand.Float64()
+}
+
+// value generates a value for an argument definition
+func (g *Generator) value(arg *manifest.ArgumentManifest, depth int) interface{} {
+	if arg == nil {
+		return nil
+	}
+	if arg.Const != nil {
+		return arg.Const
+	}
+	if arg.Default != nil {
+		return arg.Default
+	}
+	if arg.Nullable && depth >= maxDepth {
+		return nil
+	}
+
+	switch {
+	case arg.Discriminator != nil && len(arg.OneOf) > 0:
+		return g.discriminated(arg, depth)
+	case len(arg.AllOf) > 0:
+		return g.allOf(arg.AllOf, depth)
+	case len(arg.OneOf) > 0:
+		return g.value(arg.OneOf[g.rand.Intn(len(arg.OneOf))], depth)
+	case len(arg.AnyOf) > 0:
+		return g.value(arg.AnyOf[g.rand.Intn(len(arg.AnyOf))], depth)
+	case arg.ModelRef != "":
+		return g.model(arg.ModelRef, depth+1)
+	}
+
+	switch arg.Type {
+	case "string":
+		return g.stringValue(arg)
+	case "integer":
+		return g.integerValue(arg)
+	case "number":
+		return g.numberValue(arg)
+	case "boolean":
+		return g.rand.Intn(2) == 1
+	case "array":
+		return g.arrayValue(arg, depth)
+	case "object":
+		required := make(map[string]bool, len(arg.RequiredProperties))
+		for _, name := range arg.RequiredProperties {
+			required[name] = true
+		}
+		return g.properties(arg.Properties, required, depth)
+	default:
+		return nil
+	}
+}
+
+// model generates a value for a model definition
+func (g *Generator) model(name string, depth int) interface{} {
+	model, exists := g.manifest.Models[name]
+	if !exists || model == nil {
+		return map[string]interface{}{}
+	}
+
+	if model.Type != "" && model.Type != "object" {
+		return g.value(&manifest.ArgumentManifest{Name: model.Name, Type: model.Type}, depth)
+	}
+	required := make(map[string]bool, len(model.Required))
+	for _, prop := range model.Required {
+		required[prop] = true
+	}
+	return g.properties(model.Properties, required, depth)
+}
+
+// properties generates an object with required properties and, near the root, optional ones
+func (g *Generator) properties(properties map[string]*manifest.ArgumentManifest, required map[string]bool, depth int) map[string]interface{} {
+	object := make(map[string]interface{}, len(properties))
+	if depth >= maxDepth {
+		return object
+	}
+
+	for _, name := range sortedNames(properties) {
+		prop := properties[name]
+		if prop == nil {
+			continue
+		}
+		if !prop.Required && !required[name] && depth >= optionalDepth {
+			continue
+		}
+		object[name] = g.value(prop, depth+1)
+	}
+
+	// Required names without a definition only need to be present
+	for name := range required {
+		if _, exists := object[name]; !exists {
+			object[name] = name
+		}
+	}
+	return object
+}
+
+// discriminated generates one oneOf variant and sets its discriminator property
+func (g *Generator) discriminated(arg *manifest.ArgumentManifest, depth int) interface{} {
+	variant := arg.OneOf[g.rand.Intn(len(arg.OneOf))]
+	value := g.value(variant, depth)
+
+	object, ok := value.(map[string]interface{})
+	if !ok || variant.ModelRef == "" {
+		return value
+	}
+
+	tag := variant.ModelRef
+	for _, key := range sortedNames(arg.Discriminator.Mapping) {
+		if arg.Discriminator.Mapping[key] == variant.ModelRef {
+			tag = key
+			break
+		}
+	}
+	object[arg.Discriminator.PropertyName] = tag
+	return object
+}
+
+// allOf combines scalar constraints, or merges the objects generated for every alternative
+func (g *Generator) allOf(alternatives []*manifest.ArgumentManifest, depth int) interface{} {
+	if scalar, ok := mergeScalars(alternatives); ok {
+		return g.value(scalar, depth)
+	}
+
+	merged := make(map[string]interface{})
+	for _, alternative := range alternatives {
+		object, ok := g.value(alternative, depth).(map[string]interface{})
+		if !ok {
+			return g.value(alternatives[0], depth)
+		}
+		for key, value := range object {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// mergeScalars intersects the constraints of scalar alternatives into one definition
+func mergeScalars(alternatives []*manifest.ArgumentManifest) (*manifest.ArgumentManifest, bool) {
+	merged := &manifest.ArgumentManifest{}
+	for _, alternative := range alternatives {
+		if alternative == nil {
+			continue
+		}
+		switch alternative.Type {
+		case "string", "integer", "number", "boolean":
+		default:
+			return nil, false
+		}
+
+		if merged.Type == "" || alternative.Type == "integer" {
+			merged.Type = alternative.Type
+		}
+		if alternative.Minimum != nil && (merged.Minimum == nil || *alternative.Minimum > *merged.Minimum) {
+			merged.Minimum = alternative.Minimum
+		}
+		if alternative.Maximum != nil && (merged.Maximum == nil || *alternative.Maximum < *merged.Maximum) {
+			merged.Maximum = alternative.Maximum
+		}
+		if alternative.MinLength != nil && (merged.MinLength == nil || *alternative.MinLength > *merged.MinLength) {
+			merged.MinLength = alternative.MinLength
+		}
+		if alternative.MaxLength != nil && (merged.MaxLength == nil || *alternative.MaxLength < *merged.MaxLength) {
+			merged.MaxLength = alternative.MaxLength
+		}
+		if alternative.Pattern != "" {
+			merged.Pattern = alternative.Pattern
+		}
+		if alternative.Format != "" {
+			merged.Format = alternative.Format
+		}
+		if len(alternative.Enum) > 0 {
+			merged.Enum = alternative.Enum
+		}
+		if alternative.Const != nil {
+			merged.Const = alternative.Const
+		}
+	}
+	return merged, merged.Type != ""
+}
+
+// stringValue generates a string honouring enum, format, pattern and length limits
+func (g *Generator) stringValue(arg *manifest.ArgumentManifest) string {
+	if len(arg.Enum) > 0 {
+		return arg.Enum[g.rand.Intn(len(arg.Enum))]
+	}
+	if arg.Format != "" {
+		return g.formatted(arg.Format)
+	}
+	if arg.Pattern != "" {
+		if value, ok := g.fromPattern(arg.Pattern, arg.MinLength, arg.MaxLength); ok {
+			return value
+		}
+	}
+
+	base := arg.Name
+	if base == "" {
+		base = "value"
+	}
+	return fitLength(fmt.Sprintf("%s-%d", base, g.rand.Intn(1000)), arg.MinLength, arg.MaxLength)
+}
+
+// formatted generates a sample for a well-known string format
+func (g *Generator) formatted(format string) string {
+	switch format {
+	case "uuid":
+		b := make([]byte, 16)
+		g.rand.Read(b)
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	case "date-time":
+		return sampleEpoch.Add(time.Duration(g.rand.Intn(365*24*3600)) * time.Second).Format(time.RFC3339)
+	case "date":
+		return sampleEpoch.AddDate(0, 0, g.rand.Intn(365)).Format("2006-01-02")
+	case "email":
+		return fmt.Sprintf("user%d@example.com", g.rand.Intn(1000))
+	case "uri":
+		return fmt.Sprintf("https://example.com/resource/%d", g.rand.Intn(1000))
+	case "ipv4":
+		return fmt.Sprintf("192.0.2.%d", 1+g.rand.Intn(254))
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x", 1+g.rand.Intn(0xfffe))
+	case "binary":
+		b := make([]byte, 8)
+		g.rand.Read(b)
+		return base64.StdEncoding.EncodeToString(b)
+	default:
+		return format
+	}
+}
+
+// fromPattern generates a string matching a regular expression within the length limits
+func (g *Generator) fromPattern(pattern string, minLength, maxLength *int) (string, bool) {
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", false
+	}
+	parsed = parsed.Simplify()
+
+	for attempt := 0; attempt < patternAttempts; attempt++ {
+		var builder strings.Builder
+		g.writePattern(&builder, parsed)
+		value := builder.String()
+		length := len([]rune(value))
+		if (minLength != nil && length < *minLength) || (maxLength != nil && length > *maxLength) {
+			continue
+		}
+		if compiled.MatchString(value) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// writePattern emits one string matched by a simplified regular expression
+func (g *Generator) writePattern(builder *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			builder.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		builder.WriteRune(g.classRune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		builder.WriteRune(rune('a' + g.rand.Intn(26)))
+	case syntax.OpCapture:
+		g.writePattern(builder, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.writePattern(builder, sub)
+		}
+	case syntax.OpAlternate:
+		g.writePattern(builder, re.Sub[g.rand.Intn(len(re.Sub))])
+	case syntax.OpStar:
+		g.repeatPattern(builder, re.Sub[0], 0, 6)
+	case syntax.OpPlus:
+		g.repeatPattern(builder, re.Sub[0], 1, 6)
+	case syntax.OpQuest:
+		g.repeatPattern(builder, re.Sub[0], 0, 1)
+	case syntax.OpRepeat:
+		max := re.Max
+		if max < 0 {
+			max = re.Min + 2
+		}
+		g.repeatPattern(builder, re.Sub[0], re.Min, max)
+	}
+	// Anchors, word boundaries and empty matches emit nothing
+}
+
+// repeatPattern emits between min and max repetitions of a sub-expression
+func (g *Generator) repeatPattern(builder *strings.Builder, re *syntax.Regexp, min, max int) {
+	count := min
+	if max > min {
+		count += g.rand.Intn(max - min + 1)
+	}
+	for i := 0; i < count; i++ {
+		g.writePattern(builder, re)
+	}
+}
+
+// classRune picks a rune from a character class, preferring printable ASCII
+func (g *Generator) classRune(ranges []rune) rune {
+	var printable [][2]rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo < '!' {
+			lo = '!'
+		}
+		if hi > '~' {
+			hi = '~'
+		}
+		if lo <= hi {
+			printable = append(printable, [2]rune{lo, hi})
+		}
+	}
+	if len(printable) == 0 {
+		if len(ranges) == 0 {
+			return 'a'
+		}
+		return ranges[0]
+	}
+	chosen := printable[g.rand.Intn(len(printable))]
+	return chosen[0] + rune(g.rand.Intn(int(chosen[1]-chosen[0])+1))
+}
+
+// integerValue generates an integer within the argument's bounds
+func (g *Generator) integerValue(arg *manifest.ArgumentManifest) interface{} {
+	lo, hi := bounds(arg.Minimum, arg.Maximum)
+	lo, hi = math.Ceil(lo), math.Floor(hi)
+	if hi < lo {
+		return int64(lo)
+	}
+	return int64(lo) + g.rand.Int63n(int64(hi-lo)+1)
+}
+
+// numberValue generates a number within the argument's bounds, rounded to two decimals
+func (g *Generator) numberValue(arg *manifest.ArgumentManifest) interface{} {
+	lo, hi := bounds(arg.Minimum, arg.Maximum)
+	value := math.Round((lo+g.rand.Float64()*(hi-lo))*100) / 100
+	return math.Max(lo, math.Min(hi, value))
+}
+
+// bounds resolves the range for numeric values, defaulting to [0, 100]
+func bounds(minimum, maximum *float64) (float64, float64) {
+	lo, hi := 0.0, 100.0
+	switch {
+	case minimum != nil && maximum != nil:
+		lo, hi = *minimum, *maximum
+	case minimum != nil:
+		lo, hi = *minimum, *minimum+100
+	case maximum != nil:
+		lo, hi = math.Min(0, *maximum-100), *maximum
+	}
+	return lo, hi
+}
+
+// arrayValue generates between minItems and maxItems items, distinct when uniqueItems is set
+func (g *Generator) arrayValue(arg *manifest.ArgumentManifest, depth int) []interface{} {
+	count := 0
+	if arg.MinItems != nil {
+		count = *arg.MinItems
+	}
+	if depth < optionalDepth {
+		count += 1 + g.rand.Intn(2)
+	}
+	if arg.MaxItems != nil && count > *arg.MaxItems {
+		count = *arg.MaxItems
+	}
+
+	items := make([]interface{}, 0, count)
+	seen := make(map[string]bool, count)
+	for attempts := 0; len(items) < count && attempts < count*patternAttempts; attempts++ {
+		item := g.value(arg.Items, depth+1)
+		if arg.UniqueItems {
+			key := fmt.Sprintf("%#v", item)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// fitLength pads or truncates a string to the length limits
+func fitLength(value string, minLength, maxLength *int) string {
+	runes := []rune(value)
+	if minLength != nil && len(runes) < *minLength {
+		runes = append(runes, []rune(strings.Repeat("x", *minLength-len(runes)))...)
+	}
+	if maxLength != nil && len(runes) > *maxLength {
+		runes = runes[:*maxLength]
+	}
+	return string(runes)
+}
+
+// sortedNames returns map keys in order so generation is deterministic
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
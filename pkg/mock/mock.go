@@ -0,0 +1,161 @@
+// Package mock serves synthetic responses for every request in a manifest
+// so clients can be developed against the contract before the real service exists
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/server"
+)
+
+// Options configures a mock
+type Options struct {
+	Seed   int64   // Seeds generated values and injected failures; equal seeds give equal responses
+	Script *Script // Optional fixtures, injected errors and latency
+}
+
+// Mock answers manifest requests with fixtures or generated responses
+type Mock struct {
+	manifest  *manifest.Manifest
+	generator *Generator
+	script    *Script
+}
+
+// New creates a mock for the manifest, validating the script against it
+func New(m *manifest.Manifest, options Options) (*Mock, error) {
+	if m == nil {
+		return nil, fmt.Errorf("manifest is required")
+	}
+	script := options.Script
+	if script == nil {
+		script = &Script{}
+	}
+	if err := script.Validate(m); err != nil {
+		return nil, fmt.Errorf("invalid mock script: %w", err)
+	}
+
+	return &Mock{
+		manifest:  m,
+		generator: NewGenerator(m, options.Seed),
+		script:    script,
+	}, nil
+}
+
+// NewServer creates a JanusServer serving the manifest with a mock handler for each request
+func NewServer(m *manifest.Manifest, config *server.ServerConfig, options Options) (*server.JanusServer, error) {
+	mock, err := New(m, options)
+	if err != nil {
+		return nil, err
+	}
+
+	janusServer := server.NewJanusServer(config)
+	if err := mock.Register(janusServer); err != nil {
+		return nil, err
+	}
+	return janusServer, nil
+}
+
+// Register serves the manifest on the server and installs a handler for each request
+// Requests named like built-ins are answered by the server itself
+func (mock *Mock) Register(janusServer *server.JanusServer) error {
+	if err := janusServer.SetManifest(mock.manifest); err != nil {
+		return fmt.Errorf("failed to serve manifest: %w", err)
+	}
+
+	for _, name := range sortedNames(mock.manifest.Requests) {
+		if manifest.IsBuiltinRequest(name) {
+			continue
+		}
+		if err := janusServer.RegisterHandler(name, mock.Handler(name)); err != nil {
+			return fmt.Errorf("failed to register mock handler for '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Handler returns the mock handler for a single request
+func (mock *Mock) Handler(name string) server.RequestHandler {
+	return server.SyncHandler(func(cmd *models.JanusRequest) server.HandlerResult {
+		value, err := mock.respond(name, cmd.Args)
+		return server.HandlerResult{Value: value, Error: err}
+	})
+}
+
+// respond validates the arguments, then applies the script or generates a response
+func (mock *Mock) respond(name string, args map[string]interface{}) (interface{}, *models.JSONRPCError) {
+	request, exists := mock.manifest.Requests[name]
+	if !exists || request == nil {
+		return nil, models.NewJSONRPCError(models.MethodNotFound, "Request not found: "+name)
+	}
+	if argErrors := mock.manifest.ValidateRequestArgsAll(request, args); len(argErrors) > 0 {
+		return nil, argErrors.JSONRPCError()
+	}
+
+	latency := mock.script.Latency
+	requestScript := mock.script.Requests[name]
+	var fixture *Fixture
+	if requestScript != nil {
+		if requestScript.Latency > 0 {
+			latency = requestScript.Latency
+		}
+		fixture = requestScript.match(args)
+	}
+	if fixture != nil && fixture.Latency > 0 {
+		latency = fixture.Latency
+	}
+	if latency > 0 {
+		time.Sleep(time.Duration(latency))
+	}
+
+	if fixture != nil {
+		if fixture.Error != nil {
+			rpcErr, _ := fixture.Error.jsonRPCError()
+			return nil, rpcErr
+		}
+		if fixture.Result != nil {
+			return fixture.Result, nil
+		}
+	}
+
+	if requestScript != nil && requestScript.ErrorRate > 0 && mock.generator.Float64() < requestScript.ErrorRate {
+		if rpcErr, _ := requestScript.Error.jsonRPCError(); rpcErr != nil {
+			return nil, rpcErr
+		}
+		return nil, models.NewJSONRPCError(models.InternalError, "injected failure")
+	}
+
+	return mock.generator.Response(request.Response), nil
+}
+
+// match returns the first fixture whose arguments all equal the call's
+func (requestScript *RequestScript) match(args map[string]interface{}) *Fixture {
+	for _, fixture := range requestScript.Fixtures {
+		if fixture != nil && argsMatch(fixture.Match, args) {
+			return fixture
+		}
+	}
+	return nil
+}
+
+// argsMatch compares JSON encodings so YAML integers equal decoded JSON numbers
+func argsMatch(match, args map[string]interface{}) bool {
+	for name, expected := range match {
+		actual, exists := args[name]
+		if !exists {
+			return false
+		}
+		expectedJSON, err := json.Marshal(expected)
+		if err != nil {
+			return false
+		}
+		actualJSON, err := json.Marshal(actual)
+		if err != nil || string(expectedJSON) != string(actualJSON) {
+			return false
+		}
+	}
+	return true
+}
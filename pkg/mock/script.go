@@ -0,0 +1,178 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+)
+
+// Script holds scripted overrides for a mock server, loaded from JSON or YAML
+//
+// Example:
+//
+//	latency: 20ms
+//	requests:
+//	  get_user:
+//	    fixtures:
+//	      - match: {user_id: "404"}
+//	        error: {code: RESOURCE_NOT_FOUND, details: no such user}
+//	      - result: {id: "42", name: Ada}
+//	  charge:
+//	    latency: 500ms
+//	    errorRate: 0.1
+type Script struct {
+	Latency  Duration                  `json:"latency,omitempty" yaml:"latency,omitempty"` // Default for every request
+	Requests map[string]*RequestScript `json:"requests,omitempty" yaml:"requests,omitempty"`
+}
+
+// RequestScript overrides the behaviour of a single request
+type RequestScript struct {
+	Latency   Duration       `json:"latency,omitempty" yaml:"latency,omitempty"`     // Overrides the script latency
+	ErrorRate float64        `json:"errorRate,omitempty" yaml:"errorRate,omitempty"` // Fraction of calls that fail
+	Error     *ScriptedError `json:"error,omitempty" yaml:"error,omitempty"`         // Error used by ErrorRate; INTERNAL_ERROR when unset
+	Fixtures  []*Fixture     `json:"fixtures,omitempty" yaml:"fixtures,omitempty"`   // First matching fixture answers the call
+}
+
+// Fixture is a canned answer, optionally limited to calls with matching arguments
+type Fixture struct {
+	Match   map[string]interface{} `json:"match,omitempty" yaml:"match,omitempty"` // Arguments that must be equal; empty matches every call
+	Result  interface{}            `json:"result,omitempty" yaml:"result,omitempty"`
+	Error   *ScriptedError         `json:"error,omitempty" yaml:"error,omitempty"`
+	Latency Duration               `json:"latency,omitempty" yaml:"latency,omitempty"`
+}
+
+// ScriptedError describes an injected JSON-RPC error
+type ScriptedError struct {
+	Code    interface{}            `json:"code" yaml:"code"` // Name such as RESOURCE_NOT_FOUND, or the numeric code
+	Details string                 `json:"details,omitempty" yaml:"details,omitempty"`
+	Context map[string]interface{} `json:"context,omitempty" yaml:"context,omitempty"`
+}
+
+// Duration is a time.Duration written as a string such as "150ms"; bare numbers are milliseconds
+type Duration time.Duration
+
+// UnmarshalJSON decodes a duration string or a number of milliseconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return d.set(value)
+}
+
+// UnmarshalYAML decodes a duration string or a number of milliseconds
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	return d.set(value)
+}
+
+// MarshalJSON encodes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// MarshalYAML encodes the duration as a string
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return time.Duration(d).String(), nil
+}
+
+func (d *Duration) set(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid duration '%s': %w", v, err)
+		}
+		*d = Duration(parsed)
+	case int:
+		*d = Duration(time.Duration(v) * time.Millisecond)
+	case float64:
+		*d = Duration(time.Duration(v * float64(time.Millisecond)))
+	default:
+		return fmt.Errorf("invalid duration %v", value)
+	}
+	return nil
+}
+
+// ParseScript decodes a JSON or YAML script
+func ParseScript(data []byte) (*Script, error) {
+	var script Script
+	if err := yaml.Unmarshal(data, &script); err != nil {
+		return nil, fmt.Errorf("failed to parse mock script: %w", err)
+	}
+	return &script, nil
+}
+
+// LoadScript reads a JSON or YAML script file
+func LoadScript(path string) (*Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mock script '%s': %w", path, err)
+	}
+	return ParseScript(data)
+}
+
+// Validate checks the script against the manifest it overrides
+func (script *Script) Validate(m *manifest.Manifest) error {
+	if script.Latency < 0 {
+		return fmt.Errorf("latency cannot be negative")
+	}
+	for _, name := range sortedNames(script.Requests) {
+		request := script.Requests[name]
+		if request == nil {
+			continue
+		}
+		if _, exists := m.Requests[name]; !exists {
+			return fmt.Errorf("request '%s' is not defined in the manifest", name)
+		}
+		if request.Latency < 0 {
+			return fmt.Errorf("request '%s': latency cannot be negative", name)
+		}
+		if request.ErrorRate < 0 || request.ErrorRate > 1 {
+			return fmt.Errorf("request '%s': errorRate must be between 0 and 1", name)
+		}
+		if _, err := request.Error.jsonRPCError(); err != nil {
+			return fmt.Errorf("request '%s': %w", name, err)
+		}
+		for i, fixture := range request.Fixtures {
+			if fixture == nil {
+				return fmt.Errorf("request '%s': fixture %d definition is required", name, i)
+			}
+			if fixture.Latency < 0 {
+				return fmt.Errorf("request '%s': fixture %d latency cannot be negative", name, i)
+			}
+			if fixture.Error != nil && fixture.Result != nil {
+				return fmt.Errorf("request '%s': fixture %d cannot set both result and error", name, i)
+			}
+			if _, err := fixture.Error.jsonRPCError(); err != nil {
+				return fmt.Errorf("request '%s': fixture %d: %w", name, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// jsonRPCError converts the scripted error; a nil receiver yields a nil error
+func (e *ScriptedError) jsonRPCError() (*models.JSONRPCError, error) {
+	if e == nil {
+		return nil, nil
+	}
+	code, ok := models.ParseJSONRPCErrorCode(fmt.Sprint(e.Code))
+	if !ok {
+		return nil, fmt.Errorf("unknown error code '%v'", e.Code)
+	}
+
+	if len(e.Context) > 0 {
+		return models.NewJSONRPCErrorWithContext(code, e.Details, e.Context), nil
+	}
+	return models.NewJSONRPCError(code, e.Details), nil
+}
@@ -2,7 +2,10 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // JSONRPCErrorCode represents standard JSON-RPC 2.0 error codes
@@ -127,6 +130,51 @@ func (code JSONRPCErrorCode) Message() string {
 	}
 }
 
+// KnownJSONRPCErrorCodes returns every defined error code in declaration order
+func KnownJSONRPCErrorCodes() []JSONRPCErrorCode {
+	return []JSONRPCErrorCode{
+		ParseError, InvalidRequest, MethodNotFound, InvalidParams, InternalError,
+		ServerError, ServiceUnavailable, AuthenticationFailed, RateLimitExceeded,
+		ResourceNotFound, ValidationFailed, HandlerTimeout, SocketTransportError,
+		ConfigurationError, SecurityViolation, ResourceLimitExceeded,
+		MessageFramingError, ResponseTrackingError, ManifestValidationError,
+	}
+}
+
+// ParseJSONRPCErrorCode resolves an error code from its name (e.g. "VALIDATION_FAILED"),
+// its numeric value (e.g. "-32005") or a legacy name (e.g. "VALIDATION_ERROR")
+// Numeric values must be a known code or fall in the server error range (-32000 to -32099)
+func ParseJSONRPCErrorCode(name string) (JSONRPCErrorCode, bool) {
+	name = strings.TrimSpace(name)
+	
+	if number, err := strconv.Atoi(name); err == nil {
+		code := JSONRPCErrorCode(number)
+		if number <= -32000 && number >= -32099 {
+			return code, true
+		}
+		for _, known := range KnownJSONRPCErrorCodes() {
+			if code == known {
+				return code, true
+			}
+		}
+		return 0, false
+	}
+	
+	upper := strings.ToUpper(name)
+	for _, code := range KnownJSONRPCErrorCodes() {
+		if code.String() == upper {
+			return code, true
+		}
+	}
+	
+	switch upper {
+	case "UNKNOWN_REQUEST", "VALIDATION_ERROR", "INVALID_ARGUMENTS", "HANDLER_ERROR":
+		return MapLegacyErrorCode(upper), true
+	}
+	
+	return 0, false
+}
+
 // JSONRPCErrorData contains additional error context information
 type JSONRPCErrorData struct {
 	Details     string                 `json:"details,omitempty"`
@@ -152,6 +200,15 @@ func (e *JSONRPCError) Error() string {
 	return fmt.Sprintf("JSON-RPC Error %d: %s", int(e.Code), e.Message)
 }
 
+// AsJSONRPCError extracts a *JSONRPCError from an error chain
+func AsJSONRPCError(err error) (*JSONRPCError, bool) {
+	var rpcErr *JSONRPCError
+	if errors.As(err, &rpcErr) {
+		return rpcErr, true
+	}
+	return nil, false
+}
+
 // NewJSONRPCError creates a new JSON-RPC error with the manifestified code
 func NewJSONRPCError(code JSONRPCErrorCode, details string) *JSONRPCError {
 	error := &JSONRPCError{
@@ -215,10 +272,12 @@ func (e *JSONRPCError) UnmarshalJSON(data []byte) error {
 		Code int `json:"code"`
 		*Alias
 	}{
+		Code:  int(e.Code),
 		Alias: (*Alias)(e),
 	}
 	
-	if err := json.Unmarshal(data, &aux); err != nil {
+	// Decoding into aux itself, so that null leaves the error unchanged instead of clearing aux
+	if err := json.Unmarshal(data, aux); err != nil {
 		return err
 	}
 	
@@ -7,6 +7,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// ProtocolVersion is the version of the Janus wire protocol implemented by this package
+// Peers with different major versions cannot interoperate
+const ProtocolVersion = "1.0.0"
+
 // JanusRequest represents a request message sent through the Unix socket
 // PRIME DIRECTIVE: Exact format for 100% cross-platform compatibility
 type JanusRequest struct {
@@ -0,0 +1,164 @@
+// Package openrpc generates OpenRPC documents from Janus manifests
+package openrpc
+
+import (
+	"fmt"
+	"sort"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+)
+
+// Version is the OpenRPC specification version produced by Generate
+const Version = "1.3.2"
+
+// schemaRefPrefix is the reference prefix for models stored in components
+const schemaRefPrefix = "#/components/schemas/"
+
+// Document is an OpenRPC document
+type Document struct {
+	OpenRPC    string      `json:"openrpc"`
+	Info       Info        `json:"info"`
+	Servers    []Server    `json:"servers,omitempty"`
+	Methods    []Method    `json:"methods"`
+	Components *Components `json:"components,omitempty"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server describes where the API is served, e.g. unix:///tmp/my-server.sock
+type Server struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Method describes one request
+type Method struct {
+	Name           string              `json:"name"`
+	Description    string              `json:"description,omitempty"`
+	ParamStructure string              `json:"paramStructure"`
+	Params         []ContentDescriptor `json:"params"`
+	Result         *ContentDescriptor  `json:"result"`
+	Errors         []Error             `json:"errors,omitempty"`
+}
+
+// ContentDescriptor describes a parameter or result
+type ContentDescriptor struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Schema      *manifest.JSONSchema `json:"schema"`
+}
+
+// Error describes an application error a method may return
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Components holds reusable schemas
+type Components struct {
+	Schemas map[string]*manifest.JSONSchema `json:"schemas,omitempty"`
+}
+
+// Options controls document generation
+type Options struct {
+	SocketPath string // Optional server socket, emitted as a unix:// server entry
+}
+
+// Generate converts a manifest into an OpenRPC document
+// Unknown error code names and lossy schema constructs are returned as issues
+func Generate(m *manifest.Manifest, opts Options) (*Document, []manifest.SchemaIssue, error) {
+	if m == nil {
+		return nil, nil, fmt.Errorf("manifest cannot be nil")
+	}
+
+	exporter := manifest.NewJSONSchemaExporter(schemaRefPrefix)
+	var issues []manifest.SchemaIssue
+
+	doc := &Document{
+		OpenRPC: Version,
+		Info: Info{
+			Title:       m.Name,
+			Description: m.Description,
+			Version:     m.Version,
+		},
+		Methods: []Method{},
+	}
+
+	if opts.SocketPath != "" {
+		doc.Servers = []Server{{Name: m.Name, URL: "unix://" + opts.SocketPath}}
+	}
+
+	if len(m.Models) > 0 {
+		doc.Components = &Components{Schemas: make(map[string]*manifest.JSONSchema, len(m.Models))}
+		for _, name := range sortedKeys(m.Models) {
+			doc.Components.Schemas[name] = exporter.ModelSchema(name, m.Models[name], "/components/schemas/"+name)
+		}
+	}
+
+	for _, name := range sortedKeys(m.Requests) {
+		request := m.Requests[name]
+		if request == nil {
+			continue
+		}
+		path := fmt.Sprintf("/methods/%d", len(doc.Methods))
+
+		method := Method{
+			Name:           name,
+			Description:    request.Description,
+			ParamStructure: "by-name",
+			Params:         []ContentDescriptor{},
+			Result:         &ContentDescriptor{Name: "result", Schema: &manifest.JSONSchema{}},
+		}
+
+		for _, argName := range sortedKeys(request.Args) {
+			arg := request.Args[argName]
+			if arg == nil {
+				continue
+			}
+			method.Params = append(method.Params, ContentDescriptor{
+				Name:        argName,
+				Description: arg.Description,
+				Required:    arg.Required,
+				Schema:      exporter.ArgumentSchema(arg, fmt.Sprintf("%s/params/%d/schema", path, len(method.Params))),
+			})
+		}
+
+		if request.Response != nil {
+			method.Result.Description = request.Response.Description
+			method.Result.Schema = exporter.ResponseSchema(request.Response, path+"/result/schema")
+		}
+
+		for _, codeName := range request.ErrorCodes {
+			code, ok := models.ParseJSONRPCErrorCode(codeName)
+			if !ok {
+				issues = append(issues, manifest.SchemaIssue{
+					Path:    path + "/errors",
+					Message: fmt.Sprintf("unknown error code '%s' in request '%s', omitted", codeName, name),
+				})
+				continue
+			}
+			method.Errors = append(method.Errors, Error{Code: int(code), Message: code.Message()})
+		}
+
+		doc.Methods = append(doc.Methods, method)
+	}
+
+	return doc, append(exporter.Issues(), issues...), nil
+}
+
+// sortedKeys returns map keys in sorted order for deterministic output
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
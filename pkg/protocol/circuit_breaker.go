@@ -0,0 +1,458 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"GoJanus/pkg/models"
+)
+
+// CircuitState represents the state of a circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreakerConfig configures the per-socket circuit breaker
+// Zero values are replaced with the defaults from DefaultCircuitBreakerConfig
+type CircuitBreakerConfig struct {
+	FailureRatio        float64       // Failure ratio within the window that opens the circuit (0 < ratio <= 1)
+	MinimumRequests     int           // Minimum outcomes in the window before the ratio is evaluated
+	WindowSize          int           // Number of most recent outcomes considered
+	CoolDown            time.Duration // Time spent open before probing in half-open state
+	HalfOpenMaxRequests int           // Probe requests allowed (and successes required) while half-open
+}
+
+// DefaultCircuitBreakerConfig returns default circuit breaker configuration
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureRatio:        0.5,
+		MinimumRequests:     5,
+		WindowSize:          20,
+		CoolDown:            10 * time.Second,
+		HalfOpenMaxRequests: 1,
+	}
+}
+
+// withDefaults fills zero-valued fields with defaults
+func (c CircuitBreakerConfig) withDefaults() CircuitBreakerConfig {
+	defaults := DefaultCircuitBreakerConfig()
+	if c.FailureRatio == 0 {
+		c.FailureRatio = defaults.FailureRatio
+	}
+	if c.MinimumRequests == 0 {
+		c.MinimumRequests = defaults.MinimumRequests
+	}
+	if c.WindowSize == 0 {
+		c.WindowSize = defaults.WindowSize
+	}
+	if c.CoolDown == 0 {
+		c.CoolDown = defaults.CoolDown
+	}
+	if c.HalfOpenMaxRequests == 0 {
+		c.HalfOpenMaxRequests = defaults.HalfOpenMaxRequests
+	}
+	return c
+}
+
+// validate checks the circuit breaker configuration for invalid values
+func (c CircuitBreakerConfig) validate() error {
+	if c.FailureRatio <= 0 || c.FailureRatio > 1 {
+		return fmt.Errorf("configuration error: CircuitBreaker.FailureRatio must be in (0, 1]")
+	}
+	if c.MinimumRequests < 1 {
+		return fmt.Errorf("configuration error: CircuitBreaker.MinimumRequests must be at least 1")
+	}
+	if c.WindowSize < c.MinimumRequests {
+		return fmt.Errorf("configuration error: CircuitBreaker.WindowSize must be at least MinimumRequests")
+	}
+	if c.CoolDown < 0 {
+		return fmt.Errorf("configuration error: CircuitBreaker.CoolDown cannot be negative")
+	}
+	if c.HalfOpenMaxRequests < 1 {
+		return fmt.Errorf("configuration error: CircuitBreaker.HalfOpenMaxRequests must be at least 1")
+	}
+	return nil
+}
+
+// CircuitStateChange describes a circuit breaker state transition
+// Delivered to "circuit_state_change" event handlers
+type CircuitStateChange struct {
+	SocketPath string       `json:"socketPath"`
+	From       CircuitState `json:"from"`
+	To         CircuitState `json:"to"`
+	Timestamp  time.Time    `json:"timestamp"`
+}
+
+// CircuitBreakerStats holds a snapshot of circuit breaker state
+type CircuitBreakerStats struct {
+	SocketPath      string       `json:"socketPath"`
+	State           CircuitState `json:"state"`
+	WindowRequests  int          `json:"windowRequests"`
+	WindowFailures  int          `json:"windowFailures"`
+	FailureRatio    float64      `json:"failureRatio"`
+	TotalRejected   int64        `json:"totalRejected"`
+	StateChanges    int64        `json:"stateChanges"`
+	LastStateChange time.Time    `json:"lastStateChange"`
+	OpenedAt        *time.Time   `json:"openedAt,omitempty"`
+}
+
+// CircuitBreaker tracks transport failures for a single server socket
+// Fails fast with ServiceUnavailable while open instead of waiting for datagram timeouts
+type CircuitBreaker struct {
+	socketPath string
+	config     CircuitBreakerConfig
+
+	mutex           sync.Mutex
+	state           CircuitState
+	outcomes        []bool // ring buffer, true = failure
+	next            int
+	count           int
+	failures        int
+	openedAt        time.Time
+	halfOpenActive  int
+	halfOpenPassed  int
+	totalRejected   int64
+	stateChanges    int64
+	lastStateChange time.Time
+
+	listenerMutex sync.RWMutex
+	listeners     map[int]func(CircuitStateChange)
+	nextListener  int
+}
+
+// NewCircuitBreaker creates a circuit breaker for the given socket path
+func NewCircuitBreaker(socketPath string, config CircuitBreakerConfig) *CircuitBreaker {
+	config = config.withDefaults()
+	return &CircuitBreaker{
+		socketPath:      socketPath,
+		config:          config,
+		state:           CircuitClosed,
+		outcomes:        make([]bool, config.WindowSize),
+		lastStateChange: time.Now(),
+		listeners:       make(map[int]func(CircuitStateChange)),
+	}
+}
+
+// circuitBreakerKey identifies a shared breaker; clients only share a breaker when their configurations match
+type circuitBreakerKey struct {
+	socketPath string
+	config     CircuitBreakerConfig
+}
+
+// sharedCircuitBreaker is a registry entry counting the clients attached to a breaker
+type sharedCircuitBreaker struct {
+	breaker *CircuitBreaker
+	refs    int
+}
+
+// Shared breakers keyed by socket path and configuration so all clients of one server see the same health
+var (
+	circuitBreakers      = make(map[circuitBreakerKey]*sharedCircuitBreaker)
+	circuitBreakersMutex sync.Mutex
+)
+
+// acquireCircuitBreaker returns the shared circuit breaker for a socket path and configuration, creating it on first use
+// Every call must be paired with releaseCircuitBreaker
+func acquireCircuitBreaker(socketPath string, config CircuitBreakerConfig) *CircuitBreaker {
+	key := circuitBreakerKey{socketPath: socketPath, config: config.withDefaults()}
+
+	circuitBreakersMutex.Lock()
+	defer circuitBreakersMutex.Unlock()
+
+	entry, exists := circuitBreakers[key]
+	if !exists {
+		entry = &sharedCircuitBreaker{breaker: NewCircuitBreaker(socketPath, key.config)}
+		circuitBreakers[key] = entry
+	}
+	entry.refs++
+	return entry.breaker
+}
+
+// releaseCircuitBreaker detaches a client from its shared breaker and drops the breaker once no client uses it
+func releaseCircuitBreaker(breaker *CircuitBreaker) {
+	key := circuitBreakerKey{socketPath: breaker.socketPath, config: breaker.config}
+
+	circuitBreakersMutex.Lock()
+	defer circuitBreakersMutex.Unlock()
+
+	entry, exists := circuitBreakers[key]
+	if !exists || entry.breaker != breaker {
+		return
+	}
+	entry.refs--
+	if entry.refs <= 0 {
+		delete(circuitBreakers, key)
+	}
+}
+
+// CircuitTicket is issued by Allow for each request let through and passed back with its result
+// Results only count while the breaker is still in the state the ticket was issued in
+type CircuitTicket struct {
+	probe bool  // The request holds a half-open probe slot
+	epoch int64 // State changes seen when the ticket was issued
+}
+
+// Allow reports whether a request may be sent and returns the ticket to record its result with
+// Returns a ServiceUnavailable JSON-RPC error while the circuit is open
+func (cb *CircuitBreaker) Allow() (CircuitTicket, error) {
+	cb.mutex.Lock()
+	var change *CircuitStateChange
+	if cb.state == CircuitOpen && time.Since(cb.openedAt) >= cb.config.CoolDown {
+		change = cb.transitionLocked(CircuitHalfOpen)
+	}
+
+	ticket := CircuitTicket{epoch: cb.stateChanges}
+	var err error
+	switch cb.state {
+	case CircuitOpen:
+		cb.totalRejected++
+		err = cb.openErrorLocked()
+	case CircuitHalfOpen:
+		if cb.halfOpenActive >= cb.config.HalfOpenMaxRequests {
+			cb.totalRejected++
+			err = models.NewJSONRPCErrorWithContext(models.ServiceUnavailable,
+				fmt.Sprintf("circuit breaker half-open for %s, probe in progress", cb.socketPath),
+				map[string]interface{}{
+					"socketPath": cb.socketPath,
+					"state":      string(cb.state),
+				})
+		} else {
+			cb.halfOpenActive++
+			ticket.probe = true
+		}
+	}
+	cb.mutex.Unlock()
+
+	cb.notify(change)
+	return ticket, err
+}
+
+// rejectIfOpen fails fast while the circuit is open without reserving a half-open probe
+func (cb *CircuitBreaker) rejectIfOpen() error {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.state != CircuitOpen || time.Since(cb.openedAt) >= cb.config.CoolDown {
+		return nil
+	}
+	cb.totalRejected++
+	return cb.openErrorLocked()
+}
+
+// openErrorLocked builds the ServiceUnavailable error returned while open
+func (cb *CircuitBreaker) openErrorLocked() error {
+	retryAfter := cb.config.CoolDown - time.Since(cb.openedAt)
+	return models.NewJSONRPCErrorWithContext(models.ServiceUnavailable,
+		fmt.Sprintf("circuit breaker open for %s", cb.socketPath),
+		map[string]interface{}{
+			"socketPath": cb.socketPath,
+			"state":      string(cb.state),
+			"retryAfter": retryAfter.Seconds(),
+		})
+}
+
+// RecordSuccess records a successful round-trip
+func (cb *CircuitBreaker) RecordSuccess(ticket CircuitTicket) {
+	cb.record(ticket, false)
+}
+
+// RecordFailure records a transport failure
+func (cb *CircuitBreaker) RecordFailure(ticket CircuitTicket) {
+	cb.record(ticket, true)
+}
+
+// RecordResult records the outcome of a request allowed by Allow
+// Failures caused by the caller cancelling ctx release the slot without counting
+func (cb *CircuitBreaker) RecordResult(ctx context.Context, ticket CircuitTicket, err error) {
+	if err != nil && ctx.Err() == context.Canceled {
+		cb.mutex.Lock()
+		if ticket.probe && ticket.epoch == cb.stateChanges && cb.halfOpenActive > 0 {
+			cb.halfOpenActive--
+		}
+		cb.mutex.Unlock()
+		return
+	}
+	cb.record(ticket, err != nil)
+}
+
+// record applies a single outcome to the state machine
+func (cb *CircuitBreaker) record(ticket CircuitTicket, failed bool) {
+	cb.mutex.Lock()
+	var change *CircuitStateChange
+
+	// Late results from requests allowed before the last state change are ignored, so a request
+	// sent while closed cannot act as a half-open probe
+	if ticket.epoch != cb.stateChanges {
+		cb.mutex.Unlock()
+		return
+	}
+
+	switch cb.state {
+	case CircuitClosed:
+		cb.addOutcomeLocked(failed)
+		if cb.count >= cb.config.MinimumRequests &&
+			float64(cb.failures)/float64(cb.count) >= cb.config.FailureRatio {
+			change = cb.transitionLocked(CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		if !ticket.probe {
+			break
+		}
+		if cb.halfOpenActive > 0 {
+			cb.halfOpenActive--
+		}
+		if failed {
+			change = cb.transitionLocked(CircuitOpen)
+		} else {
+			cb.halfOpenPassed++
+			if cb.halfOpenPassed >= cb.config.HalfOpenMaxRequests {
+				change = cb.transitionLocked(CircuitClosed)
+			}
+		}
+	}
+	cb.mutex.Unlock()
+
+	cb.notify(change)
+}
+
+// addOutcomeLocked appends an outcome to the rolling window
+func (cb *CircuitBreaker) addOutcomeLocked(failed bool) {
+	if cb.count == len(cb.outcomes) {
+		if cb.outcomes[cb.next] {
+			cb.failures--
+		}
+	} else {
+		cb.count++
+	}
+	cb.outcomes[cb.next] = failed
+	if failed {
+		cb.failures++
+	}
+	cb.next = (cb.next + 1) % len(cb.outcomes)
+}
+
+// resetWindowLocked clears the rolling window
+func (cb *CircuitBreaker) resetWindowLocked() {
+	for i := range cb.outcomes {
+		cb.outcomes[i] = false
+	}
+	cb.next = 0
+	cb.count = 0
+	cb.failures = 0
+}
+
+// transitionLocked moves to a new state and returns the change to publish
+func (cb *CircuitBreaker) transitionLocked(to CircuitState) *CircuitStateChange {
+	if cb.state == to {
+		return nil
+	}
+
+	now := time.Now()
+	change := &CircuitStateChange{
+		SocketPath: cb.socketPath,
+		From:       cb.state,
+		To:         to,
+		Timestamp:  now,
+	}
+
+	cb.state = to
+	cb.stateChanges++
+	cb.lastStateChange = now
+	cb.halfOpenActive = 0
+	cb.halfOpenPassed = 0
+
+	switch to {
+	case CircuitOpen:
+		cb.openedAt = now
+	case CircuitClosed:
+		cb.resetWindowLocked()
+	}
+
+	return change
+}
+
+// notify delivers a state change to all listeners
+func (cb *CircuitBreaker) notify(change *CircuitStateChange) {
+	if change == nil {
+		return
+	}
+
+	cb.listenerMutex.RLock()
+	defer cb.listenerMutex.RUnlock()
+	for _, listener := range cb.listeners {
+		listener(*change)
+	}
+}
+
+// OnStateChange registers a listener for state transitions
+// Returns a function that removes the listener
+func (cb *CircuitBreaker) OnStateChange(listener func(CircuitStateChange)) func() {
+	cb.listenerMutex.Lock()
+	defer cb.listenerMutex.Unlock()
+
+	id := cb.nextListener
+	cb.nextListener++
+	cb.listeners[id] = listener
+
+	return func() {
+		cb.listenerMutex.Lock()
+		defer cb.listenerMutex.Unlock()
+		delete(cb.listeners, id)
+	}
+}
+
+// State returns the current state, applying the cool-down transition if due
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mutex.Lock()
+	var change *CircuitStateChange
+	if cb.state == CircuitOpen && time.Since(cb.openedAt) >= cb.config.CoolDown {
+		change = cb.transitionLocked(CircuitHalfOpen)
+	}
+	state := cb.state
+	cb.mutex.Unlock()
+
+	cb.notify(change)
+	return state
+}
+
+// Reset forces the breaker back to the closed state
+func (cb *CircuitBreaker) Reset() {
+	cb.mutex.Lock()
+	change := cb.transitionLocked(CircuitClosed)
+	cb.resetWindowLocked()
+	cb.mutex.Unlock()
+
+	cb.notify(change)
+}
+
+// Statistics returns a snapshot of the breaker state
+func (cb *CircuitBreaker) Statistics() CircuitBreakerStats {
+	state := cb.State()
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	stats := CircuitBreakerStats{
+		SocketPath:      cb.socketPath,
+		State:           state,
+		WindowRequests:  cb.count,
+		WindowFailures:  cb.failures,
+		TotalRejected:   cb.totalRejected,
+		StateChanges:    cb.stateChanges,
+		LastStateChange: cb.lastStateChange,
+	}
+	if cb.count > 0 {
+		stats.FailureRatio = float64(cb.failures) / float64(cb.count)
+	}
+	if state == CircuitOpen {
+		openedAt := cb.openedAt
+		stats.OpenedAt = &openedAt
+	}
+	return stats
+}
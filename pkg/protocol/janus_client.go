@@ -13,6 +13,7 @@ import (
 	"GoJanus/pkg/core"
 	"GoJanus/pkg/models"
 	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/wire"
 )
 
 // JanusClient is the main client interface for SOCK_DGRAM Unix socket communication
@@ -38,6 +39,24 @@ type JanusClient struct {
 	// Request lifecycle management (automatic ID system)
 	requestRegistry map[string]*models.RequestHandle
 	registryMutex   sync.RWMutex
+	
+	// Circuit breaker shared by all clients of the same socket (nil when disabled)
+	circuitBreaker      *CircuitBreaker
+	circuitUnsubscribe  func()
+	
+	// Set when the server manifest is outside ManifestVersionRange and the client degraded
+	versionMismatch *models.JSONRPCError
+	
+	// Guards manifest, manifestHash, manifestFetchedAt and manifestLoading across loads, refreshes and invalidation
+	manifestMutex     sync.RWMutex
+	manifestHash      string
+	manifestFetchedAt time.Time
+	manifestLoading   *manifestLoad // Fetch in flight, shared by concurrent callers
+	
+	// Encodings the server lists in get_info, read once a request needs an encoding other than JSON
+	encodingMutex   sync.Mutex
+	serverEncodings []wire.Encoding
+	encodingRetryAt time.Time // Set while serverEncodings is the JSON fallback after get_info failed
 }
 
 // JanusClientConfig holds configuration for the datagram client
@@ -46,8 +65,35 @@ type JanusClientConfig struct {
 	DefaultTimeout   time.Duration
 	DatagramTimeout  time.Duration
 	EnableValidation bool
+	CircuitBreaker   *CircuitBreakerConfig // Optional per-socket circuit breaker, nil disables it
+	
+	// Semver range of manifest versions the client supports, e.g. "^1.2.0"; empty accepts any
+	ManifestVersionRange string
+	// What to do when the server manifest is outside ManifestVersionRange
+	VersionMismatch VersionMismatchPolicy
+	
+	// Optional cache shared between clients; nil keeps the manifest per client only
+	ManifestCache ManifestCache
+	// How long a fetched or cached manifest is used before revalidating with the server;
+	// 0 never refreshes a loaded manifest and always revalidates cache entries
+	ManifestTTL time.Duration
+	
+	// Encoding of requests; empty sends JSON. Servers reply in the encoding of the request.
+	// Requests fall back to JSON when the server's get_info does not list the encoding or fails
+	Encoding wire.Encoding
 }
 
+// VersionMismatchPolicy controls client behaviour when manifest versions are incompatible
+type VersionMismatchPolicy int
+
+const (
+	// VersionMismatchRefuse fails every request with a ManifestValidationError
+	VersionMismatchRefuse VersionMismatchPolicy = iota
+	// VersionMismatchDegrade keeps sending requests without manifest validation
+	// and emits a "version_mismatch" event
+	VersionMismatchDegrade
+)
+
 // DefaultJanusClientConfig returns default configuration for SOCK_DGRAM
 func DefaultJanusClientConfig() JanusClientConfig {
 	return JanusClientConfig{
@@ -80,59 +126,83 @@ func validateConstructorInputs(socketPath string, config JanusClientConfig) erro
 		return fmt.Errorf("configuration error: DatagramTimeout too short, minimum 100ms")
 	}
 	
+	if config.CircuitBreaker != nil {
+		if err := config.CircuitBreaker.withDefaults().validate(); err != nil {
+			return err
+		}
+	}
+	
+	if config.ManifestVersionRange != "" {
+		if _, err := manifest.ParseVersionRange(config.ManifestVersionRange); err != nil {
+			return fmt.Errorf("configuration error: ManifestVersionRange: %w", err)
+		}
+	}
+	
+	if _, err := wire.ParseEncoding(string(config.Encoding)); err != nil {
+		return fmt.Errorf("configuration error: Encoding: %w", err)
+	}
+	
 	return nil
 }
 
+// manifestFetch is the outcome of a manifest request
+type manifestFetch struct {
+	manifest    *manifest.Manifest // nil when notModified
+	hash        string
+	notModified bool
+}
+
 // fetchManifestFromServer fetches the Manifest from the server
-func fetchManifestFromServer(janusClient *core.JanusClient, socketPath string, cfg JanusClientConfig) (*manifest.Manifest, error) {
+// When cfg.ManifestVersionRange is set the server is asked for a version within it,
+// and a manifest outside the range is reported as a ManifestValidationError.
+// With caching enabled the request is conditional on cachedHash
+func fetchManifestFromServer(send datagramSender, socketPath string, cfg JanusClientConfig, cachedHash string) (*manifestFetch, error) {
 	log.Printf("[GO-PROTOCOL] fetchManifestFromServer ENTER - Server: %s", socketPath)
 	
-	// Generate response socket path
-	responseSocketPath := fmt.Sprintf("/tmp/janus_manifest_%d_%s.sock", time.Now().UnixNano(), generateRandomID())
-	log.Printf("[GO-PROTOCOL] Generated response socket path: %s", responseSocketPath)
-	
-	// Create manifest request with proper JanusRequest structure
-	manifestRequest := *models.NewJanusRequest("manifest", nil, nil)
-	manifestRequest.ReplyTo = &responseSocketPath
-	
-	requestJSON, err := json.Marshal(manifestRequest)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal manifest request: %w", err)
+	args := map[string]interface{}{}
+	if cfg.ManifestVersionRange != "" {
+		args["version_range"] = cfg.ManifestVersionRange
 	}
+	// Always conditional: an empty hash never matches, and the envelope carries protocol_version
+	args["if_none_match"] = cachedHash
 	
-	// Send manifest request to server with timeout context
-	log.Printf("[GO-PROTOCOL] Creating timeout context: %v", cfg.DefaultTimeout)
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.DefaultTimeout)
-	defer func() {
-		log.Printf("[GO-PROTOCOL] Context cancel called")
-		cancel()
-	}()
-	
-	log.Printf("[GO-PROTOCOL] Calling core SendDatagram with response path: %s", responseSocketPath)
-	responseData, err := janusClient.SendDatagram(ctx, requestJSON, responseSocketPath)
+	response, err := sendBuiltinRequest(context.Background(), send, cfg, "manifest", args)
 	if err != nil {
-		log.Printf("[GO-PROTOCOL] ERROR: SendDatagram failed: %v", err)
 		return nil, fmt.Errorf("failed to fetch manifest from server: %w", err)
 	}
-	log.Printf("[GO-PROTOCOL] SendDatagram SUCCESS - Received %d bytes", len(responseData))
-	
-	// Parse the response JSON
-	var response map[string]interface{}
-	if err := json.Unmarshal(responseData, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse server response: %w", err)
-	}
 	
 	// Check for error in response (PRIME DIRECTIVE format)
-	if errorMsg, exists := response["error"]; exists && errorMsg != nil {
-		return nil, fmt.Errorf("server returned error: %v", errorMsg)
+	if response.Error != nil {
+		return nil, fmt.Errorf("server returned error: %w", response.Error)
 	}
 	
 	// Extract manifest from response
-	manifestData, exists := response["result"]
-	if !exists {
+	if response.Result == nil {
 		return nil, fmt.Errorf("server response missing 'result' field")
 	}
 	
+	// Conditional requests are answered with {"not_modified", "hash"} or {"manifest", "hash"}, plus
+	// protocol_version; servers without caching support ignore if_none_match and return the bare manifest
+	manifestData := response.Result
+	hash := ""
+	if envelope, ok := response.Result.(map[string]interface{}); ok {
+		if envelopeHash, ok := envelope["hash"].(string); ok {
+			// Refuse an incompatible protocol without a separate get_info round-trip
+			serverVersion, _ := envelope["protocol_version"].(string)
+			if err := checkProtocolVersion(serverVersion); err != nil {
+				return nil, err
+			}
+			if notModified, _ := envelope["not_modified"].(bool); notModified {
+				log.Printf("[GO-PROTOCOL] fetchManifestFromServer NOT MODIFIED")
+				return &manifestFetch{hash: envelopeHash, notModified: true}, nil
+			}
+			if wrapped, exists := envelope["manifest"]; exists {
+				manifestData = wrapped
+				hash = envelopeHash
+			}
+		}
+	}
+	
 	// Convert manifest data to JSON and parse
 	manifestJSON, err := json.Marshal(manifestData)
 	if err != nil {
@@ -140,13 +210,93 @@ func fetchManifestFromServer(janusClient *core.JanusClient, socketPath string, c
 	}
 	
 	parser := manifest.NewManifestParser()
-	manifest, err := parser.ParseJSON(manifestJSON)
+	parsed, err := parser.ParseJSON(manifestJSON)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse server manifest: %w", err)
 	}
 	
+	// Servers without version negotiation ignore version_range, so check the result too
+	if err := checkManifestVersion(parsed.Version, cfg.ManifestVersionRange, []string{parsed.Version}); err != nil {
+		return nil, err
+	}
+	
+	if hash == "" {
+		if hash, err = manifest.ContentHash(parsed); err != nil {
+			return nil, err
+		}
+	}
+	
 	log.Printf("[GO-PROTOCOL] fetchManifestFromServer SUCCESS - Returning manifest")
-	return manifest, nil
+	return &manifestFetch{manifest: parsed, hash: hash}, nil
+}
+
+// sendBuiltinRequest sends a built-in request on a fresh reply socket, bypassing manifest validation
+func sendBuiltinRequest(ctx context.Context, send datagramSender, cfg JanusClientConfig, request string, args map[string]interface{}) (*models.JanusResponse, error) {
+	// Generate response socket path
+	responseSocketPath := fmt.Sprintf("/tmp/janus_%s_%d_%s.sock", request, time.Now().UnixNano(), generateRandomID())
+	log.Printf("[GO-PROTOCOL] Generated response socket path: %s", responseSocketPath)
+	
+	// Create request with proper JanusRequest structure
+	janusRequest := *models.NewJanusRequest(request, args, nil)
+	janusRequest.ReplyTo = &responseSocketPath
+	
+	requestJSON, err := wire.EncodeRequestAs(&janusRequest, cfg.Encoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s request: %w", request, err)
+	}
+	
+	// Send request to server with timeout context
+	requestCtx, cancel := context.WithTimeout(ctx, cfg.DefaultTimeout)
+	defer cancel()
+	
+	responseData, err := send(requestCtx, requestJSON, responseSocketPath)
+	if err != nil {
+		log.Printf("[GO-PROTOCOL] ERROR: SendDatagram failed: %v", err)
+		return nil, err
+	}
+	log.Printf("[GO-PROTOCOL] SendDatagram SUCCESS - Received %d bytes", len(responseData))
+	
+	response, err := wire.DecodeResponse(responseData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse server response: %w", err)
+	}
+	return response, nil
+}
+
+// checkManifestVersion reports a ManifestValidationError when version is outside versionRange
+func checkManifestVersion(version, versionRange string, available []string) error {
+	if versionRange == "" {
+		return nil
+	}
+	
+	r, err := manifest.ParseVersionRange(versionRange)
+	if err != nil {
+		return err
+	}
+	if ok, err := r.ContainsString(version); err == nil && ok {
+		return nil
+	}
+	
+	return models.NewJSONRPCErrorWithContext(
+		models.ManifestValidationError,
+		fmt.Sprintf("server manifest version %s does not satisfy client range '%s'", version, versionRange),
+		map[string]interface{}{
+			"requested_range":    versionRange,
+			"available_versions": available,
+		},
+	)
+}
+
+// isVersionMismatch reports whether err is a manifest version negotiation failure
+func isVersionMismatch(err error) (*models.JSONRPCError, bool) {
+	rpcErr, ok := models.AsJSONRPCError(err)
+	if !ok || rpcErr.Code != models.ManifestValidationError || rpcErr.Data == nil {
+		return nil, false
+	}
+	if _, exists := rpcErr.Data.Context["available_versions"]; !exists {
+		return nil, false
+	}
+	return rpcErr, true
 }
 
 // generateRandomID generates a random ID for unique socket paths
@@ -197,7 +347,7 @@ func New(socketPath string, config ...JanusClientConfig) (*JanusClient, error) {
 	}
 	responseTracker := NewResponseTracker(trackerConfig)
 	
-	return &JanusClient{
+	client := &JanusClient{
 		socketPath:      socketPath,
 		manifest:         nil,
 		config:          cfg,
@@ -207,35 +357,193 @@ func New(socketPath string, config ...JanusClientConfig) (*JanusClient, error) {
 		timeoutManager:  timeoutManager,
 		responseTracker: responseTracker,
 		requestRegistry: make(map[string]*models.RequestHandle),
-	}, nil
+	}
+	
+	// Attach the shared circuit breaker and forward its transitions as client events
+	if cfg.CircuitBreaker != nil {
+		client.circuitBreaker = acquireCircuitBreaker(socketPath, *cfg.CircuitBreaker)
+		client.circuitUnsubscribe = client.circuitBreaker.OnStateChange(func(change CircuitStateChange) {
+			responseTracker.emit("circuit_state_change", change)
+		})
+	}
+	
+	return client, nil
+}
+
+// datagramSender sends a request datagram and waits for the reply
+type datagramSender func(ctx context.Context, data []byte, responsePath string) ([]byte, error)
+
+// sendDatagram sends via the core client, consulting the circuit breaker when enabled
+func (client *JanusClient) sendDatagram(ctx context.Context, data []byte, responsePath string) ([]byte, error) {
+	if client.circuitBreaker == nil {
+		return client.janusClient.SendDatagram(ctx, data, responsePath)
+	}
+	
+	ticket, err := client.circuitBreaker.Allow()
+	if err != nil {
+		return nil, err
+	}
+	
+	responseData, err := client.janusClient.SendDatagram(ctx, data, responsePath)
+	client.circuitBreaker.RecordResult(ctx, ticket, err)
+	return responseData, err
+}
+
+// manifestLoad is an in-flight manifest fetch shared by concurrent callers
+type manifestLoad struct {
+	done chan struct{}
+	err  error
 }
 
 // ensureManifestLoaded fetches Manifest from server if not already loaded
+// With a ManifestVersionRange the server version is negotiated even when validation is disabled.
+// The manifest response also reports the server protocol version, refused when its major differs.
+// A configured ManifestCache is consulted first, and ManifestTTL triggers conditional revalidation.
+// Concurrent callers share one fetch, and manifestMutex is not held during the round-trip
 func (client *JanusClient) ensureManifestLoaded() error {
-	if client.manifest != nil {
-		return nil // Already loaded
+	client.manifestMutex.Lock()
+	if client.manifestReadyLocked() {
+		client.manifestMutex.Unlock()
+		return nil
+	}
+	
+	if load := client.manifestLoading; load != nil {
+		client.manifestMutex.Unlock()
+		<-load.done
+		return load.err
+	}
+	
+	load := &manifestLoad{done: make(chan struct{})}
+	client.manifestLoading = load
+	knownHash := client.manifestHash
+	client.manifestMutex.Unlock()
+	
+	// Fetch manifest from server, conditional on the hash we already hold
+	var fetched *manifestFetch
+	encoding, err := client.requestEncoding(context.Background(), "")
+	if err == nil {
+		cfg := client.config
+		cfg.Encoding = encoding
+		fetched, err = fetchManifestFromServer(client.sendDatagram, client.socketPath, cfg, knownHash)
+	}
+	
+	client.manifestMutex.Lock()
+	load.err = client.applyManifestFetchLocked(fetched, err)
+	client.manifestLoading = nil
+	client.manifestMutex.Unlock()
+	
+	close(load.done)
+	return load.err
+}
+
+// manifestReadyLocked reports whether no fetch is needed, loading a fresh cache entry if there is one
+// Callers hold manifestMutex
+func (client *JanusClient) manifestReadyLocked() bool {
+	if client.versionMismatch != nil {
+		return true // Degraded after a version mismatch
+	}
+	
+	if !client.requiresManifest() {
+		return true // Validation disabled, no need to fetch
 	}
 	
-	if !client.config.EnableValidation {
-		return nil // Validation disabled, no need to fetch
+	if client.manifest != nil && !client.manifestExpired(client.manifestFetchedAt) {
+		return true // Already loaded
 	}
 	
-	// Fetch manifest from server
-	fetchedManifest, err := fetchManifestFromServer(client.janusClient, client.socketPath, client.config)
+	cache := client.config.ManifestCache
+	if client.manifest == nil && cache != nil {
+		if cached, ok := cache.Get(manifestCacheKey(client.socketPath, client.config.ManifestVersionRange)); ok {
+			client.manifest = cached.Manifest
+			client.manifestHash = cached.Hash
+			client.manifestFetchedAt = cached.FetchedAt
+			if client.config.ManifestTTL > 0 && !client.manifestExpired(cached.FetchedAt) {
+				return true // Fresh cache entry, no round-trip needed
+			}
+		}
+	}
+	return false
+}
+
+// applyManifestFetchLocked swaps a fetch result in; callers hold manifestMutex
+func (client *JanusClient) applyManifestFetchLocked(fetched *manifestFetch, err error) error {
 	if err != nil {
+		if mismatch, ok := isVersionMismatch(err); ok {
+			client.clearManifestLocked()
+			if client.config.VersionMismatch == VersionMismatchDegrade {
+				client.versionMismatch = mismatch
+				client.responseTracker.emit("version_mismatch", mismatch)
+				return nil
+			}
+		}
 		return fmt.Errorf("failed to fetch Manifest: %w", err)
 	}
 	
-	// Channels have been removed from the protocol
-	client.manifest = fetchedManifest
+	if !fetched.notModified || client.manifest == nil {
+		if fetched.manifest == nil {
+			// not_modified for a hash we no longer hold; drop it so the next load fetches in full
+			client.clearManifestLocked()
+			return fmt.Errorf("failed to fetch Manifest: server reported not modified without a cached manifest")
+		}
+		client.manifest = fetched.manifest
+	}
+	client.manifestHash = fetched.hash
+	client.manifestFetchedAt = time.Now()
+	
+	if cache := client.config.ManifestCache; cache != nil {
+		if err := cache.Put(manifestCacheKey(client.socketPath, client.config.ManifestVersionRange), &CachedManifest{
+			Manifest:  client.manifest,
+			Hash:      client.manifestHash,
+			FetchedAt: client.manifestFetchedAt,
+		}); err != nil {
+			log.Printf("[GO-PROTOCOL] Failed to cache manifest: %v", err)
+		}
+	}
 	return nil
 }
 
+// manifestExpired reports whether a manifest fetched at fetchedAt is older than ManifestTTL
+func (client *JanusClient) manifestExpired(fetchedAt time.Time) bool {
+	return client.config.ManifestTTL > 0 && time.Since(fetchedAt) >= client.config.ManifestTTL
+}
+
+// InvalidateManifest drops the loaded and cached manifest so the next request refetches it
+// Called automatically when the server answers with ManifestValidationError
+func (client *JanusClient) InvalidateManifest() {
+	client.manifestMutex.Lock()
+	defer client.manifestMutex.Unlock()
+	client.clearManifestLocked()
+}
+
+// clearManifestLocked forgets the manifest; callers hold manifestMutex
+func (client *JanusClient) clearManifestLocked() {
+	client.manifest = nil
+	client.manifestHash = ""
+	client.manifestFetchedAt = time.Time{}
+	if client.config.ManifestCache != nil {
+		client.config.ManifestCache.Invalidate(manifestCacheKey(client.socketPath, client.config.ManifestVersionRange))
+	}
+}
+
+// invalidateOnManifestError drops a stale manifest when the server rejects a request against it
+func (client *JanusClient) invalidateOnManifestError(response *models.JanusResponse) {
+	if response.Error != nil && response.Error.Code == models.ManifestValidationError {
+		client.InvalidateManifest()
+	}
+}
+
 // SendRequest sends a request via SOCK_DGRAM and waits for response
 func (client *JanusClient) SendRequest(ctx context.Context, request string, args map[string]interface{}, options ...RequestOptions) (*models.JanusResponse, error) {
 	// Apply options
 	opts := mergeRequestOptions(options...)
 	
+	// Fail fast while the server's circuit is open
+	if client.circuitBreaker != nil {
+		if err := client.circuitBreaker.rejectIfOpen(); err != nil {
+			return nil, err
+		}
+	}
+	
 	// Generate request ID
 	requestID := generateUUID()
 	
@@ -249,7 +557,7 @@ func (client *JanusClient) SendRequest(ctx context.Context, request string, args
 	janusRequest.ReplyTo = &responseSocketPath
 	
 	// Ensure Manifest is loaded for validation
-	if client.config.EnableValidation {
+	if client.requiresManifest() {
 		if err := client.ensureManifestLoaded(); err != nil {
 			// If this is a connection error, propagate it directly without wrapping as validation error
 			if strings.Contains(err.Error(), "dial") || strings.Contains(err.Error(), "connect") || strings.Contains(err.Error(), "no such file") {
@@ -261,16 +569,17 @@ func (client *JanusClient) SendRequest(ctx context.Context, request string, args
 
 	// Validate request arguments against Manifest (but don't reject unknown requests)
 	// Unknown requests should be sent to the server which will respond with an error
-	if client.config.EnableValidation && client.manifest != nil {
+	if currentManifest := client.GetManifest(); client.config.EnableValidation && currentManifest != nil {
 		// Only validate arguments if the request exists in the manifest
-		if client.manifest.HasRequest(request) {
-			requestManifest, err := client.manifest.GetRequest(request)
+		if currentManifest.HasRequest(request) {
+			requestManifest, err := currentManifest.GetRequest(request)
 			if err != nil {
 				return nil, fmt.Errorf("request validation failed: %w", err)
 			}
 			
-			if err := client.manifest.ValidateRequestArgs(requestManifest, args); err != nil {
-				return nil, fmt.Errorf("request validation failed: %w", err)
+			// Report every invalid argument at once; the list is in the error's Context["errors"]
+			if argErrors := currentManifest.ValidateRequestArgsAll(requestManifest, args); len(argErrors) > 0 {
+				return nil, fmt.Errorf("request validation failed: %w", argErrors.JSONRPCError())
 			}
 		}
 		// If request doesn't exist in manifest, still send it to server
@@ -287,20 +596,24 @@ func (client *JanusClient) SendRequest(ctx context.Context, request string, args
 	defer cancel()
 	
 	// Serialize request
-	requestData, err := json.Marshal(janusRequest)
+	encoding, err := client.requestEncoding(requestCtx, opts.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	requestData, err := wire.EncodeRequestAs(&janusRequest, encoding)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize request: %w", err)
 	}
 	
 	// Send datagram and wait for response
-	responseData, err := client.janusClient.SendDatagram(requestCtx, requestData, responseSocketPath)
+	responseData, err := client.sendDatagram(requestCtx, requestData, responseSocketPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request datagram: %w", err)
 	}
 	
 	// Deserialize response
-	var response models.JanusResponse
-	if err := json.Unmarshal(responseData, &response); err != nil {
+	response, err := wire.DecodeResponse(responseData)
+	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize response: %w", err)
 	}
 	
@@ -309,13 +622,18 @@ func (client *JanusClient) SendRequest(ctx context.Context, request string, args
 		return nil, fmt.Errorf("response correlation mismatch: expected %s, got %s", requestID, response.RequestID)
 	}
 	
+	client.invalidateOnManifestError(response)
+	
 	// PRIME DIRECTIVE: Channel validation removed - responses don't include channel info
 	
-	return &response, nil
+	return response, nil
 }
 
 // SendRequestNoResponse sends a request without expecting a response (fire-and-forget)
-func (client *JanusClient) SendRequestNoResponse(ctx context.Context, request string, args map[string]interface{}) error {
+// Only the Encoding of the options applies, since there is no response to time out
+func (client *JanusClient) SendRequestNoResponse(ctx context.Context, request string, args map[string]interface{}, options ...RequestOptions) error {
+	opts := mergeRequestOptions(options...)
+	
 	// Generate request ID
 	requestID := generateUUID()
 	
@@ -326,7 +644,11 @@ func (client *JanusClient) SendRequestNoResponse(ctx context.Context, request st
 	// Channels have been removed - skip validation
 	
 	// Serialize request
-	requestData, err := json.Marshal(janusRequest)
+	encoding, err := client.requestEncoding(ctx, opts.Encoding)
+	if err != nil {
+		return err
+	}
+	requestData, err := wire.EncodeRequestAs(&janusRequest, encoding)
 	if err != nil {
 		return fmt.Errorf("failed to serialize request: %w", err)
 	}
@@ -347,6 +669,13 @@ func (client *JanusClient) Close() error {
 		client.timeoutManager.Close()
 	}
 	
+	// Detach from the shared circuit breaker
+	if client.circuitUnsubscribe != nil {
+		client.circuitUnsubscribe()
+		client.circuitUnsubscribe = nil
+		releaseCircuitBreaker(client.circuitBreaker)
+	}
+	
 	// Clean up response tracker
 	if client.responseTracker != nil {
 		client.responseTracker.Shutdown()
@@ -375,9 +704,32 @@ func (client *JanusClient) GetSocketPath() string {
 
 // GetManifest returns the Manifest
 func (client *JanusClient) GetManifest() *manifest.Manifest {
+	client.manifestMutex.RLock()
+	defer client.manifestMutex.RUnlock()
 	return client.manifest
 }
 
+// LoadManifest returns the server manifest, fetching it first if it is not loaded or has expired
+// Returns nil without an error when validation is disabled or the client is degraded
+func (client *JanusClient) LoadManifest() (*manifest.Manifest, error) {
+	if err := client.ensureManifestLoaded(); err != nil {
+		return nil, err
+	}
+	return client.GetManifest(), nil
+}
+
+// requiresManifest reports whether requests need the server manifest first
+func (client *JanusClient) requiresManifest() bool {
+	return client.config.EnableValidation || client.config.ManifestVersionRange != ""
+}
+
+// IsDegraded reports whether the client runs without manifest validation after a version mismatch
+func (client *JanusClient) IsDegraded() bool {
+	client.manifestMutex.RLock()
+	defer client.manifestMutex.RUnlock()
+	return client.versionMismatch != nil
+}
+
 // Helper functions
 
 // generateUUID generates a simple UUID for request correlation
@@ -402,7 +754,8 @@ func (client *JanusClient) isBuiltinRequest(request string) bool {
 
 // RequestOptions holds options for sending requests
 type RequestOptions struct {
-	Timeout time.Duration
+	Timeout  time.Duration
+	Encoding wire.Encoding // Overrides JanusClientConfig.Encoding, with the same fallback to JSON
 }
 
 // mergeRequestOptions merges request options with defaults
@@ -415,6 +768,9 @@ func mergeRequestOptions(options ...RequestOptions) RequestOptions {
 		if option.Timeout > 0 {
 			opts.Timeout = option.Timeout
 		}
+		if option.Encoding != "" {
+			opts.Encoding = option.Encoding
+		}
 	}
 	
 	return opts
@@ -532,12 +888,12 @@ func (client *JanusClient) ChannelIdentifier() string {
 
 // Manifest returns the Manifest for backward compatibility  
 func (client *JanusClient) Manifest() *manifest.Manifest {
-	return client.manifest
+	return client.GetManifest()
 }
 
 // PublishRequest sends a request without expecting response for backward compatibility
-func (client *JanusClient) PublishRequest(ctx context.Context, request string, args map[string]interface{}) (string, error) {
-	err := client.SendRequestNoResponse(ctx, request, args)
+func (client *JanusClient) PublishRequest(ctx context.Context, request string, args map[string]interface{}, options ...RequestOptions) (string, error) {
+	err := client.SendRequestNoResponse(ctx, request, args, options...)
 	if err != nil {
 		return "", err
 	}
@@ -585,12 +941,12 @@ func (client *JanusClient) Ping(ctx context.Context) bool {
 // MARK: - Advanced Client Features (Response Correlation System)
 
 // SendRequestAsync sends a request and returns a channel for receiving the response
-func (client *JanusClient) SendRequestAsync(ctx context.Context, request string, args map[string]interface{}) (<-chan *models.JanusResponse, <-chan error) {
+func (client *JanusClient) SendRequestAsync(ctx context.Context, request string, args map[string]interface{}, options ...RequestOptions) (<-chan *models.JanusResponse, <-chan error) {
 	responseChan := make(chan *models.JanusResponse, 1)
 	errorChan := make(chan error, 1)
 
 	go func() {
-		response, err := client.SendRequest(ctx, request, args)
+		response, err := client.SendRequest(ctx, request, args, options...)
 		if err != nil {
 			errorChan <- err
 			return
@@ -602,7 +958,9 @@ func (client *JanusClient) SendRequestAsync(ctx context.Context, request string,
 }
 
 // SendRequestWithCorrelation sends a request with response correlation tracking
-func (client *JanusClient) SendRequestWithCorrelation(ctx context.Context, request string, args map[string]interface{}, timeout time.Duration) (<-chan *models.JanusResponse, <-chan error, string) {
+// Only the Encoding of the options applies; timeout is always the given one
+func (client *JanusClient) SendRequestWithCorrelation(ctx context.Context, request string, args map[string]interface{}, timeout time.Duration, options ...RequestOptions) (<-chan *models.JanusResponse, <-chan error, string) {
+	opts := mergeRequestOptions(options...)
 	requestID := generateUUID()
 	responseChan := make(chan *models.JanusResponse, 1)
 	errorChan := make(chan error, 1)
@@ -626,7 +984,7 @@ func (client *JanusClient) SendRequestWithCorrelation(ctx context.Context, reque
 		janusRequest.ReplyTo = &responseSocketPath
 
 		// Validate and send request
-		if client.config.EnableValidation {
+		if client.requiresManifest() {
 			if err := client.ensureManifestLoaded(); err != nil {
 				client.responseTracker.CancelRequest(requestID, fmt.Sprintf("Manifest loading failed: %v", err))
 				return
@@ -636,28 +994,34 @@ func (client *JanusClient) SendRequestWithCorrelation(ctx context.Context, reque
 		}
 
 		// Serialize and send request
-		requestData, err := json.Marshal(janusRequest)
+		encoding, err := client.requestEncoding(ctx, opts.Encoding)
+		if err != nil {
+			client.responseTracker.CancelRequest(requestID, err.Error())
+			return
+		}
+		requestData, err := wire.EncodeRequestAs(&janusRequest, encoding)
 		if err != nil {
 			client.responseTracker.CancelRequest(requestID, fmt.Sprintf("failed to serialize request: %v", err))
 			return
 		}
 
 		// Send datagram and wait for response
-		responseData, err := client.janusClient.SendDatagram(ctx, requestData, responseSocketPath)
+		responseData, err := client.sendDatagram(ctx, requestData, responseSocketPath)
 		if err != nil {
 			client.responseTracker.CancelRequest(requestID, fmt.Sprintf("failed to send request datagram: %v", err))
 			return
 		}
 
 		// Parse response
-		var response models.JanusResponse
-		if err := json.Unmarshal(responseData, &response); err != nil {
+		response, err := wire.DecodeResponse(responseData)
+		if err != nil {
 			client.responseTracker.CancelRequest(requestID, fmt.Sprintf("failed to deserialize response: %v", err))
 			return
 		}
 
 		// Handle response through tracker
-		client.responseTracker.HandleResponse(&response)
+		client.invalidateOnManifestError(response)
+		client.responseTracker.HandleResponse(response)
 	}()
 
 	return responseChan, errorChan, requestID
@@ -681,8 +1045,30 @@ func (client *JanusClient) IsRequestPending(requestID string) bool {
 }
 
 // GetRequestStatistics returns statistics about pending requests
+// Includes circuit breaker state when the breaker is enabled
 func (client *JanusClient) GetRequestStatistics() RequestStatistics {
-	return client.responseTracker.GetStatistics()
+	stats := client.responseTracker.GetStatistics()
+	if client.circuitBreaker != nil {
+		breakerStats := client.circuitBreaker.Statistics()
+		stats.CircuitBreaker = &breakerStats
+	}
+	return stats
+}
+
+// GetCircuitState returns the circuit breaker state for this client's socket
+// Always CircuitClosed when the breaker is disabled
+func (client *JanusClient) GetCircuitState() CircuitState {
+	if client.circuitBreaker == nil {
+		return CircuitClosed
+	}
+	return client.circuitBreaker.State()
+}
+
+// On registers a handler for client events
+// Events: "response", "timeout", "cleanup", "circuit_state_change" (data is CircuitStateChange),
+// "version_mismatch" (data is *models.JSONRPCError)
+func (client *JanusClient) On(event string, handler func(interface{})) {
+	client.responseTracker.On(event, handler)
 }
 
 // ExecuteRequestsInParallel executes multiple requests in parallel
@@ -695,7 +1081,7 @@ func (client *JanusClient) ExecuteRequestsInParallel(ctx context.Context, reques
 		go func(index int, request ParallelRequest) {
 			defer wg.Done()
 
-			response, err := client.SendRequest(ctx, request.Request, request.Args)
+			response, err := client.SendRequest(ctx, request.Request, request.Args, RequestOptions{Encoding: request.Encoding})
 			results[index] = ParallelResult{
 				RequestID: request.ID,
 				Response:  response,
@@ -713,9 +1099,10 @@ func (client *JanusClient) ExecuteRequestsInParallel(ctx context.Context, reques
 
 // ParallelRequest represents a request to be executed in parallel
 type ParallelRequest struct {
-	ID      string                 `json:"id"`
-	Request string                 `json:"request"`
-	Args    map[string]interface{} `json:"args"`
+	ID       string                 `json:"id"`
+	Request  string                 `json:"request"`
+	Args     map[string]interface{} `json:"args"`
+	Encoding wire.Encoding          `json:"encoding,omitempty"` // Overrides JanusClientConfig.Encoding for this request
 }
 
 // ParallelResult represents the result of a parallel request execution  
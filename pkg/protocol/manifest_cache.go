@@ -0,0 +1,162 @@
+package protocol
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"GoJanus/pkg/manifest"
+)
+
+// CachedManifest is a manifest remembered between clients together with its content hash
+type CachedManifest struct {
+	Manifest  *manifest.Manifest `json:"manifest"`
+	Hash      string             `json:"hash"`
+	FetchedAt time.Time          `json:"fetched_at"` // Last fetch or successful revalidation
+}
+
+// ManifestCache stores manifests keyed by socket path and version range
+// Implementations must be safe for concurrent use
+type ManifestCache interface {
+	Get(key string) (*CachedManifest, bool)
+	Put(key string, entry *CachedManifest) error
+	Invalidate(key string)
+}
+
+// manifestCacheKey identifies the manifest a client would negotiate
+func manifestCacheKey(socketPath, versionRange string) string {
+	return socketPath + "#" + versionRange
+}
+
+// MemoryManifestCache keeps manifests in process memory
+// Share one instance between clients to skip the startup manifest round-trip
+type MemoryManifestCache struct {
+	mutex   sync.RWMutex
+	entries map[string]CachedManifest
+}
+
+// NewMemoryManifestCache creates an empty in-memory cache
+func NewMemoryManifestCache() *MemoryManifestCache {
+	return &MemoryManifestCache{entries: make(map[string]CachedManifest)}
+}
+
+// Get returns a copy of the cached entry
+func (c *MemoryManifestCache) Get(key string) (*CachedManifest, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entry, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Put stores a copy of the entry
+func (c *MemoryManifestCache) Put(key string, entry *CachedManifest) error {
+	if entry == nil || entry.Manifest == nil {
+		return fmt.Errorf("cached manifest cannot be nil")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[key] = *entry
+	return nil
+}
+
+// Invalidate removes the entry
+func (c *MemoryManifestCache) Invalidate(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.entries, key)
+}
+
+// FileManifestCache keeps manifests as JSON files in a directory, surviving process restarts
+type FileManifestCache struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+// NewFileManifestCache creates a cache in dir, creating the directory if needed
+func NewFileManifestCache(dir string) (*FileManifestCache, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("manifest cache directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create manifest cache directory: %w", err)
+	}
+	return &FileManifestCache{dir: dir}, nil
+}
+
+// path maps a key to a file name that is safe whatever the socket path contains
+func (c *FileManifestCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// Get reads the entry; unreadable or invalid files are treated as misses
+func (c *FileManifestCache) Get(key string) (*CachedManifest, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry CachedManifest
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Manifest == nil || entry.Hash == "" {
+		return nil, false
+	}
+	if err := entry.Manifest.Validate(); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Put writes the entry atomically
+func (c *FileManifestCache) Put(key string, entry *CachedManifest) error {
+	if entry == nil || entry.Manifest == nil {
+		return fmt.Errorf("cached manifest cannot be nil")
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to serialize cached manifest: %w", err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	path := c.path(key)
+	tmp, err := os.CreateTemp(c.dir, ".manifest-*")
+	if err != nil {
+		return fmt.Errorf("failed to write manifest cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write manifest cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write manifest cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write manifest cache: %w", err)
+	}
+	return nil
+}
+
+// Invalidate removes the entry's file
+func (c *FileManifestCache) Invalidate(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	os.Remove(c.path(key))
+}
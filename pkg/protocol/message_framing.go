@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"GoJanus/pkg/models"
+	"GoJanus/pkg/wire"
 )
 
 const (
@@ -17,7 +18,10 @@ const (
 
 
 // MessageFraming provides message framing functionality with 4-byte length prefix
-type MessageFraming struct{}
+// Messages are encoded with Encoding (JSON when empty); decoding accepts both encodings
+type MessageFraming struct {
+	Encoding wire.Encoding
+}
 
 // SocketMessage represents the message envelope for framing
 type SocketMessage struct {
@@ -27,29 +31,10 @@ type SocketMessage struct {
 
 // EncodeMessage encodes a message with 4-byte big-endian length prefix
 func (mf *MessageFraming) EncodeMessage(message interface{}) ([]byte, error) {
-	// Determine message type
-	var messageType string
-	switch message.(type) {
-	case models.JanusRequest, *models.JanusRequest:
-		messageType = "request"
-	case models.JanusResponse, *models.JanusResponse:
-		messageType = "response"
-	default:
-		return nil, &models.JSONRPCError{
-			Code:    models.MessageFramingError,
-			Message: "Invalid message type",
-			Data:    &models.JSONRPCErrorData{Details: "INVALID_MESSAGE_TYPE"},
-		}
-	}
-
-	// Serialize payload to JSON
-	payloadBytes, err := json.Marshal(message)
+	// Serialize payload in the wire format
+	messageType, payloadBytes, err := encodePayload(message, mf.Encoding)
 	if err != nil {
-		return nil, &models.JSONRPCError{
-			Code:    models.MessageFramingError,
-			Message: fmt.Sprintf("Failed to marshal payload: %v", err),
-			Data:    &models.JSONRPCErrorData{Details: "MARSHAL_FAILED"},
-		}
+		return nil, err
 	}
 
 	// Create envelope with base64 payload
@@ -58,8 +43,13 @@ func (mf *MessageFraming) EncodeMessage(message interface{}) ([]byte, error) {
 		Payload: string(payloadBytes), // Direct JSON for efficiency
 	}
 
-	// Serialize envelope to JSON
-	envelopeBytes, err := json.Marshal(envelope)
+	// Serialize envelope; a CBOR envelope carries the payload as a byte string
+	var envelopeBytes []byte
+	if mf.Encoding == wire.CBOR {
+		envelopeBytes, err = wire.MarshalCBOR(map[string]interface{}{"type": envelope.Type, "payload": payloadBytes})
+	} else {
+		envelopeBytes, err = json.Marshal(envelope)
+	}
 	if err != nil {
 		return nil, &models.JSONRPCError{
 			Code:    models.MessageFramingError,
@@ -134,14 +124,10 @@ func (mf *MessageFraming) DecodeMessage(buffer []byte) (interface{}, []byte, err
 	messageBuffer := buffer[LengthPrefixSize : LengthPrefixSize+int(messageLength)]
 	remainingBuffer := buffer[LengthPrefixSize+int(messageLength):]
 
-	// Parse JSON envelope
-	var envelope SocketMessage
-	if err := json.Unmarshal(messageBuffer, &envelope); err != nil {
-		return nil, buffer, &models.JSONRPCError{
-			Code:    models.MessageFramingError,
-			Message: fmt.Sprintf("Failed to parse message envelope JSON: %v", err),
-			Data:    &models.JSONRPCErrorData{Details: "INVALID_JSON_ENVELOPE"},
-		}
+	// Parse envelope
+	envelope, err := decodeEnvelope(messageBuffer)
+	if err != nil {
+		return nil, buffer, err
 	}
 
 	// Validate envelope structure
@@ -162,37 +148,9 @@ func (mf *MessageFraming) DecodeMessage(buffer []byte) (interface{}, []byte, err
 	}
 
 	// Parse payload JSON directly (no base64 decoding needed)
-	var message interface{}
-	if envelope.Type == "request" {
-		var cmd models.JanusRequest
-		if err := json.Unmarshal([]byte(envelope.Payload), &cmd); err != nil {
-			return nil, buffer, &models.JSONRPCError{
-				Code:    models.MessageFramingError,
-				Message: fmt.Sprintf("Failed to parse request payload JSON: %v", err),
-				Data:    &models.JSONRPCErrorData{Details: "INVALID_PAYLOAD_JSON"},
-			}
-		}
-		
-		// Validate request structure
-		if err := mf.validateRequestStructure(&cmd); err != nil {
-			return nil, buffer, err
-		}
-		message = cmd
-	} else {
-		var resp models.JanusResponse
-		if err := json.Unmarshal([]byte(envelope.Payload), &resp); err != nil {
-			return nil, buffer, &models.JSONRPCError{
-				Code:    models.MessageFramingError,
-				Message: fmt.Sprintf("Failed to parse response payload JSON: %v", err),
-				Data:    &models.JSONRPCErrorData{Details: "INVALID_PAYLOAD_JSON"},
-			}
-		}
-		
-		// Validate response structure
-		if err := mf.validateResponseStructure(&resp); err != nil {
-			return nil, buffer, err
-		}
-		message = resp
+	message, err := decodePayload(envelope.Type, []byte(envelope.Payload))
+	if err != nil {
+		return nil, buffer, err
 	}
 
 	return message, remainingBuffer, nil
@@ -231,16 +189,12 @@ func (mf *MessageFraming) CalculateFramedSize(message interface{}) (int, error)
 	return len(encoded), nil
 }
 
-// EncodeDirectMessage creates a direct JSON message for simple cases (without envelope)
+// EncodeDirectMessage creates a direct message for simple cases (without envelope)
 func (mf *MessageFraming) EncodeDirectMessage(message interface{}) ([]byte, error) {
-	// Serialize message to JSON
-	messageBytes, err := json.Marshal(message)
+	// Serialize message in the wire format
+	_, messageBytes, err := encodePayload(message, mf.Encoding)
 	if err != nil {
-		return nil, &models.JSONRPCError{
-			Code:    models.MessageFramingError,
-			Message: fmt.Sprintf("Failed to marshal message: %v", err),
-			Data:    &models.JSONRPCErrorData{Details: "MARSHAL_FAILED"},
-		}
+		return nil, err
 	}
 
 	// Validate message size
@@ -264,7 +218,7 @@ func (mf *MessageFraming) EncodeDirectMessage(message interface{}) ([]byte, erro
 	return result, nil
 }
 
-// DecodeDirectMessage decodes a direct JSON message (without envelope)
+// DecodeDirectMessage decodes a direct JSON or CBOR message (without envelope)
 func (mf *MessageFraming) DecodeDirectMessage(buffer []byte) (interface{}, []byte, error) {
 	// Check length prefix
 	if len(buffer) < LengthPrefixSize {
@@ -292,7 +246,16 @@ func (mf *MessageFraming) DecodeDirectMessage(buffer []byte) (interface{}, []byt
 
 	// Try to determine message type by looking for key fields
 	var rawMessage map[string]interface{}
-	if err := json.Unmarshal(messageBuffer, &rawMessage); err != nil {
+	if wire.IsCBOR(messageBuffer) {
+		decoded, err := wire.UnmarshalCBOR(messageBuffer)
+		if rawMessage, _ = decoded.(map[string]interface{}); err != nil || rawMessage == nil {
+			return nil, buffer, &models.JSONRPCError{
+				Code:    models.MessageFramingError,
+				Message: fmt.Sprintf("Failed to parse message CBOR: %v", err),
+				Data:    &models.JSONRPCErrorData{Details: "INVALID_CBOR"},
+			}
+		}
+	} else if err := json.Unmarshal(messageBuffer, &rawMessage); err != nil {
 		return nil, buffer, &models.JSONRPCError{
 			Code:    models.MessageFramingError,
 			Message: fmt.Sprintf("Failed to parse message JSON: %v", err),
@@ -301,27 +264,11 @@ func (mf *MessageFraming) DecodeDirectMessage(buffer []byte) (interface{}, []byt
 	}
 
 	// Determine message type and parse accordingly
-	var message interface{}
+	messageType := ""
 	if _, hasRequest := rawMessage["request"]; hasRequest {
-		var cmd models.JanusRequest
-		if err := json.Unmarshal(messageBuffer, &cmd); err != nil {
-			return nil, buffer, &models.JSONRPCError{
-				Code:    models.MessageFramingError,
-				Message: fmt.Sprintf("Failed to parse request: %v", err),
-				Data:    &models.JSONRPCErrorData{Details: "INVALID_REQUEST"},
-			}
-		}
-		message = cmd
-	} else if _, hasRequestId := rawMessage["requestId"]; hasRequestId {
-		var resp models.JanusResponse
-		if err := json.Unmarshal(messageBuffer, &resp); err != nil {
-			return nil, buffer, &models.JSONRPCError{
-				Code:    models.MessageFramingError,
-				Message: fmt.Sprintf("Failed to parse response: %v", err),
-				Data:    &models.JSONRPCErrorData{Details: "INVALID_RESPONSE"},
-			}
-		}
-		message = resp
+		messageType = "request"
+	} else if _, hasRequestId := rawMessage["request_id"]; hasRequestId {
+		messageType = "response"
 	} else {
 		return nil, buffer, &models.JSONRPCError{
 			Code:    models.MessageFramingError,
@@ -329,40 +276,103 @@ func (mf *MessageFraming) DecodeDirectMessage(buffer []byte) (interface{}, []byt
 			Data:    &models.JSONRPCErrorData{Details: "UNKNOWN_MESSAGE_TYPE"},
 		}
 	}
+	message, err := decodePayload(messageType, messageBuffer)
+	if err != nil {
+		return nil, buffer, err
+	}
 
 	return message, remainingBuffer, nil
 }
 
-// validateRequestStructure validates request structure
-func (mf *MessageFraming) validateRequestStructure(cmd *models.JanusRequest) error {
-	if cmd.ID == "" {
-		return &models.JSONRPCError{
+// decodeEnvelope parses a JSON envelope, or a CBOR envelope whose payload is a byte string
+func decodeEnvelope(data []byte) (SocketMessage, error) {
+	var envelope SocketMessage
+	if !wire.IsCBOR(data) {
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return envelope, &models.JSONRPCError{
+				Code:    models.MessageFramingError,
+				Message: fmt.Sprintf("Failed to parse message envelope JSON: %v", err),
+				Data:    &models.JSONRPCErrorData{Details: "INVALID_JSON_ENVELOPE"},
+			}
+		}
+		return envelope, nil
+	}
+	
+	decoded, err := wire.UnmarshalCBOR(data)
+	fields, isMap := decoded.(map[string]interface{})
+	messageType, typeOK := fields["type"].(string)
+	payload, payloadOK := fields["payload"].([]byte)
+	if err != nil || !isMap || !typeOK || !payloadOK {
+		if err == nil {
+			err = fmt.Errorf("expected a map with a text type and a byte string payload")
+		}
+		return envelope, &models.JSONRPCError{
+			Code:    models.MessageFramingError,
+			Message: fmt.Sprintf("Failed to parse message envelope CBOR: %v", err),
+			Data:    &models.JSONRPCErrorData{Details: "INVALID_CBOR_ENVELOPE"},
+		}
+	}
+	envelope.Type, envelope.Payload = messageType, string(payload)
+	return envelope, nil
+}
+
+// encodePayload validates a request or response and encodes it in the wire format
+func encodePayload(message interface{}, encoding wire.Encoding) (string, []byte, error) {
+	var messageType string
+	var data []byte
+	var err error
+	switch m := message.(type) {
+	case models.JanusRequest:
+		messageType = "request"
+		data, err = wire.EncodeRequestAs(&m, encoding)
+	case *models.JanusRequest:
+		messageType = "request"
+		data, err = wire.EncodeRequestAs(m, encoding)
+	case models.JanusResponse:
+		messageType = "response"
+		data, err = wire.EncodeResponseAs(&m, encoding)
+	case *models.JanusResponse:
+		messageType = "response"
+		data, err = wire.EncodeResponseAs(m, encoding)
+	default:
+		return "", nil, &models.JSONRPCError{
 			Code:    models.MessageFramingError,
-			Message: "Request missing required string field: id",
-			Data:    &models.JSONRPCErrorData{Details: "MISSING_REQUEST_FIELD"},
+			Message: "Invalid message type",
+			Data:    &models.JSONRPCErrorData{Details: "INVALID_MESSAGE_TYPE"},
 		}
 	}
-	if cmd.Request == "" {
-		return &models.JSONRPCError{
+	if err != nil {
+		return "", nil, &models.JSONRPCError{
 			Code:    models.MessageFramingError,
-			Message: "Request missing required string field: request",
-			Data:    &models.JSONRPCErrorData{Details: "MISSING_REQUEST_FIELD"},
+			Message: fmt.Sprintf("Failed to marshal payload: %v", err),
+			Data:    &models.JSONRPCErrorData{Details: "MARSHAL_FAILED"},
 		}
 	}
-	return nil
+	return messageType, data, nil
 }
 
-// validateResponseStructure validates response structure
-func (mf *MessageFraming) validateResponseStructure(resp *models.JanusResponse) error {
-	if resp.RequestID == "" {
-		return &models.JSONRPCError{
+// decodePayload strictly decodes a request or response payload, returning the message by value
+func decodePayload(messageType string, data []byte) (interface{}, error) {
+	if messageType == "request" {
+		request, err := wire.DecodeRequest(data)
+		if err != nil {
+			return nil, &models.JSONRPCError{
+				Code:    models.MessageFramingError,
+				Message: fmt.Sprintf("Invalid request payload: %v", err),
+				Data:    &models.JSONRPCErrorData{Details: "INVALID_REQUEST"},
+			}
+		}
+		return *request, nil
+	}
+	response, err := wire.DecodeResponse(data)
+	if err != nil {
+		return nil, &models.JSONRPCError{
 			Code:    models.MessageFramingError,
-			Message: "Response missing required field: requestId",
-			Data:    &models.JSONRPCErrorData{Details: "MISSING_RESPONSE_FIELD"},
+			Message: fmt.Sprintf("Invalid response payload: %v", err),
+			Data:    &models.JSONRPCErrorData{Details: "INVALID_RESPONSE"},
 		}
 	}
-	// PRIME DIRECTIVE: Response no longer includes channelId field
-	return nil
+	return *response, nil
 }
 
 // NewMessageFraming creates a new MessageFraming instance
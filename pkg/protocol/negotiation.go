@@ -0,0 +1,177 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/wire"
+)
+
+// NegotiationResult describes the versions agreed with a server
+type NegotiationResult struct {
+	ProtocolVersion  string          // Server protocol version, empty for servers that do not report one
+	ManifestVersion  string          // Version of the manifest in use, empty when degraded
+	ManifestVersions []string        // All manifest versions the server offers
+	Encodings        []wire.Encoding // Request encodings the server accepts
+	Encoding         wire.Encoding   // Encoding requests are sent in
+	Degraded         bool            // True when running without manifest validation after a mismatch
+}
+
+// Negotiate checks protocol and manifest compatibility with the server and loads the manifest
+// Call it at startup to refuse early; an incompatible protocol major version always fails,
+// while a manifest outside ManifestVersionRange fails or degrades according to VersionMismatch
+func (client *JanusClient) Negotiate(ctx context.Context) (*NegotiationResult, error) {
+	info, err := client.fetchServerInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &NegotiationResult{Encodings: client.recordServerEncodings(info)}
+	result.ProtocolVersion, _ = info["protocol_version"].(string)
+	if versions, ok := info["manifest_versions"].([]interface{}); ok {
+		for _, version := range versions {
+			if versionStr, ok := version.(string); ok {
+				result.ManifestVersions = append(result.ManifestVersions, versionStr)
+			}
+		}
+	}
+
+	if err := checkProtocolVersion(result.ProtocolVersion); err != nil {
+		return nil, err
+	}
+	if result.Encoding, err = client.requestEncoding(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	// The manifest request carries the client range; mismatches fail or degrade there
+	if err := client.ensureManifestLoaded(); err != nil {
+		return nil, err
+	}
+
+	if current := client.GetManifest(); current != nil {
+		result.ManifestVersion = current.Version
+	}
+	result.Degraded = client.IsDegraded()
+	return result, nil
+}
+
+// fetchServerInfo sends get_info in JSON, which every server accepts
+func (client *JanusClient) fetchServerInfo(ctx context.Context) (map[string]interface{}, error) {
+	cfg := client.config
+	cfg.Encoding = wire.JSON
+	response, err := sendBuiltinRequest(ctx, client.sendDatagram, cfg, "get_info", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch server info: %w", err)
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("server returned error: %w", response.Error)
+	}
+	info, _ := response.Result.(map[string]interface{})
+	return info, nil
+}
+
+// recordServerEncodings remembers the encodings listed in get_info
+// Servers that list none predate CBOR and only accept JSON
+func (client *JanusClient) recordServerEncodings(info map[string]interface{}) []wire.Encoding {
+	var encodings []wire.Encoding
+	if list, ok := info["encodings"].([]interface{}); ok {
+		for _, name := range list {
+			if encoding, ok := name.(string); ok {
+				encodings = append(encodings, wire.Encoding(encoding))
+			}
+		}
+	}
+	if len(encodings) == 0 {
+		encodings = []wire.Encoding{wire.JSON}
+	}
+
+	client.encodingMutex.Lock()
+	client.serverEncodings = encodings
+	client.encodingRetryAt = time.Time{}
+	client.encodingMutex.Unlock()
+	return encodings
+}
+
+// requestEncoding returns the encoding to send a request in: requested, or the configured encoding
+// when empty, falling back to JSON when the server does not list it in get_info
+// The server is asked once, the first time an encoding other than JSON is needed; when get_info
+// fails, requests go out in JSON and the server is asked again after the cool-down
+func (client *JanusClient) requestEncoding(ctx context.Context, requested wire.Encoding) (wire.Encoding, error) {
+	if requested == "" {
+		requested = client.config.Encoding
+	}
+	encoding, err := wire.ParseEncoding(string(requested))
+	if err != nil {
+		return "", models.NewJSONRPCError(models.InvalidRequest, err.Error())
+	}
+	if encoding == wire.JSON {
+		return wire.JSON, nil
+	}
+
+	client.encodingMutex.Lock()
+	offered := client.serverEncodings
+	if !client.encodingRetryAt.IsZero() && time.Now().After(client.encodingRetryAt) {
+		offered = nil // The JSON fallback expired, ask the server again
+	}
+	client.encodingMutex.Unlock()
+	if offered == nil {
+		info, err := client.fetchServerInfo(ctx)
+		if err != nil {
+			log.Printf("[GO-PROTOCOL] Encoding negotiation failed, sending JSON: %v", err)
+			offered = client.recordEncodingFallback()
+		} else {
+			offered = client.recordServerEncodings(info)
+		}
+	}
+
+	for _, candidate := range offered {
+		if candidate == encoding {
+			return encoding, nil
+		}
+	}
+	return wire.JSON, nil
+}
+
+// recordEncodingFallback limits requests to JSON after get_info failed
+// The fallback lasts for the circuit breaker cool-down, so a failing server is not asked on every request
+func (client *JanusClient) recordEncodingFallback() []wire.Encoding {
+	coolDown := DefaultCircuitBreakerConfig().CoolDown
+	if client.config.CircuitBreaker != nil {
+		coolDown = client.config.CircuitBreaker.withDefaults().CoolDown
+	}
+	encodings := []wire.Encoding{wire.JSON}
+
+	client.encodingMutex.Lock()
+	client.serverEncodings = encodings
+	client.encodingRetryAt = time.Now().Add(coolDown)
+	client.encodingMutex.Unlock()
+	return encodings
+}
+
+// checkProtocolVersion fails when the server speaks a different protocol major version
+func checkProtocolVersion(serverVersion string) error {
+	if serverVersion == "" {
+		return nil // Servers predating negotiation speak protocol 1.x
+	}
+
+	server, err := manifest.ParseSemanticVersion(serverVersion)
+	if err != nil {
+		return models.NewJSONRPCError(models.ConfigurationError, fmt.Sprintf("server reported invalid protocol version '%s'", serverVersion))
+	}
+	local, _ := manifest.ParseSemanticVersion(models.ProtocolVersion)
+	if server.Major != local.Major {
+		return models.NewJSONRPCErrorWithContext(
+			models.ConfigurationError,
+			fmt.Sprintf("server protocol version %s is incompatible with client protocol version %s", serverVersion, models.ProtocolVersion),
+			map[string]interface{}{
+				"server_protocol_version": serverVersion,
+				"client_protocol_version": models.ProtocolVersion,
+			},
+		)
+	}
+	return nil
+}
@@ -280,6 +280,7 @@ type RequestStatistics struct {
 	AverageAge     float64              `json:"averageAge"`
 	OldestRequest  *RequestInfo         `json:"oldestRequest,omitempty"`
 	NewestRequest  *RequestInfo         `json:"newestRequest,omitempty"`
+	CircuitBreaker *CircuitBreakerStats `json:"circuitBreaker,omitempty"`
 }
 
 // RequestInfo holds information about a request
@@ -117,11 +117,11 @@ func (tm *TimeoutManager) ExtendTimeout(requestID string, extension time.Duratio
 	// Stop the existing timer
 	entry.timer.Stop()
 	
-	// Create a new timer with extended duration
+	// Create a new timer that fires at the extended deadline
 	newTimeout := entry.timeout + extension
 	entry.timeout = newTimeout
 	
-	entry.timer = time.AfterFunc(newTimeout, func() {
+	entry.timer = time.AfterFunc(time.Until(entry.registeredAt.Add(newTimeout)), func() {
 		tm.mutex.Lock()
 		delete(tm.timeouts, requestID)
 		tm.stats.totalExpired++
@@ -0,0 +1,94 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"GoJanus/pkg/models"
+)
+
+// Call sends a request whose arguments are built from a Go struct and decodes the result into Resp
+// Struct fields are mapped to argument names through their json tags
+//
+// Example:
+//   type GetUserArgs struct { UserID string `json:"user_id"` }
+//   type User struct { ID string `json:"id"`; Name string `json:"name"` }
+//
+//   user, err := protocol.Call[GetUserArgs, User](ctx, client, "get_user", GetUserArgs{UserID: "42"})
+//   if rpcErr, ok := models.AsJSONRPCError(err); ok && rpcErr.Code == models.ResourceNotFound { ... }
+func Call[Req, Resp any](ctx context.Context, client *JanusClient, request string, req Req, options ...RequestOptions) (Resp, error) {
+	var result Resp
+	
+	args, err := EncodeArgs(req)
+	if err != nil {
+		return result, err
+	}
+	
+	response, err := client.SendRequest(ctx, request, args, options...)
+	if err != nil {
+		return result, err
+	}
+	
+	if err := ResponseError(response); err != nil {
+		return result, err
+	}
+	
+	if err := DecodeResult(response.Result, &result); err != nil {
+		return result, fmt.Errorf("failed to decode '%s' result: %w", request, err)
+	}
+	
+	return result, nil
+}
+
+// EncodeArgs converts a Go value into request arguments using its json tags
+// nil values and empty structs produce no arguments; anything not encoding to a JSON object is rejected
+func EncodeArgs(value interface{}) (map[string]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	
+	if args, ok := value.(map[string]interface{}); ok {
+		return args, nil
+	}
+	
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request arguments: %w", err)
+	}
+	
+	if string(data) == "null" {
+		return nil, nil
+	}
+	
+	var args map[string]interface{}
+	if err := json.Unmarshal(data, &args); err != nil {
+		return nil, fmt.Errorf("request arguments must encode to a JSON object, got %s", data)
+	}
+	
+	if len(args) == 0 {
+		return nil, nil
+	}
+	
+	return args, nil
+}
+
+// DecodeResult converts an unwrapped response result into a Go value using its json tags
+func DecodeResult(result interface{}, target interface{}) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// ResponseError returns the response's error as a typed *models.JSONRPCError, or nil on success
+func ResponseError(response *models.JanusResponse) error {
+	if response.Error != nil {
+		return response.Error
+	}
+	if !response.Success {
+		return models.NewJSONRPCError(models.InternalError, "response reported failure without error details")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"GoJanus/pkg/models"
+)
+
+// direction marks which way a logged datagram travels
+type direction string
+
+const (
+	directionRequest  direction = "→" // Client to server
+	directionResponse direction = "←" // Server to client
+)
+
+// logf writes a line to the configured log
+func (p *Proxy) logf(format string, args ...interface{}) {
+	if p.config.Log == nil {
+		return
+	}
+	p.logMutex.Lock()
+	defer p.logMutex.Unlock()
+	fmt.Fprintf(p.config.Log, format, args...)
+}
+
+// logDatagram writes a summary line and, in pretty mode, the indented datagram
+func (p *Proxy) logDatagram(dir direction, request, id string, data []byte, summary string) {
+	if p.config.Log == nil {
+		return
+	}
+	line := fmt.Sprintf("%s %s %s id=%s (%d bytes)", time.Now().Format("15:04:05.000"), dir, request, id, len(data))
+	if summary != "" {
+		line += " " + summary
+	}
+
+	var body bytes.Buffer
+	if p.config.Pretty && json.Indent(&body, data, "    ", "  ") == nil {
+		p.logf("%s\n    %s\n", line, body.String())
+		return
+	}
+	p.logf("%s\n", line)
+}
+
+// logInvalid reports a datagram that could not be decoded
+func (p *Proxy) logInvalid(dir direction, data []byte, err error) {
+	preview := data
+	if len(preview) > 120 {
+		preview = preview[:120]
+	}
+	p.logf("%s %s undecodable datagram (%d bytes): %v: %q\n", time.Now().Format("15:04:05.000"), dir, len(data), err, preview)
+}
+
+// responseSummary describes the outcome of a response, naming error codes
+func responseSummary(response *models.JanusResponse, elapsed time.Duration) string {
+	elapsed = elapsed.Round(time.Microsecond)
+	if rpcErr := response.Error; rpcErr != nil {
+		message := rpcErr.Message
+		if rpcErr.Data != nil && rpcErr.Data.Details != "" {
+			message += ": " + rpcErr.Data.Details
+		}
+		return fmt.Sprintf("error %s (%d) %q %v", rpcErr.Code.String(), int(rpcErr.Code), message, elapsed)
+	}
+	return fmt.Sprintf("ok %v", elapsed)
+}
@@ -0,0 +1,503 @@
+// Package proxy relays datagrams between Janus clients and a server while logging,
+// filtering, delaying or dropping them, without changes to either peer
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/traffic"
+	"GoJanus/pkg/wire"
+
+	"github.com/google/uuid"
+)
+
+// Config configures a proxy
+type Config struct {
+	ListenPath   string // Socket clients send requests to
+	UpstreamPath string // Socket of the server requests are forwarded to
+	ReplyPath    string // Socket the server replies to; defaults to ListenPath + ".reply"
+
+	// Request name patterns (path.Match syntax, e.g. "get_*") selecting the traffic that is
+	// logged, dumped and subject to fault injection; empty selects everything
+	Requests []string
+
+	Latency  time.Duration // Added before forwarding each selected datagram, in both directions
+	Jitter   time.Duration // Random extra latency up to this amount
+	LossRate float64       // Fraction of selected datagrams dropped, in both directions
+	Seed     int64         // Seeds jitter and loss; 0 picks a random seed
+
+	Log    io.Writer         // Receives one summary line per datagram; nil disables logging
+	Pretty bool              // Also log each datagram as indented JSON
+	Dump   *traffic.Recorder // Receives every selected exchange, readable by `janus replay`
+
+	PendingTimeout time.Duration // How long to wait for a response before forgetting a request; defaults to 1 minute
+	MaxMessageSize int           // Largest datagram relayed; defaults to 64KB
+}
+
+// Stats counts datagrams handled by a proxy
+type Stats struct {
+	Requests  int // Requests forwarded upstream
+	Responses int // Responses relayed to clients
+	Dropped   int // Datagrams dropped by loss injection
+	Unmatched int // Responses without a pending request, e.g. after a timeout
+	Invalid   int // Datagrams that could not be decoded; such requests are still forwarded unchanged
+	Pending   int // Forwarded requests still awaiting a response
+}
+
+// pendingKey identifies a request by the client's reply address and the client's request id,
+// so clients that happen to pick the same id do not collide
+type pendingKey struct {
+	replyTo string
+	id      string
+}
+
+// pendingRequest remembers where to relay the response to a forwarded request
+type pendingRequest struct {
+	upstreamID string // Id the request was forwarded under
+	replyTo    string
+	client     string
+	request    *models.JanusRequest
+	selected   bool
+	started    time.Time
+}
+
+// Proxy relays Janus datagrams between clients and an upstream server
+type Proxy struct {
+	config Config
+
+	mutex    sync.Mutex
+	logMutex sync.Mutex
+	random   *rand.Rand
+	pending  map[pendingKey]*pendingRequest
+	upstream map[string]pendingKey // Upstream id of each pending request
+	stats    Stats
+	running  bool
+	listener *net.UnixConn
+	replies  *net.UnixConn
+}
+
+// New validates the configuration and creates a proxy
+func New(config Config) (*Proxy, error) {
+	if config.ListenPath == "" || config.UpstreamPath == "" {
+		return nil, fmt.Errorf("listen and upstream socket paths are required")
+	}
+	if config.ListenPath == config.UpstreamPath {
+		return nil, fmt.Errorf("listen and upstream socket paths must differ")
+	}
+	if config.ReplyPath == "" {
+		config.ReplyPath = config.ListenPath + ".reply"
+	}
+	if config.LossRate < 0 || config.LossRate > 1 {
+		return nil, fmt.Errorf("loss rate must be between 0 and 1")
+	}
+	if config.Latency < 0 || config.Jitter < 0 {
+		return nil, fmt.Errorf("latency and jitter cannot be negative")
+	}
+	for _, pattern := range config.Requests {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid request pattern '%s': %w", pattern, err)
+		}
+	}
+	if config.PendingTimeout <= 0 {
+		config.PendingTimeout = time.Minute
+	}
+	if config.MaxMessageSize <= 0 {
+		config.MaxMessageSize = 64 * 1024
+	}
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+
+	return &Proxy{
+		config:   config,
+		random:   rand.New(rand.NewSource(config.Seed)),
+		pending:  make(map[pendingKey]*pendingRequest),
+		upstream: make(map[string]pendingKey),
+	}, nil
+}
+
+// ReplyPath returns the socket upstream responses are sent to
+func (p *Proxy) ReplyPath() string {
+	return p.config.ReplyPath
+}
+
+// Stats returns the datagram counters
+func (p *Proxy) Stats() Stats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	stats := p.stats
+	stats.Pending = len(p.pending)
+	return stats
+}
+
+// StartListening binds the listen and reply sockets and relays datagrams
+// This method blocks until the proxy is stopped
+func (p *Proxy) StartListening() error {
+	listener, err := listenUnixgram(p.config.ListenPath)
+	if err != nil {
+		return err
+	}
+	replies, err := listenUnixgram(p.config.ReplyPath)
+	if err != nil {
+		listener.Close()
+		os.Remove(p.config.ListenPath)
+		return err
+	}
+	defer func() {
+		os.Remove(p.config.ListenPath)
+		os.Remove(p.config.ReplyPath)
+	}()
+
+	p.mutex.Lock()
+	p.listener, p.replies, p.running = listener, replies, true
+	p.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.readLoop(replies, p.handleResponse)
+		close(done)
+	}()
+	go p.expireLoop(done)
+	p.readLoop(listener, p.handleRequest)
+	replies.Close()
+	<-done
+	return nil
+}
+
+// expireLoop forgets requests that waited longer than PendingTimeout until done is closed
+func (p *Proxy) expireLoop(done <-chan struct{}) {
+	ticker := time.NewTicker(p.config.PendingTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			p.expire()
+		}
+	}
+}
+
+// expire removes pending requests older than PendingTimeout
+func (p *Proxy) expire() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for key, entry := range p.pending {
+		if time.Since(entry.started) > p.config.PendingTimeout {
+			p.removeLocked(key)
+		}
+	}
+}
+
+// Stop closes the sockets, ending StartListening
+func (p *Proxy) Stop() {
+	p.mutex.Lock()
+	p.running = false
+	listener, replies := p.listener, p.replies
+	p.mutex.Unlock()
+
+	if listener != nil {
+		listener.Close()
+	}
+	if replies != nil {
+		replies.Close()
+	}
+}
+
+// listenUnixgram binds a datagram socket, replacing a stale socket file
+func listenUnixgram(socketPath string) (*net.UnixConn, error) {
+	if info, err := os.Stat(socketPath); err == nil && info.Mode()&os.ModeSocket != 0 {
+		os.Remove(socketPath)
+	}
+	addr, err := net.ResolveUnixAddr("unixgram", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve socket address %s: %w", socketPath, err)
+	}
+	conn, err := net.ListenUnixgram("unixgram", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind datagram socket %s: %w", socketPath, err)
+	}
+	return conn, nil
+}
+
+// readLoop hands each datagram to handle until the socket is closed
+func (p *Proxy) readLoop(conn *net.UnixConn, handle func(data []byte, sender string)) {
+	buffer := make([]byte, p.config.MaxMessageSize)
+	for {
+		n, addr, err := conn.ReadFromUnix(buffer)
+		if err != nil {
+			if !p.isRunning() {
+				return
+			}
+			continue
+		}
+		sender := ""
+		if addr != nil {
+			sender = addr.String()
+		}
+		data := make([]byte, n)
+		copy(data, buffer[:n])
+		go handle(data, sender)
+	}
+}
+
+func (p *Proxy) isRunning() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.running
+}
+
+// handleRequest rewrites reply_to to the proxy's reply socket and forwards the request upstream
+func (p *Proxy) handleRequest(data []byte, client string) {
+	var request models.JanusRequest
+	if err := decodeDatagram(data, &request); err != nil {
+		p.count(func(stats *Stats) { stats.Invalid++ })
+		p.logInvalid(directionRequest, data, err)
+		p.send(p.config.UpstreamPath, data)
+		return
+	}
+	selected := p.selects(request.Request)
+
+	// Requests expecting a response are forwarded under a proxy-assigned id, so responses
+	// can be matched to the right client even when clients reuse ids
+	forwarded := data
+	var key *pendingKey
+	if request.ReplyTo != nil && *request.ReplyTo != "" {
+		upstreamID := uuid.New().String()
+		var err error
+		if forwarded, err = rewriteFields(data, map[string]string{"id": upstreamID, "reply_to": p.config.ReplyPath}); err != nil {
+			p.logf("failed to rewrite request %s: %v\n", request.ID, err)
+			return
+		}
+		key = &pendingKey{replyTo: *request.ReplyTo, id: request.ID}
+		p.track(*key, &pendingRequest{
+			upstreamID: upstreamID,
+			replyTo:    *request.ReplyTo,
+			client:     client,
+			request:    &request,
+			selected:   selected,
+			started:    time.Now(),
+		})
+	} else if selected && p.config.Dump != nil {
+		p.config.Dump.Record(&request, nil, client, time.Now(), 0)
+	}
+
+	if selected {
+		p.logDatagram(directionRequest, request.Request, request.ID, data, "")
+		if p.inject() {
+			p.count(func(stats *Stats) { stats.Dropped++ })
+			p.logf("  dropped request %s\n", request.ID)
+			if key != nil {
+				p.forget(*key)
+			}
+			return
+		}
+	}
+	p.count(func(stats *Stats) { stats.Requests++ })
+	p.send(p.config.UpstreamPath, forwarded)
+}
+
+// handleResponse relays an upstream response to the reply_to address of its request
+func (p *Proxy) handleResponse(data []byte, _ string) {
+	var response models.JanusResponse
+	if err := decodeDatagram(data, &response); err != nil {
+		p.count(func(stats *Stats) { stats.Invalid++ })
+		p.logInvalid(directionResponse, data, err)
+		return
+	}
+
+	pending := p.take(response.RequestID)
+	if pending == nil {
+		p.count(func(stats *Stats) { stats.Unmatched++ })
+		p.logf("? response for unknown request %s dropped\n", response.RequestID)
+		return
+	}
+	restored, err := rewriteFields(data, map[string]string{"request_id": pending.request.ID})
+	if err != nil {
+		p.logf("failed to rewrite response %s: %v\n", response.RequestID, err)
+		return
+	}
+	data = restored
+	response.RequestID = pending.request.ID
+
+	if pending.selected {
+		elapsed := time.Since(pending.started)
+		if p.config.Dump != nil {
+			p.config.Dump.Record(pending.request, &response, pending.client, pending.started, elapsed)
+		}
+		p.logDatagram(directionResponse, pending.request.Request, response.RequestID, data, responseSummary(&response, elapsed))
+		if p.inject() {
+			p.count(func(stats *Stats) { stats.Dropped++ })
+			p.logf("  dropped response %s\n", response.RequestID)
+			return
+		}
+	}
+	p.count(func(stats *Stats) { stats.Responses++ })
+	p.send(pending.replyTo, data)
+}
+
+// selects reports whether a request name matches the configured patterns
+func (p *Proxy) selects(request string) bool {
+	if len(p.config.Requests) == 0 {
+		return true
+	}
+	for _, pattern := range p.config.Requests {
+		if matched, _ := path.Match(pattern, request); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// inject sleeps for the configured latency and reports whether the datagram should be dropped
+func (p *Proxy) inject() bool {
+	p.mutex.Lock()
+	delay := p.config.Latency
+	if p.config.Jitter > 0 {
+		delay += time.Duration(p.random.Int63n(int64(p.config.Jitter) + 1))
+	}
+	drop := p.config.LossRate > 0 && p.random.Float64() < p.config.LossRate
+	p.mutex.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	return drop
+}
+
+// track remembers a forwarded request, replacing an earlier one with the same key
+// Requests that timed out are forgotten by expireLoop
+func (p *Proxy) track(key pendingKey, pending *pendingRequest) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.removeLocked(key)
+	p.pending[key] = pending
+	p.upstream[pending.upstreamID] = key
+}
+
+// take removes and returns the pending request forwarded under the given upstream id
+func (p *Proxy) take(upstreamID string) *pendingRequest {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	key, exists := p.upstream[upstreamID]
+	if !exists {
+		return nil
+	}
+	pending := p.pending[key]
+	p.removeLocked(key)
+	return pending
+}
+
+// forget removes a pending request that will not get a response, e.g. after it was dropped
+func (p *Proxy) forget(key pendingKey) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.removeLocked(key)
+}
+
+func (p *Proxy) removeLocked(key pendingKey) {
+	if pending, exists := p.pending[key]; exists {
+		delete(p.upstream, pending.upstreamID)
+		delete(p.pending, key)
+	}
+}
+
+func (p *Proxy) count(update func(stats *Stats)) {
+	p.mutex.Lock()
+	update(&p.stats)
+	p.mutex.Unlock()
+}
+
+// send writes a datagram to a socket path
+func (p *Proxy) send(socketPath string, data []byte) {
+	addr, err := net.ResolveUnixAddr("unixgram", socketPath)
+	if err != nil {
+		p.logf("failed to resolve %s: %v\n", socketPath, err)
+		return
+	}
+	conn, err := net.DialUnix("unixgram", nil, addr)
+	if err != nil {
+		p.logf("failed to dial %s: %v\n", socketPath, err)
+		return
+	}
+	defer conn.Close()
+	if _, err := conn.Write(data); err != nil {
+		p.logf("failed to send to %s: %v\n", socketPath, err)
+	}
+}
+
+// decodeFields decodes a JSON object keeping every field as raw JSON, so forwarded
+// requests keep fields this package does not know about
+func decodeFields(data []byte) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, fmt.Errorf("datagram is not a JSON object")
+	}
+	return fields, nil
+}
+
+// decodeCBORFields decodes a CBOR map into its generic fields
+func decodeCBORFields(data []byte) (map[string]interface{}, error) {
+	decoded, err := wire.UnmarshalCBOR(data)
+	if err != nil {
+		return nil, err
+	}
+	fields, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("datagram is not a CBOR map")
+	}
+	return fields, nil
+}
+
+// decodeDatagram leniently decodes a JSON or CBOR datagram into a message
+func decodeDatagram(data []byte, message interface{}) error {
+	if !wire.IsCBOR(data) {
+		if _, err := decodeFields(data); err != nil {
+			return err
+		}
+		return json.Unmarshal(data, message)
+	}
+	fields, err := decodeCBORFields(data)
+	if err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, message)
+}
+
+// rewriteFields replaces string fields of a message, keeping its encoding and other fields
+func rewriteFields(data []byte, values map[string]string) ([]byte, error) {
+	if wire.IsCBOR(data) {
+		fields, err := decodeCBORFields(data)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range values {
+			fields[name] = value
+		}
+		return wire.MarshalCBOR(fields)
+	}
+	fields, err := decodeFields(data)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range values {
+		fields[name], _ = json.Marshal(value)
+	}
+	return json.Marshal(fields)
+}
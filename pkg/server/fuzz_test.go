@@ -0,0 +1,82 @@
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/wire"
+)
+
+// FuzzHandleDatagram checks that every datagram is answered exactly when it should be, with a valid response
+// Run with go test ./pkg/server -run '^$' -fuzz '^FuzzHandleDatagram$' -fuzztime 60s
+func FuzzHandleDatagram(f *testing.F) {
+	paths, _ := filepath.Glob(filepath.Join("..", "..", "tests", "fixtures", "wire", "request_*.json"))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatalf("Failed to read %s: %v", path, err)
+		}
+		f.Add(data)
+	}
+	for _, request := range []string{"ping", "echo", "get_info", "validate", "manifest", "fail", "unknown"} {
+		janusRequest := models.NewJanusRequest(request, map[string]interface{}{"message": "hi"}, nil)
+		replyTo := "/tmp/fuzz-reply.sock"
+		janusRequest.ReplyTo = &replyTo
+		data, _ := json.Marshal(janusRequest)
+		f.Add(data)
+		data, _ = wire.EncodeRequestAs(janusRequest, wire.CBOR)
+		f.Add(data)
+	}
+	f.Add([]byte("not json"))
+	f.Add([]byte(`{"id": "1", "reply_to": 7}`))
+	f.Add(make([]byte, defaultMaxMessageSize+1))
+
+	server := NewJanusServer(nil)
+	server.RegisterHandler("fail", NewObjectHandler(func(cmd *models.JanusRequest) (map[string]interface{}, error) {
+		return nil, models.NewJSONRPCError(models.InternalError, "")
+	}))
+	var replies [][]byte
+	server.deliver = func(data []byte, replyToPath string) {
+		replies = append(replies, data)
+	}
+	sender := &net.UnixAddr{Name: "/tmp/fuzz-sender.sock", Net: "unixgram"}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		replies = nil
+		server.handleDatagram(data, sender)
+
+		// Rejected datagrams are answered at the sender; requests only when they name a reply_to
+		request, err := wire.DecodeRequest(data)
+		expected := 1
+		if err == nil && request.ReplyTo == nil {
+			expected = 0
+		}
+		if len(data) > defaultMaxMessageSize {
+			request, err = nil, models.NewJSONRPCError(models.ResourceLimitExceeded, "")
+		}
+		if len(replies) != expected {
+			t.Fatalf("Expected %d responses, got %d", expected, len(replies))
+		}
+		if expected == 0 {
+			return
+		}
+
+		response, decodeErr := wire.DecodeResponse(replies[0])
+		if decodeErr != nil {
+			t.Fatalf("Server sent an invalid response %s: %v", replies[0], decodeErr)
+		}
+		if err != nil {
+			if response.Success || response.Error.Code != err.(*models.JSONRPCError).Code {
+				t.Fatalf("Expected error %v, got %s", err, replies[0])
+			}
+			return
+		}
+		if response.RequestID != request.ID {
+			t.Fatalf("Response correlates to %q instead of %q", response.RequestID, request.ID)
+		}
+	})
+}
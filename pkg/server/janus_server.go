@@ -9,9 +9,16 @@ import (
 	"time"
 
 	"GoJanus/pkg/models"
+	"GoJanus/pkg/traffic"
+	"GoJanus/pkg/wire"
 )
 
 
+const (
+	defaultMaxMessageSize = 64 * 1024  // Largest request accepted when MaxMessageSize is not set
+	maxDatagramSize       = 256 * 1024 // Read buffer size, above the default kernel limit for Unix datagrams
+)
+
 // EventHandler defines the function signature for event handlers
 type EventHandler func(data interface{})
 
@@ -23,6 +30,8 @@ type ServerConfig struct {
 	MaxMessageSize    int
 	CleanupOnStart    bool
 	CleanupOnShutdown bool
+	RecordPath        string // Appends every request/response pair to this JSONL file when set
+	Encodings         []wire.Encoding // Accepted request encodings; all supported encodings when empty
 }
 
 // JanusServerEvents defines the available server events
@@ -33,6 +42,7 @@ type JanusServerEvents struct {
 	Request       []EventHandler
 	Response      []EventHandler
 	Error         []EventHandler
+	ManifestReloaded []EventHandler
 }
 
 // JanusServer provides a high-level API for listening on Unix datagram sockets
@@ -45,6 +55,16 @@ type JanusServer struct {
 	mutex           sync.RWMutex
 	events          *JanusServerEvents
 	config          *ServerConfig
+	
+	// Manifests served by the "manifest" request, keyed by version
+	manifests       map[string]*servedManifest
+	watcher         *manifestWatcher
+	
+	// Capture of handled requests, open while listening with RecordPath set
+	recorder        *traffic.Recorder
+	
+	// Delivers encoded responses instead of the reply-to socket when set (fuzzing)
+	deliver         func(data []byte, replyToPath string)
 }
 
 // NewJanusServer creates a new server instance with event architecture
@@ -69,8 +89,10 @@ func NewJanusServer(config *ServerConfig) *JanusServer {
 			Request:       make([]EventHandler, 0),
 			Response:      make([]EventHandler, 0),
 			Error:         make([]EventHandler, 0),
+			ManifestReloaded: make([]EventHandler, 0),
 		},
-		config: config,
+		config:    config,
+		manifests: make(map[string]*servedManifest),
 	}
 }
 
@@ -98,6 +120,8 @@ func (s *JanusServer) On(eventType string, handler EventHandler) {
 		s.events.Response = append(s.events.Response, handler)
 	case "error":
 		s.events.Error = append(s.events.Error, handler)
+	case "manifest_reloaded":
+		s.events.ManifestReloaded = append(s.events.ManifestReloaded, handler)
 	}
 }
 
@@ -124,6 +148,8 @@ func (s *JanusServer) Emit(eventType string, data interface{}) {
 		handlers = s.events.Response
 	case "error":
 		handlers = s.events.Error
+	case "manifest_reloaded":
+		handlers = s.events.ManifestReloaded
 	default:
 		return
 	}
@@ -201,8 +227,24 @@ func (s *JanusServer) StartListening() error {
 		return fmt.Errorf("failed to bind datagram socket: %w", err)
 	}
 	
+	var recorder *traffic.Recorder
+	if s.config.RecordPath != "" {
+		if recorder, err = traffic.OpenRecorder(s.config.RecordPath); err != nil {
+			conn.Close()
+			s.Emit("error", err)
+			return err
+		}
+		defer func() {
+			s.mutex.Lock()
+			s.recorder = nil
+			s.mutex.Unlock()
+			recorder.Close()
+		}()
+	}
+	
 	s.mutex.Lock()
 	s.conn = conn
+	s.recorder = recorder
 	s.mutex.Unlock()
 	
 	defer conn.Close()
@@ -216,7 +258,12 @@ func (s *JanusServer) StartListening() error {
 	s.Emit("listening", nil)
 
 	// Buffer for incoming datagrams
-	buffer := make([]byte, 64*1024) // 64KB buffer for datagrams
+	// Datagrams above MaxMessageSize are still read whole so the sender can be told why they were rejected
+	bufferSize := s.maxMessageSize()
+	if bufferSize < maxDatagramSize {
+		bufferSize = maxDatagramSize
+	}
+	buffer := make([]byte, bufferSize)
 
 	for {
 		s.mutex.RLock()
@@ -242,8 +289,10 @@ func (s *JanusServer) StartListening() error {
 			continue
 		}
 
-		// Handle datagram in goroutine
-		go s.handleDatagram(buffer[:n], clientAddr)
+		// Handle datagram in goroutine, on a copy since the buffer is reused for the next read
+		data := make([]byte, n)
+		copy(data, buffer[:n])
+		go s.handleDatagram(data, clientAddr)
 	}
 
 	fmt.Println("Server stopped")
@@ -252,6 +301,8 @@ func (s *JanusServer) StartListening() error {
 
 // Stop stops the server
 func (s *JanusServer) Stop() {
+	s.StopWatchingManifest()
+	
 	s.mutex.Lock()
 	s.running = false
 	conn := s.conn
@@ -273,11 +324,30 @@ func (s *JanusServer) isRunning() bool {
 // handleDatagram processes a single datagram
 // SOCK_DGRAM connectionless implementation
 func (s *JanusServer) handleDatagram(data []byte, clientAddr *net.UnixAddr) {
+	if maxSize := s.maxMessageSize(); len(data) > maxSize {
+		s.rejectDatagram(data, clientAddr, models.NewJSONRPCError(models.ResourceLimitExceeded,
+			fmt.Sprintf("message size %d exceeds maximum %d", len(data), maxSize)))
+		return
+	}
+	
+	// Responses use the encoding of the request
+	encoding := wire.DetectEncoding(data)
+	if !s.acceptsEncoding(encoding) {
+		s.rejectDatagram(data, clientAddr, models.NewJSONRPCError(models.InvalidRequest,
+			fmt.Sprintf("encoding '%s' is not accepted by this server", encoding)))
+		return
+	}
+	
 	// Parse request from datagram
-	var cmd models.JanusRequest
-	if err := json.Unmarshal(data, &cmd); err != nil {
+	cmd, err := wire.DecodeRequest(data)
+	if err != nil {
 		fmt.Printf("Failed to decode request: %v\n", err)
 		s.Emit("error", fmt.Errorf("failed to decode request: %w", err))
+		rpcErr, ok := models.AsJSONRPCError(err)
+		if !ok {
+			rpcErr = models.NewJSONRPCError(models.ParseError, err.Error())
+		}
+		s.rejectDatagram(data, clientAddr, rpcErr)
 		return
 	}
 
@@ -285,16 +355,18 @@ func (s *JanusServer) handleDatagram(data []byte, clientAddr *net.UnixAddr) {
 	
 	// Emit request event
 	s.Emit("request", map[string]interface{}{
-		"request":  &cmd,
+		"request":  cmd,
 		"clientId": clientAddr.String(),
 	})
 
 	// Process request
-	response := s.processRequest(&cmd)
+	started := time.Now()
+	response := s.processRequest(cmd)
+	s.record(cmd, response, clientAddr, started)
 
 	// Send response back to reply_to address if manifestified
 	if cmd.ReplyTo != nil && *cmd.ReplyTo != "" {
-		s.sendResponse(response, *cmd.ReplyTo)
+		s.sendResponse(response, *cmd.ReplyTo, encoding)
 		
 		// Emit response event
 		s.Emit("response", map[string]interface{}{
@@ -304,13 +376,89 @@ func (s *JanusServer) handleDatagram(data []byte, clientAddr *net.UnixAddr) {
 	}
 }
 
+// rejectDatagram answers a datagram that is not a valid request with an error response
+// The response goes to the datagram's reply_to when it can be read, otherwise to the sender's
+// address if the sender is bound; unanswerable datagrams are dropped
+// The response is in the datagram's encoding when the server accepts it, otherwise JSON
+func (s *JanusServer) rejectDatagram(data []byte, clientAddr *net.UnixAddr, rpcErr *models.JSONRPCError) {
+	var envelope map[string]interface{}
+	encoding := wire.DetectEncoding(data)
+	if encoding == wire.CBOR {
+		decoded, _ := wire.UnmarshalCBOR(data)
+		envelope, _ = decoded.(map[string]interface{})
+	} else {
+		json.Unmarshal(data, &envelope)
+	}
+	if !s.acceptsEncoding(encoding) {
+		encoding = wire.JSON
+	}
+	
+	requestID, _ := envelope["id"].(string)
+	replyTo, _ := envelope["reply_to"].(string)
+	if replyTo == "" && clientAddr != nil {
+		replyTo = clientAddr.Name
+	}
+	if replyTo == "" {
+		return
+	}
+	s.sendResponse(models.NewErrorResponse(requestID, rpcErr), replyTo, encoding)
+}
+
+// acceptedEncodings returns the request encodings the server accepts
+func (s *JanusServer) acceptedEncodings() []wire.Encoding {
+	if len(s.config.Encodings) > 0 {
+		return s.config.Encodings
+	}
+	return wire.Encodings()
+}
+
+// acceptsEncoding reports whether the server accepts requests in an encoding
+func (s *JanusServer) acceptsEncoding(encoding wire.Encoding) bool {
+	for _, accepted := range s.acceptedEncodings() {
+		if accepted == encoding {
+			return true
+		}
+	}
+	return false
+}
+
+// maxMessageSize returns the largest request the server accepts
+func (s *JanusServer) maxMessageSize() int {
+	if s.config.MaxMessageSize > 0 {
+		return s.config.MaxMessageSize
+	}
+	return defaultMaxMessageSize
+}
+
+// record appends the exchange to the capture file when recording is enabled
+func (s *JanusServer) record(cmd *models.JanusRequest, response *models.JanusResponse, clientAddr *net.UnixAddr, started time.Time) {
+	s.mutex.RLock()
+	recorder := s.recorder
+	s.mutex.RUnlock()
+	if recorder == nil {
+		return
+	}
+	
+	client := ""
+	if clientAddr != nil {
+		client = clientAddr.String()
+	}
+	if err := recorder.Record(cmd, response, client, started, time.Since(started)); err != nil {
+		s.Emit("error", err)
+	}
+}
+
 // sendResponse sends a response to the manifestified reply-to address
 // SOCK_DGRAM reply mechanism
-func (s *JanusServer) sendResponse(response *models.JanusResponse, replyToPath string) {
-	// Marshal response to JSON
-	responseData, err := json.Marshal(response)
+func (s *JanusServer) sendResponse(response *models.JanusResponse, replyToPath string, encoding wire.Encoding) {
+	// Encode response in the wire format
+	responseData, err := wire.EncodeResponseAs(response, encoding)
 	if err != nil {
-		fmt.Printf("Failed to marshal response: %v\n", err)
+		fmt.Printf("Failed to encode response: %v\n", err)
+		return
+	}
+	if s.deliver != nil {
+		s.deliver(responseData, replyToPath)
 		return
 	}
 
@@ -382,23 +530,11 @@ func (s *JanusServer) handleBuiltinRequest(cmd *models.JanusRequest) (*models.Ja
 		return models.NewSuccessResponse(cmd.ID, result), true
 
 	case "get_info":
-		result := map[string]interface{}{
-			"implementation": "go",
-			"version":        "1.0.0",
-			"architecture":   "SOCK_DGRAM",
-			"timestamp":      float64(time.Now().Unix()),
-		}
-		return models.NewSuccessResponse(cmd.ID, result), true
+		return models.NewSuccessResponse(cmd.ID, s.serverInfo()), true
 
 	case "manifest":
-		// Return a basic Manifest matching the expected format
-		manifest := map[string]interface{}{
-			"version":     "1.0.0",
-			"name":        "Go Janus Test API",
-			"description": "Test Manifest for Go implementation",
-			"models": map[string]interface{}{},
-		}
-		return models.NewSuccessResponse(cmd.ID, manifest), true
+		// Return the best matching served manifest (or a basic one when none is set)
+		return s.handleManifestRequest(cmd), true
 
 	case "validate":
 		// Basic JSON validation
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+)
+
+// fallbackManifest is served until the application sets its own
+var fallbackManifest = &manifest.Manifest{
+	Version:     "1.0.0",
+	Name:        "Go Janus Test API",
+	Description: "Test Manifest for Go implementation",
+}
+
+// servedManifest is a manifest together with its content hash
+type servedManifest struct {
+	manifest *manifest.Manifest
+	hash     string
+}
+
+// newServedManifest validates a manifest and computes its hash
+func newServedManifest(m *manifest.Manifest) (*servedManifest, error) {
+	if err := checkServableManifest(m); err != nil {
+		return nil, err
+	}
+	hash, err := manifest.ContentHash(m)
+	if err != nil {
+		return nil, err
+	}
+	return &servedManifest{manifest: m, hash: hash}, nil
+}
+
+// SetManifest replaces every served manifest with the given one
+// The manifest must validate and carry a semantic version
+func (s *JanusServer) SetManifest(m *manifest.Manifest) error {
+	served, err := newServedManifest(m)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.manifests = map[string]*servedManifest{m.Version: served}
+	return nil
+}
+
+// AddManifest serves an additional manifest version side by side with the existing ones
+// Clients select a version with the "version_range" argument of the manifest request
+func (s *JanusServer) AddManifest(m *manifest.Manifest) error {
+	served, err := newServedManifest(m)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.manifests[m.Version]; exists {
+		return fmt.Errorf("manifest version %s is already served", m.Version)
+	}
+	s.manifests[m.Version] = served
+	return nil
+}
+
+// GetManifest returns the highest served manifest version, or nil when none is set
+func (s *JanusServer) GetManifest() *manifest.Manifest {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	versions := s.manifestVersionsLocked()
+	if len(versions) == 0 {
+		return nil
+	}
+	return s.manifests[versions[len(versions)-1]].manifest
+}
+
+// ManifestVersions returns the served manifest versions in ascending order
+func (s *JanusServer) ManifestVersions() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.manifestVersionsLocked()
+}
+
+func (s *JanusServer) manifestVersionsLocked() []string {
+	versions := make([]string, 0, len(s.manifests))
+	for version := range s.manifests {
+		versions = append(versions, version)
+	}
+	manifest.SortVersions(versions)
+	return versions
+}
+
+// checkServableManifest rejects manifests that cannot take part in version negotiation
+func checkServableManifest(m *manifest.Manifest) error {
+	if m == nil {
+		return fmt.Errorf("manifest cannot be nil")
+	}
+	if err := m.Validate(); err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+	if _, err := manifest.ParseSemanticVersion(m.Version); err != nil {
+		return fmt.Errorf("manifest version must be a semantic version: %w", err)
+	}
+	return nil
+}
+
+// handleManifestRequest answers the built-in manifest request
+// An optional "version_range" argument selects the highest served version within the range.
+// When "if_none_match" is present the result is {"not_modified": true, "hash": ...} if the
+// selected manifest still has that hash, and {"manifest": ..., "hash": ...} otherwise
+func (s *JanusServer) handleManifestRequest(cmd *models.JanusRequest) *models.JanusResponse {
+	versionRange, rpcErr := stringArg(cmd, "version_range")
+	if rpcErr != nil {
+		return models.NewErrorResponse(cmd.ID, rpcErr)
+	}
+	ifNoneMatch, rpcErr := stringArg(cmd, "if_none_match")
+	if rpcErr != nil {
+		return models.NewErrorResponse(cmd.ID, rpcErr)
+	}
+	_, conditional := cmd.Args["if_none_match"]
+
+	served, rpcErr := s.selectManifest(versionRange)
+	if rpcErr != nil {
+		return models.NewErrorResponse(cmd.ID, rpcErr)
+	}
+
+	if !conditional {
+		return models.NewSuccessResponse(cmd.ID, served.manifest)
+	}
+	if ifNoneMatch == served.hash {
+		return models.NewSuccessResponse(cmd.ID, map[string]interface{}{
+			"not_modified":     true,
+			"hash":             served.hash,
+			"protocol_version": models.ProtocolVersion,
+		})
+	}
+	return models.NewSuccessResponse(cmd.ID, map[string]interface{}{
+		"manifest":         served.manifest,
+		"hash":             served.hash,
+		"protocol_version": models.ProtocolVersion,
+	})
+}
+
+// selectManifest returns the highest served manifest within versionRange (any version when empty)
+func (s *JanusServer) selectManifest(versionRange string) (*servedManifest, *models.JSONRPCError) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	manifests := s.manifests
+	if len(manifests) == 0 {
+		fallback, _ := newServedManifest(fallbackManifest)
+		manifests = map[string]*servedManifest{fallbackManifest.Version: fallback}
+	}
+
+	versions := make([]string, 0, len(manifests))
+	for version := range manifests {
+		versions = append(versions, version)
+	}
+	manifest.SortVersions(versions)
+
+	selected := versions[len(versions)-1]
+	if versionRange != "" {
+		r, err := manifest.ParseVersionRange(versionRange)
+		if err != nil {
+			return nil, models.NewJSONRPCError(models.InvalidParams, err.Error())
+		}
+		match, ok := r.HighestMatch(versions)
+		if !ok {
+			return nil, models.NewJSONRPCErrorWithContext(
+				models.ManifestValidationError,
+				fmt.Sprintf("no served manifest version satisfies '%s'", versionRange),
+				map[string]interface{}{
+					"requested_range":    versionRange,
+					"available_versions": versions,
+				},
+			)
+		}
+		selected = match
+	}
+	return manifests[selected], nil
+}
+
+// stringArg returns an optional string argument, or InvalidParams when it has another type
+func stringArg(cmd *models.JanusRequest, name string) (string, *models.JSONRPCError) {
+	value, exists := cmd.Args[name]
+	if !exists {
+		return "", nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", models.NewJSONRPCError(models.InvalidParams, fmt.Sprintf("%s must be a string", name))
+	}
+	return str, nil
+}
+
+// serverInfo builds the get_info result, including the versions clients negotiate against
+func (s *JanusServer) serverInfo() map[string]interface{} {
+	served, _ := s.selectManifest("")
+	versions := s.ManifestVersions()
+	if len(versions) == 0 {
+		versions = []string{fallbackManifest.Version}
+	}
+
+	return map[string]interface{}{
+		"implementation":    "go",
+		"version":           "1.0.0",
+		"architecture":      "SOCK_DGRAM",
+		"protocol_version":  models.ProtocolVersion,
+		"manifest_version":  served.manifest.Version,
+		"manifest_versions": versions,
+		"manifest_hash":     served.hash,
+		"encodings":         s.acceptedEncodings(),
+		"timestamp":         float64(time.Now().Unix()),
+	}
+}
@@ -0,0 +1,244 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"GoJanus/pkg/manifest"
+)
+
+// DefaultManifestPollInterval is used by WatchManifest when no interval is given
+const DefaultManifestPollInterval = 2 * time.Second
+
+// ManifestReloadEvent is the payload of the "manifest_reloaded" event
+type ManifestReloadEvent struct {
+	Path    string               `json:"path"`
+	Hash    string               `json:"hash"`
+	Summary string               `json:"summary"`
+	Diff    *manifest.DiffResult `json:"diff"`
+}
+
+// manifestWatcher polls one manifest file
+type manifestWatcher struct {
+	path     string
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+
+	// State of the file at the last check, so unchanged or already rejected files are not re-read
+	mutex   sync.Mutex
+	modTime time.Time
+	size    int64
+	content []byte
+	imports map[string]fileStamp // Imported files read by the last parse, by absolute path
+}
+
+// fileStamp is the size and modification time of a file; the zero value stands for a missing file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// stampFile stats path, returning the zero stamp when it cannot be read
+func stampFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// WatchManifest loads the manifest at path and reloads it whenever the file changes
+// The initial load must succeed; later reloads that fail parsing, validation or lint errors
+// are rejected with an "error" event and the previous manifest stays in place.
+// Successful reloads replace all served versions and emit "manifest_reloaded"
+func (s *JanusServer) WatchManifest(path string, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultManifestPollInterval
+	}
+
+	w := &manifestWatcher{path: path, interval: interval, stop: make(chan struct{}), done: make(chan struct{})}
+	if err := s.reloadFromWatcher(w); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	previous := s.watcher
+	s.watcher = w
+	s.mutex.Unlock()
+	previous.close()
+
+	go s.pollManifest(w)
+	return nil
+}
+
+// ReloadManifest re-reads the watched manifest file immediately, e.g. on SIGHUP
+func (s *JanusServer) ReloadManifest() error {
+	s.mutex.RLock()
+	w := s.watcher
+	s.mutex.RUnlock()
+
+	if w == nil {
+		return fmt.Errorf("no manifest file is being watched")
+	}
+	return s.reloadFromWatcher(w)
+}
+
+// StopWatchingManifest stops the manifest watcher; the current manifest stays served
+func (s *JanusServer) StopWatchingManifest() {
+	s.mutex.Lock()
+	w := s.watcher
+	s.watcher = nil
+	s.mutex.Unlock()
+	w.close()
+}
+
+// close stops the polling goroutine and waits for it to exit
+func (w *manifestWatcher) close() {
+	if w == nil {
+		return
+	}
+	close(w.stop)
+	<-w.done
+}
+
+// pollManifest checks the file every interval until stopped
+func (s *JanusServer) pollManifest(w *manifestWatcher) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if !w.changed() {
+				continue
+			}
+			if err := s.reloadFromWatcher(w); err != nil {
+				s.Emit("error", err)
+			}
+		}
+	}
+}
+
+// changed reports whether the size or modification time of the file or one of its imports moved since the last check
+func (w *manifestWatcher) changed() bool {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return false // Editors may briefly remove the file while saving
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if !info.ModTime().Equal(w.modTime) || info.Size() != w.size {
+		return true
+	}
+	for path, stamp := range w.imports {
+		if current := stampFile(path); !current.modTime.Equal(stamp.modTime) || current.size != stamp.size {
+			return true
+		}
+	}
+	return false
+}
+
+// restampImportsLocked records the current state of the known imports and reports whether any changed
+// Stamps are taken before the files are parsed, so edits made during a reload are picked up by the next poll
+func (w *manifestWatcher) restampImportsLocked() bool {
+	changed := false
+	for path, stamp := range w.imports {
+		current := stampFile(path)
+		if !current.modTime.Equal(stamp.modTime) || current.size != stamp.size {
+			changed = true
+		}
+		w.imports[path] = current
+	}
+	return changed
+}
+
+// trackImportsLocked replaces the watched import set with the files read by the latest parse
+func (w *manifestWatcher) trackImportsLocked(paths []string) {
+	imports := make(map[string]fileStamp, len(paths))
+	for _, path := range paths {
+		stamp, known := w.imports[path]
+		if !known {
+			stamp = stampFile(path)
+		}
+		imports[path] = stamp
+	}
+	w.imports = imports
+}
+
+// reloadFromWatcher parses, validates and lints the file, then swaps it in
+// Unchanged content, including imported files, is ignored; only reloads after the initial load emit "manifest_reloaded"
+func (s *JanusServer) reloadFromWatcher(w *manifestWatcher) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return fmt.Errorf("manifest reload rejected: %w", err)
+	}
+	content, err := os.ReadFile(w.path)
+	if err != nil {
+		return fmt.Errorf("manifest reload rejected: %w", err)
+	}
+
+	// Remember what was seen even when it is rejected, so a broken file is reported once
+	initial := w.content == nil
+	w.modTime, w.size = info.ModTime(), info.Size()
+	importsChanged := w.restampImportsLocked()
+	if !initial && !importsChanged && bytes.Equal(content, w.content) {
+		return nil
+	}
+	w.content = content
+
+	// Parse the bytes just compared rather than reading the file a second time
+	parser := manifest.NewManifestParser()
+	next, err := parser.DecodeFileContent(w.path, content)
+	if err != nil {
+		return fmt.Errorf("manifest reload rejected: %w", err)
+	}
+	imported, err := parser.ResolveFileImportsTracked(next, w.path)
+	w.trackImportsLocked(imported)
+	if err != nil {
+		return fmt.Errorf("manifest reload rejected: %w", err)
+	}
+	if err := parser.ValidateManifest(next); err != nil {
+		return fmt.Errorf("manifest reload rejected: Manifest validation failed: %w", err)
+	}
+	if report := manifest.Lint(next, nil); report.HasErrors() {
+		var messages []string
+		for _, issue := range report.Issues {
+			if issue.Severity == manifest.LintError {
+				messages = append(messages, issue.String())
+			}
+		}
+		return fmt.Errorf("manifest reload rejected: lint errors: %s", strings.Join(messages, "; "))
+	}
+
+	previous := s.GetManifest()
+	if err := s.SetManifest(next); err != nil {
+		return fmt.Errorf("manifest reload rejected: %w", err)
+	}
+
+	if initial || previous == nil {
+		return nil
+	}
+
+	hash, _ := manifest.ContentHash(next)
+	diff := manifest.Diff(previous, next)
+	s.Emit("manifest_reloaded", &ManifestReloadEvent{
+		Path:    w.path,
+		Hash:    hash,
+		Summary: diff.Summary(),
+		Diff:    diff,
+	})
+	return nil
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"GoJanus/pkg/models"
+)
+
+// TypedHandler receives request arguments already bound into a Go struct
+type TypedHandler[Req, Resp any] func(*models.JanusRequest, Req) (Resp, error)
+
+// NewTypedHandler creates a handler that binds args into Req before invoking fn
+// Binding failures are reported as INVALID_PARAMS without calling fn
+//
+// Example:
+//   type GetUserArgs struct { UserID string `json:"user_id"` }
+//
+//   server.RegisterHandler("get_user", NewTypedHandler(func(cmd *models.JanusRequest, args GetUserArgs) (User, error) {
+//       return lookupUser(args.UserID)
+//   }))
+func NewTypedHandler[Req, Resp any](fn TypedHandler[Req, Resp]) RequestHandler {
+	return SyncHandler(func(cmd *models.JanusRequest) HandlerResult {
+		var args Req
+		if err := BindArgs(cmd, &args); err != nil {
+			return HandlerResult{Error: err}
+		}
+		
+		value, err := fn(cmd, args)
+		if err != nil {
+			// If error is or wraps a JSONRPCError, preserve it
+			if jsonRPCErr, ok := models.AsJSONRPCError(err); ok {
+				return HandlerResult{Error: jsonRPCErr}
+			}
+			return HandlerResult{Error: &models.JSONRPCError{
+				Code:    models.InternalError,
+				Message: err.Error(),
+			}}
+		}
+		return HandlerResult{Value: value}
+	})
+}
+
+// BindArgs decodes request args into target using its json tags
+// Unknown arguments and type mismatches are rejected with an INVALID_PARAMS error
+func BindArgs(cmd *models.JanusRequest, target interface{}) *models.JSONRPCError {
+	args := cmd.Args
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	
+	data, err := json.Marshal(args)
+	if err != nil {
+		return models.NewJSONRPCError(models.InvalidParams, fmt.Sprintf("failed to encode arguments: %v", err))
+	}
+	
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		context := map[string]interface{}{"method": cmd.Request}
+		if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+			context["field"] = typeErr.Field
+			context["expected"] = typeErr.Type.String()
+			context["actual"] = typeErr.Value
+		}
+		return models.NewJSONRPCErrorWithContext(models.InvalidParams,
+			fmt.Sprintf("failed to bind arguments for '%s': %v", cmd.Request, err), context)
+	}
+	
+	return nil
+}
@@ -0,0 +1,136 @@
+package shell
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseArgs decodes request arguments typed at the prompt
+//
+// Input starting with '{' is a JSON object. Anything else is a list of key=value pairs:
+// unquoted values that are valid JSON (numbers, true, null, [1, 2], {"a": 1}) keep their
+// JSON type, quoted values and all other text are strings.
+//
+//	name="Ada Lovelace" age=36 tags=[math, "engines"] active=true
+func ParseArgs(input string) (map[string]interface{}, error) {
+	input = strings.TrimSpace(input)
+	args := map[string]interface{}{}
+	if input == "" {
+		return args, nil
+	}
+
+	if strings.HasPrefix(input, "{") {
+		if err := json.Unmarshal([]byte(input), &args); err != nil {
+			return nil, fmt.Errorf("invalid JSON arguments: %w", err)
+		}
+		return args, nil
+	}
+
+	tokens, err := splitTokens(input)
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range tokens {
+		key, value, found := strings.Cut(token.text, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("expected key=value, got '%s'", token.text)
+		}
+		if _, exists := args[key]; exists {
+			return nil, fmt.Errorf("argument '%s' is given more than once", key)
+		}
+		args[key] = parseValue(value, token.quoted)
+	}
+	return args, nil
+}
+
+// parseValue keeps quoted values as strings and decodes unquoted JSON literals
+func parseValue(value string, quoted bool) interface{} {
+	if quoted {
+		return value
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+		return decoded
+	}
+	return value
+}
+
+// token is one whitespace-separated word with quotes removed
+type token struct {
+	text   string
+	quoted bool // Part of the word was in quotes, so its value is a string
+}
+
+// splitTokens splits input on whitespace outside quotes and JSON brackets
+// Quotes inside brackets are kept so the bracketed text stays valid JSON
+func splitTokens(input string) ([]token, error) {
+	var tokens []token
+	var current strings.Builder
+	var quote rune
+	inToken, quoted, escaped, jsonString := false, false, false, false
+	depth := 0
+
+	for _, r := range input {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote != 0:
+			switch {
+			case r == quote:
+				quote = 0
+			case r == '\\' && quote == '"':
+				escaped = true
+			default:
+				current.WriteRune(r)
+			}
+		case depth > 0:
+			current.WriteRune(r)
+			switch {
+			case jsonString && r == '\\':
+				escaped = true
+			case r == '"':
+				jsonString = !jsonString
+			case jsonString:
+			case r == '[' || r == '{':
+				depth++
+			case r == ']' || r == '}':
+				depth--
+			}
+		case unicode.IsSpace(r):
+			if inToken {
+				tokens = append(tokens, token{text: current.String(), quoted: quoted})
+				current.Reset()
+				inToken, quoted = false, false
+			}
+		default:
+			inToken = true
+			switch r {
+			case '"', '\'':
+				quote, quoted = r, true
+			case '\\':
+				escaped = true
+			case '[', '{':
+				depth++
+				current.WriteRune(r)
+			default:
+				current.WriteRune(r)
+			}
+		}
+	}
+
+	switch {
+	case quote != 0:
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	case depth > 0:
+		return nil, fmt.Errorf("unbalanced brackets in '%s'", current.String())
+	case escaped:
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inToken {
+		tokens = append(tokens, token{text: current.String(), quoted: quoted})
+	}
+	return tokens, nil
+}
@@ -0,0 +1,138 @@
+package shell
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"GoJanus/pkg/manifest"
+)
+
+// Completer suggests request names, argument keys and enum values for a partial line
+type Completer struct {
+	requests map[string]*manifest.RequestManifest
+	commands []string
+}
+
+// NewCompleter completes the manifest's requests, the built-in requests and shell commands
+func NewCompleter(m *manifest.Manifest) *Completer {
+	requests := manifest.BuiltinRequests()
+	if m != nil {
+		for name, request := range m.Requests {
+			requests[name] = request
+		}
+	}
+	return &Completer{requests: requests, commands: commandNames()}
+}
+
+// Complete returns the candidates for the word ending at the end of line and the
+// index where that word starts; each candidate replaces line[start:]
+func (completer *Completer) Complete(line string) ([]string, int) {
+	start := strings.LastIndexAny(line, " \t") + 1
+	word := line[start:]
+	fields := strings.Fields(line[:start])
+
+	if len(fields) == 0 {
+		names := append(completer.requestNames(), completer.commands...)
+		return withSuffix(matching(names, word), " "), start
+	}
+	if fields[0] == ".describe" && len(fields) == 1 {
+		return matching(completer.requestNames(), word), start
+	}
+
+	request, exists := completer.requests[fields[0]]
+	if !exists || strings.HasPrefix(strings.TrimSpace(line[len(fields[0]):]), "{") {
+		return nil, start
+	}
+
+	if key, prefix, found := strings.Cut(word, "="); found {
+		arg := request.Args[key]
+		if arg == nil {
+			return nil, start
+		}
+		var candidates []string
+		for _, value := range argumentValues(arg) {
+			if strings.HasPrefix(value, prefix) {
+				candidates = append(candidates, key+"="+value+" ")
+			}
+		}
+		return candidates, start
+	}
+
+	given := map[string]bool{}
+	for _, field := range fields[1:] {
+		if key, _, found := strings.Cut(field, "="); found {
+			given[key] = true
+		}
+	}
+	var keys []string
+	for name := range request.Args {
+		if !given[name] {
+			keys = append(keys, name)
+		}
+	}
+	sort.Strings(keys)
+	return withSuffix(matching(keys, word), "="), start
+}
+
+// requestNames returns every completable request name in order
+func (completer *Completer) requestNames() []string {
+	names := make([]string, 0, len(completer.requests))
+	for name := range completer.requests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// argumentValues lists the values worth completing for an argument
+func argumentValues(arg *manifest.ArgumentManifest) []string {
+	if arg.Type == "boolean" {
+		return []string{"false", "true"}
+	}
+	values := make([]string, len(arg.Enum))
+	for i, value := range arg.Enum {
+		values[i] = quoteValue(value)
+	}
+	return values
+}
+
+// quoteValue quotes a string value that would otherwise be split or read as JSON
+func quoteValue(value string) string {
+	if value == "" || json.Valid([]byte(value)) || strings.ContainsAny(value, " \t\"'\\[{") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func matching(names []string, prefix string) []string {
+	var matches []string
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			matches = append(matches, name)
+		}
+	}
+	return matches
+}
+
+func withSuffix(names []string, suffix string) []string {
+	for i := range names {
+		names[i] += suffix
+	}
+	return names
+}
+
+// commonPrefix returns the longest prefix shared by all candidates
+func commonPrefix(candidates []string) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+	prefix := candidates[0]
+	for _, candidate := range candidates[1:] {
+		for !strings.HasPrefix(candidate, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,206 @@
+package shell
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// ErrInterrupted is returned by ReadLine when the user presses Ctrl-C
+var ErrInterrupted = errors.New("interrupted")
+
+// LineEditor reads lines from a terminal in raw mode with history and tab completion
+//
+// Keys: arrows move and browse history, Tab completes, Ctrl-A/Ctrl-E jump to the
+// start/end, Ctrl-U/Ctrl-K delete before/after the cursor, Ctrl-W deletes a word,
+// Ctrl-C discards the line and Ctrl-D on an empty line ends input
+type LineEditor struct {
+	in        *bufio.Reader
+	out       io.Writer
+	history   *History
+	completer *Completer
+}
+
+// NewLineEditor creates an editor; history and completer may be nil
+func NewLineEditor(in io.Reader, out io.Writer, history *History, completer *Completer) *LineEditor {
+	return &LineEditor{in: bufio.NewReader(in), out: out, history: history, completer: completer}
+}
+
+// lineState is the line being edited
+type lineState struct {
+	prompt string
+	buffer []rune
+	cursor int
+}
+
+// ReadLine shows prompt and returns the entered line without its newline
+// It returns io.EOF on Ctrl-D at an empty line and ErrInterrupted on Ctrl-C
+func (editor *LineEditor) ReadLine(prompt string) (string, error) {
+	state := &lineState{prompt: prompt}
+	var entries []string
+	if editor.history != nil {
+		entries = editor.history.Entries()
+	}
+	historyIndex := len(entries)
+	draft := ""
+	editor.refresh(state)
+
+	for {
+		r, _, err := editor.in.ReadRune()
+		if err != nil {
+			if err == io.EOF && len(state.buffer) > 0 {
+				fmt.Fprint(editor.out, "\n")
+				return string(state.buffer), nil
+			}
+			return "", err
+		}
+
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(editor.out, "\n")
+			return string(state.buffer), nil
+		case 3: // Ctrl-C
+			fmt.Fprint(editor.out, "^C\n")
+			return "", ErrInterrupted
+		case 4: // Ctrl-D
+			if len(state.buffer) == 0 {
+				fmt.Fprint(editor.out, "\n")
+				return "", io.EOF
+			}
+			state.deleteAt(state.cursor)
+		case 1: // Ctrl-A
+			state.cursor = 0
+		case 5: // Ctrl-E
+			state.cursor = len(state.buffer)
+		case 11: // Ctrl-K
+			state.buffer = state.buffer[:state.cursor]
+		case 21: // Ctrl-U
+			state.buffer = append([]rune(nil), state.buffer[state.cursor:]...)
+			state.cursor = 0
+		case 23: // Ctrl-W
+			start := state.cursor
+			for start > 0 && unicode.IsSpace(state.buffer[start-1]) {
+				start--
+			}
+			for start > 0 && !unicode.IsSpace(state.buffer[start-1]) {
+				start--
+			}
+			state.buffer = append(state.buffer[:start], state.buffer[state.cursor:]...)
+			state.cursor = start
+		case 127, 8: // Backspace
+			if state.cursor > 0 {
+				state.cursor--
+				state.deleteAt(state.cursor)
+			}
+		case '\t':
+			editor.complete(state)
+		case 27: // Escape sequence
+			switch editor.readEscape() {
+			case "[A", "OA": // Up
+				if historyIndex > 0 {
+					if historyIndex == len(entries) {
+						draft = string(state.buffer)
+					}
+					historyIndex--
+					state.set(entries[historyIndex])
+				}
+			case "[B", "OB": // Down
+				if historyIndex < len(entries) {
+					historyIndex++
+					if historyIndex == len(entries) {
+						state.set(draft)
+					} else {
+						state.set(entries[historyIndex])
+					}
+				}
+			case "[C", "OC": // Right
+				if state.cursor < len(state.buffer) {
+					state.cursor++
+				}
+			case "[D", "OD": // Left
+				if state.cursor > 0 {
+					state.cursor--
+				}
+			case "[H", "OH", "[1~":
+				state.cursor = 0
+			case "[F", "OF", "[4~":
+				state.cursor = len(state.buffer)
+			case "[3~": // Delete
+				state.deleteAt(state.cursor)
+			}
+		default:
+			if unicode.IsPrint(r) {
+				state.insert(string(r))
+			}
+		}
+		editor.refresh(state)
+	}
+}
+
+// readEscape reads the rest of an escape sequence such as "[A" or "[3~"
+func (editor *LineEditor) readEscape() string {
+	var sequence strings.Builder
+	for sequence.Len() < 8 {
+		r, _, err := editor.in.ReadRune()
+		if err != nil {
+			break
+		}
+		sequence.WriteRune(r)
+		if sequence.Len() > 1 && (unicode.IsLetter(r) || r == '~') {
+			break
+		}
+	}
+	return sequence.String()
+}
+
+// complete inserts the completion for the text before the cursor, or lists the candidates
+func (editor *LineEditor) complete(state *lineState) {
+	if editor.completer == nil {
+		return
+	}
+	before := string(state.buffer[:state.cursor])
+	candidates, start := editor.completer.Complete(before)
+	if len(candidates) == 0 {
+		return
+	}
+
+	word := before[start:]
+	if prefix := commonPrefix(candidates); len(prefix) > len(word) {
+		state.insert(prefix[len(word):])
+		return
+	}
+	if len(candidates) > 1 {
+		fmt.Fprintf(editor.out, "\n%s\n", strings.Join(candidates, "  "))
+	}
+}
+
+// refresh redraws the prompt and line and positions the cursor
+func (editor *LineEditor) refresh(state *lineState) {
+	fmt.Fprintf(editor.out, "\r%s%s\x1b[K", state.prompt, string(state.buffer))
+	if back := len(state.buffer) - state.cursor; back > 0 {
+		fmt.Fprintf(editor.out, "\x1b[%dD", back)
+	}
+}
+
+func (state *lineState) insert(text string) {
+	runes := []rune(text)
+	buffer := make([]rune, 0, len(state.buffer)+len(runes))
+	buffer = append(buffer, state.buffer[:state.cursor]...)
+	buffer = append(buffer, runes...)
+	state.buffer = append(buffer, state.buffer[state.cursor:]...)
+	state.cursor += len(runes)
+}
+
+func (state *lineState) deleteAt(index int) {
+	if index < len(state.buffer) {
+		state.buffer = append(state.buffer[:index], state.buffer[index+1:]...)
+	}
+}
+
+func (state *lineState) set(text string) {
+	state.buffer = []rune(text)
+	state.cursor = len(state.buffer)
+}
@@ -0,0 +1,110 @@
+package shell
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// DefaultHistoryLimit is the number of entries kept when no limit is given
+const DefaultHistoryLimit = 1000
+
+// History keeps entered lines in memory and, when it has a path, appends them to a file
+type History struct {
+	mutex   sync.Mutex
+	entries []string
+	path    string
+	limit   int
+}
+
+// NewHistory creates an in-memory history; limit <= 0 uses DefaultHistoryLimit
+func NewHistory(limit int) *History {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	return &History{limit: limit}
+}
+
+// LoadHistory reads the history file at path, which need not exist yet,
+// and appends new entries to it
+func LoadHistory(path string, limit int) (*History, error) {
+	history := NewHistory(limit)
+	history.path = path
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		history.push(scanner.Text())
+		lines++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file '%s': %w", path, err)
+	}
+
+	// Compact the file once it holds twice the entries kept
+	if lines > 2*history.limit {
+		data := strings.Join(history.entries, "\n") + "\n"
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			return nil, fmt.Errorf("failed to compact history file '%s': %w", path, err)
+		}
+	}
+	return history, nil
+}
+
+// Add records a line, skipping blank lines and repeats of the previous entry
+func (history *History) Add(line string) error {
+	line = strings.TrimSpace(line)
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+	if line == "" || strings.ContainsAny(line, "\r\n") ||
+		(len(history.entries) > 0 && history.entries[len(history.entries)-1] == line) {
+		return nil
+	}
+	history.pushLocked(line)
+
+	if history.path == "" {
+		return nil
+	}
+	file, err := os.OpenFile(history.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history file '%s': %w", history.path, err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(line + "\n"); err != nil {
+		return fmt.Errorf("failed to write history file '%s': %w", history.path, err)
+	}
+	return nil
+}
+
+// Entries returns the recorded lines, oldest first
+func (history *History) Entries() []string {
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+	return append([]string(nil), history.entries...)
+}
+
+func (history *History) push(line string) {
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+	if strings.TrimSpace(line) != "" {
+		history.pushLocked(line)
+	}
+}
+
+func (history *History) pushLocked(line string) {
+	history.entries = append(history.entries, line)
+	if len(history.entries) > history.limit {
+		history.entries = history.entries[len(history.entries)-history.limit:]
+	}
+}
@@ -0,0 +1,249 @@
+// Package shell implements an interactive client for Janus servers with
+// manifest-aware tab completion, client-side validation and history
+package shell
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+)
+
+// Config configures a shell
+type Config struct {
+	Prompt  string        // Defaults to "janus> "
+	Timeout time.Duration // Per request; 0 uses the client default
+	History *History      // nil keeps no history
+}
+
+// Shell sends requests typed as `name key=value ...` or `name {json}` and prints the responses
+type Shell struct {
+	client    *protocol.JanusClient
+	manifest  *manifest.Manifest
+	completer *Completer
+	history   *History
+	out       io.Writer
+	prompt    string
+	timeout   time.Duration
+}
+
+// commands are handled by the shell itself; they start with a dot so they never shadow requests
+var commands = map[string]string{
+	".describe": "Show the arguments of a request: .describe <request>",
+	".exit":     "Leave the shell (also Ctrl-D)",
+	".help":     "Show this help",
+	".history":  "List previous lines",
+	".requests": "List the requests the server offers",
+}
+
+// commandNames returns the shell commands in order
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates a shell for a client; m may be nil when the server manifest is unavailable
+func New(client *protocol.JanusClient, m *manifest.Manifest, out io.Writer, config Config) *Shell {
+	if config.Prompt == "" {
+		config.Prompt = "janus> "
+	}
+	history := config.History
+	if history == nil {
+		history = NewHistory(0)
+	}
+	return &Shell{
+		client:    client,
+		manifest:  m,
+		completer: NewCompleter(m),
+		history:   history,
+		out:       out,
+		prompt:    config.Prompt,
+		timeout:   config.Timeout,
+	}
+}
+
+// Run reads and executes lines until input ends or .exit
+// Terminal input is edited in raw mode with completion; other input is read line by line
+func (shell *Shell) Run(ctx context.Context, in io.Reader) error {
+	if file, ok := in.(*os.File); ok && isTerminal(file.Fd()) {
+		restore, err := makeRaw(file.Fd())
+		if err == nil {
+			defer restore()
+			return shell.runEditor(ctx, NewLineEditor(in, shell.out, shell.history, shell.completer))
+		}
+	}
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if shell.Execute(ctx, scanner.Text()) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// runEditor reads lines with the editor until Ctrl-D or .exit
+func (shell *Shell) runEditor(ctx context.Context, editor *LineEditor) error {
+	for {
+		line, err := editor.ReadLine(shell.prompt)
+		switch {
+		case err == ErrInterrupted:
+			continue
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		}
+		if shell.Execute(ctx, line) {
+			return nil
+		}
+	}
+}
+
+// Execute runs one line and reports whether the shell should exit
+func (shell *Shell) Execute(ctx context.Context, line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return false
+	}
+	if err := shell.history.Add(line); err != nil {
+		fmt.Fprintf(shell.out, "warning: %v\n", err)
+	}
+
+	name, rest, _ := strings.Cut(line, " ")
+	if strings.HasPrefix(name, ".") {
+		return shell.runCommand(name, strings.TrimSpace(rest))
+	}
+
+	args, err := ParseArgs(rest)
+	if err != nil {
+		fmt.Fprintf(shell.out, "error: %v\n", err)
+		return false
+	}
+	if shell.manifest != nil {
+		if request, exists := shell.manifest.Requests[name]; exists {
+			if argErrors := shell.manifest.ValidateRequestArgsAll(request, args); len(argErrors) > 0 {
+				fmt.Fprintf(shell.out, "invalid arguments for %s (not sent):\n", name)
+				for _, argError := range argErrors {
+					fmt.Fprintf(shell.out, "  %s: %s\n", errorPath(argError.Path, argError.Field), argError.Message)
+				}
+				return false
+			}
+		}
+	}
+
+	var options []protocol.RequestOptions
+	if shell.timeout > 0 {
+		options = append(options, protocol.RequestOptions{Timeout: shell.timeout})
+	}
+	started := time.Now()
+	response, err := shell.client.SendRequest(ctx, name, args, options...)
+	elapsed := time.Since(started).Round(time.Microsecond)
+	if err != nil {
+		fmt.Fprintf(shell.out, "error: %v\n", err)
+		return false
+	}
+	shell.printResponse(response, elapsed)
+	return false
+}
+
+// runCommand executes a dot command
+func (shell *Shell) runCommand(name, argument string) bool {
+	switch name {
+	case ".exit", ".quit":
+		return true
+	case ".help":
+		fmt.Fprintln(shell.out, "Send a request as `name key=value ...` or `name {\"key\": value}`; Tab completes names and keys.")
+		for _, command := range commandNames() {
+			fmt.Fprintf(shell.out, "  %-10s %s\n", command, commands[command])
+		}
+	case ".history":
+		for i, entry := range shell.history.Entries() {
+			fmt.Fprintf(shell.out, "%4d  %s\n", i+1, entry)
+		}
+	case ".requests":
+		for _, request := range shell.completer.requestNames() {
+			fmt.Fprintf(shell.out, "  %-24s %s\n", request, shell.completer.requests[request].Description)
+		}
+	case ".describe":
+		shell.describe(argument)
+	default:
+		fmt.Fprintf(shell.out, "unknown command %s (see .help)\n", name)
+	}
+	return false
+}
+
+// describe prints a request's description and arguments
+func (shell *Shell) describe(name string) {
+	request, exists := shell.completer.requests[name]
+	if !exists {
+		fmt.Fprintf(shell.out, "unknown request '%s' (see .requests)\n", name)
+		return
+	}
+	fmt.Fprintf(shell.out, "%s: %s\n", name, request.Description)
+
+	names := make([]string, 0, len(request.Args))
+	for argName := range request.Args {
+		names = append(names, argName)
+	}
+	sort.Strings(names)
+	for _, argName := range names {
+		arg := request.Args[argName]
+		argType := arg.Type
+		if arg.ModelRef != "" {
+			argType = arg.ModelRef
+		}
+		required := ""
+		if arg.Required {
+			required = " (required)"
+		}
+		fmt.Fprintf(shell.out, "  %s: %s%s  %s\n", argName, argType, required, arg.Description)
+	}
+}
+
+// printResponse writes a result as indented JSON, or an error with its code name
+func (shell *Shell) printResponse(response *models.JanusResponse, elapsed time.Duration) {
+	if rpcErr := response.Error; rpcErr != nil {
+		fmt.Fprintf(shell.out, "error %s (%d): %s\n", rpcErr.Code.String(), int(rpcErr.Code), rpcErr.Message)
+		if rpcErr.Data != nil {
+			if rpcErr.Data.Details != "" {
+				fmt.Fprintf(shell.out, "  %s\n", rpcErr.Data.Details)
+			}
+			for _, validationErr := range manifest.ValidationErrorsFrom(rpcErr) {
+				fmt.Fprintf(shell.out, "  %s: %s\n", errorPath(validationErr.Path, validationErr.Field), validationErr.Message)
+			}
+		}
+		fmt.Fprintf(shell.out, "(%v)\n", elapsed)
+		return
+	}
+
+	data, err := json.MarshalIndent(response.Result, "", "  ")
+	if err != nil {
+		fmt.Fprintf(shell.out, "%v\n", response.Result)
+	} else {
+		fmt.Fprintln(shell.out, string(data))
+	}
+	fmt.Fprintf(shell.out, "(%v)\n", elapsed)
+}
+
+// errorPath prefers the JSON pointer of a validation error over its field name
+func errorPath(path, field string) string {
+	if path != "" {
+		return path
+	}
+	return field
+}
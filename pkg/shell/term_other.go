@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package shell
+
+import "fmt"
+
+// isTerminal is false where raw mode is unsupported, so input is read line by line
+func isTerminal(fd uintptr) bool {
+	return false
+}
+
+func makeRaw(fd uintptr) (func() error, error) {
+	return nil, fmt.Errorf("raw terminal mode is not supported on this platform")
+}
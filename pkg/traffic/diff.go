@@ -0,0 +1,172 @@
+package traffic
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"GoJanus/pkg/models"
+)
+
+// DefaultIgnoredFields are volatile response fields left out of comparisons:
+// the response id and request id, and every "timestamp" key
+var DefaultIgnoredFields = []string{"/id", "/request_id", "timestamp"}
+
+// Difference is one mismatch between a captured and a replayed response
+type Difference struct {
+	Path     string      `json:"path"` // JSON pointer into the response, e.g. /result/items/0/name
+	Message  string      `json:"message"`
+	Expected interface{} `json:"expected,omitempty"`
+	Actual   interface{} `json:"actual,omitempty"`
+}
+
+// String formats the difference for text output
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s", d.Path, d.Message)
+}
+
+// ignoreSet matches JSON pointers against ignored fields
+// Entries starting with "/" match that exact pointer; bare names match the key at any depth
+type ignoreSet struct {
+	paths map[string]bool
+	names map[string]bool
+}
+
+func newIgnoreSet(fields []string) ignoreSet {
+	set := ignoreSet{paths: map[string]bool{}, names: map[string]bool{}}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		switch {
+		case field == "":
+		case strings.HasPrefix(field, "/"):
+			set.paths[field] = true
+		default:
+			set.names[field] = true
+		}
+	}
+	return set
+}
+
+func (set ignoreSet) ignores(path, key string) bool {
+	return set.paths[path] || set.names[key]
+}
+
+// DiffResponses compares two responses by their JSON encoding, skipping the ignored fields
+// A nil ignore list uses DefaultIgnoredFields
+func DiffResponses(expected, actual *models.JanusResponse, ignore []string) ([]Difference, error) {
+	if ignore == nil {
+		ignore = DefaultIgnoredFields
+	}
+	expectedValue, err := normalize(expected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode captured response: %w", err)
+	}
+	actualValue, err := normalize(actual)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode replayed response: %w", err)
+	}
+
+	var differences []Difference
+	diffValues("", expectedValue, actualValue, newIgnoreSet(ignore), &differences)
+	return differences, nil
+}
+
+// normalize round-trips a value through JSON so numbers and maps compare uniformly
+func normalize(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func diffValues(path string, expected, actual interface{}, ignore ignoreSet, differences *[]Difference) {
+	switch expectedValue := expected.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(expectedValue)+len(actualValue))
+		for key := range expectedValue {
+			keys = append(keys, key)
+		}
+		for key := range actualValue {
+			if _, exists := expectedValue[key]; !exists {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childPath := path + "/" + escapePointer(key)
+			if ignore.ignores(childPath, key) {
+				continue
+			}
+			expectedChild, inExpected := expectedValue[key]
+			actualChild, inActual := actualValue[key]
+			switch {
+			case !inActual:
+				*differences = append(*differences, Difference{Path: childPath, Message: "missing from replayed response", Expected: expectedChild})
+			case !inExpected:
+				*differences = append(*differences, Difference{Path: childPath, Message: "not in captured response", Actual: actualChild})
+			default:
+				diffValues(childPath, expectedChild, actualChild, ignore, differences)
+			}
+		}
+		return
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(expectedValue) || i < len(actualValue); i++ {
+			childPath := path + "/" + strconv.Itoa(i)
+			switch {
+			case i >= len(actualValue):
+				*differences = append(*differences, Difference{Path: childPath, Message: "missing from replayed response", Expected: expectedValue[i]})
+			case i >= len(expectedValue):
+				*differences = append(*differences, Difference{Path: childPath, Message: "not in captured response", Actual: actualValue[i]})
+			default:
+				diffValues(childPath, expectedValue[i], actualValue[i], ignore, differences)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		if path == "" {
+			path = "/"
+		}
+		*differences = append(*differences, Difference{
+			Path:     path,
+			Message:  fmt.Sprintf("expected %s, got %s", describe(expected), describe(actual)),
+			Expected: expected,
+			Actual:   actual,
+		})
+	}
+}
+
+// describe renders a value compactly for difference messages
+func describe(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	if len(data) > 80 {
+		return string(data[:77]) + "..."
+	}
+	return string(data)
+}
+
+// escapePointer escapes a key for use in a JSON pointer (RFC 6901)
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,134 @@
+// Package traffic captures request/response pairs to JSONL files and replays them
+// against a server to find behaviour changes
+package traffic
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"GoJanus/pkg/models"
+)
+
+// Record is one captured exchange, written as a single JSONL line
+type Record struct {
+	Time       time.Time             `json:"time"`             // When the server received the request
+	DurationMs float64               `json:"duration_ms"`      // Time spent producing the response
+	Client     string                `json:"client,omitempty"` // Sender address; empty for unbound clients
+	Request    *models.JanusRequest  `json:"request"`
+	Response   *models.JanusResponse `json:"response"`
+}
+
+// Duration returns the recorded processing time
+func (record *Record) Duration() time.Duration {
+	return time.Duration(record.DurationMs * float64(time.Millisecond))
+}
+
+// Recorder appends records to a writer, one JSON object per line
+// It is safe for concurrent use
+type Recorder struct {
+	mutex  sync.Mutex
+	writer io.Writer
+	closer io.Closer
+}
+
+// NewRecorder writes records to w
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{writer: w}
+}
+
+// OpenRecorder appends records to the file at path, creating it if needed
+func OpenRecorder(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open capture file '%s': %w", path, err)
+	}
+	return &Recorder{writer: file, closer: file}, nil
+}
+
+// Record writes an exchange that started at started and took duration
+func (recorder *Recorder) Record(request *models.JanusRequest, response *models.JanusResponse, client string, started time.Time, duration time.Duration) error {
+	return recorder.Write(&Record{
+		Time:       started.UTC(),
+		DurationMs: float64(duration) / float64(time.Millisecond),
+		Client:     client,
+		Request:    request,
+		Response:   response,
+	})
+}
+
+// Write appends a record as one line
+func (recorder *Recorder) Write(record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode capture record: %w", err)
+	}
+	data = append(data, '\n')
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if recorder.writer == nil {
+		return fmt.Errorf("recorder is closed")
+	}
+	if _, err := recorder.writer.Write(data); err != nil {
+		return fmt.Errorf("failed to write capture record: %w", err)
+	}
+	return nil
+}
+
+// Close stops recording and closes the file opened by OpenRecorder
+func (recorder *Recorder) Close() error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.writer = nil
+	if recorder.closer == nil {
+		return nil
+	}
+	err := recorder.closer.Close()
+	recorder.closer = nil
+	return err
+}
+
+// maxRecordSize bounds a single JSONL line; requests and responses are limited by datagram size
+const maxRecordSize = 4 * 1024 * 1024
+
+// ReadRecords decodes JSONL records, skipping blank lines
+func ReadRecords(r io.Reader) ([]*Record, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+
+	var records []*Record
+	for line := 1; scanner.Scan(); line++ {
+		data := scanner.Bytes()
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, fmt.Errorf("line %d: invalid capture record: %w", line, err)
+		}
+		if record.Request == nil {
+			return nil, fmt.Errorf("line %d: capture record has no request", line)
+		}
+		records = append(records, &record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read capture records: %w", err)
+	}
+	return records, nil
+}
+
+// LoadRecords reads a capture file
+func LoadRecords(path string) ([]*Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open capture file '%s': %w", path, err)
+	}
+	defer file.Close()
+	return ReadRecords(file)
+}
@@ -0,0 +1,98 @@
+package traffic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"GoJanus/pkg/protocol"
+)
+
+// ReplayOptions configures a replay
+type ReplayOptions struct {
+	Timeout time.Duration // Per request; 0 uses the captured request timeout, or 30 seconds
+	Ignore  []string      // Fields left out of comparisons; nil uses DefaultIgnoredFields
+}
+
+// ReplayResult is the outcome of resending one captured request
+type ReplayResult struct {
+	Index       int          `json:"index"` // Position in the capture, starting at 0
+	Request     string       `json:"request"`
+	CapturedID  string       `json:"captured_id"`
+	DurationMs  float64      `json:"duration_ms"`
+	Differences []Difference `json:"differences,omitempty"`
+	Error       string       `json:"error,omitempty"` // Set when no response could be compared
+}
+
+// Matched reports whether the replayed response equals the captured one
+func (result *ReplayResult) Matched() bool {
+	return result.Error == "" && len(result.Differences) == 0
+}
+
+// ReplayReport summarizes a replay
+type ReplayReport struct {
+	Results    []*ReplayResult `json:"results"`
+	Matched    int             `json:"matched"`
+	Mismatched int             `json:"mismatched"`
+	Failed     int             `json:"failed"`
+}
+
+// OK reports whether every replayed response matched
+func (report *ReplayReport) OK() bool {
+	return report.Mismatched == 0 && report.Failed == 0
+}
+
+// Summary returns a one-line description of the report
+func (report *ReplayReport) Summary() string {
+	return fmt.Sprintf("%d replayed: %d matched, %d mismatched, %d failed",
+		len(report.Results), report.Matched, report.Mismatched, report.Failed)
+}
+
+// Replay resends the captured requests in order and compares each response with the capture
+// Records without a captured response are sent but only fail when the replay errors
+func Replay(ctx context.Context, client *protocol.JanusClient, records []*Record, options ReplayOptions) *ReplayReport {
+	report := &ReplayReport{Results: make([]*ReplayResult, 0, len(records))}
+	for i, record := range records {
+		result := replayRecord(ctx, client, record, options)
+		result.Index = i
+		report.Results = append(report.Results, result)
+
+		switch {
+		case result.Error != "":
+			report.Failed++
+		case len(result.Differences) > 0:
+			report.Mismatched++
+		default:
+			report.Matched++
+		}
+	}
+	return report
+}
+
+func replayRecord(ctx context.Context, client *protocol.JanusClient, record *Record, options ReplayOptions) *ReplayResult {
+	result := &ReplayResult{Request: record.Request.Request, CapturedID: record.Request.ID}
+
+	requestOptions := protocol.RequestOptions{Timeout: options.Timeout}
+	if requestOptions.Timeout == 0 && record.Request.Timeout != nil && *record.Request.Timeout > 0 {
+		requestOptions.Timeout = time.Duration(*record.Request.Timeout * float64(time.Second))
+	}
+
+	started := time.Now()
+	response, err := client.SendRequest(ctx, record.Request.Request, record.Request.Args, requestOptions)
+	result.DurationMs = float64(time.Since(started)) / float64(time.Millisecond)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if record.Response == nil {
+		return result
+	}
+
+	differences, err := DiffResponses(record.Response, response, options.Ignore)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Differences = differences
+	return result
+}
@@ -0,0 +1,360 @@
+package wire
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"unicode/utf8"
+)
+
+// CBORMagic starts every CBOR message: the self-describe tag 55799 (RFC 8949 section 3.4.6)
+// No JSON document can start with 0xd9, so the first bytes select the encoding
+var CBORMagic = []byte{0xd9, 0xd9, 0xf7}
+
+// maxCBORDepth bounds the nesting of decoded arrays and maps
+const maxCBORDepth = 512
+
+const (
+	cborUnsigned = 0 << 5
+	cborNegative = 1 << 5
+	cborBytes    = 2 << 5
+	cborText     = 3 << 5
+	cborArray    = 4 << 5
+	cborMap      = 5 << 5
+	cborTag      = 6 << 5
+	cborSimple   = 7 << 5
+
+	cborFalse   = 0xf4
+	cborTrue    = 0xf5
+	cborNull    = 0xf6
+	cborFloat32 = 0xfa
+	cborFloat64 = 0xfb
+
+	selfDescribeTag = 55799
+)
+
+// MarshalCBOR encodes a value in the JSON data model as CBOR, prefixed with CBORMagic
+// []byte becomes a byte string; map keys are sorted, integral numbers become integers
+// and other numbers the shortest exact float. Types outside the data model (structs,
+// typed maps and slices) are encoded as their JSON form
+func MarshalCBOR(value interface{}) ([]byte, error) {
+	buffer := append([]byte{}, CBORMagic...)
+	return appendCBOR(buffer, value)
+}
+
+// UnmarshalCBOR decodes one CBOR item, with or without CBORMagic, into the JSON data model:
+// maps become map[string]interface{}, arrays []interface{}, numbers float64 and byte strings []byte
+// Map keys must be unique text strings; indefinite lengths and tags other than 55799 are rejected
+func UnmarshalCBOR(data []byte) (interface{}, error) {
+	d := &cborDecoder{data: data}
+	value, err := d.item(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.offset != len(data) {
+		return nil, fmt.Errorf("%d trailing bytes after CBOR item", len(data)-d.offset)
+	}
+	return value, nil
+}
+
+// IsCBOR reports whether data starts with CBORMagic
+func IsCBOR(data []byte) bool {
+	return len(data) >= len(CBORMagic) && string(data[:len(CBORMagic)]) == string(CBORMagic)
+}
+
+func appendCBOR(buffer []byte, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(buffer, cborNull), nil
+	case bool:
+		if v {
+			return append(buffer, cborTrue), nil
+		}
+		return append(buffer, cborFalse), nil
+	case string:
+		return append(appendHead(buffer, cborText, uint64(len(v))), v...), nil
+	case []byte:
+		return append(appendHead(buffer, cborBytes, uint64(len(v))), v...), nil
+	case float64:
+		return appendFloat(buffer, v)
+	case float32:
+		return appendFloat(buffer, float64(v))
+	case int:
+		return appendInt(buffer, int64(v)), nil
+	case int8:
+		return appendInt(buffer, int64(v)), nil
+	case int16:
+		return appendInt(buffer, int64(v)), nil
+	case int32:
+		return appendInt(buffer, int64(v)), nil
+	case int64:
+		return appendInt(buffer, v), nil
+	case uint:
+		return appendHead(buffer, cborUnsigned, uint64(v)), nil
+	case uint8:
+		return appendHead(buffer, cborUnsigned, uint64(v)), nil
+	case uint16:
+		return appendHead(buffer, cborUnsigned, uint64(v)), nil
+	case uint32:
+		return appendHead(buffer, cborUnsigned, uint64(v)), nil
+	case uint64:
+		return appendHead(buffer, cborUnsigned, v), nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", v, err)
+		}
+		return appendFloat(buffer, f)
+	case []interface{}:
+		buffer = appendHead(buffer, cborArray, uint64(len(v)))
+		for _, item := range v {
+			var err error
+			if buffer, err = appendCBOR(buffer, item); err != nil {
+				return nil, err
+			}
+		}
+		return buffer, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buffer = appendHead(buffer, cborMap, uint64(len(v)))
+		for _, key := range keys {
+			buffer = append(appendHead(buffer, cborText, uint64(len(key))), key...)
+			var err error
+			if buffer, err = appendCBOR(buffer, v[key]); err != nil {
+				return nil, err
+			}
+		}
+		return buffer, nil
+	}
+
+	// Nil pointers, maps and slices of other types are null, like in JSON
+	if rv := reflect.ValueOf(value); (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice) && rv.IsNil() {
+		return append(buffer, cborNull), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode %T as CBOR: %w", value, err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("cannot encode %T as CBOR: %w", value, err)
+	}
+	return appendCBOR(buffer, generic)
+}
+
+// appendHead appends the initial byte and argument of an item
+func appendHead(buffer []byte, major byte, argument uint64) []byte {
+	switch {
+	case argument < 24:
+		return append(buffer, major|byte(argument))
+	case argument <= math.MaxUint8:
+		return append(buffer, major|24, byte(argument))
+	case argument <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buffer, major|25), uint16(argument))
+	case argument <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buffer, major|26), uint32(argument))
+	}
+	return binary.BigEndian.AppendUint64(append(buffer, major|27), argument)
+}
+
+func appendInt(buffer []byte, v int64) []byte {
+	if v < 0 {
+		return appendHead(buffer, cborNegative, uint64(-(v + 1)))
+	}
+	return appendHead(buffer, cborUnsigned, uint64(v))
+}
+
+// appendFloat encodes integral values that survive a float64 round trip as integers
+func appendFloat(buffer []byte, v float64) ([]byte, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, fmt.Errorf("unsupported number %v", v)
+	}
+	if v == math.Trunc(v) && math.Abs(v) < 1<<53 && !(v == 0 && math.Signbit(v)) {
+		return appendInt(buffer, int64(v)), nil
+	}
+	if float64(float32(v)) == v {
+		return binary.BigEndian.AppendUint32(append(buffer, cborFloat32), math.Float32bits(float32(v))), nil
+	}
+	return binary.BigEndian.AppendUint64(append(buffer, cborFloat64), math.Float64bits(v)), nil
+}
+
+type cborDecoder struct {
+	data   []byte
+	offset int
+}
+
+var errCBORTruncated = errors.New("truncated CBOR data")
+
+func (d *cborDecoder) item(depth int) (interface{}, error) {
+	if depth > maxCBORDepth {
+		return nil, fmt.Errorf("CBOR nesting exceeds %d levels", maxCBORDepth)
+	}
+	if d.offset >= len(d.data) {
+		return nil, errCBORTruncated
+	}
+	initial := d.data[d.offset]
+	major, info := initial&0xe0, initial&0x1f
+	if major == cborSimple {
+		return d.simple(info)
+	}
+	if info == 31 {
+		return nil, fmt.Errorf("indefinite-length CBOR items are not supported")
+	}
+	argument, err := d.argument(info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+		return float64(argument), nil
+	case cborNegative:
+		return -1 - float64(argument), nil
+	case cborBytes, cborText:
+		content, err := d.take(argument)
+		if err != nil {
+			return nil, err
+		}
+		if major == cborBytes {
+			return append([]byte{}, content...), nil
+		}
+		if !utf8.Valid(content) {
+			return nil, fmt.Errorf("CBOR text string is not valid UTF-8")
+		}
+		return string(content), nil
+	case cborArray:
+		// Every item takes at least one byte, which bounds the allocation by the input size
+		if argument > uint64(len(d.data)-d.offset) {
+			return nil, errCBORTruncated
+		}
+		items := make([]interface{}, 0, argument)
+		for i := uint64(0); i < argument; i++ {
+			item, err := d.item(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case cborMap:
+		if argument > uint64(len(d.data)-d.offset)/2 {
+			return nil, errCBORTruncated
+		}
+		object := make(map[string]interface{}, argument)
+		for i := uint64(0); i < argument; i++ {
+			key, err := d.item(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			name, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("CBOR map keys must be text strings, got %T", key)
+			}
+			if _, duplicate := object[name]; duplicate {
+				return nil, fmt.Errorf("duplicate CBOR map key %q", name)
+			}
+			if object[name], err = d.item(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return object, nil
+	}
+
+	// Tags: only the self-describe tag, which has no meaning of its own
+	if argument != selfDescribeTag {
+		return nil, fmt.Errorf("unsupported CBOR tag %d", argument)
+	}
+	return d.item(depth + 1)
+}
+
+// argument reads the argument of the item at the offset and moves past its head
+func (d *cborDecoder) argument(info byte) (uint64, error) {
+	d.offset++
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info > 27:
+		return 0, fmt.Errorf("invalid CBOR additional information %d", info)
+	}
+	size := uint64(1) << (info - 24)
+	content, err := d.take(size)
+	if err != nil {
+		return 0, err
+	}
+	var argument uint64
+	for _, b := range content {
+		argument = argument<<8 | uint64(b)
+	}
+	return argument, nil
+}
+
+func (d *cborDecoder) simple(info byte) (interface{}, error) {
+	d.offset++
+	switch info {
+	case cborFalse & 0x1f:
+		return false, nil
+	case cborTrue & 0x1f:
+		return true, nil
+	case cborNull & 0x1f, 0x17: // null and undefined
+		return nil, nil
+	case 25:
+		content, err := d.take(2)
+		if err != nil {
+			return nil, err
+		}
+		return halfToFloat(binary.BigEndian.Uint16(content)), nil
+	case cborFloat32 & 0x1f:
+		content, err := d.take(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(content))), nil
+	case cborFloat64 & 0x1f:
+		content, err := d.take(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(content)), nil
+	}
+	return nil, fmt.Errorf("unsupported CBOR simple value %d", info)
+}
+
+func (d *cborDecoder) take(size uint64) ([]byte, error) {
+	if size > uint64(len(d.data)-d.offset) {
+		return nil, errCBORTruncated
+	}
+	content := d.data[d.offset : d.offset+int(size)]
+	d.offset += int(size)
+	return content, nil
+}
+
+// halfToFloat converts an IEEE 754 half-precision float
+func halfToFloat(half uint16) float64 {
+	exponent := int(half>>10) & 0x1f
+	mantissa := float64(half & 0x3ff)
+	var value float64
+	switch exponent {
+	case 0:
+		value = math.Ldexp(mantissa, -24)
+	case 31:
+		if mantissa == 0 {
+			value = math.Inf(1)
+		} else {
+			value = math.NaN()
+		}
+	default:
+		value = math.Ldexp(mantissa+1024, exponent-25)
+	}
+	if half&0x8000 != 0 {
+		return -value
+	}
+	return value
+}
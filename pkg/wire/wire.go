@@ -0,0 +1,555 @@
+// Package wire encodes and strictly decodes Janus requests and responses in the
+// cross-language wire format shared with the Rust, Swift and TypeScript implementations
+//
+// Decoding rejects unknown and missing mandatory fields, timestamps in any other format,
+// a method that differs from the request name and responses whose success, result and
+// error contradict each other. Violations are reported as *models.JSONRPCError:
+// PARSE_ERROR for data that cannot be decoded, INVALID_REQUEST for malformed requests
+// and MESSAGE_FRAMING_ERROR for malformed responses
+//
+// Messages are JSON by default. CBOR messages start with CBORMagic, carry the same fields
+// and are checked by the same rules; decoding detects the encoding from the first bytes
+package wire
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"GoJanus/pkg/models"
+)
+
+// TimestampLayout is the timestamp format of requests and responses: RFC 3339 in UTC with milliseconds
+const TimestampLayout = "2006-01-02T15:04:05.000Z"
+
+// Encoding is the serialization of a message
+type Encoding string
+
+const (
+	JSON Encoding = "json" // The default, understood by every implementation
+	CBOR Encoding = "cbor" // RFC 8949, prefixed with CBORMagic; byte strings carry binary data without base64
+)
+
+// Encodings lists the supported encodings
+func Encodings() []Encoding {
+	return []Encoding{JSON, CBOR}
+}
+
+// ParseEncoding returns the encoding named by name; empty selects JSON
+func ParseEncoding(name string) (Encoding, error) {
+	switch Encoding(name) {
+	case "", JSON:
+		return JSON, nil
+	case CBOR:
+		return CBOR, nil
+	}
+	return "", fmt.Errorf("unsupported encoding '%s' (supported: json, cbor)", name)
+}
+
+// DetectEncoding returns the encoding of a message from its first bytes
+func DetectEncoding(data []byte) Encoding {
+	if IsCBOR(data) {
+		return CBOR
+	}
+	return JSON
+}
+
+// field describes a wire field
+type field struct {
+	mandatory bool
+	nullable  bool // null is accepted, and means the same as an absent field
+}
+
+var requestFields = map[string]field{
+	"id":        {mandatory: true},
+	"method":    {mandatory: true},
+	"request":   {mandatory: true},
+	"timestamp": {mandatory: true},
+	"reply_to":  {},
+	"args":      {nullable: true},
+	"timeout":   {},
+}
+
+var responseFields = map[string]field{
+	"id":         {mandatory: true},
+	"request_id": {mandatory: true},
+	"success":    {mandatory: true},
+	"timestamp":  {mandatory: true},
+	"result":     {nullable: true},
+	"error":      {nullable: true},
+}
+
+var errorFields = map[string]field{
+	"code":    {mandatory: true},
+	"message": {mandatory: true},
+	"data":    {nullable: true},
+}
+
+// EncodeRequest validates a request and encodes it as JSON
+func EncodeRequest(request *models.JanusRequest) ([]byte, error) {
+	return EncodeRequestAs(request, JSON)
+}
+
+// EncodeRequestAs validates a request and encodes it; an empty encoding selects JSON
+func EncodeRequestAs(request *models.JanusRequest, encoding Encoding) ([]byte, error) {
+	if err := ValidateRequest(request); err != nil {
+		return nil, err
+	}
+	var data []byte
+	var err error
+	switch encoding {
+	case "", JSON:
+		data, err = json.Marshal(request)
+	case CBOR:
+		data, err = MarshalCBOR(requestMap(request))
+	default:
+		err = fmt.Errorf("unsupported encoding '%s'", encoding)
+	}
+	if err != nil {
+		return nil, invalid(models.InvalidRequest, "failed to encode request: %v", err)
+	}
+	return data, nil
+}
+
+// DecodeRequest strictly decodes a JSON or CBOR request
+func DecodeRequest(data []byte) (*models.JanusRequest, error) {
+	if !IsCBOR(data) {
+		return decodeRequestJSON(data)
+	}
+	object, err := decodeCBORMap(data, models.InvalidRequest)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFieldMap(object, requestFields, models.InvalidRequest); err != nil {
+		return nil, err
+	}
+	fields := newCBORFields(object, models.InvalidRequest)
+	request := &models.JanusRequest{
+		ID:        fields.text("id"),
+		Method:    fields.text("method"),
+		Request:   fields.text("request"),
+		ReplyTo:   fields.optionalText("reply_to"),
+		Args:      fields.object("args"),
+		Timeout:   fields.optionalNumber("timeout"),
+		Timestamp: fields.text("timestamp"),
+	}
+	if *fields.err != nil {
+		return nil, *fields.err
+	}
+	if err := ValidateRequest(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func decodeRequestJSON(data []byte) (*models.JanusRequest, error) {
+	if err := checkFields(data, requestFields, models.InvalidRequest); err != nil {
+		return nil, err
+	}
+	var request models.JanusRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, invalid(models.InvalidRequest, "%s", typeError(err))
+	}
+	if err := ValidateRequest(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// ValidateRequest checks the values of a request
+func ValidateRequest(request *models.JanusRequest) error {
+	switch {
+	case request == nil:
+		return invalid(models.InvalidRequest, "request is missing")
+	case request.ID == "":
+		return invalid(models.InvalidRequest, "id must not be empty")
+	case request.Request == "":
+		return invalid(models.InvalidRequest, "request must not be empty")
+	case request.Method != request.Request:
+		return invalid(models.InvalidRequest, "method %q does not match request %q", request.Method, request.Request)
+	case request.ReplyTo != nil && *request.ReplyTo == "":
+		return invalid(models.InvalidRequest, "reply_to must not be empty when present")
+	case request.Timeout != nil && *request.Timeout <= 0:
+		return invalid(models.InvalidRequest, "timeout must be positive, got %v", *request.Timeout)
+	}
+	return checkTimestamp(request.Timestamp, models.InvalidRequest)
+}
+
+// EncodeResponse validates a response and encodes it as JSON
+func EncodeResponse(response *models.JanusResponse) ([]byte, error) {
+	return EncodeResponseAs(response, JSON)
+}
+
+// EncodeResponseAs validates a response and encodes it; an empty encoding selects JSON
+func EncodeResponseAs(response *models.JanusResponse, encoding Encoding) ([]byte, error) {
+	if err := ValidateResponse(response); err != nil {
+		return nil, err
+	}
+	var data []byte
+	var err error
+	switch encoding {
+	case "", JSON:
+		data, err = json.Marshal(response)
+	case CBOR:
+		data, err = MarshalCBOR(responseMap(response))
+	default:
+		err = fmt.Errorf("unsupported encoding '%s'", encoding)
+	}
+	if err != nil {
+		return nil, invalid(models.MessageFramingError, "failed to encode response: %v", err)
+	}
+	return data, nil
+}
+
+// DecodeResponse strictly decodes a JSON or CBOR response
+func DecodeResponse(data []byte) (*models.JanusResponse, error) {
+	if !IsCBOR(data) {
+		return decodeResponseJSON(data)
+	}
+	object, err := decodeCBORMap(data, models.MessageFramingError)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFieldMap(object, responseFields, models.MessageFramingError); err != nil {
+		return nil, err
+	}
+	fields := newCBORFields(object, models.MessageFramingError)
+	response := &models.JanusResponse{
+		Result:    object["result"],
+		Success:   fields.boolean("success"),
+		RequestID: fields.text("request_id"),
+		ID:        fields.text("id"),
+		Timestamp: fields.text("timestamp"),
+	}
+	if errorObject := fields.object("error"); errorObject != nil {
+		if err := checkFieldMap(errorObject, errorFields, models.MessageFramingError); err != nil {
+			return nil, invalid(models.MessageFramingError, "error: %s", err.(*models.JSONRPCError).Data.Details)
+		}
+		response.Error = fields.nested(errorObject, "error").jsonrpcError()
+	}
+	if *fields.err != nil {
+		return nil, *fields.err
+	}
+	if err := ValidateResponse(response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func decodeResponseJSON(data []byte) (*models.JanusResponse, error) {
+	if err := checkFields(data, responseFields, models.MessageFramingError); err != nil {
+		return nil, err
+	}
+	var envelope struct {
+		Error json.RawMessage `json:"error"`
+	}
+	json.Unmarshal(data, &envelope)
+	if len(envelope.Error) > 0 && !bytes.Equal(envelope.Error, []byte("null")) {
+		if err := checkFields(envelope.Error, errorFields, models.MessageFramingError); err != nil {
+			return nil, invalid(models.MessageFramingError, "error: %s", err.(*models.JSONRPCError).Data.Details)
+		}
+	}
+	var response models.JanusResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, invalid(models.MessageFramingError, "%s", typeError(err))
+	}
+	if err := ValidateResponse(&response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ValidateResponse checks the values of a response and that success, result and error agree
+// The request_id may only be empty in an error response to a request whose id could not be read
+func ValidateResponse(response *models.JanusResponse) error {
+	switch {
+	case response == nil:
+		return invalid(models.MessageFramingError, "response is missing")
+	case response.ID == "":
+		return invalid(models.MessageFramingError, "id must not be empty")
+	case response.Success && response.Error != nil:
+		return invalid(models.MessageFramingError, "success is true but error is set")
+	case response.Success && response.RequestID == "":
+		return invalid(models.MessageFramingError, "request_id must not be empty in a success response")
+	case !response.Success && response.Error == nil:
+		return invalid(models.MessageFramingError, "success is false but error is missing")
+	case !response.Success && response.Result != nil:
+		return invalid(models.MessageFramingError, "success is false but result is set")
+	case response.Error != nil && response.Error.Message == "":
+		return invalid(models.MessageFramingError, "error.message must not be empty")
+	}
+	return checkTimestamp(response.Timestamp, models.MessageFramingError)
+}
+
+// requestMap holds the fields of a request as json.Marshal would write them, with values
+// such as byte strings kept as they are
+func requestMap(request *models.JanusRequest) map[string]interface{} {
+	fields := map[string]interface{}{
+		"id":        request.ID,
+		"method":    request.Method,
+		"request":   request.Request,
+		"timestamp": request.Timestamp,
+	}
+	if request.ReplyTo != nil {
+		fields["reply_to"] = *request.ReplyTo
+	}
+	if len(request.Args) > 0 {
+		fields["args"] = request.Args
+	}
+	if request.Timeout != nil {
+		fields["timeout"] = *request.Timeout
+	}
+	return fields
+}
+
+// responseMap holds the fields of a response as json.Marshal would write them
+func responseMap(response *models.JanusResponse) map[string]interface{} {
+	fields := map[string]interface{}{
+		"result":     response.Result,
+		"error":      nil,
+		"success":    response.Success,
+		"request_id": response.RequestID,
+		"id":         response.ID,
+		"timestamp":  response.Timestamp,
+	}
+	if rpcErr := response.Error; rpcErr != nil {
+		errorFields := map[string]interface{}{"code": int(rpcErr.Code), "message": rpcErr.Message}
+		if data := rpcErr.Data; data != nil {
+			dataFields := map[string]interface{}{}
+			if data.Details != "" {
+				dataFields["details"] = data.Details
+			}
+			if data.Field != "" {
+				dataFields["field"] = data.Field
+			}
+			if data.Value != nil {
+				dataFields["value"] = data.Value
+			}
+			if len(data.Constraints) > 0 {
+				dataFields["constraints"] = data.Constraints
+			}
+			if len(data.Context) > 0 {
+				dataFields["context"] = data.Context
+			}
+			errorFields["data"] = dataFields
+		}
+		fields["error"] = errorFields
+	}
+	return fields
+}
+
+// decodeCBORMap decodes a CBOR message that must be a map
+func decodeCBORMap(data []byte, code models.JSONRPCErrorCode) (map[string]interface{}, error) {
+	value, err := UnmarshalCBOR(data)
+	if err != nil {
+		return nil, invalid(models.ParseError, "invalid CBOR: %v", err)
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, invalid(code, "not a CBOR map")
+	}
+	return object, nil
+}
+
+// cborFields reads typed fields of a decoded CBOR map; absent and null fields read as zero values
+// Only the first type error is kept, in err, which nested objects share
+type cborFields struct {
+	values map[string]interface{}
+	code   models.JSONRPCErrorCode
+	prefix string // Names nested fields in errors, e.g. "error."
+	err    *error
+}
+
+func newCBORFields(values map[string]interface{}, code models.JSONRPCErrorCode) *cborFields {
+	return &cborFields{values: values, code: code, err: new(error)}
+}
+
+// nested reads the fields of an object field
+func (f *cborFields) nested(values map[string]interface{}, name string) *cborFields {
+	return &cborFields{values: values, code: f.code, prefix: f.prefix + name + ".", err: f.err}
+}
+
+// value returns a present, non-null field of the given Go kind
+func (f *cborFields) value(name, kind string) (interface{}, bool) {
+	value := f.values[name]
+	if value == nil || *f.err != nil {
+		return nil, false
+	}
+	if actualKind, actual := cborType(value); actualKind != kind {
+		*f.err = invalid(f.code, "field %q must be %s, got %s", f.prefix+name, jsonType(kind), actual)
+		return nil, false
+	}
+	return value, true
+}
+
+func (f *cborFields) text(name string) string {
+	value, _ := f.value(name, "string")
+	text, _ := value.(string)
+	return text
+}
+
+func (f *cborFields) optionalText(name string) *string {
+	if value, ok := f.value(name, "string"); ok {
+		text := value.(string)
+		return &text
+	}
+	return nil
+}
+
+func (f *cborFields) boolean(name string) bool {
+	value, _ := f.value(name, "bool")
+	flag, _ := value.(bool)
+	return flag
+}
+
+func (f *cborFields) optionalNumber(name string) *float64 {
+	if value, ok := f.value(name, "float64"); ok {
+		number := value.(float64)
+		return &number
+	}
+	return nil
+}
+
+func (f *cborFields) object(name string) map[string]interface{} {
+	value, _ := f.value(name, "map")
+	object, _ := value.(map[string]interface{})
+	return object
+}
+
+// jsonrpcError reads the fields of a JSON-RPC error object
+func (f *cborFields) jsonrpcError() *models.JSONRPCError {
+	rpcErr := &models.JSONRPCError{Message: f.text("message")}
+	if code := f.optionalNumber("code"); code != nil {
+		if *code != math.Trunc(*code) {
+			*f.err = invalid(f.code, "field %q must be an integer, got %v", f.prefix+"code", *code)
+		}
+		rpcErr.Code = models.JSONRPCErrorCode(*code)
+	}
+	if values := f.object("data"); values != nil {
+		data := f.nested(values, "data")
+		rpcErr.Data = &models.JSONRPCErrorData{
+			Details:     data.text("details"),
+			Field:       data.text("field"),
+			Value:       values["value"],
+			Constraints: data.object("constraints"),
+			Context:     data.object("context"),
+		}
+	}
+	return rpcErr
+}
+
+// cborType returns the Go kind and JSON type name of a decoded CBOR value
+func cborType(value interface{}) (string, string) {
+	switch value.(type) {
+	case string:
+		return "string", "string"
+	case bool:
+		return "bool", "bool"
+	case float64:
+		return "float64", "number"
+	case map[string]interface{}:
+		return "map", "object"
+	case []interface{}:
+		return "slice", "array"
+	case []byte:
+		return "slice", "byte string"
+	}
+	return fmt.Sprintf("%T", value), fmt.Sprintf("%T", value)
+}
+
+// checkFields rejects data that is not JSON or not an object, unknown fields and missing mandatory fields
+func checkFields(data []byte, fields map[string]field, code models.JSONRPCErrorCode) error {
+	if !json.Valid(data) {
+		return invalid(models.ParseError, "invalid JSON")
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		return invalid(code, "not a JSON object")
+	}
+	nulls := make(map[string]bool, len(object))
+	for name, value := range object {
+		nulls[name] = bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+	}
+	return checkFieldNames(nulls, fields, code)
+}
+
+// checkFieldMap applies the checks of checkFields to a decoded CBOR map
+func checkFieldMap(object map[string]interface{}, fields map[string]field, code models.JSONRPCErrorCode) error {
+	nulls := make(map[string]bool, len(object))
+	for name, value := range object {
+		nulls[name] = value == nil
+	}
+	return checkFieldNames(nulls, fields, code)
+}
+
+// checkFieldNames rejects unknown fields, null fields that are not nullable and missing mandatory fields
+// nulls holds every field of the message and whether its value is null
+func checkFieldNames(nulls map[string]bool, fields map[string]field, code models.JSONRPCErrorCode) error {
+	names := make([]string, 0, len(nulls))
+	for name := range nulls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		definition, known := fields[name]
+		if !known {
+			return invalid(code, "unknown field %q", name)
+		}
+		if nulls[name] && !definition.nullable {
+			return invalid(code, "field %q must not be null", name)
+		}
+	}
+
+	mandatory := make([]string, 0, len(fields))
+	for name, definition := range fields {
+		if definition.mandatory {
+			mandatory = append(mandatory, name)
+		}
+	}
+	sort.Strings(mandatory)
+	for _, name := range mandatory {
+		if _, present := nulls[name]; !present {
+			return invalid(code, "missing mandatory field %q", name)
+		}
+	}
+	return nil
+}
+
+// checkTimestamp requires the exact TimestampLayout
+func checkTimestamp(timestamp string, code models.JSONRPCErrorCode) error {
+	parsed, err := time.Parse(TimestampLayout, timestamp)
+	if err != nil || parsed.Format(TimestampLayout) != timestamp {
+		return invalid(code, "timestamp %q is not in the format %s", timestamp, TimestampLayout)
+	}
+	return nil
+}
+
+// typeError describes a field with the wrong JSON type
+func typeError(err error) string {
+	if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+		return fmt.Sprintf("field %q must be %s, got %s", typeErr.Field, jsonType(typeErr.Type.Kind().String()), typeErr.Value)
+	}
+	return err.Error()
+}
+
+// jsonType names the JSON type for a Go kind
+func jsonType(kind string) string {
+	switch kind {
+	case "string":
+		return "a string"
+	case "bool":
+		return "a boolean"
+	case "map", "struct":
+		return "an object"
+	case "float64", "int", "int64":
+		return "a number"
+	}
+	return kind
+}
+
+func invalid(code models.JSONRPCErrorCode, format string, args ...interface{}) *models.JSONRPCError {
+	return models.NewJSONRPCError(code, fmt.Sprintf(format, args...))
+}
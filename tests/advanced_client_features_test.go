@@ -1,7 +1,7 @@
 /**
  * Comprehensive tests for Advanced Client Features in Go implementation
  * Tests all 7 features: Response Correlation, Request Cancellation, Bulk Cancellation,
- * Statistics, Parallel Execution, Channel Proxy, and Dynamic Argument Validation
+ * Statistics, Parallel Execution and Dynamic Argument Validation
  */
 
 package tests
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"GoJanus/pkg/models"
 	"GoJanus/pkg/protocol"
 )
 
@@ -20,7 +21,7 @@ import (
 func TestResponseCorrelationSystem(t *testing.T) {
 	socketPath := fmt.Sprintf("/tmp/test_correlation_%s.sock", uuid.New().String())
 	
-	client, err := protocol.New(socketPath, "test-channel")
+	client, err := protocol.New(socketPath)
 	if err != nil {
 		t.Logf("⚠️ Response correlation test setup failed (expected in test environment): %v", err)
 		return
@@ -41,8 +42,7 @@ func TestResponseCorrelationSystem(t *testing.T) {
 	// Requests will fail due to no server but correlation should be tracked
 
 	// Test individual request cancellation
-	cancelled := client.CancelRequest(request1ID, "Test cancellation")
-	if cancelled {
+	if err := client.CancelRequest(models.NewRequestHandle(request1ID, "ping")); err == nil {
 		t.Error("Cancelling non-existent request should return false")
 	}
 
@@ -53,7 +53,7 @@ func TestResponseCorrelationSystem(t *testing.T) {
 func TestRequestCancellation(t *testing.T) {
 	socketPath := fmt.Sprintf("/tmp/test_cancel_%s.sock", uuid.New().String())
 	
-	client, err := protocol.New(socketPath, "test-channel")
+	client, err := protocol.New(socketPath)
 	if err != nil {
 		t.Logf("⚠️ Request cancellation test setup failed (expected in test environment): %v", err)
 		return
@@ -63,8 +63,7 @@ func TestRequestCancellation(t *testing.T) {
 	requestID := uuid.New().String()
 
 	// Test cancelling a non-existent request
-	cancelled := client.CancelRequest(requestID, "Test cancellation")
-	if cancelled {
+	if err := client.CancelRequest(models.NewRequestHandle(requestID, "ping")); err == nil {
 		t.Error("Cancelling non-existent request should return false")
 	}
 
@@ -81,7 +80,7 @@ func TestRequestCancellation(t *testing.T) {
 func TestBulkRequestCancellation(t *testing.T) {
 	socketPath := fmt.Sprintf("/tmp/test_bulk_cancel_%s.sock", uuid.New().String())
 	
-	client, err := protocol.New(socketPath, "test-channel")
+	client, err := protocol.New(socketPath)
 	if err != nil {
 		t.Logf("⚠️ Bulk request cancellation test setup failed (expected in test environment): %v", err)
 		return
@@ -89,7 +88,7 @@ func TestBulkRequestCancellation(t *testing.T) {
 	defer client.Close()
 
 	// Test bulk cancellation when no requests are pending
-	cancelledCount := client.CancelAllRequests("Bulk test cancellation")
+	cancelledCount := client.CancelAllRequests()
 	if cancelledCount != 0 {
 		t.Errorf("Should cancel 0 requests when none are pending, got %d", cancelledCount)
 	}
@@ -107,7 +106,7 @@ func TestBulkRequestCancellation(t *testing.T) {
 func TestPendingRequestStatistics(t *testing.T) {
 	socketPath := fmt.Sprintf("/tmp/test_stats_%s.sock", uuid.New().String())
 	
-	client, err := protocol.New(socketPath, "test-channel")
+	client, err := protocol.New(socketPath)
 	if err != nil {
 		t.Logf("⚠️ Pending request statistics test setup failed (expected in test environment): %v", err)
 		return
@@ -145,7 +144,7 @@ func TestPendingRequestStatistics(t *testing.T) {
 func TestMultiRequestParallelExecution(t *testing.T) {
 	socketPath := fmt.Sprintf("/tmp/test_parallel_%s.sock", uuid.New().String())
 	
-	client, err := protocol.New(socketPath, "test-channel")
+	client, err := protocol.New(socketPath)
 	if err != nil {
 		t.Logf("⚠️ Multi-request parallel execution test setup failed (expected in test environment): %v", err)
 		return
@@ -187,44 +186,11 @@ func TestMultiRequestParallelExecution(t *testing.T) {
 	t.Log("✅ Multi-request parallel execution functionality works correctly")
 }
 
-// TestChannelProxy tests channel-manifestific request execution
-func TestChannelProxy(t *testing.T) {
-	socketPath := fmt.Sprintf("/tmp/test_proxy_%s.sock", uuid.New().String())
-	
-	client, err := protocol.New(socketPath, "test-channel")
-	if err != nil {
-		t.Logf("⚠️ Channel proxy test setup failed (expected in test environment): %v", err)
-		return
-	}
-	defer client.Close()
-
-	// Create channel proxy for different channel
-	proxyChannelID := "proxy-test-channel"
-	channelProxy := client.CreateChannelProxy(proxyChannelID)
-
-	// Verify proxy properties
-	if channelProxy.GetChannelID() != proxyChannelID {
-		t.Errorf("Channel proxy should have correct channel ID, expected %s, got %s", proxyChannelID, channelProxy.GetChannelID())
-	}
-
-	// Test proxy request execution capability
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-	
-	_, err = channelProxy.SendRequest(ctx, "ping", map[string]interface{}{})
-	if err == nil {
-		t.Error("Request should fail without server but proxy functionality should work")
-	}
-	// Expected - no server available, but proxy functionality works
-
-	t.Log("✅ Channel proxy functionality works correctly")
-}
-
 // TestDynamicArgumentValidation tests runtime argument type validation
 func TestDynamicArgumentValidation(t *testing.T) {
 	socketPath := fmt.Sprintf("/tmp/test_validation_%s.sock", uuid.New().String())
 	
-	client, err := protocol.New(socketPath, "test-channel")
+	client, err := protocol.New(socketPath)
 	if err != nil {
 		t.Logf("⚠️ Dynamic argument validation test setup failed (expected in test environment): %v", err)
 		return
@@ -263,7 +229,7 @@ func TestDynamicArgumentValidation(t *testing.T) {
 func TestAdvancedClientFeaturesIntegration(t *testing.T) {
 	socketPath := fmt.Sprintf("/tmp/test_integration_%s.sock", uuid.New().String())
 	
-	client, err := protocol.New(socketPath, "test-channel")
+	client, err := protocol.New(socketPath)
 	if err != nil {
 		t.Logf("⚠️ Advanced Client Features integration test setup failed (expected in test environment): %v", err)
 		return
@@ -278,19 +244,13 @@ func TestAdvancedClientFeaturesIntegration(t *testing.T) {
 		t.Errorf("Should start with no pending requests, got %d", initialStats.PendingCount)
 	}
 
-	// 2. Create channel proxy
-	proxy := client.CreateChannelProxy("integration-test")
-	if proxy.GetChannelID() != "integration-test" {
-		t.Errorf("Proxy should have correct channel, expected integration-test, got %s", proxy.GetChannelID())
-	}
-
-	// 3. Test bulk operations
-	bulkCancelled := client.CancelAllRequests("Integration test cleanup")
+	// 2. Test bulk operations
+	bulkCancelled := client.CancelAllRequests()
 	if bulkCancelled != 0 {
 		t.Errorf("Should cancel 0 requests initially, got %d", bulkCancelled)
 	}
 
-	// 4. Verify final state
+	// 3. Verify final state
 	finalStats := client.GetRequestStatistics()
 	if finalStats.PendingCount != 0 {
 		t.Errorf("Should end with no pending requests, got %d", finalStats.PendingCount)
@@ -303,7 +263,7 @@ func TestAdvancedClientFeaturesIntegration(t *testing.T) {
 func TestRequestTimeoutAndCorrelation(t *testing.T) {
 	socketPath := fmt.Sprintf("/tmp/test_timeout_%s.sock", uuid.New().String())
 	
-	client, err := protocol.New(socketPath, "test-channel")
+	client, err := protocol.New(socketPath)
 	if err != nil {
 		t.Logf("⚠️ Request timeout test setup failed (expected in test environment): %v", err)
 		return
@@ -341,7 +301,7 @@ func TestRequestTimeoutAndCorrelation(t *testing.T) {
 func TestConcurrentOperations(t *testing.T) {
 	socketPath := fmt.Sprintf("/tmp/test_concurrent_%s.sock", uuid.New().String())
 	
-	client, err := protocol.New(socketPath, "test-channel")
+	client, err := protocol.New(socketPath)
 	if err != nil {
 		t.Logf("⚠️ Concurrent operations test setup failed (expected in test environment): %v", err)
 		return
@@ -386,7 +346,7 @@ func TestConcurrentOperations(t *testing.T) {
 
 	for i := 0; i < 5; i++ {
 		go func(index int) {
-			cancelled := client.CancelAllRequests(fmt.Sprintf("Concurrent test %d", index))
+			cancelled := client.CancelAllRequests()
 			cancelResults <- cancelResult{index, cancelled}
 		}(i)
 	}
@@ -12,19 +12,14 @@ func TestRequestHandleCreation(t *testing.T) {
 	// Test F0194: Request ID Assignment and F0196: RequestHandle Structure
 	internalID := "test-uuid-12345"
 	request := "test_request"
-	channel := "test_channel"
 	
-	handle := models.NewRequestHandle(internalID, request, channel)
+	handle := models.NewRequestHandle(internalID, request)
 	
 	// Verify handle properties
 	if handle.GetRequest() != request {
 		t.Errorf("Expected request %s, got %s", request, handle.GetRequest())
 	}
 	
-	if handle.GetChannel() != channel {
-		t.Errorf("Expected channel %s, got %s", channel, handle.GetChannel())
-	}
-	
 	if handle.GetInternalID() != internalID {
 		t.Errorf("Expected internal ID %s, got %s", internalID, handle.GetInternalID())
 	}
@@ -41,7 +36,7 @@ func TestRequestHandleCreation(t *testing.T) {
 
 func TestRequestHandleCancellation(t *testing.T) {
 	// Test F0204: Request Cancellation and F0212: Request Cleanup
-	handle := models.NewRequestHandle("test-id", "test_request", "test_channel")
+	handle := models.NewRequestHandle("test-id", "test_request")
 	
 	if handle.IsCancelled() {
 		t.Error("New handle should not be cancelled")
@@ -59,14 +54,14 @@ func TestRequestStatusTracking(t *testing.T) {
 	config := protocol.DefaultJanusClientConfig()
 	config.EnableValidation = false // Skip validation for test
 	
-	client, err := protocol.New("/tmp/test_socket", "test_channel", config)
+	client, err := protocol.New("/tmp/test_socket", config)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
 	defer client.Close()
 	
 	// Create a handle
-	handle := models.NewRequestHandle("test-id", "test_request", "test_channel")
+	handle := models.NewRequestHandle("test-id", "test_request")
 	
 	// Test initial status (should be completed since not in registry)
 	status := client.GetRequestStatus(handle)
@@ -87,7 +82,7 @@ func TestPendingRequestManagement(t *testing.T) {
 	config := protocol.DefaultJanusClientConfig()
 	config.EnableValidation = false
 	
-	client, err := protocol.New("/tmp/test_socket", "test_channel", config)
+	client, err := protocol.New("/tmp/test_socket", config)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -111,7 +106,7 @@ func TestRequestLifecycleManagement(t *testing.T) {
 	config := protocol.DefaultJanusClientConfig()
 	config.EnableValidation = false
 	
-	client, err := protocol.New("/tmp/test_socket", "test_channel", config)
+	client, err := protocol.New("/tmp/test_socket", config)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -119,9 +114,9 @@ func TestRequestLifecycleManagement(t *testing.T) {
 	
 	// Create multiple handles to test bulk operations
 	handles := []*models.RequestHandle{
-		models.NewRequestHandle("id1", "cmd1", "test_channel"),
-		models.NewRequestHandle("id2", "cmd2", "test_channel"),
-		models.NewRequestHandle("id3", "cmd3", "test_channel"),
+		models.NewRequestHandle("id1", "cmd1"),
+		models.NewRequestHandle("id2", "cmd2"),
+		models.NewRequestHandle("id3", "cmd3"),
 	}
 	
 	// Test that handles start as completed (not in registry)
@@ -141,17 +136,13 @@ func TestRequestLifecycleManagement(t *testing.T) {
 
 func TestIDVisibilityControl(t *testing.T) {
 	// Test F0195: ID Visibility Control - UUIDs should be hidden from normal API
-	handle := models.NewRequestHandle("internal-uuid-12345", "test_request", "test_channel")
+	handle := models.NewRequestHandle("internal-uuid-12345", "test_request")
 	
-	// User should only see request and channel, not internal UUID through normal API
+	// User should only see the request, not internal UUID through normal API
 	if handle.GetRequest() != "test_request" {
 		t.Errorf("Expected request test_request, got %s", handle.GetRequest())
 	}
 	
-	if handle.GetChannel() != "test_channel" {
-		t.Errorf("Expected channel test_channel, got %s", handle.GetChannel())
-	}
-	
 	// Internal ID should only be accessible for internal operations
 	if handle.GetInternalID() != "internal-uuid-12345" {
 		t.Errorf("Expected internal ID internal-uuid-12345, got %s", handle.GetInternalID())
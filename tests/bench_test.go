@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/bench"
+	"GoJanus/pkg/mock"
+)
+
+// TestBenchParseMix validates request mix and payload size parsing
+func TestBenchParseMix(t *testing.T) {
+	mix, err := bench.ParseMix("ping=3, echo ,greet=0")
+	if err != nil {
+		t.Fatalf("ParseMix failed: %v", err)
+	}
+	expected := []bench.MixEntry{{Request: "ping", Weight: 3}, {Request: "echo", Weight: 1}, {Request: "greet", Weight: 0}}
+	if !reflect.DeepEqual(mix, expected) {
+		t.Errorf("Expected %v, got %v", expected, mix)
+	}
+	for _, spec := range []string{"ping=x", "=2", "ping=-1"} {
+		if _, err := bench.ParseMix(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+
+	sizes, err := bench.ParseSizes("64, 1k,0")
+	if err != nil || !reflect.DeepEqual(sizes, []int{64, 1024, 0}) {
+		t.Errorf("Expected [64 1024 0], got %v (%v)", sizes, err)
+	}
+	if _, err := bench.ParseSizes("big"); err == nil {
+		t.Error("Expected an invalid payload size to fail")
+	}
+
+	path := filepath.Join(t.TempDir(), "mix.yaml")
+	os.WriteFile(path, []byte("- request: greet\n  weight: 2\n  args: {name: Ada}\n- request: ping\n- request: echo\n  weight: 0\n"), 0644)
+	mix, err = bench.LoadMix(path)
+	if err != nil {
+		t.Fatalf("LoadMix failed: %v", err)
+	}
+	expected = []bench.MixEntry{{Request: "greet", Weight: 2, Args: map[string]interface{}{"name": "Ada"}}, {Request: "ping", Weight: 1}, {Request: "echo", Weight: 0}}
+	if !reflect.DeepEqual(mix, expected) {
+		t.Errorf("Expected %v, got %v", expected, mix)
+	}
+
+	// The same mix from the flag and from a file has the same weights
+	if flagMix, _ := bench.ParseMix("ping,echo=0"); !reflect.DeepEqual(flagMix, mix[1:]) {
+		t.Errorf("Expected flag mix %v to match file mix %v", flagMix, mix[1:])
+	}
+
+	os.WriteFile(path, []byte("- request: ping\n  weight: -1\n"), 0644)
+	if _, err := bench.LoadMix(path); err == nil {
+		t.Error("Expected a negative weight in a mix file to fail")
+	}
+
+	t.Log("✅ Bench mixes parse from flags and files")
+}
+
+// TestBenchRun validates counts, breakdowns and error codes in both reply socket modes
+func TestBenchRun(t *testing.T) {
+	socketPath, stop := startGreetingServer(t, "Hello", "")
+	defer stop()
+
+	for _, shared := range []bool{false, true} {
+		report, err := bench.Run(context.Background(), bench.Config{
+			SocketPath:  socketPath,
+			Concurrency: 4,
+			Requests:    60,
+			Timeout:     2 * time.Second,
+			Mix: []bench.MixEntry{
+				{Request: "greet", Weight: 2, Args: map[string]interface{}{"name": "Ada"}},
+				{Request: "greet", Weight: 1},
+				{Request: "missing", Weight: 1},
+			},
+			PayloadSizes: []int{16, 256},
+			PayloadArg:   "note",
+			SharedReply:  shared,
+			Seed:         7,
+		})
+		if err != nil {
+			t.Fatalf("shared=%v: Run failed: %v", shared, err)
+		}
+
+		if report.Sent != 60 || report.Succeeded+report.Failed+report.Timeouts+report.SendErrors != 60 {
+			t.Errorf("shared=%v: expected 60 accounted requests, got %+v", shared, report.Stats)
+		}
+		if report.SendErrors != 0 || report.Succeeded == 0 {
+			t.Errorf("shared=%v: expected successful delivery, got %+v (%s)", shared, report.Stats, report.FirstSendError)
+		}
+		if report.Errors["INVALID_PARAMS"] == 0 || report.Errors["METHOD_NOT_FOUND"] == 0 || len(report.Errors) != 2 {
+			t.Errorf("shared=%v: expected INVALID_PARAMS and METHOD_NOT_FOUND errors, got %v", shared, report.Errors)
+		}
+		if len(report.Requests) != 2 || report.Requests["greet"].Sent+report.Requests["missing"].Sent != 60 {
+			t.Errorf("shared=%v: expected a breakdown for greet and missing, got %v", shared, report.Requests)
+		}
+		if report.PayloadStats["16"].Sent != 30 || report.PayloadStats["256"].Sent != 30 {
+			t.Errorf("shared=%v: expected payload sizes used in turn, got %v", shared, report.PayloadStats)
+		}
+		latency := report.Latency
+		if latency.MinMs <= 0 || latency.MinMs > latency.P50Ms || latency.P50Ms > latency.P99Ms || latency.P99Ms > latency.MaxMs {
+			t.Errorf("shared=%v: expected ordered latency percentiles, got %+v", shared, latency)
+		}
+		if report.Throughput <= 0 {
+			t.Errorf("shared=%v: expected a throughput, got %v", shared, report.Throughput)
+		}
+	}
+
+	t.Log("✅ Bench reports counts, latency and errors with both reply socket modes")
+}
+
+// TestBenchTimeouts validates that unanswered requests count as timeouts
+func TestBenchTimeouts(t *testing.T) {
+	script, err := mock.ParseScript([]byte(mockScript))
+	if err != nil {
+		t.Fatalf("Failed to parse script: %v", err)
+	}
+	socketPath := startMockServer(t, script)
+
+	for _, shared := range []bool{false, true} {
+		report, err := bench.Run(context.Background(), bench.Config{
+			SocketPath:  socketPath,
+			Concurrency: 2,
+			Duration:    300 * time.Millisecond,
+			Timeout:     100 * time.Millisecond,
+			Mix:         []bench.MixEntry{{Request: "place_order", Weight: 1, Args: map[string]interface{}{"customer": "slow"}}},
+			SharedReply: shared,
+		})
+		if err != nil {
+			t.Fatalf("shared=%v: Run failed: %v", shared, err)
+		}
+		if report.Sent == 0 || report.Timeouts+report.Errors["HANDLER_TIMEOUT"] != report.Sent {
+			t.Errorf("shared=%v: expected every request to time out, got %+v", shared, report.Stats)
+		}
+	}
+
+	if _, err := bench.Run(context.Background(), bench.Config{SocketPath: socketPath, Mix: []bench.MixEntry{{Request: "ping"}}}); err == nil {
+		t.Error("Expected a mix without weight to be rejected")
+	}
+
+	t.Log("✅ Bench counts unanswered requests as timeouts")
+}
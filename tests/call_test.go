@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/call"
+	"GoJanus/pkg/mock"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+)
+
+// TestCallParseArgs validates key=value, key:=json, inline JSON and @file arguments
+func TestCallParseArgs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "args.json")
+	if err := os.WriteFile(path, []byte(`{"from_file": true, "name": "file"}`), 0644); err != nil {
+		t.Fatalf("Failed to write args file: %v", err)
+	}
+
+	args, err := call.ParseArgs([]string{
+		"@" + path, "name=Ada Lovelace", "count:=3", `tags:=["a", "b"]`, "zip=01234", `{"extra": null}`, "@-",
+	}, strings.NewReader(`{"stdin": 1}`))
+	if err != nil {
+		t.Fatalf("ParseArgs failed: %v", err)
+	}
+	expected := map[string]interface{}{
+		"from_file": true, "name": "Ada Lovelace", "count": 3.0, "tags": []interface{}{"a", "b"},
+		"zip": "01234", "extra": nil, "stdin": 1.0,
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected %v, got %v", expected, args)
+	}
+
+	for _, words := range [][]string{{"novalue"}, {"=x"}, {":=1"}, {"n:=nope"}, {`{"a": 1} trailing`}, {"[1]"}, {"@/does/not/exist"}} {
+		if _, err := call.ParseArgs(words, nil); err == nil {
+			t.Errorf("%q: expected an error", words)
+		}
+	}
+
+	t.Log("✅ Call arguments combine pairs, JSON values, files and stdin")
+}
+
+// TestCallExtractField validates jq-style field paths
+func TestCallExtractField(t *testing.T) {
+	response := models.NewSuccessResponse("r1", map[string]interface{}{
+		"items":    []interface{}{map[string]interface{}{"name": "tea"}, map[string]interface{}{"name": "milk"}},
+		"a.b":      "dotted",
+		"count":    2,
+		"weird]\"": "bracket",
+	})
+
+	cases := map[string]string{
+		".result.items[0].name":  "tea",
+		".result.items[-1].name": "milk",
+		`.result["a.b"]`:         "dotted",
+		`.result["weird]\""]`:    "bracket",
+		".result.count":          "2",
+		".success":               "true",
+		".result.missing":        "null",
+		".result.items[5]":       "null",
+		".error":                 "null",
+		".result.items[1]":       `{"name":"milk"}`,
+	}
+	for path, expected := range cases {
+		value, err := call.ExtractField(response, path)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", path, err)
+			continue
+		}
+		if text, _ := call.FormatField(value); text != expected {
+			t.Errorf("%s: expected %s, got %s", path, expected, text)
+		}
+	}
+
+	for _, path := range []string{"result", ".result.items.name", ".result[0]", ".result.items[x]", ".result[\"open", ".result..count"} {
+		if _, err := call.ExtractField(response, path); err == nil {
+			t.Errorf("%s: expected an error", path)
+		}
+	}
+
+	t.Log("✅ Field paths select keys, indexes and quoted keys")
+}
+
+// TestCallExitCodes validates the exit code for each outcome
+func TestCallExitCodes(t *testing.T) {
+	cases := map[models.JSONRPCErrorCode]int{
+		models.ParseError:              10,
+		models.MethodNotFound:          12,
+		models.InvalidParams:           13,
+		models.InternalError:           14,
+		models.ServerError:             20,
+		models.ServiceUnavailable:      21,
+		models.ResourceNotFound:        24,
+		models.ManifestValidationError: 33,
+		models.JSONRPCErrorCode(42):    call.ExitOtherError,
+	}
+	for code, expected := range cases {
+		if exit := call.ExitCode(code); exit != expected {
+			t.Errorf("%s: expected exit %d, got %d", code, expected, exit)
+		}
+	}
+
+	if exit := call.Outcome(models.NewSuccessResponse("r1", nil), nil); exit != call.ExitOK {
+		t.Errorf("Expected success to exit 0, got %d", exit)
+	}
+	if exit := call.Outcome(nil, errors.New("timeout")); exit != call.ExitNoResponse {
+		t.Errorf("Expected delivery failures to exit %d, got %d", call.ExitNoResponse, exit)
+	}
+	localValidation := models.NewJSONRPCError(models.InvalidParams, "bad")
+	if exit := call.Outcome(nil, localValidation); exit != 13 {
+		t.Errorf("Expected client-side validation to exit 13, got %d", exit)
+	}
+
+	t.Log("✅ Exit codes are derived from JSON-RPC error codes")
+}
+
+// TestCallRetries validates retrying transient errors and stopping on permanent ones
+func TestCallRetries(t *testing.T) {
+	script, err := mock.ParseScript([]byte(`
+requests:
+  place_order:
+    fixtures:
+      - match: {customer: busy}
+        error: {code: SERVICE_UNAVAILABLE}
+      - match: {customer: gone}
+        error: {code: RESOURCE_NOT_FOUND}
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse script: %v", err)
+	}
+	socketPath := startMockServer(t, script)
+	client, err := protocol.New(socketPath, protocol.DefaultJanusClientConfig())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for customer, expected := range map[string]struct {
+		retries int
+		exit    int
+	}{
+		"busy": {2, 21},
+		"gone": {0, 24},
+		"ann":  {0, 0},
+	} {
+		retries := 0
+		response, err := call.Send(context.Background(), client, "place_order", map[string]interface{}{"customer": customer}, call.Options{
+			Retries:    2,
+			RetryDelay: 10 * time.Millisecond,
+			OnRetry:    func(int, error) { retries++ },
+		})
+		if retries != expected.retries {
+			t.Errorf("%s: expected %d retries, got %d", customer, expected.retries, retries)
+		}
+		if exit := call.Outcome(response, err); exit != expected.exit {
+			t.Errorf("%s: expected exit %d, got %d (%v)", customer, expected.exit, exit, err)
+		}
+	}
+
+	t.Log("✅ Calls retry transient errors only")
+}
@@ -0,0 +1,271 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+)
+
+// TestCircuitBreakerOpensOnFailureRatio validates the closed -> open transition
+func TestCircuitBreakerOpensOnFailureRatio(t *testing.T) {
+	breaker := protocol.NewCircuitBreaker("/tmp/cb-ratio.sock", protocol.CircuitBreakerConfig{
+		FailureRatio:    0.5,
+		MinimumRequests: 4,
+		WindowSize:      4,
+		CoolDown:        time.Minute,
+	})
+
+	tickets := make([]protocol.CircuitTicket, 4)
+	for i := range tickets {
+		ticket, err := breaker.Allow()
+		if err != nil {
+			t.Fatalf("Expected closed circuit to allow requests: %v", err)
+		}
+		tickets[i] = ticket
+	}
+
+	breaker.RecordSuccess(tickets[0])
+	breaker.RecordFailure(tickets[1])
+	breaker.RecordFailure(tickets[2])
+	if breaker.State() != protocol.CircuitClosed {
+		t.Fatalf("Expected closed before minimum requests, got %s", breaker.State())
+	}
+
+	breaker.RecordSuccess(tickets[3])
+	if breaker.State() != protocol.CircuitOpen {
+		t.Fatalf("Expected open at 50%% failures, got %s", breaker.State())
+	}
+
+	_, err := breaker.Allow()
+	var rpcErr *models.JSONRPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != models.ServiceUnavailable {
+		t.Fatalf("Expected ServiceUnavailable while open, got %v", err)
+	}
+
+	stats := breaker.Statistics()
+	if stats.TotalRejected != 1 || stats.OpenedAt == nil {
+		t.Errorf("Unexpected statistics: %+v", stats)
+	}
+
+	t.Log("✅ Circuit opens once the failure ratio is reached")
+}
+
+// TestCircuitBreakerHalfOpenRecovery validates open -> half-open -> closed and half-open -> open
+func TestCircuitBreakerHalfOpenRecovery(t *testing.T) {
+	breaker := protocol.NewCircuitBreaker("/tmp/cb-recovery.sock", protocol.CircuitBreakerConfig{
+		FailureRatio:    1,
+		MinimumRequests: 1,
+		WindowSize:      1,
+		CoolDown:        50 * time.Millisecond,
+	})
+
+	var transitions []string
+	breaker.OnStateChange(func(change protocol.CircuitStateChange) {
+		transitions = append(transitions, fmt.Sprintf("%s->%s", change.From, change.To))
+	})
+
+	ticket, _ := breaker.Allow()
+	breaker.RecordFailure(ticket)
+	time.Sleep(60 * time.Millisecond)
+
+	// Cool-down elapsed: one probe allowed, a second is rejected
+	probe, err := breaker.Allow()
+	if err != nil {
+		t.Fatalf("Expected probe to be allowed after cool-down: %v", err)
+	}
+	if _, err := breaker.Allow(); err == nil {
+		t.Fatal("Expected second concurrent probe to be rejected")
+	}
+
+	// Failed probe re-opens the circuit
+	breaker.RecordFailure(probe)
+	if breaker.State() != protocol.CircuitOpen {
+		t.Fatalf("Expected open after failed probe, got %s", breaker.State())
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if probe, err = breaker.Allow(); err != nil {
+		t.Fatalf("Expected probe to be allowed: %v", err)
+	}
+	breaker.RecordSuccess(probe)
+	if breaker.State() != protocol.CircuitClosed {
+		t.Fatalf("Expected closed after successful probe, got %s", breaker.State())
+	}
+
+	expected := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if fmt.Sprint(transitions) != fmt.Sprint(expected) {
+		t.Errorf("Expected transitions %v, got %v", expected, transitions)
+	}
+
+	t.Log("✅ Half-open probes close or re-open the circuit")
+}
+
+// TestCircuitBreakerIgnoresLateClosedResults validates that a request sent while closed and still
+// in flight once the breaker is half-open neither acts as a probe nor frees the probe slot
+func TestCircuitBreakerIgnoresLateClosedResults(t *testing.T) {
+	breaker := protocol.NewCircuitBreaker("/tmp/cb-late.sock", protocol.CircuitBreakerConfig{
+		FailureRatio:    1,
+		MinimumRequests: 1,
+		WindowSize:      1,
+		CoolDown:        50 * time.Millisecond,
+	})
+
+	// Two requests leave while closed; one fails and opens the circuit, the other is still in flight
+	inFlight, _ := breaker.Allow()
+	failed, _ := breaker.Allow()
+	breaker.RecordFailure(failed)
+	time.Sleep(60 * time.Millisecond)
+
+	probe, err := breaker.Allow()
+	if err != nil || breaker.State() != protocol.CircuitHalfOpen {
+		t.Fatalf("Expected a half-open probe after cool-down, got %v in %s", err, breaker.State())
+	}
+
+	// The in-flight request times out: no reopen, and the probe slot stays taken
+	breaker.RecordResult(context.Background(), inFlight, context.DeadlineExceeded)
+	if breaker.State() != protocol.CircuitHalfOpen {
+		t.Fatalf("Expected a late closed-state failure to be ignored, got %s", breaker.State())
+	}
+	if _, err := breaker.Allow(); err == nil {
+		t.Fatal("Expected the probe slot to stay held by the real probe")
+	}
+
+	// A late success does not close the circuit either
+	breaker.RecordSuccess(inFlight)
+	if breaker.State() != protocol.CircuitHalfOpen {
+		t.Fatalf("Expected a late closed-state success to be ignored, got %s", breaker.State())
+	}
+
+	breaker.RecordSuccess(probe)
+	if breaker.State() != protocol.CircuitClosed {
+		t.Fatalf("Expected the real probe to close the circuit, got %s", breaker.State())
+	}
+
+	t.Log("✅ Results of requests sent while closed do not count as half-open probes")
+}
+
+// TestClientCircuitBreakerFailsFast validates fast failure and statistics through JanusClient
+func TestClientCircuitBreakerFailsFast(t *testing.T) {
+	socketPath := fmt.Sprintf("/tmp/cb-client-%d.sock", time.Now().UnixNano())
+	os.Remove(socketPath)
+
+	config := protocol.DefaultJanusClientConfig()
+	config.EnableValidation = false
+	config.CircuitBreaker = &protocol.CircuitBreakerConfig{
+		FailureRatio:    1,
+		MinimumRequests: 2,
+		WindowSize:      2,
+		CoolDown:        time.Minute,
+	}
+
+	client, err := protocol.New(socketPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	events := make(chan interface{}, 4)
+	client.On("circuit_state_change", func(data interface{}) {
+		events <- data
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := client.SendRequest(ctx, "ping", nil); err == nil {
+			t.Fatal("Expected transport error with no server running")
+		}
+	}
+
+	start := time.Now()
+	_, err = client.SendRequest(ctx, "ping", nil)
+	var rpcErr *models.JSONRPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != models.ServiceUnavailable {
+		t.Fatalf("Expected ServiceUnavailable from open circuit, got %v", err)
+	}
+	if time.Since(start) > 100*time.Millisecond {
+		t.Errorf("Open circuit should fail fast, took %v", time.Since(start))
+	}
+
+	stats := client.GetRequestStatistics()
+	if stats.CircuitBreaker == nil || stats.CircuitBreaker.State != protocol.CircuitOpen {
+		t.Fatalf("Expected open circuit in statistics, got %+v", stats.CircuitBreaker)
+	}
+
+	select {
+	case data := <-events:
+		change, ok := data.(protocol.CircuitStateChange)
+		if !ok || change.To != protocol.CircuitOpen || change.SocketPath != socketPath {
+			t.Errorf("Unexpected state change event: %+v", data)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected circuit_state_change event")
+	}
+
+	t.Log("✅ Client fails fast with ServiceUnavailable while the circuit is open")
+}
+
+// TestCircuitBreakerConfigValidation validates rejected breaker configurations
+func TestCircuitBreakerConfigValidation(t *testing.T) {
+	config := protocol.DefaultJanusClientConfig()
+	config.CircuitBreaker = &protocol.CircuitBreakerConfig{FailureRatio: 1.5}
+
+	if _, err := protocol.New("/tmp/cb-invalid.sock", config); err == nil {
+		t.Error("Expected error for failure ratio above 1")
+	}
+}
+
+// TestCircuitBreakerSharingByConfig validates that breakers are shared per socket path and configuration
+// and released once the last client closes
+func TestCircuitBreakerSharingByConfig(t *testing.T) {
+	socketPath := fmt.Sprintf("/tmp/cb-shared-%d.sock", time.Now().UnixNano())
+	os.Remove(socketPath)
+
+	newClient := func(minimumRequests int) *protocol.JanusClient {
+		config := protocol.DefaultJanusClientConfig()
+		config.EnableValidation = false
+		config.CircuitBreaker = &protocol.CircuitBreakerConfig{
+			FailureRatio:    1,
+			MinimumRequests: minimumRequests,
+			WindowSize:      minimumRequests,
+			CoolDown:        time.Minute,
+		}
+		client, err := protocol.New(socketPath, config)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		return client
+	}
+
+	strict := newClient(1)
+	sibling := newClient(1)
+	lenient := newClient(5)
+	defer lenient.Close()
+
+	if _, err := strict.SendRequest(context.Background(), "ping", nil); err == nil {
+		t.Fatal("Expected transport error with no server running")
+	}
+
+	if strict.GetCircuitState() != protocol.CircuitOpen || sibling.GetCircuitState() != protocol.CircuitOpen {
+		t.Fatal("Expected clients with the same configuration to share an open circuit")
+	}
+	if lenient.GetCircuitState() != protocol.CircuitClosed {
+		t.Fatal("Expected a client with a different configuration to keep its own closed circuit")
+	}
+
+	strict.Close()
+	sibling.Close()
+
+	fresh := newClient(1)
+	defer fresh.Close()
+	if fresh.GetCircuitState() != protocol.CircuitClosed {
+		t.Error("Expected a fresh circuit after every client of the old one closed")
+	}
+
+	t.Log("✅ Circuit breakers are shared per configuration and released on close")
+}
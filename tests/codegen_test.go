@@ -0,0 +1,227 @@
+package tests
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"GoJanus/pkg/codegen"
+	"GoJanus/pkg/manifest"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "rewrite golden files from generated output")
+
+const codegenTestManifest = `{
+	"version": "1.2.0",
+	"name": "User API",
+	"description": "User management",
+	"requests": {
+		"get_user": {
+			"name": "get_user",
+			"description": "Retrieve user information",
+			"args": {
+				"user_id": {"name": "user_id", "type": "string", "required": true, "description": "User identifier"},
+				"include_roles": {"name": "include_roles", "type": "boolean", "description": "Include roles"}
+			},
+			"response": {"type": "object", "description": "User", "modelRef": "User"}
+		},
+		"list-users": {
+			"name": "list-users",
+			"description": "List users",
+			"args": {
+				"filter": {
+					"name": "filter", "type": "object", "description": "Filter",
+					"properties": {
+						"role": {"name": "role", "type": "string", "description": "Role", "enum": ["admin", "member"]}
+					}
+				},
+				"limit": {"name": "limit", "type": "integer", "description": "Page size", "minimum": 1}
+			},
+			"response": {"type": "array", "description": "Users", "items": {"name": "user", "type": "object", "description": "User", "modelRef": "User"}}
+		}
+	},
+	"models": {
+		"User": {
+			"name": "User",
+			"type": "object",
+			"description": "A registered user",
+			"properties": {
+				"id": {"name": "id", "type": "string", "description": "Identifier"},
+				"email": {"name": "email", "type": "string", "description": "Email address"},
+				"tags": {"name": "tags", "type": "array", "description": "Tags", "items": {"name": "tag", "type": "string", "description": "Tag"}}
+			},
+			"required": ["id"]
+		}
+	}
+}`
+
+// TestGenerateGoBindings validates generated declarations for models, requests, client and server
+func TestGenerateGoBindings(t *testing.T) {
+	m, err := manifest.ParseJSONString(codegenTestManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	source, err := codegen.GenerateGo(m, codegen.GoOptions{PackageName: "userapi"})
+	if err != nil {
+		t.Fatalf("Failed to generate Go code: %v", err)
+	}
+	code := string(source)
+
+	expected := []string{
+		"package userapi",
+		"type User struct",
+		"ID string `json:\"id\"`",
+		"Email *string `json:\"email,omitempty\"`",
+		"type GetUserArgs struct",
+		"UserID string `json:\"user_id\"`",
+		"type GetUserResponse = User",
+		"type ListUsersResponse = []User",
+		"type ListUsersArgsFilterRole string",
+		"ListUsersArgsFilterRoleMember ListUsersArgsFilterRole = \"member\"",
+		"func (c *Client) GetUser(ctx context.Context, args GetUserArgs, options ...protocol.RequestOptions) (GetUserResponse, error)",
+		"GetUser(cmd *models.JanusRequest, args GetUserArgs) (GetUserResponse, error)",
+		"srv.RegisterHandler(\"list-users\", server.NewTypedHandler[ListUsersArgs, ListUsersResponse](impl.ListUsers))",
+	}
+	for _, fragment := range expected {
+		if !strings.Contains(code, fragment) {
+			t.Errorf("Generated code missing %q\n%s", fragment, code)
+		}
+	}
+
+	t.Log("✅ Go bindings generated for models, requests, client and server")
+}
+
+// TestGenerateGoBindingsCompile validates that generated code builds against the library
+func TestGenerateGoBindingsCompile(t *testing.T) {
+	m, err := manifest.ParseJSONString(codegenTestManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	source, err := codegen.GenerateGo(m, codegen.DefaultGoOptions())
+	if err != nil {
+		t.Fatalf("Failed to generate Go code: %v", err)
+	}
+
+	vetGeneratedGo(t, source)
+
+	t.Log("✅ Generated bindings compile")
+}
+
+// TestGenerateGoCollidingNames validates that model and request names never shadow the fixed identifiers
+// Run with -update-golden to rewrite tests/fixtures/codegen/colliding_gen.go.golden
+func TestGenerateGoCollidingNames(t *testing.T) {
+	m, err := manifest.ParseFromFile(filepath.Join("fixtures", "codegen", "colliding.json"))
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	source, err := codegen.GenerateGo(m, codegen.DefaultGoOptions())
+	if err != nil {
+		t.Fatalf("Failed to generate Go code: %v", err)
+	}
+
+	goldenPath := filepath.Join("fixtures", "codegen", "colliding_gen.go.golden")
+	if *updateGolden {
+		if err := os.WriteFile(goldenPath, source, 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+	golden, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if string(source) != string(golden) {
+		t.Errorf("Generated code differs from %s:\n%s", goldenPath, source)
+	}
+
+	vetGeneratedGo(t, source)
+
+	t.Log("✅ Colliding names are renamed and the bindings compile")
+}
+
+// TestGenerateGoRecursiveModels validates that models reaching themselves are referenced through pointers
+// Run with -update-golden to rewrite tests/fixtures/codegen/recursive_gen.go.golden
+func TestGenerateGoRecursiveModels(t *testing.T) {
+	m, err := manifest.ParseFromFile(filepath.Join("fixtures", "codegen", "recursive.json"))
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	source, err := codegen.GenerateGo(m, codegen.DefaultGoOptions())
+	if err != nil {
+		t.Fatalf("Failed to generate Go code: %v", err)
+	}
+
+	goldenPath := filepath.Join("fixtures", "codegen", "recursive_gen.go.golden")
+	if *updateGolden {
+		if err := os.WriteFile(goldenPath, source, 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+	golden, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if string(source) != string(golden) {
+		t.Errorf("Generated code differs from %s:\n%s", goldenPath, source)
+	}
+
+	vetGeneratedGo(t, source)
+
+	t.Log("✅ Recursive model references become pointers and the bindings compile")
+}
+
+// vetGeneratedGo builds generated source as a package inside the module
+func vetGeneratedGo(t *testing.T, source []byte) {
+	t.Helper()
+
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not available")
+	}
+
+	// Underscore-prefixed directories are ignored by ./... patterns
+	dir, err := os.MkdirTemp(".", "_codegen")
+	if err != nil {
+		t.Fatalf("Failed to create package directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "api_gen.go"), source, 0644); err != nil {
+		t.Fatalf("Failed to write generated code: %v", err)
+	}
+
+	cmd := exec.Command(goTool, "vet", "./"+filepath.Base(dir))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Generated code does not build: %v\n%s\n%s", err, output, source)
+	}
+}
+
+// TestGenerateGoModelsOnly validates output for manifests without requests
+func TestGenerateGoModelsOnly(t *testing.T) {
+	m := &manifest.Manifest{
+		Name:    "Models",
+		Version: "1.0.0",
+		Models: map[string]*manifest.ModelDefinition{
+			"status": {Name: "status", Type: "string", Description: "Status code"},
+		},
+	}
+
+	source, err := codegen.GenerateGo(m, codegen.DefaultGoOptions())
+	if err != nil {
+		t.Fatalf("Failed to generate Go code: %v", err)
+	}
+
+	code := string(source)
+	if strings.Contains(code, "import") || strings.Contains(code, "type Client") {
+		t.Errorf("Models-only manifest should not produce client code:\n%s", code)
+	}
+	if !strings.Contains(code, "type Status string") {
+		t.Errorf("Expected scalar model type:\n%s", code)
+	}
+}
@@ -0,0 +1,270 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"GoJanus/pkg/manifest"
+)
+
+const collectionConstraintsManifest = `{
+	"version": "1.0.0",
+	"name": "Collections",
+	"description": "Array and object constraint test",
+	"requests": {
+		"tag": {
+			"name": "tag",
+			"description": "Tag a document",
+			"args": {
+				"tags": {"name": "tags", "type": "array", "description": "Tags", "required": true,
+					"minItems": 1, "maxItems": 3, "uniqueItems": true, "items": {"name": "tag", "type": "string", "description": "Tag"}},
+				"options": {"name": "options", "type": "object", "description": "Options", "additionalProperties": false,
+					"requiredProperties": ["mode"],
+					"properties": {
+						"mode": {"name": "mode", "type": "string", "description": "Mode"},
+						"dryRun": {"name": "dryRun", "type": "boolean", "description": "Dry run"}
+					}},
+				"owner": {"name": "owner", "type": "object", "description": "Owner", "modelRef": "Owner"}
+			},
+			"response": {
+				"type": "object",
+				"description": "Tagged document",
+				"properties": {
+					"tags": {"name": "tags", "type": "array", "description": "Tags", "minItems": 1, "maxItems": 3, "uniqueItems": true,
+						"items": {"name": "tag", "type": "string", "description": "Tag"}},
+					"options": {"name": "options", "type": "object", "description": "Options", "additionalProperties": false,
+						"requiredProperties": ["mode"],
+						"properties": {"mode": {"name": "mode", "type": "string", "description": "Mode"}}},
+					"owner": {"name": "owner", "type": "object", "description": "Owner", "modelRef": "Owner"}
+				}
+			}
+		},
+		"nest": {
+			"name": "nest",
+			"description": "Accept a tree",
+			"args": {
+				"root": {"name": "root", "type": "object", "description": "Tree root", "modelRef": "Node"}
+			},
+			"response": {"type": "object", "description": "Tree", "modelRef": "Node"}
+		}
+	},
+	"models": {
+		"Owner": {
+			"name": "Owner",
+			"type": "object",
+			"description": "Document owner",
+			"required": ["id"],
+			"additionalProperties": false,
+			"properties": {
+				"id": {"name": "id", "type": "string", "description": "Owner ID"},
+				"team": {"name": "team", "type": "object", "description": "Team", "modelRef": "Team"}
+			}
+		},
+		"Team": {
+			"name": "Team",
+			"type": "object",
+			"description": "Team",
+			"properties": {
+				"name": {"name": "name", "type": "string", "description": "Team name", "required": true}
+			}
+		},
+		"Node": {
+			"name": "Node",
+			"type": "object",
+			"description": "Tree node",
+			"properties": {
+				"child": {"name": "child", "type": "object", "description": "Child node", "modelRef": "Node"}
+			}
+		}
+	}
+}`
+
+func parseCollectionConstraintsManifest(t *testing.T) *manifest.Manifest {
+	t.Helper()
+	m, err := manifest.ParseJSONString(collectionConstraintsManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	return m
+}
+
+// nestedNodes builds a Node value with the given number of child levels
+func nestedNodes(depth int) map[string]interface{} {
+	node := map[string]interface{}{}
+	for i := 0; i < depth; i++ {
+		node = map[string]interface{}{"child": node}
+	}
+	return node
+}
+
+// TestCollectionConstraintsArguments validates array and object constraints on request arguments
+func TestCollectionConstraintsArguments(t *testing.T) {
+	m := parseCollectionConstraintsManifest(t)
+	request, _ := m.GetRequest("tag")
+
+	valid := map[string]interface{}{
+		"tags":    []interface{}{"a", "b"},
+		"options": map[string]interface{}{"mode": "fast", "dryRun": true},
+		"owner":   map[string]interface{}{"id": "u1", "team": map[string]interface{}{"name": "core"}},
+	}
+	if errors := m.ValidateRequestArgsAll(request, valid); errors != nil {
+		t.Fatalf("Expected valid arguments, got %v", errors)
+	}
+
+	cases := []struct {
+		name       string
+		args       map[string]interface{}
+		path       string
+		constraint string
+	}{
+		{"too few items", map[string]interface{}{"tags": []interface{}{}}, "/tags", "minItems"},
+		{"too many items", map[string]interface{}{"tags": []interface{}{"a", "b", "c", "d"}}, "/tags", "maxItems"},
+		{"duplicate items", map[string]interface{}{"tags": []interface{}{"a", "b", "a"}}, "/tags", "uniqueItems"},
+		{"closed inline object", map[string]interface{}{"tags": []interface{}{"a"}, "options": map[string]interface{}{"mode": "x", "extra": 1}}, "/options/extra", "additionalProperties"},
+		{"required list", map[string]interface{}{"tags": []interface{}{"a"}, "options": map[string]interface{}{"dryRun": false}}, "/options/mode", "required"},
+		{"model required", map[string]interface{}{"tags": []interface{}{"a"}, "owner": map[string]interface{}{}}, "/owner/id", "required"},
+		{"nested property required", map[string]interface{}{"tags": []interface{}{"a"}, "owner": map[string]interface{}{"id": "u1", "team": map[string]interface{}{}}}, "/owner/team/name", "required"},
+	}
+	for _, tc := range cases {
+		errors := m.ValidateRequestArgsAll(request, tc.args)
+		if len(errors) != 1 || errors[0].Path != tc.path || errors[0].Constraint != tc.constraint {
+			t.Errorf("%s: expected %s (%s), got %v", tc.name, tc.path, tc.constraint, errors)
+		}
+	}
+
+	t.Log("✅ minItems, maxItems, uniqueItems, additionalProperties and required lists are enforced on arguments")
+}
+
+// TestCollectionConstraintsResponses validates the same constraints in the ResponseValidator
+func TestCollectionConstraintsResponses(t *testing.T) {
+	m := parseCollectionConstraintsManifest(t)
+	request, _ := m.GetRequest("tag")
+	validator := manifest.NewResponseValidator(m)
+
+	valid := map[string]interface{}{
+		"tags":    []interface{}{"a"},
+		"options": map[string]interface{}{"mode": "fast"},
+		"owner":   map[string]interface{}{"id": "u1", "team": map[string]interface{}{"name": "core"}},
+	}
+	if result := validator.ValidateResponse(valid, request.Response); !result.Valid {
+		t.Fatalf("Expected valid response, got %v", result.Errors)
+	}
+
+	cases := []struct {
+		name     string
+		response map[string]interface{}
+		field    string
+		message  string
+	}{
+		{"too few items", map[string]interface{}{"tags": []interface{}{}}, "tags", "too few items"},
+		{"too many items", map[string]interface{}{"tags": []interface{}{"a", "b", "c", "d"}}, "tags", "too many items"},
+		{"duplicate items", map[string]interface{}{"tags": []interface{}{"a", "a"}}, "tags", "not unique"},
+		{"closed inline object", map[string]interface{}{"options": map[string]interface{}{"mode": "x", "extra": 1}}, "options.extra", "not allowed"},
+		{"required list", map[string]interface{}{"options": map[string]interface{}{}}, "options.mode", "missing"},
+		{"model required", map[string]interface{}{"owner": map[string]interface{}{}}, "owner.id", "missing"},
+		{"closed model", map[string]interface{}{"owner": map[string]interface{}{"id": "u1", "extra": true}}, "owner.extra", "not allowed"},
+		{"nested property required", map[string]interface{}{"owner": map[string]interface{}{"id": "u1", "team": map[string]interface{}{}}}, "owner.team.name", "missing"},
+	}
+	for _, tc := range cases {
+		result := validator.ValidateResponse(tc.response, request.Response)
+		if result.Valid || len(result.Errors) != 1 || result.Errors[0].Field != tc.field || !strings.Contains(result.Errors[0].Message, tc.message) {
+			t.Errorf("%s: expected %s error at %s, got %v", tc.name, tc.message, tc.field, result.Errors)
+		}
+	}
+
+	t.Log("✅ Collection constraints and nested required properties are enforced on responses")
+}
+
+// TestCollectionConstraintsDepthGuard validates that self-referencing models stop at MaxValidationDepth
+func TestCollectionConstraintsDepthGuard(t *testing.T) {
+	m := parseCollectionConstraintsManifest(t)
+	request, _ := m.GetRequest("nest")
+
+	shallow := map[string]interface{}{"root": nestedNodes(10)}
+	if errors := m.ValidateRequestArgsAll(request, shallow); errors != nil {
+		t.Fatalf("Expected shallow tree to be accepted, got %v", errors)
+	}
+
+	deep := map[string]interface{}{"root": nestedNodes(manifest.MaxValidationDepth + 10)}
+	errors := m.ValidateRequestArgsAll(request, deep)
+	if len(errors) != 1 || errors[0].Constraint != "maxDepth" {
+		t.Errorf("Expected a single maxDepth error for the argument, got %d errors", len(errors))
+	}
+
+	validator := manifest.NewResponseValidator(m)
+	if result := validator.ValidateResponse(nestedNodes(10), request.Response); !result.Valid {
+		t.Errorf("Expected shallow response to be valid, got %v", result.Errors)
+	}
+	result := validator.ValidateResponse(nestedNodes(manifest.MaxValidationDepth+10), request.Response)
+	if result.Valid || len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "deeper") {
+		t.Errorf("Expected a single depth error for the response, got %d errors", len(result.Errors))
+	}
+
+	t.Log("✅ Validation of self-referencing models is bounded by MaxValidationDepth")
+}
+
+// TestCollectionConstraintsManifestValidation validates rejection of malformed constraint definitions
+func TestCollectionConstraintsManifestValidation(t *testing.T) {
+	tagsArg := `"minItems": 1, "maxItems": 3, "uniqueItems": true`
+	modeArg := `"name": "mode", "type": "string", "description": "Mode"}`
+	cases := map[string][2]string{
+		"negative minItems":          {tagsArg, `"minItems": -1`},
+		"minItems above maxItems":    {tagsArg, `"minItems": 4, "maxItems": 3`},
+		"undeclared required":        {`"requiredProperties": ["mode"]`, `"requiredProperties": ["missing"]`},
+		"array constraint on string": {modeArg, `"name": "mode", "type": "string", "description": "Mode", "maxItems": 2}`},
+		"closing a string":           {modeArg, `"name": "mode", "type": "string", "description": "Mode", "additionalProperties": false}`},
+	}
+	for name, replacement := range cases {
+		content := strings.Replace(collectionConstraintsManifest, replacement[0], replacement[1], 1)
+		if _, err := manifest.ParseJSONString(content); err == nil {
+			t.Errorf("%s: expected manifest to be rejected", name)
+		}
+	}
+
+	t.Log("✅ Manifest validation rejects malformed collection constraints")
+}
+
+// TestCollectionConstraintsRoundTrip validates JSON, YAML and JSON Schema serialization of the new keywords
+func TestCollectionConstraintsRoundTrip(t *testing.T) {
+	m := parseCollectionConstraintsManifest(t)
+	parser := manifest.NewManifestParser()
+
+	yamlData, err := parser.SerializeToYAML(m)
+	if err != nil {
+		t.Fatalf("SerializeToYAML failed: %v", err)
+	}
+	fromYAML, err := parser.ParseYAML(yamlData)
+	if err != nil {
+		t.Fatalf("Failed to parse serialized YAML: %v\n%s", err, yamlData)
+	}
+	original, _ := json.Marshal(m)
+	roundTripped, _ := json.Marshal(fromYAML)
+	if string(original) != string(roundTripped) {
+		t.Errorf("YAML round trip changed the manifest:\n%s\n%s", original, roundTripped)
+	}
+
+	doc, issues := manifest.ToJSONSchema(m)
+	if len(issues) != 0 {
+		t.Fatalf("Expected lossless export, got %v", issues)
+	}
+	tags := doc.Requests["tag"].Args.Properties["tags"]
+	options := doc.Requests["tag"].Args.Properties["options"]
+	if *tags.MinItems != 1 || *tags.MaxItems != 3 || !tags.UniqueItems || options.AdditionalProperties != false ||
+		len(options.Required) != 1 || options.Required[0] != "mode" || doc.Defs["Owner"].AdditionalProperties != false {
+		t.Errorf("Unexpected exported schema: %+v %+v", tags, options)
+	}
+
+	data, _ := json.Marshal(doc)
+	imported, issues, err := manifest.FromJSONSchema(data)
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("Failed to import exported schema: %v %v", err, issues)
+	}
+	importedOptions := imported.Requests["tag"].Args["options"]
+	if importedOptions.AdditionalProperties == nil || *importedOptions.AdditionalProperties || !importedOptions.Properties["mode"].Required ||
+		*imported.Requests["tag"].Args["tags"].MaxItems != 3 || *imported.Models["Owner"].AdditionalProperties {
+		t.Errorf("Expected constraints to survive the JSON Schema round trip, got %+v", importedOptions)
+	}
+
+	t.Log("✅ Collection constraints round-trip through YAML and JSON Schema")
+}
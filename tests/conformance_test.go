@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/conformance"
+	"GoJanus/pkg/mock"
+)
+
+// TestConformanceGoServer validates that the Go server passes every conformance case
+func TestConformanceGoServer(t *testing.T) {
+	script, err := mock.ParseScript([]byte(mockScript))
+	if err != nil {
+		t.Fatalf("Failed to parse script: %v", err)
+	}
+	socketPath := startMockServer(t, script)
+
+	report, err := conformance.Run(socketPath, conformance.Options{})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if report.Implementation != "go" {
+		t.Errorf("Expected implementation go, got %q", report.Implementation)
+	}
+	if len(report.Results) != len(conformance.Cases()) {
+		t.Errorf("Expected %d results, got %d", len(conformance.Cases()), len(report.Results))
+	}
+	for _, result := range report.Results {
+		if result.Status != conformance.Pass {
+			t.Errorf("%s: %s: %s", result.Name, result.Status, result.Detail)
+		}
+	}
+
+	// Without manifest arguments there is nothing to validate
+	plainPath, stop := startGreetingServer(t, "Hello", "")
+	defer stop()
+	report, err = conformance.Run(plainPath, conformance.Options{Cases: []string{"validation_error"}})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(report.Results) != 1 || report.Results[0].Status != conformance.Skip || report.Skipped != 1 || !report.OK() {
+		t.Errorf("Expected validation_error to be skipped, got %+v", report.Results)
+	}
+
+	t.Log("✅ The Go server passes the conformance catalogue")
+}
+
+// TestConformanceDetectsViolations validates failures against a server that breaks the protocol
+func TestConformanceDetectsViolations(t *testing.T) {
+	socketPath := fmt.Sprintf("/tmp/conformance-test-%d.sock", time.Now().UnixNano())
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to bind socket: %v", err)
+	}
+	defer os.Remove(socketPath)
+	defer conn.Close()
+
+	// Answers every decodable request with success, an extra field and a second-precision timestamp
+	go func() {
+		buffer := make([]byte, 256*1024)
+		for {
+			n, _, err := conn.ReadFromUnix(buffer)
+			if err != nil {
+				return
+			}
+			var request map[string]interface{}
+			if json.Unmarshal(buffer[:n], &request) != nil {
+				continue
+			}
+			replyTo, _ := request["reply_to"].(string)
+			if replyTo == "" {
+				continue
+			}
+			data, _ := json.Marshal(map[string]interface{}{
+				"id": "fixed", "request_id": request["id"], "success": true, "channel": "legacy",
+				"result":    map[string]interface{}{"message": "pong"},
+				"timestamp": time.Now().UTC().Format(time.RFC3339),
+			})
+			conn.WriteToUnix(data, &net.UnixAddr{Name: replyTo, Net: "unixgram"})
+		}
+	}()
+
+	report, err := conformance.Run(socketPath, conformance.Options{Timeout: 200 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	statuses := map[string]conformance.Status{}
+	for _, result := range report.Results {
+		statuses[result.Name] = result.Status
+	}
+	expected := map[string]conformance.Status{
+		"response_fields":      conformance.Fail,
+		"response_correlation": conformance.Fail,
+		"timestamp_format":     conformance.Fail,
+		"ping":                 conformance.Pass,
+		"unknown_method":       conformance.Fail,
+		"bad_json":             conformance.Fail,
+		"oversized_payload":    conformance.Fail,
+		"missing_reply_to":     conformance.Pass,
+	}
+	for name, status := range expected {
+		if statuses[name] != status {
+			t.Errorf("%s: expected %s, got %s", name, status, statuses[name])
+		}
+	}
+	if report.OK() || report.Failed == 0 {
+		t.Error("Expected the report to fail")
+	}
+
+	if cases, err := conformance.Select([]string{"errors", "ping"}); err != nil || len(cases) != 7 {
+		t.Errorf("Expected 7 cases for the errors category and ping, got %d (%v)", len(cases), err)
+	}
+	if _, err := conformance.Select([]string{"nothing*"}); err == nil {
+		t.Error("Expected an unmatched case pattern to fail")
+	}
+
+	t.Log("✅ Conformance cases report protocol violations")
+}
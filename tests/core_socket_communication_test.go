@@ -140,7 +140,7 @@ func TestSendWithResponse(t *testing.T) {
 	}
 	
 	// Test message with reply_to field
-	request := models.NewJanusRequest("test-channel", "test-request", map[string]interface{}{
+	request := models.NewJanusRequest("test-request", map[string]interface{}{
 		"test_param": "test_value",
 	}, nil)
 	request.ReplyTo = &responseSocketPath
@@ -178,10 +178,6 @@ func TestSendWithResponse(t *testing.T) {
 		t.Fatalf("Failed to deserialize received request: %v", err)
 	}
 	
-	if receivedRequest.ChannelID != "test-channel" {
-		t.Errorf("Expected channel 'test-channel', got '%s'", receivedRequest.ChannelID)
-	}
-	
 	if receivedRequest.Request != "test-request" {
 		t.Errorf("Expected request 'test-request', got '%s'", receivedRequest.Request)
 	}
@@ -219,7 +215,7 @@ func TestFireAndForgetSend(t *testing.T) {
 	// We don't need a client for this low-level test, just create the request directly
 	
 	// Test fire-and-forget request (no reply_to field)
-	request := models.NewJanusRequest("test-channel", "fire-and-forget", map[string]interface{}{
+	request := models.NewJanusRequest("fire-and-forget", map[string]interface{}{
 		"message": "no response needed",
 	}, nil)
 	// ReplyTo should be empty for fire-and-forget
@@ -419,7 +415,7 @@ func TestConnectionTesting(t *testing.T) {
 	defer os.Remove(serverSocketPath)
 	
 	// Create client for connection testing
-	client, err := protocol.New(serverSocketPath, "test-channel")
+	client, err := protocol.New(serverSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"GoJanus/pkg/docs"
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+)
+
+const docsTestManifest = `{
+	"version": "3.1.0",
+	"name": "Library",
+	"description": "Book catalogue",
+	"requests": {
+		"find_books": {
+			"name": "find_books",
+			"description": "Search the catalogue",
+			"args": {
+				"query": {"name": "query", "type": "string", "description": "Search | terms", "required": true, "minLength": 2, "pattern": "^[a-z ]+$"},
+				"genre": {"name": "genre", "type": "string", "description": "Genre", "enum": ["fiction", "poetry"]}
+			},
+			"response": {"type": "array", "description": "Matches", "items": {"name": "book", "type": "object", "description": "Book", "modelRef": "Book"}},
+			"errorCodes": ["RESOURCE_NOT_FOUND", "BOOK_LOCKED"]
+		}
+	},
+	"models": {
+		"Book": {
+			"name": "Book",
+			"type": "object",
+			"description": "A book <with markup>",
+			"properties": {
+				"title": {"name": "title", "type": "string", "description": "Title"},
+				"sequel": {"name": "sequel", "type": "object", "description": "Next book", "modelRef": "Book"}
+			},
+			"required": ["title"]
+		}
+	}
+}`
+
+// TestRenderMarkdownDocs validates request, model, built-in and error sections
+func TestRenderMarkdownDocs(t *testing.T) {
+	m, err := manifest.ParseJSONString(docsTestManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	output, err := docs.RenderMarkdown(m, docs.DefaultOptions())
+	if err != nil {
+		t.Fatalf("Failed to render Markdown: %v", err)
+	}
+	markdown := string(output)
+
+	expected := []string{
+		"# Library",
+		"### `find_books`",
+		"| `query` | `string` | yes | Search \\| terms | `pattern: ^[a-z ]+$`<br>`min length: 2` |",
+		"`one of: fiction, poetry`",
+		"Type: `array<`[Book](#model-book)`>` — Matches",
+		"| `[].title` | `string` | yes |",
+		"| -32004 | `RESOURCE_NOT_FOUND` | " + models.ResourceNotFound.Message() + " |",
+		"|  | `BOOK_LOCKED` | Application-defined error |",
+		"## Built-in Requests",
+		"### `ping`",
+		"<a id=\"model-book\"></a>",
+		"## Error Codes",
+		"| -32013 | `MANIFEST_VALIDATION_ERROR` | " + models.ManifestValidationError.Message() + " |",
+	}
+	for _, fragment := range expected {
+		if !strings.Contains(markdown, fragment) {
+			t.Errorf("Markdown missing %q\n%s", fragment, markdown)
+		}
+	}
+
+	// Recursive model references are expanded once
+	if strings.Contains(markdown, "sequel.sequel.sequel") {
+		t.Error("Recursive model reference expanded without bound")
+	}
+
+	t.Log("✅ Markdown documentation rendered from manifest")
+}
+
+// TestRenderHTMLDocs validates escaping and anchors in HTML output
+func TestRenderHTMLDocs(t *testing.T) {
+	m, err := manifest.ParseJSONString(docsTestManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	output, err := docs.RenderHTML(m, docs.Options{})
+	if err != nil {
+		t.Fatalf("Failed to render HTML: %v", err)
+	}
+	html := string(output)
+
+	expected := []string{
+		"<title>Library</title>",
+		`<section id="request-find-books">`,
+		`<a href="#model-book">Book</a>`,
+		"A book &lt;with markup&gt;",
+		`<span class="required">yes</span>`,
+	}
+	for _, fragment := range expected {
+		if !strings.Contains(html, fragment) {
+			t.Errorf("HTML missing %q\n%s", fragment, html)
+		}
+	}
+
+	if strings.Contains(html, "Built-in Requests") || strings.Contains(html, `id="error-codes"`) {
+		t.Error("Expected built-ins and error codes to be omitted")
+	}
+
+	t.Log("✅ HTML documentation rendered with escaping")
+}
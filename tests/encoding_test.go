@@ -0,0 +1,480 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/core"
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/server"
+	"GoJanus/pkg/wire"
+)
+
+// TestCBORCodec validates the CBOR encoder against known encodings and the decoder's rejections
+func TestCBORCodec(t *testing.T) {
+	encoded, err := wire.MarshalCBOR(map[string]interface{}{"b": []byte{1, 2}, "a": 1})
+	if err != nil {
+		t.Fatalf("MarshalCBOR failed: %v", err)
+	}
+	// Magic, map(2), "a": 1, "b": bytes(2)
+	expected := []byte{0xd9, 0xd9, 0xf7, 0xa2, 0x61, 'a', 0x01, 0x61, 'b', 0x42, 1, 2}
+	if !bytes.Equal(encoded, expected) {
+		t.Errorf("Expected % x, got % x", expected, encoded)
+	}
+
+	value := map[string]interface{}{
+		"text":    "héllo",
+		"bytes":   []byte{0, 0xff},
+		"numbers": []interface{}{0.0, -1.0, 1.5, 0.1, 1e300, float64(1 << 40)},
+		"flags":   []interface{}{true, false, nil},
+		"nested":  map[string]interface{}{"empty": map[string]interface{}{}},
+	}
+	encoded, err = wire.MarshalCBOR(value)
+	if err != nil {
+		t.Fatalf("MarshalCBOR failed: %v", err)
+	}
+	decoded, err := wire.UnmarshalCBOR(encoded)
+	if err != nil || !reflect.DeepEqual(decoded, value) {
+		t.Errorf("Round trip changed the value: %v (%v)", decoded, err)
+	}
+
+	if half, err := wire.UnmarshalCBOR([]byte{0xf9, 0x3c, 0x00}); err != nil || half != 1.0 {
+		t.Errorf("Expected a half-precision 1.0, got %v (%v)", half, err)
+	}
+	for name, data := range map[string][]byte{
+		"truncated":          {0x62, 'a'},
+		"trailing bytes":     {0x01, 0x02},
+		"duplicate keys":     {0xa2, 0x61, 'a', 0x01, 0x61, 'a', 0x02},
+		"integer key":        {0xa1, 0x01, 0x01},
+		"indefinite length":  {0x9f, 0x01, 0xff},
+		"unsupported tag":    {0xc1, 0x01},
+		"invalid UTF-8":      {0x61, 0xff},
+		"oversized array":    {0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"unsupported simple": {0xf0},
+	} {
+		if _, err := wire.UnmarshalCBOR(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := wire.MarshalCBOR([]interface{}{func() {}}); err == nil {
+		t.Error("Expected a value outside the data model to be refused")
+	}
+
+	t.Log("✅ CBOR encodes deterministically and decodes strictly")
+}
+
+// TestWireCBOREncoding validates CBOR requests and responses through the strict codec
+func TestWireCBOREncoding(t *testing.T) {
+	request := models.NewJanusRequest("upload", map[string]interface{}{"data": []byte{0, 1, 2}, "name": "blob"}, nil)
+	replyTo := "/tmp/reply.sock"
+	request.ReplyTo = &replyTo
+	data, err := wire.EncodeRequestAs(request, wire.CBOR)
+	if err != nil {
+		t.Fatalf("Failed to encode request: %v", err)
+	}
+	if wire.DetectEncoding(data) != wire.CBOR {
+		t.Fatalf("Expected a CBOR message, got %q", data)
+	}
+	decoded, err := wire.DecodeRequest(data)
+	if err != nil {
+		t.Fatalf("Failed to decode request: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Args, request.Args) || *decoded.ReplyTo != replyTo || decoded.Timestamp != request.Timestamp {
+		t.Errorf("Round trip changed the request: %+v", decoded)
+	}
+
+	response := models.NewSuccessResponse(request.ID, map[string]interface{}{"checksum": []byte{9, 9}, "size": 3})
+	data, err = wire.EncodeResponseAs(response, wire.CBOR)
+	if err != nil {
+		t.Fatalf("Failed to encode response: %v", err)
+	}
+	decodedResponse, err := wire.DecodeResponse(data)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	expectedResult := map[string]interface{}{"checksum": []byte{9, 9}, "size": 3.0}
+	if !reflect.DeepEqual(decodedResponse.Result, expectedResult) || decodedResponse.RequestID != request.ID {
+		t.Errorf("Round trip changed the response: %+v", decodedResponse)
+	}
+
+	// CBOR messages follow the same rules as JSON ones
+	request.Method = "other"
+	if _, err := wire.EncodeRequestAs(request, wire.CBOR); err == nil {
+		t.Error("Expected an invalid request to be refused in CBOR too")
+	}
+	missingID, _ := wire.MarshalCBOR(map[string]interface{}{"method": "ping", "request": "ping", "timestamp": request.Timestamp})
+	array, _ := wire.MarshalCBOR([]interface{}{1})
+	for _, tc := range []struct {
+		name string
+		data []byte
+		code models.JSONRPCErrorCode
+	}{
+		{"malformed CBOR", append(append([]byte{}, wire.CBORMagic...), 0x62, 'a'), models.ParseError},
+		{"not a map", array, models.InvalidRequest},
+		{"missing id", missingID, models.InvalidRequest},
+	} {
+		_, err := wire.DecodeRequest(tc.data)
+		if rpcErr, ok := err.(*models.JSONRPCError); !ok || rpcErr.Code != tc.code {
+			t.Errorf("%s: expected code %d, got %v", tc.name, tc.code, err)
+		}
+	}
+
+	if encoding, err := wire.ParseEncoding(""); err != nil || encoding != wire.JSON {
+		t.Errorf("Expected JSON by default, got %q (%v)", encoding, err)
+	}
+	if _, err := wire.ParseEncoding("msgpack"); err == nil {
+		t.Error("Expected an unsupported encoding to be refused")
+	}
+
+	t.Log("✅ Requests and responses round-trip through CBOR with byte strings intact")
+}
+
+// BenchmarkWireEncoding compares encoding and strictly decoding a request and its response in each encoding
+func BenchmarkWireEncoding(b *testing.B) {
+	args := map[string]interface{}{
+		"name":  "blob",
+		"size":  4096,
+		"tags":  []interface{}{"alpha", "beta", "gamma"},
+		"owner": map[string]interface{}{"id": 42, "email": "ann@example.com"},
+	}
+	request := models.NewJanusRequest("upload", args, nil)
+	replyTo := "/tmp/reply.sock"
+	request.ReplyTo = &replyTo
+	response := models.NewSuccessResponse(request.ID, map[string]interface{}{"stored": true, "path": "/blobs/blob", "size": 4096})
+
+	for _, encoding := range wire.Encodings() {
+		b.Run(string(encoding)+"/request", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				data, err := wire.EncodeRequestAs(request, encoding)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := wire.DecodeRequest(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(string(encoding)+"/response", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				data, err := wire.EncodeResponseAs(response, encoding)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := wire.DecodeResponse(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// startEncodingServer starts a server whose "checksum" handler sums the bytes of its "data" argument
+func startEncodingServer(t *testing.T, encodings ...wire.Encoding) string {
+	t.Helper()
+	_, socketPath, _ := startTestServer(t, &server.ServerConfig{Encodings: encodings}, func(srv *server.JanusServer) {
+		srv.RegisterHandler("checksum", server.SyncHandler(func(cmd *models.JanusRequest) server.HandlerResult {
+			data, ok := cmd.Args["data"].([]byte)
+			if !ok {
+				return server.HandlerResult{Error: models.NewJSONRPCError(models.InvalidParams, fmt.Sprintf("data is %T, not bytes", cmd.Args["data"]))}
+			}
+			sum := 0
+			for _, b := range data {
+				sum += int(b)
+			}
+			return server.HandlerResult{Value: map[string]interface{}{"sum": sum, "echo": data}}
+		}))
+	})
+	return socketPath
+}
+
+// TestServerRepliesInRequestEncoding validates binary arguments over CBOR and per-server encoding limits
+func TestServerRepliesInRequestEncoding(t *testing.T) {
+	ctx := context.Background()
+	socketPath := startEncodingServer(t)
+
+	client := newTestClient(t, socketPath, wire.CBOR)
+	response, err := client.SendRequest(ctx, "checksum", map[string]interface{}{"data": []byte{1, 2, 3, 250}})
+	if err != nil || !response.Success {
+		t.Fatalf("Expected a CBOR response, got %v %+v", err, response)
+	}
+	result, _ := response.Result.(map[string]interface{})
+	if result["sum"] != 256.0 || !bytes.Equal(result["echo"].([]byte), []byte{1, 2, 3, 250}) {
+		t.Errorf("Unexpected result: %v", response.Result)
+	}
+
+	response, err = client.SendRequest(ctx, "get_info", nil)
+	if err != nil || !response.Success {
+		t.Fatalf("get_info failed: %v %+v", err, response)
+	}
+	info, _ := response.Result.(map[string]interface{})
+	if !reflect.DeepEqual(info["encodings"], []interface{}{"json", "cbor"}) {
+		t.Errorf("Expected get_info to list json and cbor, got %v", info["encodings"])
+	}
+
+	// JSON has no byte strings, so the same argument arrives as base64 text
+	response, err = newTestClient(t, socketPath, wire.JSON).SendRequest(ctx, "checksum", map[string]interface{}{"data": []byte{1}})
+	if err != nil || response.Error == nil || response.Error.Code != models.InvalidParams {
+		t.Errorf("Expected INVALID_PARAMS for a JSON request, got %v %+v", err, response)
+	}
+
+	// A CBOR client falls back to JSON for a server whose get_info does not list cbor
+	jsonOnly := startEncodingServer(t, wire.JSON)
+	fallback := newTestClient(t, jsonOnly, wire.CBOR)
+	negotiated, err := fallback.Negotiate(ctx)
+	if err != nil || negotiated.Encoding != wire.JSON || !reflect.DeepEqual(negotiated.Encodings, []wire.Encoding{wire.JSON}) {
+		t.Fatalf("Expected negotiation to fall back to JSON, got %+v (%v)", negotiated, err)
+	}
+	response, err = fallback.SendRequest(ctx, "checksum", map[string]interface{}{"data": []byte{1}})
+	if err != nil || response.Error == nil || response.Error.Code != models.InvalidParams {
+		t.Errorf("Expected the JSON fallback to reach the handler, got %v %+v", err, response)
+	}
+	if negotiated, err := client.Negotiate(ctx); err != nil || negotiated.Encoding != wire.CBOR {
+		t.Errorf("Expected CBOR with a server that offers it, got %+v (%v)", negotiated, err)
+	}
+
+	// The server itself still refuses CBOR, answering in JSON
+	replyPath := fmt.Sprintf("/tmp/encoding-reply-%d.sock", time.Now().UnixNano())
+	reply, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: replyPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to bind reply socket: %v", err)
+	}
+	defer os.Remove(replyPath)
+	defer reply.Close()
+	rawRequest := models.NewJanusRequest("checksum", map[string]interface{}{"data": []byte{1}}, nil)
+	rawRequest.ReplyTo = &replyPath
+	data, _ := wire.EncodeRequestAs(rawRequest, wire.CBOR)
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: jsonOnly, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to dial server: %v", err)
+	}
+	conn.Write(data)
+	conn.Close()
+	buffer := make([]byte, 64*1024)
+	reply.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := reply.Read(buffer)
+	if err != nil {
+		t.Fatalf("No reply to the CBOR request: %v", err)
+	}
+	refused, err := wire.DecodeResponse(buffer[:n])
+	if err != nil || wire.DetectEncoding(buffer[:n]) != wire.JSON || refused.Error == nil || refused.Error.Code != models.InvalidRequest {
+		t.Errorf("Expected INVALID_REQUEST in JSON from a JSON-only server, got %v %+v", err, refused)
+	}
+
+	// RequestOptions.Encoding overrides the client encoding for one request
+	response, err = client.SendRequest(ctx, "checksum", map[string]interface{}{"data": []byte{1}}, protocol.RequestOptions{Encoding: wire.JSON})
+	if err != nil || response.Error == nil || response.Error.Code != models.InvalidParams {
+		t.Errorf("Expected the JSON override to send base64 text, got %v %+v", err, response)
+	}
+	if _, err := client.SendRequest(ctx, "checksum", nil, protocol.RequestOptions{Encoding: "xml"}); err == nil {
+		t.Error("Expected an unsupported request encoding to be refused")
+	}
+
+	// The override applies on the other send paths too
+	jsonOnce := protocol.RequestOptions{Encoding: wire.JSON}
+	responses, errs, _ := client.SendRequestWithCorrelation(ctx, "checksum", map[string]interface{}{"data": []byte{1}}, 5*time.Second, jsonOnce)
+	select {
+	case response = <-responses:
+		t.Errorf("Expected the correlated JSON override to send base64 text, got %+v", response)
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "INVALID_PARAMS") {
+			t.Errorf("Expected INVALID_PARAMS for the correlated JSON override, got %v", err)
+		}
+	}
+	results := client.ExecuteRequestsInParallel(ctx, []protocol.ParallelRequest{
+		{ID: "cbor", Request: "checksum", Args: map[string]interface{}{"data": []byte{1}}},
+		{ID: "json", Request: "checksum", Args: map[string]interface{}{"data": []byte{1}}, Encoding: wire.JSON},
+	})
+	if results[0].Error != nil || !results[0].Response.Success {
+		t.Errorf("Expected the parallel CBOR request to succeed, got %v %+v", results[0].Error, results[0].Response)
+	}
+	if results[1].Error != nil || results[1].Response.Error == nil || results[1].Response.Error.Code != models.InvalidParams {
+		t.Errorf("Expected the parallel JSON override to send base64 text, got %v %+v", results[1].Error, results[1].Response)
+	}
+	if err := client.SendRequestNoResponse(ctx, "checksum", nil, protocol.RequestOptions{Encoding: "xml"}); err == nil {
+		t.Error("Expected an unsupported encoding to be refused without a response too")
+	}
+
+	config := protocol.DefaultJanusClientConfig()
+	config.Encoding = "xml"
+	if _, err := protocol.New(socketPath, config); err == nil {
+		t.Error("Expected an unsupported client encoding to be refused")
+	}
+
+	t.Log("✅ Servers reply in the encoding of each request, and clients use only encodings the server advertises")
+}
+
+// TestEncodingFallsBackWhenGetInfoFails validates that a failed get_info sends JSON and is not retried on every request
+func TestEncodingFallsBackWhenGetInfoFails(t *testing.T) {
+	// A server without built-ins: get_info fails, everything else succeeds when it arrives as JSON
+	socketPath := fmt.Sprintf("/tmp/encoding-no-info-test-%d.sock", time.Now().UnixNano())
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer os.Remove(socketPath)
+	defer conn.Close()
+
+	var infoRequests, cborRequests int32
+	go func() {
+		buffer := make([]byte, 64*1024)
+		for {
+			n, _, err := conn.ReadFromUnix(buffer)
+			if err != nil {
+				return
+			}
+			request, err := wire.DecodeRequest(buffer[:n])
+			if err != nil || request.ReplyTo == nil {
+				continue
+			}
+			if wire.DetectEncoding(buffer[:n]) == wire.CBOR {
+				atomic.AddInt32(&cborRequests, 1)
+			}
+			response := models.NewSuccessResponse(request.ID, map[string]interface{}{"ok": true})
+			if request.Request == "get_info" {
+				atomic.AddInt32(&infoRequests, 1)
+				response = models.NewErrorResponse(request.ID, models.NewJSONRPCError(models.MethodNotFound, "get_info"))
+			}
+			data, _ := wire.EncodeResponse(response)
+			reply, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: *request.ReplyTo, Net: "unixgram"})
+			if err != nil {
+				continue
+			}
+			reply.Write(data)
+			reply.Close()
+		}
+	}()
+
+	client := newTestClient(t, socketPath, wire.CBOR)
+	for i := 0; i < 3; i++ {
+		response, err := client.SendRequest(context.Background(), "store", nil)
+		if err != nil || !response.Success {
+			t.Fatalf("Request %d: expected the JSON fallback to succeed, got %v %+v", i, err, response)
+		}
+	}
+	if count := atomic.LoadInt32(&infoRequests); count != 1 {
+		t.Errorf("Expected get_info once for the cool-down, got %d", count)
+	}
+	if count := atomic.LoadInt32(&cborRequests); count != 0 {
+		t.Errorf("Expected every request in JSON, got %d in CBOR", count)
+	}
+
+	t.Log("✅ A failed get_info falls back to JSON for the cool-down instead of failing requests")
+}
+
+// TestMessageFramingCBOR validates framed and direct CBOR messages
+func TestMessageFramingCBOR(t *testing.T) {
+	cborFraming := &protocol.MessageFraming{Encoding: wire.CBOR}
+	jsonFraming := protocol.NewMessageFraming()
+	request := models.NewJanusRequest("upload", map[string]interface{}{"data": []byte("raw\x00bytes")}, nil)
+	response := models.NewSuccessResponse(request.ID, []interface{}{[]byte{7}})
+
+	for _, message := range []interface{}{*request, *response} {
+		for name, encode := range map[string]func(interface{}) ([]byte, error){
+			"envelope": cborFraming.EncodeMessage,
+			"direct":   cborFraming.EncodeDirectMessage,
+		} {
+			framed, err := encode(message)
+			if err != nil {
+				t.Fatalf("%s: failed to encode %T: %v", name, message, err)
+			}
+			if !wire.IsCBOR(framed[protocol.LengthPrefixSize:]) {
+				t.Errorf("%s: expected a CBOR frame", name)
+			}
+
+			// Decoding detects the encoding, whatever the framing's own
+			decode := jsonFraming.DecodeMessage
+			if name == "direct" {
+				decode = jsonFraming.DecodeDirectMessage
+			}
+			decoded, remaining, err := decode(framed)
+			if err != nil || len(remaining) != 0 {
+				t.Fatalf("%s: failed to decode %T: %v", name, message, err)
+			}
+			if !reflect.DeepEqual(decoded, message) {
+				t.Errorf("%s: round trip changed the message:\n%+v\n%+v", name, message, decoded)
+			}
+		}
+	}
+
+	badEnvelope, _ := wire.MarshalCBOR(map[string]interface{}{"type": "request", "payload": "text"})
+	framed := append([]byte{0, 0, 0, byte(len(badEnvelope))}, badEnvelope...)
+	if _, _, err := jsonFraming.DecodeMessage(framed); err == nil {
+		t.Error("Expected a CBOR envelope with a text payload to be refused")
+	}
+
+	t.Log("✅ Message framing encodes CBOR on request and detects it when decoding")
+}
+
+// TestSecurityValidatorCBORText validates that NUL bytes are refused in CBOR text but allowed in byte strings
+func TestSecurityValidatorCBORText(t *testing.T) {
+	validator := core.NewSecurityValidator()
+	encode := func(args map[string]interface{}) []byte {
+		data, err := wire.EncodeRequestAs(models.NewJanusRequest("store", args, nil), wire.CBOR)
+		if err != nil {
+			t.Fatalf("Failed to encode request: %v", err)
+		}
+		return data
+	}
+
+	if err := validator.ValidateMessageData(encode(map[string]interface{}{"data": []byte{0, 0, 1}})); err != nil {
+		t.Errorf("Expected NUL bytes in a byte string to pass, got %v", err)
+	}
+	for name, args := range map[string]map[string]interface{}{
+		"value":        {"name": "value\x00injection"},
+		"nested value": {"tags": []interface{}{map[string]interface{}{"label": "a\x00"}}},
+		"key":          {"na\x00me": "value"},
+	} {
+		err := validator.ValidateMessageData(encode(args))
+		if err == nil || !strings.Contains(err.Error(), "null byte") {
+			t.Errorf("%s: expected a null byte error, got %v", name, err)
+		}
+	}
+	if err := validator.ValidateMessageData(append(append([]byte{}, wire.CBORMagic...), 0x62, 'a')); err == nil {
+		t.Error("Expected malformed CBOR to be refused")
+	}
+
+	t.Log("✅ CBOR text strings get the same NUL byte check as JSON messages")
+}
+
+// TestBinaryFormat validates the binary string format for byte strings and base64 text
+func TestBinaryFormat(t *testing.T) {
+	m, err := manifest.ParseJSONString(`{
+		"version": "1.0.0",
+		"name": "Blobs",
+		"requests": {
+			"store": {
+				"name": "Store",
+				"description": "Stores a blob",
+				"args": {"data": {"type": "string", "format": "binary", "maxLength": 8, "required": true}}
+			}
+		}
+	}`)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	request, _ := m.GetRequest("store")
+
+	for _, value := range []interface{}{[]byte{0xff, 0x00}, "AAEC"} {
+		if err := m.ValidateRequestArgs(request, map[string]interface{}{"data": value}); err != nil {
+			t.Errorf("Expected %v to be valid binary data, got %v", value, err)
+		}
+	}
+	// maxLength applies to the base64 text, so 7 bytes (12 characters) is too long
+	for _, value := range []interface{}{"not base64!", []byte("7 bytes"), 42} {
+		if err := m.ValidateRequestArgs(request, map[string]interface{}{"data": value}); err == nil {
+			t.Errorf("Expected %v to be refused", value)
+		}
+	}
+
+	t.Log("✅ Binary arguments validate as base64 whether sent as bytes or text")
+}
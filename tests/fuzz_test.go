@@ -0,0 +1,347 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GoJanus/pkg/core"
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/wire"
+)
+
+// Run a target with e.g. go test ./tests -run '^$' -fuzz '^FuzzDecodeMessage$' -fuzztime 60s
+// Without -fuzz the seed corpus and the inputs under testdata/fuzz run as regular tests
+
+// wireSeeds returns the shared wire fixtures, valid and invalid
+func wireSeeds(f *testing.F) [][]byte {
+	paths, err := filepath.Glob(filepath.Join("fixtures", "wire", "*.json"))
+	if err != nil || len(paths) == 0 {
+		f.Fatalf("Failed to find wire fixtures: %v", err)
+	}
+	var seeds [][]byte
+	for _, path := range paths {
+		if filepath.Base(path) == "index.json" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatalf("Failed to read %s: %v", path, err)
+		}
+		seeds = append(seeds, data)
+	}
+	return seeds
+}
+
+// frame prefixes data with its 4-byte big-endian length
+func frame(data []byte) []byte {
+	framed := make([]byte, protocol.LengthPrefixSize, protocol.LengthPrefixSize+len(data))
+	binary.BigEndian.PutUint32(framed, uint32(len(data)))
+	return append(framed, data...)
+}
+
+// cborSeed re-encodes a JSON fixture as CBOR, or returns nil when it is not an object
+func cborSeed(seed []byte) []byte {
+	var fields map[string]interface{}
+	if json.Unmarshal(seed, &fields) != nil || fields == nil {
+		return nil
+	}
+	data, _ := wire.MarshalCBOR(fields)
+	return data
+}
+
+// addFramingSeeds adds framed fixtures, in JSON and CBOR, and the truncated and malformed frames of the framing tests
+func addFramingSeeds(f *testing.F, envelope bool) {
+	for _, seed := range wireSeeds(f) {
+		messageType := "request"
+		if bytes.Contains(seed, []byte(`"request_id"`)) {
+			messageType = "response"
+		}
+		payloads := [][]byte{seed}
+		if envelope {
+			payloads[0], _ = json.Marshal(protocol.SocketMessage{Type: messageType, Payload: string(seed)})
+		}
+		if cbor := cborSeed(seed); cbor != nil && envelope {
+			cborEnvelope, _ := wire.MarshalCBOR(map[string]interface{}{"type": messageType, "payload": cbor})
+			payloads = append(payloads, cborEnvelope)
+		} else if cbor != nil {
+			payloads = append(payloads, cbor)
+		}
+		for _, payload := range payloads {
+			framed := frame(payload)
+			f.Add(framed)
+			f.Add(append(framed, framed...))
+			f.Add(framed[:len(framed)/2])
+		}
+	}
+	f.Add([]byte{})
+	f.Add([]byte{0, 0})
+	f.Add([]byte{0, 0, 0, 0})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, '{'})
+	f.Add(frame([]byte(`{"type":"request","payload":""}`)))
+	f.Add(frame([]byte(`{"type":"unknown","payload":"{}"}`)))
+	f.Add(frame([]byte(`not json`)))
+}
+
+// framingFor returns a framing that encodes in the encoding of a decoded frame, so
+// byte strings from CBOR survive re-encoding
+func framingFor(buffer []byte) *protocol.MessageFraming {
+	return &protocol.MessageFraming{Encoding: wire.DetectEncoding(buffer[protocol.LengthPrefixSize:])}
+}
+
+// checkDecoded asserts the invariants shared by both framing decoders and reports whether a message was decoded
+func checkDecoded(t *testing.T, buffer []byte, message interface{}, remaining []byte, err error) bool {
+	if err != nil {
+		if _, ok := err.(*models.JSONRPCError); !ok {
+			t.Fatalf("Expected a JSON-RPC error, got %T: %v", err, err)
+		}
+		if !bytes.Equal(remaining, buffer) {
+			t.Fatalf("A failed decode must return the whole buffer")
+		}
+		return false
+	}
+	length := int(binary.BigEndian.Uint32(buffer))
+	if !bytes.Equal(remaining, buffer[protocol.LengthPrefixSize+length:]) {
+		t.Fatalf("Remaining buffer is not what follows the frame")
+	}
+	switch message.(type) {
+	case models.JanusRequest, models.JanusResponse:
+	default:
+		t.Fatalf("Decoded an unexpected message type %T", message)
+	}
+	return true
+}
+
+// FuzzDecodeMessage checks MessageFraming.DecodeMessage on arbitrary frames
+func FuzzDecodeMessage(f *testing.F) {
+	addFramingSeeds(f, true)
+	framing := protocol.NewMessageFraming()
+	f.Fuzz(func(t *testing.T, buffer []byte) {
+		message, remaining, err := framing.DecodeMessage(buffer)
+		if !checkDecoded(t, buffer, message, remaining, err) {
+			return
+		}
+
+		// Anything accepted re-encodes, and decodes to the same message
+		encoded, err := framingFor(buffer).EncodeMessage(message)
+		if err != nil {
+			t.Fatalf("Failed to re-encode %+v: %v", message, err)
+		}
+		again, rest, err := framing.DecodeMessage(encoded)
+		if err != nil || len(rest) != 0 || !reflect.DeepEqual(again, message) {
+			t.Fatalf("Round trip changed the message: %+v, %+v, %v", message, again, err)
+		}
+	})
+}
+
+// FuzzDecodeDirectMessage checks MessageFraming.DecodeDirectMessage on arbitrary frames
+func FuzzDecodeDirectMessage(f *testing.F) {
+	addFramingSeeds(f, false)
+	framing := protocol.NewMessageFraming()
+	f.Fuzz(func(t *testing.T, buffer []byte) {
+		message, remaining, err := framing.DecodeDirectMessage(buffer)
+		if !checkDecoded(t, buffer, message, remaining, err) {
+			return
+		}
+
+		// Anything accepted re-encodes, and decodes to the same message
+		encoded, err := framingFor(buffer).EncodeDirectMessage(message)
+		if err != nil {
+			t.Fatalf("Failed to re-encode %+v: %v", message, err)
+		}
+		again, rest, err := framing.DecodeDirectMessage(encoded)
+		if err != nil || len(rest) != 0 || !reflect.DeepEqual(again, message) {
+			t.Fatalf("Round trip changed the message: %+v, %+v, %v", message, again, err)
+		}
+	})
+}
+
+// FuzzUnmarshalCBOR checks that decoded CBOR re-encodes and decodes to the same value
+func FuzzUnmarshalCBOR(f *testing.F) {
+	for _, seed := range wireSeeds(f) {
+		if cbor := cborSeed(seed); cbor != nil {
+			f.Add(cbor)
+		}
+	}
+	for _, seed := range [][]byte{
+		{0xf9, 0x7c, 0x00},
+		{0xfb, 0x3f, 0xb9, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a},
+		{0x3b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		{0xa2, 0x61, 'a', 0x01, 0x61, 'a', 0x02},
+		{0x9f, 0x01, 0xff},
+		{0xd9, 0xd9, 0xf7, 0xd9, 0xd9, 0xf7, 0x80},
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		value, err := wire.UnmarshalCBOR(data)
+		if err != nil {
+			return
+		}
+		encoded, err := wire.MarshalCBOR(value)
+		if err != nil {
+			// Only non-finite numbers have no encoding
+			if !strings.Contains(err.Error(), "unsupported number") {
+				t.Fatalf("Failed to re-encode %v: %v", value, err)
+			}
+			return
+		}
+		again, err := wire.UnmarshalCBOR(encoded)
+		if err != nil || !reflect.DeepEqual(again, value) {
+			t.Fatalf("Round trip changed the value: %v -> %v (%v)", value, again, err)
+		}
+	})
+}
+
+// FuzzValidateJSONStructure checks the security validator's JSON pre-check against encoding/json
+func FuzzValidateJSONStructure(f *testing.F) {
+	for _, seed := range wireSeeds(f) {
+		f.Add(seed)
+	}
+	for _, seed := range []string{
+		`{"incomplete": `,
+		`{"invalid": "unclosed string}`,
+		`{"nested": {"too": {"deep": {"structure": {"causes": {"stack": {"overflow": "maybe"}}}}}}}`,
+		`{invalid_json_without_quotes: "value"}`,
+		`{"unicode_attack": "￿￾\u0000"}`,
+		`{"escaped": "\\\"}\\"}`,
+		`[{}]`,
+		" \t\n{}\n",
+	} {
+		f.Add([]byte(seed))
+	}
+	validator := core.NewSecurityValidator()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		err := validator.ValidateJSONStructure(data)
+		if err == nil {
+			trimmed := strings.TrimSpace(string(data))
+			if !strings.HasPrefix(trimmed, "{") || !strings.HasSuffix(trimmed, "}") {
+				t.Fatalf("Accepted data that is not enclosed in braces: %q", data)
+			}
+			return
+		}
+		var object map[string]interface{}
+		if json.Unmarshal(data, &object) == nil && object != nil {
+			t.Fatalf("Rejected a valid JSON object %q: %v", data, err)
+		}
+	})
+}
+
+// manifestSeeds returns the manifests of the schema, collection and validation tests
+func manifestSeeds() []string {
+	return []string{
+		schemaConstructsManifest,
+		collectionConstraintsManifest,
+		validationErrorsManifest,
+		`{"version": "1.0.0", "name": "Empty"}`,
+		`{"version": "1.0.0", "name": "Loop", "models": {"Node": {"name": "Node", "type": "object", "properties": {"next": {"modelRef": "Node"}}}}, "requests": {"walk": {"name": "Walk", "description": "Walks a list", "args": {"head": {"modelRef": "Node"}}}}}`,
+	}
+}
+
+// checkManifest asserts that a parsed manifest is valid and survives a JSON round trip
+func checkManifest(t *testing.T, parsed *manifest.Manifest, err error) {
+	if err != nil {
+		return
+	}
+	if parsed == nil {
+		t.Fatal("Parsing succeeded without a manifest")
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Fatalf("Parsed manifest fails validation: %v", err)
+	}
+	data, err := json.Marshal(parsed)
+	if err != nil {
+		t.Fatalf("Failed to serialize parsed manifest: %v", err)
+	}
+	if _, err := manifest.NewManifestParser().ParseJSON(data); err != nil {
+		t.Fatalf("Serialized manifest does not parse: %v\n%s", err, data)
+	}
+}
+
+// FuzzParseJSONManifest checks ManifestParser.ParseJSON on arbitrary documents
+func FuzzParseJSONManifest(f *testing.F) {
+	for _, seed := range manifestSeeds() {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		parsed, err := manifest.NewManifestParser().ParseJSON(data)
+		checkManifest(t, parsed, err)
+	})
+}
+
+// FuzzParseYAMLManifest checks ManifestParser.ParseYAML on arbitrary documents
+func FuzzParseYAMLManifest(f *testing.F) {
+	for _, seed := range manifestSeeds() {
+		f.Add([]byte(seed))
+	}
+	f.Add([]byte(`
+version: "1.0.0"
+name: "Test API"
+requests:
+  greet:
+    name: Greet
+    description: Greets someone
+    args:
+      name:
+        type: string
+        required: true
+        minLength: 1
+    response:
+      type: object
+`))
+	f.Add([]byte(`
+version: "1.0.0"
+name: Aliases
+requests:
+  first: &request {name: First, description: Aliased, args: {a: &arg {type: string, minLength: 1}, b: *arg, c: *arg}}
+  second: *request
+  third: *request
+`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		parsed, err := manifest.NewManifestParser().ParseYAML(data)
+		checkManifest(t, parsed, err)
+	})
+}
+
+// FuzzJSONRPCErrorUnmarshal checks JSONRPCError.UnmarshalJSON and that accepted errors serialize stably
+func FuzzJSONRPCErrorUnmarshal(f *testing.F) {
+	for _, rpcErr := range []*models.JSONRPCError{
+		models.NewJSONRPCError(models.MethodNotFound, "Unknown request: reboot"),
+		models.NewJSONRPCError(models.ParseError, ""),
+		models.NewValidationError("age", 0, "must be at least 1", map[string]interface{}{"minimum": 1}),
+		models.NewJSONRPCErrorWithContext(models.InternalError, "handler failed", map[string]interface{}{"attempt": 2}),
+	} {
+		data, _ := json.Marshal(rpcErr)
+		f.Add(data)
+	}
+	for _, seed := range []string{`{"code": 1.5}`, `{"code": 1e30, "message": "x"}`, `{"code": "-32601"}`, `{"data": null}`, `null`, `[]`} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var rpcErr models.JSONRPCError
+		if json.Unmarshal(data, &rpcErr) != nil {
+			return
+		}
+		encoded, err := json.Marshal(&rpcErr)
+		if err != nil {
+			t.Fatalf("Failed to serialize %+v: %v", rpcErr, err)
+		}
+		var again models.JSONRPCError
+		if err := json.Unmarshal(encoded, &again); err != nil {
+			t.Fatalf("Serialized error %s does not parse: %v", encoded, err)
+		}
+
+		// Empty data maps are dropped on the first encoding, after that it is stable
+		reencoded, _ := json.Marshal(&again)
+		if !bytes.Equal(reencoded, encoded) {
+			t.Fatalf("Round trip changed the error: %s -> %s", encoded, reencoded)
+		}
+	})
+}
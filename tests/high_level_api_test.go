@@ -67,7 +67,7 @@ func TestClientServerCommunication(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	
 	// Test client communication - use nil manifest to fetch from server dynamically
-	client, err := protocol.New(socketPath, "test", protocol.DefaultJanusClientConfig())
+	client, err := protocol.New(socketPath, protocol.DefaultJanusClientConfig())
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -130,7 +130,7 @@ func TestClientServerWithArgs(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	
 	// Test client with arguments - use nil manifest to fetch from server dynamically
-	client, err := protocol.New(socketPath, "test", protocol.DefaultJanusClientConfig())
+	client, err := protocol.New(socketPath, protocol.DefaultJanusClientConfig())
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -205,7 +205,7 @@ func TestClientRequestNotFound(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	
 	// Test client with non-existent request
-	client, err := protocol.New(socketPath, "test", protocol.DefaultJanusClientConfig())
+	client, err := protocol.New(socketPath, protocol.DefaultJanusClientConfig())
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -263,7 +263,7 @@ func TestClientFireAndForget(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	
 	// Test fire-and-forget
-	client, err := protocol.New(socketPath, "test", protocol.DefaultJanusClientConfig())
+	client, err := protocol.New(socketPath, protocol.DefaultJanusClientConfig())
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -313,7 +313,7 @@ func TestClientTimeout(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	
 	// Test client with short timeout
-	client, err := protocol.New(socketPath, "test", protocol.DefaultJanusClientConfig())
+	client, err := protocol.New(socketPath, protocol.DefaultJanusClientConfig())
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -356,7 +356,7 @@ func TestClientPing(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	
 	// Test ping
-	client, err := protocol.New(socketPath, "test", protocol.DefaultJanusClientConfig())
+	client, err := protocol.New(socketPath, protocol.DefaultJanusClientConfig())
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -428,7 +428,7 @@ func TestMultipleHandlers(t *testing.T) {
 	// Disable client-side validation to test server-side validation
 	clientConfig := protocol.DefaultJanusClientConfig()
 	clientConfig.EnableValidation = false
-	client, err := protocol.New(socketPath, "test", clientConfig)
+	client, err := protocol.New(socketPath, clientConfig)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -24,7 +26,7 @@ func TestClientInitializationWithValidManifest(t *testing.T) {
 	os.Remove(testSocketPath)
 	defer os.Remove(testSocketPath)
 	
-	client, err := protocol.New(testSocketPath, "test")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client with valid manifest: %v", err)
 	}
@@ -34,10 +36,6 @@ func TestClientInitializationWithValidManifest(t *testing.T) {
 		t.Errorf("Expected socket path '%s', got '%s'", testSocketPath, client.SocketPathString())
 	}
 	
-	if client.ChannelIdentifier() != "test" {
-		t.Errorf("Expected channel 'test', got '%s'", client.ChannelIdentifier())
-	}
-	
 	// In Dynamic Manifest Architecture, manifests are auto-fetched from server
 	// For this test, we expect the manifest to be nil since no server is running
 	if client.Manifest() != nil {
@@ -54,7 +52,7 @@ func TestClientInitializationWithInvalidChannel(t *testing.T) {
 	os.Remove(testSocketPath)
 	defer os.Remove(testSocketPath)
 	
-	client, err := protocol.New(testSocketPath, "nonexistent-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		// Constructor should succeed - validation happens during sendRequest
 		t.Errorf("Constructor should not fail for invalid channel: %v", err)
@@ -76,27 +74,6 @@ func TestClientInitializationWithInvalidChannel(t *testing.T) {
 	}
 }
 
-// TestClientInitializationWithInvalidChannel tests client creation failure with invalid channel format
-// Matches Swift: testClientInitializationWithInvalidChannel()
-func TestClientInitializationWithInvalidChannelFormat(t *testing.T) {
-	testSocketPath := "/tmp/gojanus-client-test.sock"
-	
-	// Clean up before and after test
-	os.Remove(testSocketPath)
-	defer os.Remove(testSocketPath)
-	
-	// Test invalid channel ID format (contains invalid characters)
-	_, err := protocol.New(testSocketPath, "invalid/channel")
-	if err == nil {
-		t.Error("Expected error for invalid channel format")
-		return
-	}
-	
-	if !strings.Contains(err.Error(), "channel") && !strings.Contains(err.Error(), "invalid") {
-		t.Errorf("Expected channel validation error, got: %v", err)
-	}
-}
-
 // TestRegisterValidRequestHandler tests registering a valid request handler
 // Matches Swift: testRegisterValidRequestHandler()
 func TestRegisterValidRequestHandler(t *testing.T) {
@@ -128,7 +105,7 @@ func TestRegisterValidRequestHandler(t *testing.T) {
 		}
 	}()
 	
-	client, err := protocol.New(testSocketPath, "test")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -136,7 +113,7 @@ func TestRegisterValidRequestHandler(t *testing.T) {
 	
 	// Register handler for existing request
 	handler := func(request *models.JanusRequest) (*models.JanusResponse, error) {
-		return models.NewSuccessResponse(request.ID, request.ChannelID, map[string]interface{}{
+		return models.NewSuccessResponse(request.ID, map[string]interface{}{
 			"message": "Handler executed successfully",
 		}), nil
 	}
@@ -156,7 +133,7 @@ func TestRegisterInvalidRequestHandler(t *testing.T) {
 	os.Remove(testSocketPath)
 	defer os.Remove(testSocketPath)
 	
-	client, err := protocol.New(testSocketPath, "test")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -164,7 +141,7 @@ func TestRegisterInvalidRequestHandler(t *testing.T) {
 	
 	// Try to register handler for nonexistent request
 	handler := func(request *models.JanusRequest) (*models.JanusResponse, error) {
-		return models.NewSuccessResponse(request.ID, request.ChannelID, nil), nil
+		return models.NewSuccessResponse(request.ID, nil), nil
 	}
 	
 	err = client.RegisterRequestHandler("nonexistent-request", handler)
@@ -187,7 +164,7 @@ func TestJanusRequestValidation(t *testing.T) {
 	os.Remove(testSocketPath)
 	defer os.Remove(testSocketPath)
 	
-	client, err := protocol.New(testSocketPath, "test")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -238,16 +215,11 @@ func TestRequestMessageSerialization(t *testing.T) {
 	os.Remove(testSocketPath)
 	defer os.Remove(testSocketPath)
 	
-	client, err := protocol.New(testSocketPath, "test")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
-	// Note: SOCK_DGRAM clients are connectionless and don't need explicit cleanup
-	
-	// Verify client was created properly
-	if client.ChannelIdentifier() != "test" {
-		t.Errorf("Expected channel ID 'test', got %s", client.ChannelIdentifier())
-	}
+	defer client.Close()
 	
 	args := map[string]interface{}{
 		"title":  "Test Book",
@@ -256,7 +228,7 @@ func TestRequestMessageSerialization(t *testing.T) {
 	}
 	
 	// Create request directly for serialization testing
-	request := models.NewJanusRequest("test", "echo", args, nil)
+	request := models.NewJanusRequest("echo", args, nil)
 	
 	// Test serialization
 	jsonData, err := request.ToJSON()
@@ -272,9 +244,6 @@ func TestRequestMessageSerialization(t *testing.T) {
 	}
 	
 	// Verify integrity
-	if deserializedRequest.ChannelID != request.ChannelID {
-		t.Errorf("ChannelID mismatch: expected '%s', got '%s'", request.ChannelID, deserializedRequest.ChannelID)
-	}
 	
 	if deserializedRequest.Request != request.Request {
 		t.Errorf("Request mismatch: expected '%s', got '%s'", request.Request, deserializedRequest.Request)
@@ -336,14 +305,14 @@ func TestMultipleClientInstances(t *testing.T) {
 	}()
 	
 	// Create first client
-	client1, err := protocol.New(testSocketPath1, "test")
+	client1, err := protocol.New(testSocketPath1)
 	if err != nil {
 		t.Fatalf("Failed to create first client: %v", err)
 	}
 	defer client1.Close()
 	
 	// Create second client with different socket path and same channel
-	client2, err := protocol.New(testSocketPath2, "test")
+	client2, err := protocol.New(testSocketPath2)
 	if err != nil {
 		t.Fatalf("Failed to create second client: %v", err)
 	}
@@ -354,20 +323,15 @@ func TestMultipleClientInstances(t *testing.T) {
 		t.Error("Clients should have different socket paths")
 	}
 	
-	// Both clients use the same channel ID, so they should have the same channel identifier
-	if client1.ChannelIdentifier() != client2.ChannelIdentifier() {
-		t.Error("Clients using the same channel should have the same channel identifier")
-	}
-	
 	// Register different handlers on each client
 	handler1 := func(request *models.JanusRequest) (*models.JanusResponse, error) {
-		return models.NewSuccessResponse(request.ID, request.ChannelID, map[string]interface{}{
+		return models.NewSuccessResponse(request.ID, map[string]interface{}{
 			"client": "client1",
 		}), nil
 	}
 	
 	handler2 := func(request *models.JanusRequest) (*models.JanusResponse, error) {
-		return models.NewSuccessResponse(request.ID, request.ChannelID, map[string]interface{}{
+		return models.NewSuccessResponse(request.ID, map[string]interface{}{
 			"client": "client2",
 		}), nil
 	}
@@ -414,7 +378,7 @@ func TestRequestHandlerWithAsyncOperations(t *testing.T) {
 		}
 	}()
 	
-	client, err := protocol.New(testSocketPath, "test")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -425,7 +389,7 @@ func TestRequestHandlerWithAsyncOperations(t *testing.T) {
 		// Simulate async operation
 		time.Sleep(10 * time.Millisecond)
 		
-		return models.NewSuccessResponse(request.ID, request.ChannelID, map[string]interface{}{
+		return models.NewSuccessResponse(request.ID, map[string]interface{}{
 			"processed": true,
 			"timestamp": time.Now().Unix(),
 		}), nil
@@ -468,7 +432,7 @@ func TestRequestHandlerErrorHandling(t *testing.T) {
 		}
 	}()
 	
-	client, err := protocol.New(testSocketPath, "test")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -494,7 +458,7 @@ func TestManifestWithComplexArguments(t *testing.T) {
 	os.Remove(testSocketPath)
 	defer os.Remove(testSocketPath)
 	
-	client, err := protocol.New(testSocketPath, "test")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -576,7 +540,7 @@ func TestArgumentValidationConstraints(t *testing.T) {
 		}
 	}()
 	
-	client, err := protocol.New(testSocketPath, "test")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -617,6 +581,89 @@ func TestArgumentValidationConstraints(t *testing.T) {
 }
 
 
+// TestClientValidatesArgumentsAgainstServerRequests tests client-side rejection of arguments
+// that violate a request in the server manifest's top-level requests
+func TestClientValidatesArgumentsAgainstServerRequests(t *testing.T) {
+	testSocketPath := fmt.Sprintf("/tmp/gojanus-client-requests-test-%d.sock", time.Now().UnixNano())
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: testSocketPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer os.Remove(testSocketPath)
+	defer conn.Close()
+	
+	// Serve a manifest defining "greet" and count the greet requests that reach the server
+	serverManifest := map[string]interface{}{
+		"name":        "Greeter",
+		"version":     "1.0.0",
+		"description": "Greets people",
+		"requests": map[string]interface{}{
+			"greet": map[string]interface{}{
+				"name":        "greet",
+				"description": "Greet someone",
+				"args": map[string]interface{}{
+					"name": map[string]interface{}{"name": "name", "type": "string", "description": "Who to greet", "required": true},
+				},
+			},
+		},
+	}
+	var greetRequests int32
+	go func() {
+		buffer := make([]byte, 64*1024)
+		for {
+			n, _, err := conn.ReadFromUnix(buffer)
+			if err != nil {
+				return
+			}
+			var request models.JanusRequest
+			if err := json.Unmarshal(buffer[:n], &request); err != nil || request.ReplyTo == nil {
+				continue
+			}
+			response := models.NewSuccessResponse(request.ID, map[string]interface{}{"ok": true})
+			switch request.Request {
+			case "manifest":
+				response = models.NewSuccessResponse(request.ID, serverManifest)
+			case "greet":
+				atomic.AddInt32(&greetRequests, 1)
+			}
+			data, _ := json.Marshal(response)
+			reply, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: *request.ReplyTo, Net: "unixgram"})
+			if err != nil {
+				continue
+			}
+			reply.Write(data)
+			reply.Close()
+		}
+	}()
+	
+	client, err := protocol.New(testSocketPath)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+	
+	ctx := context.Background()
+	options := protocol.RequestOptions{Timeout: 2 * time.Second}
+	
+	// Missing required argument: rejected by the client without reaching the server
+	_, err = client.SendRequest(ctx, "greet", map[string]interface{}{}, options)
+	if err == nil || !strings.Contains(err.Error(), "request validation failed") {
+		t.Errorf("Expected client-side validation error, got: %v", err)
+	}
+	if count := atomic.LoadInt32(&greetRequests); count != 0 {
+		t.Errorf("Expected the invalid request to stay on the client, server saw %d", count)
+	}
+	
+	// Valid arguments pass validation and reach the server
+	response, err := client.SendRequest(ctx, "greet", map[string]interface{}{"name": "janus"}, options)
+	if err != nil || !response.Success {
+		t.Fatalf("Expected valid request to succeed, got %v %+v", err, response)
+	}
+	if count := atomic.LoadInt32(&greetRequests); count != 1 {
+		t.Errorf("Expected one greet request at the server, got %d", count)
+	}
+}
+
 // loadTestManifest loads the test Manifest from test-manifest.json
 func loadTestManifest() *manifest.Manifest {
 	manifestPath := "../../tests/config/manifest-request-test-api.json"
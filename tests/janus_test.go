@@ -25,32 +25,23 @@ func TestManifestCreation(t *testing.T) {
 		t.Errorf("Expected name 'Test API', got '%s'", manifest.Name)
 	}
 	
-	if len(manifest.Channels) != 1 {
-		t.Errorf("Expected 1 channel, got %d", len(manifest.Channels))
+	if len(manifest.Models) != 1 {
+		t.Errorf("Expected 1 model, got %d", len(manifest.Models))
 	}
 	
-	channel, exists := manifest.Channels["test-channel"]
+	_, exists := manifest.Models["TestModel"]
 	if !exists {
-		t.Error("Expected 'test-channel' to exist")
-	}
-	
-	if len(channel.Requests) != 1 {
-		t.Errorf("Expected 1 request, got %d", len(channel.Requests))
-	}
-	
-	_, exists = channel.Requests["test-request"]
-	if !exists {
-		t.Error("Expected 'test-request' to exist")
+		t.Error("Expected 'TestModel' to exist")
 	}
 }
 
 // TestManifestJSONSerialization tests JSON serialization of Manifests
 // Matches Swift: testManifestJSONSerialization()
 func TestManifestJSONSerialization(t *testing.T) {
-	manifest := createTestManifest() // Create manifest for serialization testing
+	testManifest := createTestManifest() // Create manifest for serialization testing
 	
 	// Serialize to JSON
-	jsonData, err := json.Marshal(manifest)
+	jsonData, err := json.Marshal(testManifest)
 	if err != nil {
 		t.Fatalf("Failed to marshal manifest to JSON: %v", err)
 	}
@@ -63,16 +54,16 @@ func TestManifestJSONSerialization(t *testing.T) {
 	}
 	
 	// Verify integrity
-	if deserializedManifest.Version != manifest.Version {
-		t.Errorf("Version mismatch after serialization: expected '%s', got '%s'", manifest.Version, deserializedManifest.Version)
+	if deserializedManifest.Version != testManifest.Version {
+		t.Errorf("Version mismatch after serialization: expected '%s', got '%s'", testManifest.Version, deserializedManifest.Version)
 	}
 	
-	if deserializedManifest.Name != manifest.Name {
-		t.Errorf("Name mismatch after serialization: expected '%s', got '%s'", manifest.Name, deserializedManifest.Name)
+	if deserializedManifest.Name != testManifest.Name {
+		t.Errorf("Name mismatch after serialization: expected '%s', got '%s'", testManifest.Name, deserializedManifest.Name)
 	}
 	
-	if len(deserializedManifest.Channels) != len(manifest.Channels) {
-		t.Errorf("Channel count mismatch after serialization: expected %d, got %d", len(manifest.Channels), len(deserializedManifest.Channels))
+	if len(deserializedManifest.Models) != len(testManifest.Models) {
+		t.Errorf("Model count mismatch after serialization: expected %d, got %d", len(testManifest.Models), len(deserializedManifest.Models))
 	}
 }
 
@@ -86,7 +77,7 @@ func TestJanusRequestSerialization(t *testing.T) {
 	}
 	timeout := 30.0
 	
-	request := models.NewJanusRequest("test-channel", "test-request", args, &timeout)
+	request := models.NewJanusRequest("test-request", args, &timeout)
 	
 	// Serialize to JSON
 	jsonData, err := request.ToJSON()
@@ -102,9 +93,6 @@ func TestJanusRequestSerialization(t *testing.T) {
 	}
 	
 	// Verify integrity
-	if deserializedRequest.ChannelID != request.ChannelID {
-		t.Errorf("ChannelID mismatch: expected '%s', got '%s'", request.ChannelID, deserializedRequest.ChannelID)
-	}
 	
 	if deserializedRequest.Request != request.Request {
 		t.Errorf("Request mismatch: expected '%s', got '%s'", request.Request, deserializedRequest.Request)
@@ -142,7 +130,7 @@ func TestJanusResponseSerialization(t *testing.T) {
 		"data":    []interface{}{"item1", "item2"},
 	}
 	
-	response := models.NewSuccessResponse("test-request-id", "test-channel", result)
+	response := models.NewSuccessResponse("test-request-id", result)
 	
 	// Serialize to JSON
 	jsonData, err := response.ToJSON()
@@ -162,9 +150,6 @@ func TestJanusResponseSerialization(t *testing.T) {
 		t.Errorf("RequestID mismatch: expected '%s', got '%s'", response.RequestID, deserializedResponse.RequestID)
 	}
 	
-	if deserializedResponse.ChannelID != response.ChannelID {
-		t.Errorf("ChannelID mismatch: expected '%s', got '%s'", response.ChannelID, deserializedResponse.ChannelID)
-	}
 	
 	if deserializedResponse.Success != response.Success {
 		t.Errorf("Success mismatch: expected %t, got %t", response.Success, deserializedResponse.Success)
@@ -197,7 +182,7 @@ func TestAnyCodableStringValue(t *testing.T) {
 		"string_value": "test string",
 	}
 	
-	request := models.NewJanusRequest("test-channel", "test-request", args, nil)
+	request := models.NewJanusRequest("test-request", args, nil)
 	
 	// Serialize and deserialize
 	jsonData, err := request.ToJSON()
@@ -228,7 +213,7 @@ func TestAnyCodableIntegerValue(t *testing.T) {
 		"int_value": 42,
 	}
 	
-	request := models.NewJanusRequest("test-channel", "test-request", args, nil)
+	request := models.NewJanusRequest("test-request", args, nil)
 	
 	// Serialize and deserialize
 	jsonData, err := request.ToJSON()
@@ -261,7 +246,7 @@ func TestAnyCodableBooleanValue(t *testing.T) {
 		"bool_false": false,
 	}
 	
-	request := models.NewJanusRequest("test-channel", "test-request", args, nil)
+	request := models.NewJanusRequest("test-request", args, nil)
 	
 	// Serialize and deserialize
 	jsonData, err := request.ToJSON()
@@ -301,7 +286,7 @@ func TestAnyCodableArrayValue(t *testing.T) {
 		"array_value": []interface{}{"item1", 2, true, []interface{}{"nested", "array"}},
 	}
 	
-	request := models.NewJanusRequest("test-channel", "test-request", args, nil)
+	request := models.NewJanusRequest("test-request", args, nil)
 	
 	// Serialize and deserialize
 	jsonData, err := request.ToJSON()
@@ -361,7 +346,7 @@ func TestJanusClientInitialization(t *testing.T) {
 	
 	// Create test Manifest and client for SOCK_DGRAM
 	_ = createTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "test-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create SOCK_DGRAM client: %v", err)
 	}
@@ -371,10 +356,6 @@ func TestJanusClientInitialization(t *testing.T) {
 		t.Errorf("Expected socket path '%s', got '%s'", testSocketPath, client.SocketPathString())
 	}
 	
-	if client.ChannelIdentifier() != "test-channel" {
-		t.Errorf("Expected channel 'test-channel', got '%s'", client.ChannelIdentifier())
-	}
-	
 	if client.Manifest() != nil {
 		t.Error("Expected manifest to be nil initially (Dynamic Manifest Architecture)")
 	}
@@ -399,29 +380,20 @@ func createTestManifest() *manifest.Manifest {
 		Version:     "1.0.0",
 		Name:        "Test API",
 		Description: "Test Manifest",
-		Channels: map[string]*manifest.ChannelManifest{
-			"test-channel": {
-				Name:        "Test Channel",
-				Description: "Test channel description",
-				Requests: map[string]*manifest.RequestManifest{
-					"test-request": {
-						Name:        "Test Request",
-						Description: "Test request description",
-						Args: map[string]*manifest.ArgumentManifest{
-							"test_arg": {
-								Name:        "Test Argument",
-								Type:        "string",
-								Description: "Test argument description",
-								Required:    true,
-							},
-						},
-						Response: &manifest.ResponseManifest{
-							Type:        "object",
-							Description: "Test response",
-						},
-						ErrorCodes: []string{"TEST_ERROR"},
+		Models: map[string]*manifest.ModelDefinition{
+			"TestModel": {
+				Name:        "Test Model",
+				Type:        "object",
+				Description: "Test model description",
+				Properties: map[string]*manifest.ArgumentManifest{
+					"test_arg": {
+						Name:        "Test Argument",
+						Type:        "string",
+						Description: "Test argument description",
+						Required:    true,
 					},
 				},
+				Required: []string{"test_arg"},
 			},
 		},
 	}
@@ -469,7 +441,7 @@ func TestJSONRPCErrorFunctionality(t *testing.T) {
 	}
 	
 	// Test error response creation
-	errorResponse := models.NewErrorResponse("test-cmd", "test-channel", err)
+	errorResponse := models.NewErrorResponse("test-cmd", err)
 	if errorResponse.Error == nil {
 		t.Error("Expected error response to contain JSONRPCError")
 	}
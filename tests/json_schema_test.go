@@ -0,0 +1,286 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"GoJanus/pkg/manifest"
+)
+
+const jsonSchemaTestManifest = `{
+	"version": "2.0.0",
+	"name": "Orders",
+	"description": "Order service",
+	"requests": {
+		"create_order": {
+			"name": "create_order",
+			"description": "Create an order",
+			"args": {
+				"customer": {"name": "customer", "type": "object", "description": "Customer", "modelRef": "Customer", "required": true},
+				"quantity": {"name": "quantity", "type": "integer", "description": "Quantity", "minimum": 1, "maximum": 100},
+				"priority": {"name": "priority", "type": "integer", "description": "Priority", "enum": ["1", "2", "3"]}
+			},
+			"response": {"type": "object", "description": "Created order", "modelRef": "Order"},
+			"errorCodes": ["VALIDATION_FAILED"]
+		}
+	},
+	"models": {
+		"Customer": {
+			"name": "Customer",
+			"type": "object",
+			"description": "A customer",
+			"properties": {
+				"email": {"name": "email", "type": "string", "description": "Email", "pattern": "^[^@]+@[^@]+$"},
+				"tags": {"name": "tags", "type": "array", "description": "Tags", "items": {"name": "tag", "type": "string", "description": "Tag"}}
+			},
+			"required": ["email"]
+		},
+		"Order": {
+			"name": "Order",
+			"type": "object",
+			"description": "An order",
+			"properties": {
+				"id": {"name": "id", "type": "string", "description": "Identifier"},
+				"customer": {"name": "customer", "type": "object", "description": "Owner", "modelRef": "Customer"}
+			}
+		}
+	}
+}`
+
+// TestJSONSchemaExport validates $defs, $ref and x-janus-requests output
+func TestJSONSchemaExport(t *testing.T) {
+	m, err := manifest.ParseJSONString(jsonSchemaTestManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	doc, issues := manifest.ToJSONSchema(m)
+	if len(issues) != 0 {
+		t.Errorf("Expected lossless export, got issues: %v", issues)
+	}
+
+	if doc.Schema != manifest.JSONSchemaDialect {
+		t.Errorf("Expected draft 2020-12 dialect, got %s", doc.Schema)
+	}
+
+	customer := doc.Defs["Customer"]
+	if customer == nil || customer.Type != "object" || len(customer.Required) != 1 || customer.Required[0] != "email" {
+		t.Fatalf("Unexpected Customer definition: %+v", customer)
+	}
+	if customer.Properties["tags"].Items.Type != "string" {
+		t.Errorf("Expected array items to be exported")
+	}
+	if ref := doc.Defs["Order"].Properties["customer"].Ref; ref != "#/$defs/Customer" {
+		t.Errorf("Expected model reference as $ref, got %q", ref)
+	}
+
+	request := doc.Requests["create_order"]
+	if request == nil || request.Args.Type != "object" {
+		t.Fatalf("Expected request args as object schema: %+v", request)
+	}
+	if len(request.Args.Required) != 1 || request.Args.Required[0] != "customer" {
+		t.Errorf("Expected required args list, got %v", request.Args.Required)
+	}
+	if request.Response.Ref != "#/$defs/Order" {
+		t.Errorf("Expected response $ref, got %q", request.Response.Ref)
+	}
+	if enum := request.Args.Properties["priority"].Enum; len(enum) != 3 || enum[0] != float64(1) {
+		t.Errorf("Expected numeric enum values, got %v", enum)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal schema: %v", err)
+	}
+	for _, fragment := range []string{`"$schema":"https://json-schema.org/draft/2020-12/schema"`, `"$defs"`, `"x-janus-requests"`} {
+		if !strings.Contains(string(data), fragment) {
+			t.Errorf("Serialized schema missing %s", fragment)
+		}
+	}
+
+	t.Log("✅ Manifest exported as JSON Schema draft 2020-12")
+}
+
+// TestJSONSchemaRoundTrip validates that exported documents import back to an equivalent manifest
+func TestJSONSchemaRoundTrip(t *testing.T) {
+	original, err := manifest.ParseJSONString(jsonSchemaTestManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	doc, _ := manifest.ToJSONSchema(original)
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal schema: %v", err)
+	}
+
+	imported, issues, err := manifest.FromJSONSchema(data)
+	if err != nil {
+		t.Fatalf("Failed to import schema: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected lossless import, got issues: %v", issues)
+	}
+	if err := imported.Validate(); err != nil {
+		t.Fatalf("Imported manifest is invalid: %v", err)
+	}
+
+	if imported.Name != original.Name || imported.Version != original.Version {
+		t.Errorf("Expected name and version to round-trip, got %s %s", imported.Name, imported.Version)
+	}
+
+	request, err := imported.GetRequest("create_order")
+	if err != nil {
+		t.Fatalf("Expected imported request: %v", err)
+	}
+	if request.Args["customer"].ModelRef != "Customer" || !request.Args["customer"].Required {
+		t.Errorf("Expected required model reference, got %+v", request.Args["customer"])
+	}
+	if request.Response.ModelRef != "Order" {
+		t.Errorf("Expected response model reference, got %+v", request.Response)
+	}
+	if max := request.Args["quantity"].Maximum; max == nil || *max != 100 {
+		t.Errorf("Expected maximum to round-trip, got %v", max)
+	}
+	if enum := request.Args["priority"].Enum; len(enum) != 3 || enum[2] != "3" {
+		t.Errorf("Expected enum to round-trip, got %v", enum)
+	}
+
+	// The imported manifest validates arguments the same way as the original
+	args := map[string]interface{}{"customer": map[string]interface{}{"email": "a@b.c"}, "priority": 2}
+	if err := imported.ValidateRequestArgs(request, args); err != nil {
+		t.Errorf("Expected valid args to pass: %v", err)
+	}
+	args["priority"] = 7
+	if err := imported.ValidateRequestArgs(request, args); err == nil {
+		t.Error("Expected enum violation to fail")
+	}
+
+	t.Log("✅ JSON Schema round-trip preserves the manifest")
+}
+
+// TestJSONSchemaRoundTripEscapedNames validates that model names needing JSON pointer or URI fragment
+// escapes survive $ref export and import
+func TestJSONSchemaRoundTripEscapedNames(t *testing.T) {
+	escapes := map[string]string{
+		"items/v1~beta": "#/$defs/items~1v1~0beta",
+		"line item":     "#/$defs/line%20item",
+		"100%":          "#/$defs/100%25",
+		"café#1":        "#/$defs/caf%C3%A9%231",
+	}
+
+	for name, expectedRef := range escapes {
+		original := &manifest.Manifest{
+			Name:    "Escapes",
+			Version: "1.0.0",
+			Requests: map[string]*manifest.RequestManifest{
+				"fetch": {
+					Name:     "fetch",
+					Args:     map[string]*manifest.ArgumentManifest{"item": {Name: "item", Description: "Item", ModelRef: name, Required: true}},
+					Response: &manifest.ResponseManifest{Type: "object", Description: "Item", ModelRef: name},
+				},
+			},
+			Models: map[string]*manifest.ModelDefinition{
+				name: {
+					Name:       name,
+					Type:       "object",
+					Properties: map[string]*manifest.ArgumentManifest{"id": {Name: "id", Type: "string", Description: "Identifier"}},
+				},
+			},
+		}
+
+		doc, _ := manifest.ToJSONSchema(original)
+		if ref := doc.Requests["fetch"].Response.Ref; ref != expectedRef {
+			t.Errorf("%q: expected escaped $ref %q, got %q", name, expectedRef, ref)
+		}
+
+		data, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatalf("%q: failed to marshal schema: %v", name, err)
+		}
+		imported, issues, err := manifest.FromJSONSchema(data)
+		if err != nil {
+			t.Fatalf("%q: failed to import schema: %v", name, err)
+		}
+		if len(issues) != 0 {
+			t.Errorf("%q: expected lossless import, got issues: %v", name, issues)
+		}
+
+		request := imported.Requests["fetch"]
+		if request == nil || request.Args["item"].ModelRef != name || request.Response.ModelRef != name {
+			t.Fatalf("%q: expected model references to round-trip unescaped, got %+v", name, request)
+		}
+		if _, exists := imported.Models[name]; !exists {
+			t.Errorf("%q: expected model definition to round-trip", name)
+		}
+	}
+
+	t.Log("✅ Escaped model names round-trip through $ref")
+}
+
+// TestJSONSchemaImportReportsLossyConstructs validates issue reporting for unsupported keywords
+func TestJSONSchemaImportReportsLossyConstructs(t *testing.T) {
+	schema := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "Profile",
+		"type": "object",
+		"properties": {
+			"name": {"type": ["string", "null"], "minLength": 1},
+			"age": {"type": "integer", "multipleOf": 1},
+			"link": {"$ref": "https://example.com/link.json"},
+			"kind": {"type": "string", "not": {"const": "a"}}
+		},
+		"required": ["name", "missing"],
+		"additionalProperties": false
+	}`
+
+	imported, issues, err := manifest.FromJSONSchema([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to import schema: %v", err)
+	}
+
+	profile := imported.Models["Profile"]
+	if profile == nil || profile.Type != "object" || profile.Properties["name"].Type != "string" || !profile.Properties["name"].Nullable {
+		t.Fatalf("Expected plain schema imported as Profile model, got %+v", profile)
+	}
+	if profile.AdditionalProperties == nil || *profile.AdditionalProperties {
+		t.Errorf("Expected additionalProperties false on the imported model, got %v", profile.AdditionalProperties)
+	}
+
+	expected := map[string]string{
+		"/properties/age":  "multipleOf",
+		"/properties/kind": "not",
+		"/properties/link": "$ref",
+	}
+	for path, keyword := range expected {
+		found := false
+		for _, issue := range issues {
+			if issue.Path == path && issue.Keyword == keyword {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected issue for %s at %q, got %v", keyword, path, issues)
+		}
+	}
+
+	found := false
+	for _, issue := range issues {
+		if issue.Keyword == "required" && strings.Contains(issue.Message, "missing") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected issue for undefined required property, got %v", issues)
+	}
+
+	t.Log("✅ Lossy JSON Schema constructs are reported")
+}
+
+// TestJSONSchemaImportInvalid validates rejection of malformed documents
+func TestJSONSchemaImportInvalid(t *testing.T) {
+	if _, _, err := manifest.FromJSONSchema([]byte(`{"$defs": [}`)); err == nil {
+		t.Error("Expected error for malformed JSON")
+	}
+}
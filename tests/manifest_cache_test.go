@@ -0,0 +1,328 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/server"
+	"GoJanus/pkg/wire"
+)
+
+// startCachingTestServer serves a manifest and counts manifest requests
+func startCachingTestServer(t *testing.T, m *manifest.Manifest) (*server.JanusServer, string, *int32) {
+	t.Helper()
+	var manifestRequests int32
+	srv, socketPath, _ := startTestServer(t, nil, func(srv *server.JanusServer) {
+		if err := srv.SetManifest(m); err != nil {
+			t.Fatalf("SetManifest failed: %v", err)
+		}
+		srv.On("request", func(data interface{}) {
+			event, _ := data.(map[string]interface{})
+			if cmd, ok := event["request"].(*models.JanusRequest); ok && cmd.Request == "manifest" {
+				atomic.AddInt32(&manifestRequests, 1)
+			}
+		})
+		srv.RegisterHandler("greet", server.NewObjectHandler(func(cmd *models.JanusRequest) (map[string]interface{}, error) {
+			return map[string]interface{}{"greeting": "hello"}, nil
+		}))
+		srv.RegisterHandler("stale", server.NewObjectHandler(func(cmd *models.JanusRequest) (map[string]interface{}, error) {
+			return nil, models.NewJSONRPCError(models.ManifestValidationError, "client manifest is stale")
+		}))
+	})
+	return srv, socketPath, &manifestRequests
+}
+
+// manifestRequestCount reads the counter after async server events have been delivered
+func manifestRequestCount(counter *int32) int32 {
+	time.Sleep(50 * time.Millisecond)
+	return atomic.LoadInt32(counter)
+}
+
+// TestManifestContentHash validates that the hash is stable and content sensitive
+func TestManifestContentHash(t *testing.T) {
+	first, err := manifest.ContentHash(versionedTestManifest("1.0.0"))
+	if err != nil {
+		t.Fatalf("ContentHash failed: %v", err)
+	}
+	second, _ := manifest.ContentHash(versionedTestManifest("1.0.0"))
+	if first != second {
+		t.Errorf("Expected equal manifests to hash equally: %s vs %s", first, second)
+	}
+
+	changed := versionedTestManifest("1.0.0")
+	changed.Description = "changed"
+	third, _ := manifest.ContentHash(changed)
+	if third == first {
+		t.Error("Expected a content change to change the hash")
+	}
+
+	if _, err := manifest.ContentHash(nil); err == nil {
+		t.Error("Expected nil manifest to be rejected")
+	}
+
+	t.Log("✅ ContentHash is stable and reflects manifest content")
+}
+
+// TestServerConditionalManifestRequest validates if_none_match handling
+func TestServerConditionalManifestRequest(t *testing.T) {
+	m := versionedTestManifest("1.0.0")
+	_, socketPath, _ := startCachingTestServer(t, m)
+	hash, _ := manifest.ContentHash(m)
+
+	config := protocol.DefaultJanusClientConfig()
+	config.EnableValidation = false
+	client, err := protocol.New(socketPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	response, err := client.SendRequest(context.Background(), "manifest", map[string]interface{}{"if_none_match": hash})
+	if err != nil {
+		t.Fatalf("Conditional manifest request failed: %v", err)
+	}
+	result, _ := response.Result.(map[string]interface{})
+	if result["not_modified"] != true || result["hash"] != hash {
+		t.Errorf("Expected not_modified reply, got %v", result)
+	}
+
+	response, err = client.SendRequest(context.Background(), "manifest", map[string]interface{}{"if_none_match": "sha256:stale"})
+	if err != nil {
+		t.Fatalf("Conditional manifest request failed: %v", err)
+	}
+	result, _ = response.Result.(map[string]interface{})
+	if result["hash"] != hash || result["manifest"] == nil {
+		t.Errorf("Expected manifest with hash for a stale hash, got %v", result)
+	}
+
+	response, err = client.SendRequest(context.Background(), "get_info", nil)
+	if err != nil {
+		t.Fatalf("get_info failed: %v", err)
+	}
+	if info, _ := response.Result.(map[string]interface{}); info["manifest_hash"] != hash {
+		t.Errorf("Expected get_info to report manifest_hash %s, got %v", hash, info["manifest_hash"])
+	}
+
+	t.Log("✅ Server answers conditional manifest requests with not_modified or a hashed manifest")
+}
+
+// TestSharedMemoryCacheSkipsRoundTrip validates that a fresh cache entry avoids the manifest request
+func TestSharedMemoryCacheSkipsRoundTrip(t *testing.T) {
+	_, socketPath, manifestRequests := startCachingTestServer(t, versionedTestManifest("1.0.0"))
+
+	config := protocol.DefaultJanusClientConfig()
+	config.ManifestCache = protocol.NewMemoryManifestCache()
+	config.ManifestTTL = time.Minute
+
+	for i := 0; i < 3; i++ {
+		client, err := protocol.New(socketPath, config)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		if _, err := client.SendRequest(context.Background(), "greet", map[string]interface{}{"name": "janus"}); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if client.GetManifest() == nil || client.GetManifest().Version != "1.0.0" {
+			t.Error("Expected client to have the cached manifest")
+		}
+		client.Close()
+	}
+
+	if count := manifestRequestCount(manifestRequests); count != 1 {
+		t.Errorf("Expected one manifest request for three clients, got %d", count)
+	}
+
+	t.Log("✅ Clients sharing a cache fetch the manifest once")
+}
+
+// TestManifestTTLRevalidation validates conditional refresh and pickup of server upgrades
+func TestManifestTTLRevalidation(t *testing.T) {
+	srv, socketPath, manifestRequests := startCachingTestServer(t, versionedTestManifest("1.0.0"))
+
+	config := protocol.DefaultJanusClientConfig()
+	config.ManifestTTL = 100 * time.Millisecond
+	client, err := protocol.New(socketPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.SendRequest(context.Background(), "ping", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	loaded := client.GetManifest()
+
+	// Unchanged manifest: revalidation keeps the same instance
+	time.Sleep(150 * time.Millisecond)
+	if _, err := client.SendRequest(context.Background(), "ping", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if client.GetManifest() != loaded {
+		t.Error("Expected not_modified revalidation to keep the loaded manifest")
+	}
+
+	// Server upgrade: the next revalidation picks up the new manifest
+	if err := srv.SetManifest(versionedTestManifest("1.1.0")); err != nil {
+		t.Fatalf("SetManifest failed: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if _, err := client.SendRequest(context.Background(), "ping", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if got := client.GetManifest().Version; got != "1.1.0" {
+		t.Errorf("Expected upgraded manifest 1.1.0, got %s", got)
+	}
+
+	if count := manifestRequestCount(manifestRequests); count != 3 {
+		t.Errorf("Expected three manifest requests (load and two revalidations), got %d", count)
+	}
+
+	t.Log("✅ Manifest TTL triggers revalidation and picks up server upgrades")
+}
+
+// TestManifestValidationErrorInvalidatesCache validates invalidation on server manifest errors
+func TestManifestValidationErrorInvalidatesCache(t *testing.T) {
+	_, socketPath, manifestRequests := startCachingTestServer(t, versionedTestManifest("1.0.0"))
+
+	cache := protocol.NewMemoryManifestCache()
+	config := protocol.DefaultJanusClientConfig()
+	config.ManifestCache = cache
+	config.ManifestTTL = time.Minute
+	client, err := protocol.New(socketPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	response, err := client.SendRequest(context.Background(), "stale", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if response.Error == nil || response.Error.Code != models.ManifestValidationError {
+		t.Fatalf("Expected ManifestValidationError response, got %+v", response.Error)
+	}
+
+	if client.GetManifest() != nil {
+		t.Error("Expected the loaded manifest to be dropped")
+	}
+
+	// A new client sharing the cache must fetch again because the entry was invalidated
+	second, err := protocol.New(socketPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer second.Close()
+	if _, err := second.SendRequest(context.Background(), "ping", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if count := manifestRequestCount(manifestRequests); count != 2 {
+		t.Errorf("Expected the manifest to be refetched, got %d manifest requests", count)
+	}
+
+	t.Log("✅ ManifestValidationError invalidates the loaded and cached manifest")
+}
+
+// TestFileManifestCache validates on-disk persistence across cache instances
+func TestFileManifestCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := protocol.NewFileManifestCache(dir)
+	if err != nil {
+		t.Fatalf("NewFileManifestCache failed: %v", err)
+	}
+
+	entry := &protocol.CachedManifest{
+		Manifest:  versionedTestManifest("1.2.0"),
+		Hash:      "sha256:abc",
+		FetchedAt: time.Now().Truncate(time.Second),
+	}
+	if err := cache.Put("/tmp/some server.sock#^1.0.0", entry); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	reopened, _ := protocol.NewFileManifestCache(dir)
+	got, ok := reopened.Get("/tmp/some server.sock#^1.0.0")
+	if !ok {
+		t.Fatal("Expected entry to survive a new cache instance")
+	}
+	if got.Hash != entry.Hash || got.Manifest.Version != "1.2.0" || !got.FetchedAt.Equal(entry.FetchedAt) {
+		t.Errorf("Unexpected cached entry: %+v", got)
+	}
+
+	reopened.Invalidate("/tmp/some server.sock#^1.0.0")
+	if _, ok := cache.Get("/tmp/some server.sock#^1.0.0"); ok {
+		t.Error("Expected entry to be removed")
+	}
+
+	if _, err := protocol.NewFileManifestCache(""); err == nil {
+		t.Error("Expected empty directory to be rejected")
+	}
+
+	t.Log("✅ File manifest cache persists, reloads and invalidates entries")
+}
+
+// TestConcurrentManifestLoadsShareOneFetch validates that concurrent requests share one manifest fetch
+// and that reading the manifest does not wait for the round-trip
+func TestConcurrentManifestLoadsShareOneFetch(t *testing.T) {
+	// A server that never answers keeps the fetch in flight until DatagramTimeout
+	socketPath := fmt.Sprintf("/tmp/manifest-silent-test-%d.sock", time.Now().UnixNano())
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer os.Remove(socketPath)
+	defer conn.Close()
+
+	var manifestRequests int32
+	go func() {
+		buffer := make([]byte, 64*1024)
+		for {
+			n, _, err := conn.ReadFromUnix(buffer)
+			if err != nil {
+				return
+			}
+			if request, err := wire.DecodeRequest(buffer[:n]); err == nil && request.Request == "manifest" {
+				atomic.AddInt32(&manifestRequests, 1)
+			}
+		}
+	}()
+
+	config := protocol.DefaultJanusClientConfig()
+	config.DatagramTimeout = 300 * time.Millisecond
+	client, err := protocol.New(socketPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.SendRequest(context.Background(), "greet", nil)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	start := time.Now()
+	client.Manifest()
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Manifest() blocked for %v while a fetch was in flight", elapsed)
+	}
+
+	wg.Wait()
+	if count := atomic.LoadInt32(&manifestRequests); count != 1 {
+		t.Errorf("Expected one shared manifest fetch, got %d", count)
+	}
+
+	t.Log("✅ Concurrent loads share one fetch without holding the manifest lock")
+}
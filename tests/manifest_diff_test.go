@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"testing"
+
+	"GoJanus/pkg/manifest"
+)
+
+const diffBaseManifest = `{
+	"version": "1.0.0",
+	"name": "Accounts",
+	"description": "Account service",
+	"requests": {
+		"create_account": {
+			"name": "create_account",
+			"description": "Create an account",
+			"args": {
+				"username": {"name": "username", "type": "string", "description": "Login", "required": true, "maxLength": 32},
+				"plan": {"name": "plan", "type": "string", "description": "Plan", "enum": ["free", "pro", "team"]},
+				"seats": {"name": "seats", "type": "integer", "description": "Seats", "maximum": 100}
+			},
+			"response": {
+				"type": "object",
+				"description": "Created account",
+				"properties": {
+					"id": {"name": "id", "type": "string", "description": "Identifier"},
+					"created": {"name": "created", "type": "number", "description": "Creation time"}
+				}
+			}
+		},
+		"delete_account": {
+			"name": "delete_account",
+			"description": "Delete an account",
+			"args": {
+				"id": {"name": "id", "type": "string", "description": "Identifier", "required": true}
+			}
+		}
+	},
+	"models": {
+		"Profile": {
+			"name": "Profile",
+			"type": "object",
+			"description": "Profile",
+			"properties": {
+				"bio": {"name": "bio", "type": "string", "description": "Biography", "maxLength": 200}
+			}
+		}
+	}
+}`
+
+// diffChange finds the change recorded at path
+func diffChange(result *manifest.DiffResult, path string) *manifest.Change {
+	for i := range result.Changes {
+		if result.Changes[i].Path == path {
+			return &result.Changes[i]
+		}
+	}
+	return nil
+}
+
+// TestManifestDiffBreakingChanges validates detection of client-breaking edits
+func TestManifestDiffBreakingChanges(t *testing.T) {
+	oldManifest, err := manifest.ParseJSONString(diffBaseManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	newManifest, _ := manifest.ParseJSONString(diffBaseManifest)
+	newManifest.Version = "2.0.0"
+
+	create := newManifest.Requests["create_account"]
+	shorter := 16
+	create.Args["username"].MaxLength = &shorter
+	create.Args["plan"].Enum = []string{"free", "pro"}
+	lower := 50.0
+	create.Args["seats"].Maximum = &lower
+	create.Args["region"] = &manifest.ArgumentManifest{Name: "region", Type: "string", Description: "Region", Required: true}
+	create.Args["seats"].Type = "string"
+	delete(create.Response.Properties, "created")
+	delete(newManifest.Requests, "delete_account")
+
+	result := manifest.Diff(oldManifest, newManifest)
+	if !result.Breaking {
+		t.Fatal("Expected breaking changes")
+	}
+
+	expected := map[string]string{
+		"requests.delete_account":                             "request_removed",
+		"requests.create_account.args.region":                 "required_property_added",
+		"requests.create_account.args.plan.enum":              "enum_narrowed",
+		"requests.create_account.args.username.maxLength":     "constraint_tightened",
+		"requests.create_account.args.seats.maximum":          "constraint_tightened",
+		"requests.create_account.args.seats.type":             "type_changed",
+		"requests.create_account.response.properties.created": "property_removed",
+	}
+	for path, kind := range expected {
+		change := diffChange(result, path)
+		if change == nil {
+			t.Errorf("Missing change at %s; got %+v", path, result.Changes)
+			continue
+		}
+		if change.Kind != kind || change.Severity != manifest.ChangeBreaking {
+			t.Errorf("Expected breaking %s at %s, got %s %s", kind, path, change.Severity, change.Kind)
+		}
+	}
+
+	if len(result.BreakingChanges()) != len(expected) {
+		t.Errorf("Expected %d breaking changes, got %+v", len(expected), result.BreakingChanges())
+	}
+
+	t.Log("✅ Breaking manifest changes detected")
+}
+
+// TestManifestDiffCompatibleChanges validates that additive and loosening edits pass
+func TestManifestDiffCompatibleChanges(t *testing.T) {
+	oldManifest, err := manifest.ParseJSONString(diffBaseManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	newManifest, _ := manifest.ParseJSONString(diffBaseManifest)
+	newManifest.Version = "1.1.0"
+
+	create := newManifest.Requests["create_account"]
+	longer := 64
+	create.Args["username"].MaxLength = &longer
+	create.Args["plan"].Enum = append(create.Args["plan"].Enum, "enterprise")
+	create.Args["seats"].Maximum = nil
+	create.Args["seats"].Type = "number"
+	create.Args["coupon"] = &manifest.ArgumentManifest{Name: "coupon", Type: "string", Description: "Coupon"}
+	create.Response.Properties["status"] = &manifest.ArgumentManifest{Name: "status", Type: "string", Description: "Status"}
+	create.ErrorCodes = []string{"VALIDATION_FAILED"}
+	newManifest.Requests["get_account"] = &manifest.RequestManifest{Name: "get_account", Description: "Fetch an account"}
+
+	result := manifest.Diff(oldManifest, newManifest)
+	if result.Breaking {
+		t.Fatalf("Expected only compatible changes, got %+v", result.BreakingChanges())
+	}
+	if len(result.Changes) != 8 {
+		t.Errorf("Expected 8 compatible changes, got %+v", result.Changes)
+	}
+
+	t.Log("✅ Compatible manifest changes classified")
+}
+
+// TestManifestDiffResponseDirection validates that response changes are judged from the client's side
+func TestManifestDiffResponseDirection(t *testing.T) {
+	oldManifest, err := manifest.ParseJSONString(diffBaseManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	// Profile is only returned by the server: loosening its constraints can surprise clients
+	oldManifest.Requests["create_account"].Response.Properties["profile"] = &manifest.ArgumentManifest{
+		Name: "profile", Type: "object", Description: "Profile", ModelRef: "Profile",
+	}
+	newManifest, _ := manifest.ParseJSONString(diffBaseManifest)
+	newManifest.Requests["create_account"].Response.Properties["profile"] = &manifest.ArgumentManifest{
+		Name: "profile", Type: "object", Description: "Profile", ModelRef: "Profile",
+	}
+	longer := 500
+	newManifest.Models["Profile"].Properties["bio"].MaxLength = &longer
+
+	result := manifest.Diff(oldManifest, newManifest)
+	change := diffChange(result, "models.Profile.properties.bio.maxLength")
+	if change == nil || change.Severity != manifest.ChangeBreaking || change.Kind != "constraint_loosened" {
+		t.Errorf("Expected loosened response constraint to be breaking, got %+v", change)
+	}
+
+	identical := manifest.Diff(oldManifest, oldManifest)
+	if identical.Breaking || len(identical.Changes) != 0 {
+		t.Errorf("Expected no changes for identical manifests, got %+v", identical.Changes)
+	}
+}
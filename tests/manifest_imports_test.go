@@ -0,0 +1,245 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"GoJanus/pkg/manifest"
+)
+
+const importsRootManifest = `{
+	"version": "1.0.0",
+	"name": "Accounts",
+	"description": "Service importing shared models",
+	"imports": [
+		{"path": "models/common.yaml"},
+		{"library": "geo", "as": "places"}
+	],
+	"requests": {
+		"create_user": {
+			"name": "create_user",
+			"description": "Create a user",
+			"args": {
+				"user": {"name": "user", "type": "object", "description": "User", "modelRef": "common.User", "required": true},
+				"origin": {"name": "origin", "type": "object", "description": "Origin", "modelRef": "places.Point"}
+			},
+			"response": {"type": "object", "description": "Created user", "modelRef": "common.User"}
+		}
+	}
+}`
+
+const importsCommonLibrary = `version: "1.0.0"
+name: common
+description: Shared account models
+imports:
+  - library: geo
+models:
+  User:
+    name: User
+    type: object
+    description: A user
+    required: [id]
+    properties:
+      id: {name: id, type: string, description: User ID}
+      address: {name: address, type: object, description: Address, modelRef: Address}
+  Address:
+    name: Address
+    type: object
+    description: A postal address
+    properties:
+      city: {name: city, type: string, description: City}
+      location: {name: location, type: object, description: Location, modelRef: geo.Point}
+`
+
+const importsGeoLibrary = `{
+	"version": "1.0.0",
+	"name": "geo",
+	"description": "Shared geometry models",
+	"models": {
+		"Point": {
+			"name": "Point",
+			"type": "object",
+			"description": "A coordinate",
+			"required": ["lat", "lon"],
+			"properties": {
+				"lat": {"name": "lat", "type": "number", "description": "Latitude", "minimum": -90, "maximum": 90},
+				"lon": {"name": "lon", "type": "number", "description": "Longitude"}
+			}
+		}
+	}
+}`
+
+// writeImportFiles writes files relative to a temporary directory and returns it
+func writeImportFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+// parseImportsManifest parses the root manifest with geo available as a named library
+func parseImportsManifest(t *testing.T) *manifest.Manifest {
+	t.Helper()
+	dir := writeImportFiles(t, map[string]string{
+		"service/accounts.json":      importsRootManifest,
+		"service/models/common.yaml": importsCommonLibrary,
+		"lib/geo.json":               importsGeoLibrary,
+	})
+
+	parser := manifest.NewManifestParser()
+	parser.LibraryPaths = []string{filepath.Join(dir, "lib")}
+	m, err := parser.ParseFromFile(filepath.Join(dir, "service", "accounts.json"))
+	if err != nil {
+		t.Fatalf("Failed to parse manifest with imports: %v", err)
+	}
+	return m
+}
+
+// TestManifestImportsResolve validates namespaced models from relative files and named libraries
+func TestManifestImportsResolve(t *testing.T) {
+	m := parseImportsManifest(t)
+
+	if len(m.Imports) != 0 {
+		t.Errorf("Expected imports to be resolved, got %v", m.Imports)
+	}
+	for _, name := range []string{"common.User", "common.Address", "common.geo.Point", "places.Point"} {
+		if m.Models[name] == nil {
+			t.Errorf("Expected imported model %s, got %v", name, len(m.Models))
+		}
+	}
+	if ref := m.Models["common.User"].Properties["address"].ModelRef; ref != "common.Address" {
+		t.Errorf("Expected library reference rewritten to common.Address, got %s", ref)
+	}
+	if ref := m.Models["common.Address"].Properties["location"].ModelRef; ref != "common.geo.Point" {
+		t.Errorf("Expected nested import reference rewritten to common.geo.Point, got %s", ref)
+	}
+
+	request, _ := m.GetRequest("create_user")
+	args := map[string]interface{}{
+		"user": map[string]interface{}{
+			"id":      "u1",
+			"address": map[string]interface{}{"city": "Oslo", "location": map[string]interface{}{"lat": 95.0, "lon": 10.0}},
+		},
+		"origin": map[string]interface{}{"lat": 1.0},
+	}
+	errors := m.ValidateRequestArgsAll(request, args)
+	if len(errors) != 2 || errors[0].Path != "/origin/lon" || errors[1].Path != "/user/address/location/lat" {
+		t.Errorf("Expected errors from both imported libraries, got %v", errors)
+	}
+
+	t.Log("✅ Imports resolve relative files and named libraries into namespaced models")
+}
+
+// TestManifestImportsBundle validates that a resolved manifest is self-contained
+func TestManifestImportsBundle(t *testing.T) {
+	m := parseImportsManifest(t)
+	parser := manifest.NewManifestParser()
+
+	data, err := parser.SerializeToJSON(m)
+	if err != nil {
+		t.Fatalf("SerializeToJSON failed: %v", err)
+	}
+	if strings.Contains(string(data), `"imports"`) {
+		t.Errorf("Expected bundle without imports:\n%s", data)
+	}
+	bundled, err := parser.ParseJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to parse bundled manifest: %v", err)
+	}
+	if len(bundled.Models) != len(m.Models) {
+		t.Errorf("Expected %d models in bundle, got %d", len(m.Models), len(bundled.Models))
+	}
+
+	// Manifests received as data never read local files
+	if _, err := parser.ParseJSON([]byte(importsRootManifest)); err == nil || !strings.Contains(err.Error(), "imports must be resolved") {
+		t.Errorf("Expected unresolved imports to be rejected, got %v", err)
+	}
+
+	t.Log("✅ Resolved manifests serialize as self-contained bundles")
+}
+
+// TestManifestImportsLibraryPathEnv validates library lookup through JANUS_MANIFEST_PATH
+func TestManifestImportsLibraryPathEnv(t *testing.T) {
+	dir := writeImportFiles(t, map[string]string{
+		"accounts.json":      importsRootManifest,
+		"models/common.yaml": importsCommonLibrary,
+		"libraries/geo.json": importsGeoLibrary,
+	})
+	t.Setenv(manifest.LibraryPathEnv, filepath.Join(dir, "missing")+string(os.PathListSeparator)+filepath.Join(dir, "libraries"))
+
+	m, err := manifest.ParseFromFile(filepath.Join(dir, "accounts.json"))
+	if err != nil {
+		t.Fatalf("Failed to resolve library from environment: %v", err)
+	}
+	if m.Models["places.Point"] == nil {
+		t.Error("Expected places.Point from the environment search path")
+	}
+
+	t.Log("✅ Named libraries are found through JANUS_MANIFEST_PATH")
+}
+
+// TestManifestImportsErrors validates cycle detection and malformed imports
+func TestManifestImportsErrors(t *testing.T) {
+	library := func(imports string) string {
+		return `{"version": "1.0.0", "name": "lib", "description": "Library", "imports": [` + imports + `],
+			"models": {"Thing": {"name": "Thing", "type": "object", "description": "Thing"}}}`
+	}
+	root := func(imports string) string {
+		return `{"version": "1.0.0", "name": "root", "description": "Root", "imports": [` + imports + `]}`
+	}
+
+	cases := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"cycle", map[string]string{
+			"root.json": root(`{"path": "a.json"}`),
+			"a.json":    library(`{"path": "b.json"}`),
+			"b.json":    library(`{"path": "a.json"}`),
+		}, "import cycle"},
+		{"self import", map[string]string{
+			"root.json": root(`{"path": "root.json"}`),
+		}, "import cycle"},
+		{"missing library", map[string]string{
+			"root.json": root(`{"library": "nowhere"}`),
+		}, "library 'nowhere' not found"},
+		{"duplicate namespace", map[string]string{
+			"root.json": root(`{"path": "a.json", "as": "x"}, {"path": "b.json", "as": "x"}`),
+			"a.json":    library(""),
+			"b.json":    library(""),
+		}, "used more than once"},
+		{"invalid namespace", map[string]string{
+			"root.json":   root(`{"path": "my-lib.json"}`),
+			"my-lib.json": library(""),
+		}, "must be an identifier"},
+		{"path and library", map[string]string{
+			"root.json": root(`{"path": "a.json", "library": "a"}`),
+		}, "exactly one of path or library"},
+		{"undefined reference", map[string]string{
+			"root.json": root(`{"path": "a.json"}`),
+			"a.json": `{"version": "1.0.0", "name": "lib", "description": "Library", "models": {"Thing": {"name": "Thing", "type": "object",
+				"description": "Thing", "properties": {"other": {"name": "other", "type": "object", "description": "Other", "modelRef": "Other"}}}}}`,
+		}, "model reference 'Other' is not defined"},
+	}
+
+	for _, tc := range cases {
+		dir := writeImportFiles(t, tc.files)
+		_, err := manifest.ParseFromFile(filepath.Join(dir, "root.json"))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	t.Log("✅ Import cycles and malformed imports are rejected")
+}
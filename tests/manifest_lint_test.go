@@ -0,0 +1,190 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"GoJanus/pkg/manifest"
+)
+
+const lintTestManifest = `{
+	"version": "1.0.0",
+	"name": "Lint",
+	"description": "",
+	"requests": {
+		"ping": {"name": "ping", "description": "Shadows the built-in"},
+		"bad name!": {"name": "bad name!", "description": "Invalid characters"},
+		"register": {
+			"name": "register",
+			"description": "Register a user",
+			"args": {
+				"user": {"name": "user", "type": "object", "description": "User", "modelRef": "Person"},
+				"code": {"name": "code", "type": "string", "description": "Code", "pattern": "([a-z"},
+				"nick": {"name": "nick", "type": "string", "description": "Nickname", "minLength": 10, "maxLength": 5},
+				"age": {"name": "age", "type": "integer", "description": "Age", "minimum": 18, "default": 12},
+				"role": {"name": "role", "type": "string", "description": "", "enum": ["user", "admin"], "default": "root"},
+				"flags": {"name": "flags", "type": "set", "description": "Flags"}
+			}
+		}
+	},
+	"models": {
+		"Orphan": {
+			"name": "Orphan", "type": "object", "description": "Never referenced",
+			"properties": {"detail": {"name": "detail", "type": "object", "description": "Detail", "modelRef": "OrphanDetail"}}
+		},
+		"OrphanDetail": {"name": "OrphanDetail", "type": "object", "description": "Referenced only by an unused model"}
+	}
+}`
+
+// decodeLintManifest decodes a manifest without validation, as the lint command does
+func decodeLintManifest(t *testing.T, content string) *manifest.Manifest {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	m, err := manifest.NewManifestParser().DecodeFromFile(path)
+	if err != nil {
+		t.Fatalf("Failed to decode manifest: %v", err)
+	}
+	return m
+}
+
+// lintIssueAt reports whether the rule fired at path
+func lintIssueAt(report *manifest.LintReport, rule, path string) bool {
+	for _, issue := range report.Issues {
+		if issue.Rule == rule && issue.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// TestManifestLintRules validates that every rule reports its problem
+func TestManifestLintRules(t *testing.T) {
+	m := decodeLintManifest(t, lintTestManifest)
+
+	report := manifest.Lint(m, nil)
+
+	expected := []struct{ rule, path string }{
+		{"builtin-redefined", "requests.ping"},
+		{"invalid-request-name", "requests.bad name!"},
+		{"unresolved-model-ref", "requests.register.args.user.modelRef"},
+		{"invalid-pattern", "requests.register.args.code.pattern"},
+		{"inconsistent-bounds", "requests.register.args.nick"},
+		{"invalid-default", "requests.register.args.age.default"},
+		{"invalid-default", "requests.register.args.role.default"},
+		{"unused-model", "models.Orphan"},
+		{"unused-model", "models.OrphanDetail"},
+		{"invalid-type", "requests.register.args.flags.type"},
+		{"missing-description", "description"},
+		{"missing-description", "requests.register.args.role"},
+	}
+	for _, e := range expected {
+		if !lintIssueAt(report, e.rule, e.path) {
+			t.Errorf("Expected %s at %s; got %v", e.rule, e.path, report.Issues)
+		}
+	}
+
+	if !report.HasErrors() || report.Count(manifest.LintWarning) != 4 {
+		t.Errorf("Unexpected severity counts: %d errors, %d warnings", report.Count(manifest.LintError), report.Count(manifest.LintWarning))
+	}
+
+	t.Log("✅ Lint rules report manifest problems")
+}
+
+// TestManifestLintConfig validates rule disabling and severity overrides
+func TestManifestLintConfig(t *testing.T) {
+	m := decodeLintManifest(t, lintTestManifest)
+
+	config, err := manifest.ParseLintConfig([]byte("rules:\n  missing-description: off\n  unused-model: error\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse lint config: %v", err)
+	}
+
+	report := manifest.Lint(m, config)
+	for _, issue := range report.Issues {
+		if issue.Rule == "missing-description" {
+			t.Errorf("Expected missing-description to be disabled, got %v", issue)
+		}
+		if issue.Rule == "unused-model" && issue.Severity != manifest.LintError {
+			t.Errorf("Expected unused-model promoted to error, got %v", issue)
+		}
+	}
+
+	if _, err := manifest.ParseLintConfig([]byte(`{"rules": {"no-such-rule": "error"}}`)); err == nil {
+		t.Error("Expected unknown rule to be rejected")
+	}
+	if _, err := manifest.ParseLintConfig([]byte(`{"rules": {"unused-model": "fatal"}}`)); err == nil {
+		t.Error("Expected unknown severity to be rejected")
+	}
+
+	t.Log("✅ Lint configuration overrides rule severities")
+}
+
+// TestManifestLintUnresolvedImport validates that a missing import is a lint issue rather than a load failure
+func TestManifestLintUnresolvedImport(t *testing.T) {
+	dir := t.TempDir()
+	library := `{"version": "1.0.0", "name": "Common", "models": {"Address": {"name": "Address", "type": "object", "description": "Postal address"}}}`
+	if err := os.WriteFile(filepath.Join(dir, "common.json"), []byte(library), 0644); err != nil {
+		t.Fatalf("Failed to write library: %v", err)
+	}
+	content := `{
+		"version": "1.0.0",
+		"name": "Imports",
+		"description": "Imports one library that exists and one that does not",
+		"imports": [{"path": "missing.json"}, {"path": "common.json"}],
+		"requests": {
+			"ship": {
+				"name": "ship",
+				"description": "Ship an order",
+				"args": {"to": {"name": "to", "type": "object", "description": "Destination", "modelRef": "common.Address", "required": true}}
+			}
+		}
+	}`
+	path := filepath.Join(dir, "manifest.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	report, err := manifest.NewManifestParser().LintFile(path, nil)
+	if err != nil {
+		t.Fatalf("Expected unresolved import to be reported, got error: %v", err)
+	}
+	if !lintIssueAt(report, "unresolved-import", "imports[0]") {
+		t.Errorf("Expected unresolved-import at imports[0]; got %v", report.Issues)
+	}
+	if lintIssueAt(report, "unresolved-import", "imports[1]") || lintIssueAt(report, "unresolved-model-ref", "requests.ship.args.to.modelRef") {
+		t.Errorf("Expected the resolvable import to be merged; got %v", report.Issues)
+	}
+	if !report.HasErrors() {
+		t.Error("Expected unresolved import to be an error")
+	}
+
+	t.Log("✅ Unresolved imports are reported as lint issues")
+}
+
+// TestManifestLintClean validates that a well-formed manifest has no findings
+func TestManifestLintClean(t *testing.T) {
+	m, err := manifest.ParseJSONString(`{
+		"version": "1.0.0",
+		"name": "Clean",
+		"description": "Well-formed manifest",
+		"requests": {
+			"get_user": {
+				"name": "get_user",
+				"description": "Fetch a user",
+				"args": {"id": {"name": "id", "type": "string", "description": "Identifier", "required": true, "pattern": "^[a-z0-9]+$"}},
+				"response": {"type": "object", "description": "User", "modelRef": "User"}
+			}
+		},
+		"models": {"User": {"name": "User", "type": "object", "description": "A user"}}
+	}`)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	if report := manifest.Lint(m, nil); len(report.Issues) != 0 {
+		t.Errorf("Expected no issues, got %v", report.Issues)
+	}
+}
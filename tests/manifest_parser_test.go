@@ -16,26 +16,17 @@ func TestParseJSONManifest(t *testing.T) {
 		"version": "1.0.0",
 		"name": "Test API",
 		"description": "Test Manifest",
-		"channels": {
-			"test-channel": {
-				"name": "Test Channel",
-				"description": "Test channel",
-				"requests": {
-					"test-request": {
-						"name": "Test Request",
-						"description": "Test request",
-						"args": {
-							"arg1": {
-								"name": "Argument 1",
-								"type": "string",
-								"description": "Test argument",
-								"required": true
-							}
-						},
-						"response": {
-							"type": "object",
-							"description": "Test response"
-						}
+		"models": {
+			"TestModel": {
+				"name": "Test Model",
+				"type": "object",
+				"description": "Test model",
+				"properties": {
+					"arg1": {
+						"name": "Argument 1",
+						"type": "string",
+						"description": "Test argument",
+						"required": true
 					}
 				}
 			}
@@ -55,29 +46,20 @@ func TestParseJSONManifest(t *testing.T) {
 		t.Errorf("Expected name 'Test API', got '%s'", manifest.Name)
 	}
 	
-	if len(manifest.Channels) != 1 {
-		t.Errorf("Expected 1 channel, got %d", len(manifest.Channels))
+	if len(manifest.Models) != 1 {
+		t.Errorf("Expected 1 model, got %d", len(manifest.Models))
 	}
 	
-	channel, exists := manifest.Channels["test-channel"]
+	model, exists := manifest.Models["TestModel"]
 	if !exists {
-		t.Fatal("Expected 'test-channel' to exist")
+		t.Fatal("Expected 'TestModel' to exist")
 	}
 	
-	if len(channel.Requests) != 1 {
-		t.Errorf("Expected 1 request, got %d", len(channel.Requests))
+	if len(model.Properties) != 1 {
+		t.Errorf("Expected 1 property, got %d", len(model.Properties))
 	}
 	
-	request, exists := channel.Requests["test-request"]
-	if !exists {
-		t.Fatal("Expected 'test-request' to exist")
-	}
-	
-	if len(request.Args) != 1 {
-		t.Errorf("Expected 1 argument, got %d", len(request.Args))
-	}
-	
-	arg, exists := request.Args["arg1"]
+	arg, exists := model.Properties["arg1"]
 	if !exists {
 		t.Fatal("Expected 'arg1' to exist")
 	}
@@ -98,23 +80,17 @@ func TestParseYAMLManifest(t *testing.T) {
 version: "1.0.0"
 name: "Test API"
 description: "Test Manifest"
-channels:
-  test-channel:
-    name: "Test Channel"
-    description: "Test channel"
-    requests:
-      test-request:
-        name: "Test Request"
-        description: "Test request"
-        args:
-          arg1:
-            name: "Argument 1"
-            type: "string"
-            description: "Test argument"
-            required: true
-        response:
-          type: "object"
-          description: "Test response"
+models:
+  TestModel:
+    name: "Test Model"
+    type: "object"
+    description: "Test model"
+    properties:
+      arg1:
+        name: "Argument 1"
+        type: "string"
+        description: "Test argument"
+        required: true
 `
 	
 	manifest, err := gojanus.ParseYAML([]byte(yamlData))
@@ -130,21 +106,16 @@ channels:
 		t.Errorf("Expected name 'Test API', got '%s'", manifest.Name)
 	}
 	
-	if len(manifest.Channels) != 1 {
-		t.Errorf("Expected 1 channel, got %d", len(manifest.Channels))
+	if len(manifest.Models) != 1 {
+		t.Errorf("Expected 1 model, got %d", len(manifest.Models))
 	}
 	
-	channel, exists := manifest.Channels["test-channel"]
+	model, exists := manifest.Models["TestModel"]
 	if !exists {
-		t.Fatal("Expected 'test-channel' to exist")
+		t.Fatal("Expected 'TestModel' to exist")
 	}
 	
-	request, exists := channel.Requests["test-request"]
-	if !exists {
-		t.Fatal("Expected 'test-request' to exist")
-	}
-	
-	arg, exists := request.Args["arg1"]
+	arg, exists := model.Properties["arg1"]
 	if !exists {
 		t.Fatal("Expected 'arg1' to exist")
 	}
@@ -181,75 +152,42 @@ func TestValidateManifestWithEmptyVersion(t *testing.T) {
 	}
 }
 
-// TestValidateManifestWithNoChannels tests validation failure for no channels
-// Matches Swift: testValidateManifestWithNoChannels()
-func TestValidateManifestWithNoChannels(t *testing.T) {
-	manifest := createValidManifest()
-	manifest.Channels = make(map[string]*gojanus.ChannelManifest)
-	
-	err := gojanus.Validate(manifest)
-	if err == nil {
-		t.Error("Expected validation error for no channels")
-	}
-	
-	if !strings.Contains(err.Error(), "at least one channel") {
-		t.Errorf("Expected channels-related error, got: %v", err)
-	}
-}
-
-// TestValidateManifestWithEmptyChannelId tests validation failure for empty channel ID
-// Matches Swift: testValidateManifestWithEmptyChannelId()
-func TestValidateManifestWithEmptyChannelId(t *testing.T) {
+// TestValidateManifestWithEmptyRequestName tests validation failure for empty request name
+// Matches Swift: testValidateManifestWithEmptyRequestName()
+func TestValidateManifestWithEmptyRequestName(t *testing.T) {
 	manifest := createValidManifest()
 	
-	// Add channel with empty ID
-	channelManifest := manifest.Channels["test-channel"]
-	delete(manifest.Channels, "test-channel")
-	manifest.Channels[""] = channelManifest
-	
-	err := gojanus.Validate(manifest)
-	if err == nil {
-		t.Error("Expected validation error for empty channel ID")
-	}
-	
-	if !strings.Contains(err.Error(), "channel ID cannot be empty") {
-		t.Errorf("Expected channel ID error, got: %v", err)
-	}
-}
-
-// TestValidateManifestWithNoRequests tests validation failure for no requests
-// Matches Swift: testValidateManifestWithNoRequests()
-func TestValidateManifestWithNoRequests(t *testing.T) {
-	manifest := createValidManifest()
-	manifest.Channels["test-channel"].Requests = make(map[string]*gojanus.RequestManifest)
+	// Add request with empty name
+	requestManifest := manifest.Requests["test-request"]
+	delete(manifest.Requests, "test-request")
+	manifest.Requests[""] = requestManifest
 	
 	err := gojanus.Validate(manifest)
 	if err == nil {
-		t.Error("Expected validation error for no requests")
+		t.Error("Expected validation error for empty request name")
 	}
 	
-	if !strings.Contains(err.Error(), "at least one request") {
-		t.Errorf("Expected requests-related error, got: %v", err)
+	if !strings.Contains(err.Error(), "request name cannot be empty") {
+		t.Errorf("Expected request name error, got: %v", err)
 	}
 }
 
-// TestValidateManifestWithEmptyRequestName tests validation failure for empty request name
-// Matches Swift: testValidateManifestWithEmptyRequestName()
-func TestValidateManifestWithEmptyRequestName(t *testing.T) {
+// TestValidateManifestWithEmptyModelName tests validation failure for empty model name
+func TestValidateManifestWithEmptyModelName(t *testing.T) {
 	manifest := createValidManifest()
 	
-	// Add request with empty name
-	requestManifest := manifest.Channels["test-channel"].Requests["test-request"]
-	delete(manifest.Channels["test-channel"].Requests, "test-request")
-	manifest.Channels["test-channel"].Requests[""] = requestManifest
+	// Add model with empty name
+	model := manifest.Models["TestModel"]
+	delete(manifest.Models, "TestModel")
+	manifest.Models[""] = model
 	
 	err := gojanus.Validate(manifest)
 	if err == nil {
-		t.Error("Expected validation error for empty request name")
+		t.Error("Expected validation error for empty model name")
 	}
 	
-	if !strings.Contains(err.Error(), "request name cannot be empty") {
-		t.Errorf("Expected request name error, got: %v", err)
+	if !strings.Contains(err.Error(), "model name cannot be empty") {
+		t.Errorf("Expected model name error, got: %v", err)
 	}
 }
 
@@ -259,7 +197,7 @@ func TestValidateManifestWithInvalidValidation(t *testing.T) {
 	manifest := createValidManifest()
 	
 	// Add invalid argument manifest (empty type)
-	manifest.Channels["test-channel"].Requests["test-request"].Args["invalid_arg"] = &gojanus.ArgumentManifest{
+	manifest.Models["TestModel"].Properties["invalid_arg"] = &gojanus.ArgumentManifest{
 		Name:        "Invalid Argument",
 		Type:        "", // Empty type should cause validation error
 		Description: "Invalid argument",
@@ -282,7 +220,7 @@ func TestValidateManifestWithInvalidRegexPattern(t *testing.T) {
 	manifest := createValidManifest()
 	
 	// Add argument with invalid regex pattern
-	manifest.Channels["test-channel"].Requests["test-request"].Args["regex_arg"] = &gojanus.ArgumentManifest{
+	manifest.Models["TestModel"].Properties["regex_arg"] = &gojanus.ArgumentManifest{
 		Name:        "Regex Argument",
 		Type:        "string",
 		Description: "Argument with invalid regex",
@@ -306,13 +244,9 @@ func TestParseInvalidJSON(t *testing.T) {
 	invalidJSON := `{
 		"version": "1.0.0",
 		"name": "Test API",
-		"channels": {
-			"test-channel": {
-				"requests": {
-					"test-request": {
-						// Invalid comment in JSON
-					}
-				}
+		"models": {
+			"TestModel": {
+				// Invalid comment in JSON
 			}
 		}
 	}` // Missing closing brace
@@ -367,16 +301,11 @@ func TestParseFromJSONFile(t *testing.T) {
 		"version": "1.0.0",
 		"name": "File Test API",
 		"description": "Test API from file",
-		"channels": {
-			"file-channel": {
-				"name": "File Channel",
-				"description": "Test channel from file",
-				"requests": {
-					"file-request": {
-						"name": "File Request",
-						"description": "Test request from file"
-					}
-				}
+		"models": {
+			"FileModel": {
+				"name": "File Model",
+				"type": "object",
+				"description": "Test model from file"
 			}
 		}
 	}`
@@ -395,17 +324,17 @@ func TestParseFromJSONFile(t *testing.T) {
 		t.Errorf("Expected name 'File Test API', got '%s'", manifest.Name)
 	}
 	
-	if len(manifest.Channels) != 1 {
-		t.Errorf("Expected 1 channel, got %d", len(manifest.Channels))
+	if len(manifest.Models) != 1 {
+		t.Errorf("Expected 1 model, got %d", len(manifest.Models))
 	}
 	
-	channel, exists := manifest.Channels["file-channel"]
+	model, exists := manifest.Models["FileModel"]
 	if !exists {
-		t.Fatal("Expected 'file-channel' to exist")
+		t.Fatal("Expected 'FileModel' to exist")
 	}
 	
-	if channel.Name != "File Channel" {
-		t.Errorf("Expected channel name 'File Channel', got '%s'", channel.Name)
+	if model.Name != "File Model" {
+		t.Errorf("Expected model name 'File Model', got '%s'", model.Name)
 	}
 }
 
@@ -424,14 +353,11 @@ func TestParseFromYAMLFile(t *testing.T) {
 version: "1.0.0"
 name: "YAML Test API"
 description: "Test API from YAML file"
-channels:
-  yaml-channel:
-    name: "YAML Channel"
-    description: "Test channel from YAML file"
-    requests:
-      yaml-request:
-        name: "YAML Request"
-        description: "Test request from YAML file"
+models:
+  YAMLModel:
+    name: "YAML Model"
+    type: "object"
+    description: "Test model from YAML file"
 `
 	
 	err = os.WriteFile(yamlFile, []byte(yamlData), 0644)
@@ -448,17 +374,17 @@ channels:
 		t.Errorf("Expected name 'YAML Test API', got '%s'", manifest.Name)
 	}
 	
-	if len(manifest.Channels) != 1 {
-		t.Errorf("Expected 1 channel, got %d", len(manifest.Channels))
+	if len(manifest.Models) != 1 {
+		t.Errorf("Expected 1 model, got %d", len(manifest.Models))
 	}
 	
-	channel, exists := manifest.Channels["yaml-channel"]
+	model, exists := manifest.Models["YAMLModel"]
 	if !exists {
-		t.Fatal("Expected 'yaml-channel' to exist")
+		t.Fatal("Expected 'YAMLModel' to exist")
 	}
 	
-	if channel.Name != "YAML Channel" {
-		t.Errorf("Expected channel name 'YAML Channel', got '%s'", channel.Name)
+	if model.Name != "YAML Model" {
+		t.Errorf("Expected model name 'YAML Model', got '%s'", model.Name)
 	}
 }
 
@@ -477,16 +403,10 @@ func TestParseMultipleFiles(t *testing.T) {
 		"version": "1.0.0",
 		"name": "Multi-File Test API",
 		"description": "Base Manifest",
-		"channels": {
-			"base-channel": {
-				"name": "Base Channel",
-				"description": "Base channel",
-				"requests": {
-					"base-request": {
-						"name": "Base Request",
-						"description": "Base request"
-					}
-				}
+		"requests": {
+			"base-request": {
+				"name": "Base Request",
+				"description": "Base request"
 			}
 		},
 		"models": {
@@ -517,16 +437,10 @@ func TestParseMultipleFiles(t *testing.T) {
 		"version": "1.0.0",
 		"name": "Additional Manifest",
 		"description": "Additional Manifest",
-		"channels": {
-			"additional-channel": {
-				"name": "Additional Channel",
-				"description": "Additional channel",
-				"requests": {
-					"additional-request": {
-						"name": "Additional Request",
-						"description": "Additional request"
-					}
-				}
+		"requests": {
+			"additional-request": {
+				"name": "Additional Request",
+				"description": "Additional request"
 			}
 		},
 		"models": {
@@ -563,18 +477,18 @@ func TestParseMultipleFiles(t *testing.T) {
 		t.Errorf("Expected base name 'Multi-File Test API', got '%s'", manifest.Name)
 	}
 	
-	if len(manifest.Channels) != 2 {
-		t.Errorf("Expected 2 channels after merge, got %d", len(manifest.Channels))
+	if len(manifest.Requests) != 2 {
+		t.Errorf("Expected 2 requests after merge, got %d", len(manifest.Requests))
 	}
 	
-	// Verify base channel exists
-	if _, exists := manifest.Channels["base-channel"]; !exists {
-		t.Error("Expected 'base-channel' to exist after merge")
+	// Verify base request exists
+	if _, exists := manifest.Requests["base-request"]; !exists {
+		t.Error("Expected 'base-request' to exist after merge")
 	}
 	
-	// Verify additional channel exists
-	if _, exists := manifest.Channels["additional-channel"]; !exists {
-		t.Error("Expected 'additional-channel' to exist after merge")
+	// Verify additional request exists
+	if _, exists := manifest.Requests["additional-request"]; !exists {
+		t.Error("Expected 'additional-request' to exist after merge")
 	}
 	
 	if len(manifest.Models) != 2 {
@@ -592,7 +506,7 @@ func TestParseMultipleFiles(t *testing.T) {
 	}
 }
 
-// TestParseMultipleFilesWithConflict tests that merging fails when channels conflict
+// TestParseMultipleFilesWithConflict tests that merging fails when models conflict
 func TestParseMultipleFilesWithConflict(t *testing.T) {
 	// Create temporary directory
 	tempDir, err := os.MkdirTemp("", "gojanus-conflict-test")
@@ -606,14 +520,10 @@ func TestParseMultipleFilesWithConflict(t *testing.T) {
 	baseData := `{
 		"version": "1.0.0",
 		"name": "Conflict Test API",
-		"channels": {
-			"conflict-channel": {
-				"name": "Base Channel",
-				"requests": {
-					"test-request": {
-						"name": "Test Request"
-					}
-				}
+		"models": {
+			"TestModel": {
+				"name": "Test Model",
+				"type": "object"
 			}
 		}
 	}`
@@ -623,19 +533,15 @@ func TestParseMultipleFilesWithConflict(t *testing.T) {
 		t.Fatalf("Failed to write base file: %v", err)
 	}
 	
-	// Create second file with conflicting channel
+	// Create second file with conflicting model
 	conflictFile := filepath.Join(tempDir, "conflict.json")
 	conflictData := `{
 		"version": "1.0.0",
 		"name": "Conflict Manifest",
-		"channels": {
-			"conflict-channel": {
-				"name": "Conflicting Channel",
-				"requests": {
-					"different-request": {
-						"name": "Different Request"
-					}
-				}
+		"models": {
+			"TestModel": {
+				"name": "Conflicting Model",
+				"type": "object"
 			}
 		}
 	}`
@@ -649,7 +555,7 @@ func TestParseMultipleFilesWithConflict(t *testing.T) {
 	parser := gojanus.NewManifestParser()
 	_, err = parser.ParseMultipleFiles([]string{baseFile, conflictFile})
 	if err == nil {
-		t.Error("Expected error for conflicting channel names")
+		t.Error("Expected error for conflicting model names")
 	}
 	
 	if !strings.Contains(err.Error(), "already exists") {
@@ -663,14 +569,9 @@ func TestManifestMerging(t *testing.T) {
 	baseManifest := &gojanus.Manifest{
 		Version: "1.0.0",
 		Name:    "Base API",
-		Channels: map[string]*gojanus.ChannelManifest{
-			"base-channel": {
-				Name: "Base Channel",
-				Requests: map[string]*gojanus.RequestManifest{
-					"base-request": {
-						Name: "Base Request",
-					},
-				},
+		Requests: map[string]*gojanus.RequestManifest{
+			"base-request": {
+				Name: "Base Request",
 			},
 		},
 		Models: map[string]*gojanus.ModelDefinition{
@@ -685,14 +586,9 @@ func TestManifestMerging(t *testing.T) {
 	additionalManifest := &gojanus.Manifest{
 		Version: "1.0.0",
 		Name:    "Additional API",
-		Channels: map[string]*gojanus.ChannelManifest{
-			"additional-channel": {
-				Name: "Additional Channel",
-				Requests: map[string]*gojanus.RequestManifest{
-					"additional-request": {
-						Name: "Additional Request",
-					},
-				},
+		Requests: map[string]*gojanus.RequestManifest{
+			"additional-request": {
+				Name: "Additional Request",
 			},
 		},
 		Models: map[string]*gojanus.ModelDefinition{
@@ -711,21 +607,21 @@ func TestManifestMerging(t *testing.T) {
 	}
 	
 	// Verify merge results
-	if len(baseManifest.Channels) != 2 {
-		t.Errorf("Expected 2 channels after merge, got %d", len(baseManifest.Channels))
+	if len(baseManifest.Requests) != 2 {
+		t.Errorf("Expected 2 requests after merge, got %d", len(baseManifest.Requests))
 	}
 	
 	if len(baseManifest.Models) != 2 {
 		t.Errorf("Expected 2 models after merge, got %d", len(baseManifest.Models))
 	}
 	
-	// Verify both channels exist
-	if _, exists := baseManifest.Channels["base-channel"]; !exists {
-		t.Error("Expected 'base-channel' to exist after merge")
+	// Verify both requests exist
+	if _, exists := baseManifest.Requests["base-request"]; !exists {
+		t.Error("Expected 'base-request' to exist after merge")
 	}
 	
-	if _, exists := baseManifest.Channels["additional-channel"]; !exists {
-		t.Error("Expected 'additional-channel' to exist after merge")
+	if _, exists := baseManifest.Requests["additional-request"]; !exists {
+		t.Error("Expected 'additional-request' to exist after merge")
 	}
 	
 	// Verify both models exist
@@ -744,14 +640,10 @@ func TestStaticInterfaceMethods(t *testing.T) {
 	jsonData := `{
 		"version": "1.0.0",
 		"name": "Static Test API",
-		"channels": {
-			"static-channel": {
-				"name": "Static Channel",
-				"requests": {
-					"static-request": {
-						"name": "Static Request"
-					}
-				}
+		"models": {
+			"StaticModel": {
+				"name": "Static Model",
+				"type": "object"
 			}
 		}
 	}`
@@ -759,12 +651,10 @@ func TestStaticInterfaceMethods(t *testing.T) {
 	yamlData := `
 version: "1.0.0"
 name: "Static YAML API"
-channels:
-  static-yaml-channel:
-    name: "Static YAML Channel"
-    requests:
-      static-yaml-request:
-        name: "Static YAML Request"
+models:
+  StaticYAMLModel:
+    name: "Static YAML Model"
+    type: "object"
 `
 	
 	// Test static ParseJSON
@@ -892,29 +782,23 @@ func createValidManifest() *gojanus.Manifest {
 		Version:     "1.0.0",
 		Name:        "Valid Test API",
 		Description: "Valid test Manifest",
-		Channels: map[string]*gojanus.ChannelManifest{
-			"test-channel": {
-				Name:        "Test Channel",
-				Description: "Test channel description",
-				Requests: map[string]*gojanus.RequestManifest{
-					"test-request": {
-						Name:        "Test Request",
-						Description: "Test request description",
-						Args: map[string]*gojanus.ArgumentManifest{
-							"test_arg": {
-								Name:        "Test Argument",
-								Type:        "string",
-								Description: "Test argument description",
-								Required:    true,
-							},
-						},
-						Response: &gojanus.ResponseManifest{
-							Type:        "object",
-							Description: "Test response",
-						},
-						ErrorCodes: []string{"TEST_ERROR"},
+		Requests: map[string]*gojanus.RequestManifest{
+			"test-request": {
+				Name:        "Test Request",
+				Description: "Test request description",
+				Args: map[string]*gojanus.ArgumentManifest{
+					"test_arg": {
+						Name:        "Test Argument",
+						Type:        "string",
+						Description: "Test argument description",
+						Required:    true,
 					},
 				},
+				Response: &gojanus.ResponseManifest{
+					Type:        "object",
+					Description: "Test response",
+				},
+				ErrorCodes: []string{"TEST_ERROR"},
 			},
 		},
 		Models: map[string]*gojanus.ModelDefinition{
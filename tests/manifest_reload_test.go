@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/server"
+)
+
+const reloadManifestV1 = `{
+  "name": "Reload API",
+  "version": "1.0.0",
+  "description": "Hot reload test",
+  "requests": {
+    "get_user": {
+      "name": "get_user",
+      "description": "Fetch a user",
+      "args": {
+        "user_id": {"name": "user_id", "type": "string", "description": "User identifier", "required": true}
+      }
+    }
+  }
+}`
+
+const reloadManifestV2 = `{
+  "name": "Reload API",
+  "version": "1.1.0",
+  "description": "Hot reload test",
+  "requests": {
+    "get_user": {
+      "name": "get_user",
+      "description": "Fetch a user",
+      "args": {
+        "user_id": {"name": "user_id", "type": "string", "description": "User identifier", "required": true}
+      }
+    },
+    "list_users": {
+      "name": "list_users",
+      "description": "List users"
+    }
+  }
+}`
+
+// writeReloadManifest writes manifest content for the watcher to pick up
+func writeReloadManifest(t *testing.T, path, content string) {
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+}
+
+// waitForEvent waits for one event payload or fails the test
+func waitForEvent(t *testing.T, events chan interface{}, name string) interface{} {
+	select {
+	case data := <-events:
+		return data
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timed out waiting for %s event", name)
+		return nil
+	}
+}
+
+// TestManifestHotReload validates that file changes are swapped in with a diff summary
+func TestManifestHotReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.json")
+	writeReloadManifest(t, path, reloadManifestV1)
+
+	srv := server.NewJanusServer(nil)
+	reloads := make(chan interface{}, 4)
+	srv.On("manifest_reloaded", func(data interface{}) { reloads <- data })
+
+	if err := srv.WatchManifest(path, 20*time.Millisecond); err != nil {
+		t.Fatalf("WatchManifest failed: %v", err)
+	}
+	defer srv.StopWatchingManifest()
+
+	if got := srv.GetManifest().Version; got != "1.0.0" {
+		t.Fatalf("Expected initial manifest 1.0.0, got %s", got)
+	}
+
+	writeReloadManifest(t, path, reloadManifestV2)
+	event, ok := waitForEvent(t, reloads, "manifest_reloaded").(*server.ManifestReloadEvent)
+	if !ok {
+		t.Fatal("Expected *server.ManifestReloadEvent payload")
+	}
+
+	if event.Summary != "Manifest 1.0.0 -> 1.1.0: 1 change(s), 0 breaking" {
+		t.Errorf("Unexpected summary: %s", event.Summary)
+	}
+	if event.Diff == nil || len(event.Diff.Changes) != 1 || event.Diff.Changes[0].Kind != "request_added" {
+		t.Errorf("Unexpected diff: %+v", event.Diff)
+	}
+	if hash, _ := manifest.ContentHash(srv.GetManifest()); event.Hash != hash {
+		t.Errorf("Expected event hash %s, got %s", hash, event.Hash)
+	}
+	if got := srv.GetManifest().Version; got != "1.1.0" {
+		t.Errorf("Expected reloaded manifest 1.1.0, got %s", got)
+	}
+
+	t.Log("✅ Manifest file changes are swapped in and reported with a diff summary")
+}
+
+// TestManifestHotReloadRejectsInvalid validates that bad reloads keep the old manifest
+func TestManifestHotReloadRejectsInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.json")
+	writeReloadManifest(t, path, reloadManifestV1)
+
+	srv := server.NewJanusServer(nil)
+	reloads := make(chan interface{}, 4)
+	errors := make(chan interface{}, 4)
+	srv.On("manifest_reloaded", func(data interface{}) { reloads <- data })
+	srv.On("error", func(data interface{}) { errors <- data })
+
+	if err := srv.WatchManifest(path, 20*time.Millisecond); err != nil {
+		t.Fatalf("WatchManifest failed: %v", err)
+	}
+	defer srv.StopWatchingManifest()
+
+	// Syntax error
+	writeReloadManifest(t, path, `{"name": "Reload API", "version": `)
+	if err, _ := waitForEvent(t, errors, "error").(error); err == nil || !strings.Contains(err.Error(), "manifest reload rejected") {
+		t.Errorf("Expected reload rejection, got %v", err)
+	}
+
+	// Parses and validates, but fails lint (built-in request redefined)
+	writeReloadManifest(t, path, strings.Replace(reloadManifestV2, `"list_users": {
+      "name": "list_users"`, `"ping": {
+      "name": "ping"`, 1))
+	if err, _ := waitForEvent(t, errors, "error").(error); err == nil || !strings.Contains(err.Error(), "builtin-redefined") {
+		t.Errorf("Expected lint rejection, got %v", err)
+	}
+
+	if got := srv.GetManifest().Version; got != "1.0.0" {
+		t.Errorf("Expected old manifest to stay in place, got %s", got)
+	}
+	select {
+	case data := <-reloads:
+		t.Errorf("Unexpected manifest_reloaded event: %v", data)
+	default:
+	}
+
+	if err := srv.ReloadManifest(); err != nil {
+		t.Errorf("Expected unchanged rejected file to be ignored, got %v", err)
+	}
+
+	writeReloadManifest(t, path, reloadManifestV2)
+	waitForEvent(t, reloads, "manifest_reloaded")
+	if got := srv.GetManifest().Version; got != "1.1.0" {
+		t.Errorf("Expected fixed manifest to load, got %s", got)
+	}
+
+	t.Log("✅ Invalid reloads are rejected and the previous manifest is kept")
+}
+
+// TestManifestHotReloadFollowsImports validates that editing an imported file reloads the manifest
+func TestManifestHotReloadFollowsImports(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "api.json")
+	libraryPath := filepath.Join(dir, "common.json")
+	writeReloadManifest(t, libraryPath, `{"version": "1.0.0", "name": "common", "description": "Shared models",
+		"models": {"User": {"name": "User", "type": "object", "description": "A user"}}}`)
+	writeReloadManifest(t, path, `{"version": "1.0.0", "name": "Reload API", "description": "Hot reload test",
+		"imports": [{"path": "common.json"}]}`)
+
+	srv := server.NewJanusServer(nil)
+	reloads := make(chan interface{}, 4)
+	srv.On("manifest_reloaded", func(data interface{}) { reloads <- data })
+
+	if err := srv.WatchManifest(path, 20*time.Millisecond); err != nil {
+		t.Fatalf("WatchManifest failed: %v", err)
+	}
+	defer srv.StopWatchingManifest()
+
+	writeReloadManifest(t, libraryPath, `{"version": "1.0.0", "name": "common", "description": "Shared models",
+		"models": {"User": {"name": "User", "type": "object", "description": "A user"},
+			"Team": {"name": "Team", "type": "object", "description": "A team"}}}`)
+	event, ok := waitForEvent(t, reloads, "manifest_reloaded").(*server.ManifestReloadEvent)
+	if !ok {
+		t.Fatal("Expected *server.ManifestReloadEvent payload")
+	}
+	if event.Diff == nil || len(event.Diff.Changes) != 1 || event.Diff.Changes[0].Kind != "model_added" {
+		t.Errorf("Unexpected diff: %+v", event.Diff)
+	}
+	if _, exists := srv.GetManifest().Models["common.Team"]; !exists {
+		t.Error("Expected the imported model to be served after reload")
+	}
+
+	t.Log("✅ Edits to imported files reload the manifest")
+}
+
+// TestWatchManifestInitialLoad validates that a bad initial file is an error
+func TestWatchManifestInitialLoad(t *testing.T) {
+	srv := server.NewJanusServer(nil)
+
+	if err := srv.WatchManifest(filepath.Join(t.TempDir(), "missing.json"), 0); err == nil {
+		t.Error("Expected missing manifest file to be rejected")
+	}
+	if err := srv.ReloadManifest(); err == nil {
+		t.Error("Expected ReloadManifest without a watched file to fail")
+	}
+	if srv.GetManifest() != nil {
+		t.Error("Expected no manifest after a failed initial load")
+	}
+
+	t.Log("✅ WatchManifest requires a valid initial manifest")
+}
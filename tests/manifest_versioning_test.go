@@ -0,0 +1,375 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/server"
+	"GoJanus/pkg/wire"
+)
+
+// versionedTestManifest builds a minimal valid manifest with the given version
+func versionedTestManifest(version string) *manifest.Manifest {
+	return &manifest.Manifest{
+		Name:    "Versioned API",
+		Version: version,
+		Requests: map[string]*manifest.RequestManifest{
+			"greet": {
+				Name:        "greet",
+				Description: "Greets someone",
+				Args: map[string]*manifest.ArgumentManifest{
+					"name": {Name: "name", Type: "string", Required: true},
+				},
+			},
+		},
+		Models: map[string]*manifest.ModelDefinition{},
+	}
+}
+
+// startVersionedTestServer serves the given manifest versions side by side
+func startVersionedTestServer(t *testing.T, versions ...string) (*server.JanusServer, string) {
+	t.Helper()
+	srv, socketPath, _ := startTestServer(t, nil, func(srv *server.JanusServer) {
+		for _, version := range versions {
+			if err := srv.AddManifest(versionedTestManifest(version)); err != nil {
+				t.Fatalf("Failed to add manifest %s: %v", version, err)
+			}
+		}
+	})
+	return srv, socketPath
+}
+
+// TestSemanticVersionOrdering validates version parsing and precedence
+func TestSemanticVersionOrdering(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0", "1.2.0", "1.10.0", "2.0.0"}
+	for i := 0; i < len(ordered)-1; i++ {
+		low, err := manifest.ParseSemanticVersion(ordered[i])
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", ordered[i], err)
+		}
+		high, _ := manifest.ParseSemanticVersion(ordered[i+1])
+		if low.Compare(high) >= 0 {
+			t.Errorf("Expected %s < %s", ordered[i], ordered[i+1])
+		}
+	}
+
+	if v, err := manifest.ParseSemanticVersion("v1.2.3+build.5"); err != nil || v.String() != "1.2.3" {
+		t.Errorf("Expected v-prefix and build metadata to be accepted, got %v, %v", v, err)
+	}
+
+	for _, invalid := range []string{"1.2", "1.2.3.4", "01.2.3", "1.x.0", "1.2.3-", ""} {
+		if _, err := manifest.ParseSemanticVersion(invalid); err == nil {
+			t.Errorf("Expected '%s' to be rejected", invalid)
+		}
+	}
+
+	t.Log("✅ Semantic versions parse and order per semver precedence")
+}
+
+// TestVersionRangeMatching validates range operators, wildcards and alternatives
+func TestVersionRangeMatching(t *testing.T) {
+	cases := []struct {
+		expression string
+		matches    []string
+		rejects    []string
+	}{
+		{"^1.2.0", []string{"1.2.0", "1.9.3"}, []string{"1.1.9", "2.0.0", "1.3.0-beta"}},
+		{"^0.2.1", []string{"0.2.1", "0.2.9"}, []string{"0.3.0"}},
+		{"~1.2.0", []string{"1.2.0", "1.2.7"}, []string{"1.3.0"}},
+		{">=1.0.0 <2.0.0", []string{"1.0.0", "1.99.0"}, []string{"0.9.0", "2.0.0"}},
+		{">= 1.0.0, < 1.5.0", []string{"1.4.9"}, []string{"1.5.0"}},
+		{"1.x", []string{"1.0.0", "1.8.2"}, []string{"2.0.0"}},
+		{"1.2", []string{"1.2.5"}, []string{"1.3.0"}},
+		{"*", []string{"0.0.1", "9.9.9"}, []string{"1.0.0-rc.1"}},
+		{"1.0.0 || ^3.0.0", []string{"1.0.0", "3.4.0"}, []string{"2.0.0"}},
+		{">=2.0.0-rc.1", []string{"2.0.0-rc.2", "2.0.0", "2.1.0"}, []string{"2.1.0-beta"}},
+	}
+
+	for _, tc := range cases {
+		r, err := manifest.ParseVersionRange(tc.expression)
+		if err != nil {
+			t.Fatalf("Failed to parse range '%s': %v", tc.expression, err)
+		}
+		for _, version := range tc.matches {
+			if ok, _ := r.ContainsString(version); !ok {
+				t.Errorf("Expected '%s' to contain %s", tc.expression, version)
+			}
+		}
+		for _, version := range tc.rejects {
+			if ok, _ := r.ContainsString(version); ok {
+				t.Errorf("Expected '%s' not to contain %s", tc.expression, version)
+			}
+		}
+	}
+
+	for _, invalid := range []string{"", ">=", "^1.2", "1.2.3.4", "1.0.0 ||"} {
+		if _, err := manifest.ParseVersionRange(invalid); err == nil {
+			t.Errorf("Expected range '%s' to be rejected", invalid)
+		}
+	}
+
+	r, _ := manifest.ParseVersionRange("^1.0.0")
+	if best, ok := r.HighestMatch([]string{"0.9.0", "1.2.0", "1.10.0", "2.0.0"}); !ok || best != "1.10.0" {
+		t.Errorf("Expected highest match 1.10.0, got %q", best)
+	}
+
+	t.Log("✅ Version ranges support comparators, caret, tilde, wildcards and alternatives")
+}
+
+// TestServerManifestRegistration validates SetManifest and AddManifest checks
+func TestServerManifestRegistration(t *testing.T) {
+	srv := server.NewJanusServer(nil)
+
+	if err := srv.SetManifest(versionedTestManifest("not-semver")); err == nil {
+		t.Error("Expected non-semver manifest version to be rejected")
+	}
+	if err := srv.SetManifest(nil); err == nil {
+		t.Error("Expected nil manifest to be rejected")
+	}
+
+	if err := srv.SetManifest(versionedTestManifest("1.0.0")); err != nil {
+		t.Fatalf("SetManifest failed: %v", err)
+	}
+	if err := srv.AddManifest(versionedTestManifest("2.1.0")); err != nil {
+		t.Fatalf("AddManifest failed: %v", err)
+	}
+	if err := srv.AddManifest(versionedTestManifest("2.1.0")); err == nil {
+		t.Error("Expected duplicate manifest version to be rejected")
+	}
+
+	if got := srv.GetManifest().Version; got != "2.1.0" {
+		t.Errorf("Expected GetManifest to return the highest version, got %s", got)
+	}
+
+	if err := srv.SetManifest(versionedTestManifest("3.0.0")); err != nil {
+		t.Fatalf("SetManifest failed: %v", err)
+	}
+	if versions := srv.ManifestVersions(); len(versions) != 1 || versions[0] != "3.0.0" {
+		t.Errorf("Expected SetManifest to replace all versions, got %v", versions)
+	}
+
+	t.Log("✅ Server manifest registration validates and orders versions")
+}
+
+// TestGetInfoReportsVersions validates that get_info reports protocol and manifest versions
+func TestGetInfoReportsVersions(t *testing.T) {
+	_, socketPath := startVersionedTestServer(t, "1.0.0", "1.4.0", "2.0.0")
+
+	client, err := protocol.New(socketPath)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	response, err := client.SendRequest(context.Background(), "get_info", nil)
+	if err != nil {
+		t.Fatalf("get_info failed: %v", err)
+	}
+
+	info, ok := response.Result.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected object result, got %T", response.Result)
+	}
+	if info["protocol_version"] != models.ProtocolVersion {
+		t.Errorf("Expected protocol_version %s, got %v", models.ProtocolVersion, info["protocol_version"])
+	}
+	if info["manifest_version"] != "2.0.0" {
+		t.Errorf("Expected manifest_version 2.0.0, got %v", info["manifest_version"])
+	}
+	if versions, ok := info["manifest_versions"].([]interface{}); !ok || len(versions) != 3 {
+		t.Errorf("Expected three manifest_versions, got %v", info["manifest_versions"])
+	}
+
+	t.Log("✅ get_info reports protocol_version, manifest_version and manifest_versions")
+}
+
+// TestClientNegotiatesManifestVersion validates that the client receives the best version in its range
+func TestClientNegotiatesManifestVersion(t *testing.T) {
+	_, socketPath := startVersionedTestServer(t, "1.0.0", "1.4.0", "2.0.0")
+
+	config := protocol.DefaultJanusClientConfig()
+	config.ManifestVersionRange = "^1.0.0"
+	client, err := protocol.New(socketPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	result, err := client.Negotiate(context.Background())
+	if err != nil {
+		t.Fatalf("Negotiate failed: %v", err)
+	}
+
+	if result.ManifestVersion != "1.4.0" {
+		t.Errorf("Expected negotiated manifest 1.4.0, got %s", result.ManifestVersion)
+	}
+	if result.ProtocolVersion != models.ProtocolVersion || result.Degraded {
+		t.Errorf("Unexpected negotiation result: %+v", result)
+	}
+	if client.GetManifest() == nil || client.GetManifest().Version != "1.4.0" {
+		t.Error("Expected client manifest to be the negotiated version")
+	}
+
+	t.Log("✅ Client negotiates the highest manifest version within its range")
+}
+
+// TestClientRefusesIncompatibleManifest validates the default refuse policy
+func TestClientRefusesIncompatibleManifest(t *testing.T) {
+	_, socketPath := startVersionedTestServer(t, "2.0.0")
+
+	config := protocol.DefaultJanusClientConfig()
+	config.ManifestVersionRange = "^1.0.0"
+	client, err := protocol.New(socketPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.Negotiate(context.Background())
+	rpcErr, ok := models.AsJSONRPCError(err)
+	if !ok || rpcErr.Code != models.ManifestValidationError {
+		t.Fatalf("Expected ManifestValidationError, got %v", err)
+	}
+	if rpcErr.Data == nil || rpcErr.Data.Context["requested_range"] != "^1.0.0" {
+		t.Errorf("Expected error context to name the requested range, got %+v", rpcErr.Data)
+	}
+
+	if _, err := client.SendRequest(context.Background(), "greet", map[string]interface{}{"name": "janus"}); err == nil {
+		t.Error("Expected requests to fail while the manifest version is incompatible")
+	}
+
+	t.Log("✅ Client refuses an incompatible manifest with a ManifestValidationError")
+}
+
+// TestClientRefusesIncompatibleProtocolWithoutNegotiate validates the protocol check in the lazy manifest load
+func TestClientRefusesIncompatibleProtocolWithoutNegotiate(t *testing.T) {
+	// A server from the next protocol major version, reporting it with the manifest
+	socketPath := fmt.Sprintf("/tmp/protocol-version-test-%d.sock", time.Now().UnixNano())
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer os.Remove(socketPath)
+	defer conn.Close()
+
+	var getInfoRequests, otherRequests int32
+	go func() {
+		buffer := make([]byte, 64*1024)
+		for {
+			n, _, err := conn.ReadFromUnix(buffer)
+			if err != nil {
+				return
+			}
+			request, err := wire.DecodeRequest(buffer[:n])
+			if err != nil || request.ReplyTo == nil {
+				continue
+			}
+			response := models.NewSuccessResponse(request.ID, map[string]interface{}{"ok": true})
+			switch request.Request {
+			case "manifest":
+				response = models.NewSuccessResponse(request.ID, map[string]interface{}{
+					"manifest":         versionedTestManifest("1.0.0"),
+					"hash":             "sha256:next",
+					"protocol_version": "2.0.0",
+				})
+			case "get_info":
+				atomic.AddInt32(&getInfoRequests, 1)
+			default:
+				atomic.AddInt32(&otherRequests, 1)
+			}
+			data, _ := wire.EncodeResponse(response)
+			reply, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: *request.ReplyTo, Net: "unixgram"})
+			if err != nil {
+				continue
+			}
+			reply.Write(data)
+			reply.Close()
+		}
+	}()
+
+	config := protocol.DefaultJanusClientConfig()
+	config.ManifestVersionRange = "^1.0.0"
+	client, err := protocol.New(socketPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.SendRequest(context.Background(), "greet", map[string]interface{}{"name": "janus"})
+	rpcErr, ok := models.AsJSONRPCError(err)
+	if !ok || rpcErr.Code != models.ConfigurationError {
+		t.Fatalf("Expected ConfigurationError without calling Negotiate, got %v", err)
+	}
+	if rpcErr.Data == nil || rpcErr.Data.Context["server_protocol_version"] != "2.0.0" {
+		t.Errorf("Expected error context to name the server protocol version, got %+v", rpcErr.Data)
+	}
+	if count := atomic.LoadInt32(&otherRequests); count != 0 {
+		t.Errorf("Expected the request to stay on the client, server saw %d", count)
+	}
+	if count := atomic.LoadInt32(&getInfoRequests); count != 0 {
+		t.Errorf("Expected the manifest response alone to carry the protocol version, got %d get_info requests", count)
+	}
+
+	t.Log("✅ Client refuses an incompatible protocol on first use without Negotiate")
+}
+
+// TestClientDegradesOnIncompatibleManifest validates the degrade policy and event
+func TestClientDegradesOnIncompatibleManifest(t *testing.T) {
+	_, socketPath := startVersionedTestServer(t, "2.0.0")
+
+	config := protocol.DefaultJanusClientConfig()
+	config.ManifestVersionRange = "^1.0.0"
+	config.VersionMismatch = protocol.VersionMismatchDegrade
+	client, err := protocol.New(socketPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	mismatches := make(chan interface{}, 1)
+	client.On("version_mismatch", func(data interface{}) {
+		mismatches <- data
+	})
+
+	result, err := client.Negotiate(context.Background())
+	if err != nil {
+		t.Fatalf("Negotiate should degrade, got error: %v", err)
+	}
+	if !result.Degraded || !client.IsDegraded() || client.GetManifest() != nil {
+		t.Errorf("Expected degraded client without manifest, got %+v", result)
+	}
+
+	select {
+	case data := <-mismatches:
+		if rpcErr, ok := data.(*models.JSONRPCError); !ok || rpcErr.Code != models.ManifestValidationError {
+			t.Errorf("Unexpected version_mismatch payload: %v", data)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected version_mismatch event")
+	}
+
+	if _, err := client.SendRequest(context.Background(), "ping", nil); err != nil {
+		t.Errorf("Expected degraded client to keep sending requests: %v", err)
+	}
+
+	t.Log("✅ Client degrades without validation and emits version_mismatch")
+}
+
+// TestClientRejectsInvalidVersionRange validates constructor checks on ManifestVersionRange
+func TestClientRejectsInvalidVersionRange(t *testing.T) {
+	config := protocol.DefaultJanusClientConfig()
+	config.ManifestVersionRange = ">=banana"
+	if _, err := protocol.New("/tmp/versioning-invalid.sock", config); err == nil {
+		t.Error("Expected invalid version range to be rejected")
+	}
+
+	t.Log("✅ Invalid ManifestVersionRange is a configuration error")
+}
@@ -12,7 +12,7 @@ func TestMessageFraming_EncodeMessage(t *testing.T) {
 	framing := protocol.NewMessageFraming()
 	
 	t.Run("should encode a request message", func(t *testing.T) {
-		request := models.NewJanusRequest("test-service", "ping", nil, nil)
+		request := models.NewJanusRequest("ping", nil, nil)
 		
 		encoded, err := framing.EncodeMessage(request)
 		if err != nil {
@@ -33,11 +33,11 @@ func TestMessageFraming_EncodeMessage(t *testing.T) {
 	
 	t.Run("should encode a response message", func(t *testing.T) {
 		response := &models.JanusResponse{
+			ID:        "660e8400-e29b-41d4-a716-446655440001",
 			RequestID: "550e8400-e29b-41d4-a716-446655440000",
-			ChannelID: "test-service",
 			Success:   true,
 			Result:    map[string]interface{}{"pong": true},
-			Timestamp: 1722248201,
+			Timestamp: "2024-07-29T10:16:41.000Z",
 		}
 		
 		encoded, err := framing.EncodeMessage(response)
@@ -55,7 +55,7 @@ func TestMessageFraming_EncodeMessage(t *testing.T) {
 		largeArgs := make(map[string]interface{})
 		largeArgs["data"] = string(make([]byte, 20*1024*1024)) // 20MB
 		
-		request := models.NewJanusRequest("test-service", "large", largeArgs, nil)
+		request := models.NewJanusRequest("large", largeArgs, nil)
 		
 		_, err := framing.EncodeMessage(request)
 		if err == nil {
@@ -77,7 +77,7 @@ func TestMessageFraming_DecodeMessage(t *testing.T) {
 	framing := protocol.NewMessageFraming()
 	
 	t.Run("should decode a request message", func(t *testing.T) {
-		originalRequest := models.NewJanusRequest("test-service", "ping", nil, nil)
+		originalRequest := models.NewJanusRequest("ping", nil, nil)
 		
 		encoded, err := framing.EncodeMessage(originalRequest)
 		if err != nil {
@@ -102,9 +102,6 @@ func TestMessageFraming_DecodeMessage(t *testing.T) {
 			t.Errorf("Request ID mismatch: expected %s, got %s", originalRequest.ID, decodedRequest.ID)
 		}
 		
-		if decodedRequest.ChannelID != originalRequest.ChannelID {
-			t.Errorf("Channel ID mismatch: expected %s, got %s", originalRequest.ChannelID, decodedRequest.ChannelID)
-		}
 		
 		if decodedRequest.Request != originalRequest.Request {
 			t.Errorf("Request mismatch: expected %s, got %s", originalRequest.Request, decodedRequest.Request)
@@ -113,11 +110,11 @@ func TestMessageFraming_DecodeMessage(t *testing.T) {
 	
 	t.Run("should decode a response message", func(t *testing.T) {
 		originalResponse := &models.JanusResponse{
+			ID:        "660e8400-e29b-41d4-a716-446655440002",
 			RequestID: "550e8400-e29b-41d4-a716-446655440000",
-			ChannelID: "test-service",
 			Success:   true,
 			Result:    map[string]interface{}{"pong": true},
-			Timestamp: 1722248201,
+			Timestamp: "2024-07-29T10:16:41.000Z",
 		}
 		
 		encoded, err := framing.EncodeMessage(originalResponse)
@@ -149,13 +146,13 @@ func TestMessageFraming_DecodeMessage(t *testing.T) {
 	})
 	
 	t.Run("should handle multiple messages in buffer", func(t *testing.T) {
-		request := models.NewJanusRequest("test-service", "ping", nil, nil)
+		request := models.NewJanusRequest("ping", nil, nil)
 		response := &models.JanusResponse{
+			ID:        "660e8400-e29b-41d4-a716-446655440003",
 			RequestID: "550e8400-e29b-41d4-a716-446655440000",
-			ChannelID: "test-service",
 			Success:   true,
 			Result:    map[string]interface{}{"pong": true},
-			Timestamp: 1722248201,
+			Timestamp: "2024-07-29T10:16:41.000Z",
 		}
 		
 		encoded1, err := framing.EncodeMessage(request)
@@ -214,7 +211,7 @@ func TestMessageFraming_DecodeMessage(t *testing.T) {
 	})
 	
 	t.Run("should return error for incomplete message", func(t *testing.T) {
-		request := models.NewJanusRequest("test-service", "ping", nil, nil)
+		request := models.NewJanusRequest("ping", nil, nil)
 		encoded, err := framing.EncodeMessage(request)
 		if err != nil {
 			t.Fatalf("Failed to encode request: %v", err)
@@ -261,13 +258,13 @@ func TestMessageFraming_ExtractMessages(t *testing.T) {
 	framing := protocol.NewMessageFraming()
 	
 	t.Run("should extract multiple complete messages", func(t *testing.T) {
-		request := models.NewJanusRequest("test-service", "ping", nil, nil)
+		request := models.NewJanusRequest("ping", nil, nil)
 		response := &models.JanusResponse{
+			ID:        "660e8400-e29b-41d4-a716-446655440004",
 			RequestID: "550e8400-e29b-41d4-a716-446655440000",
-			ChannelID: "test-service",
 			Success:   true,
 			Result:    map[string]interface{}{"pong": true},
-			Timestamp: 1722248201,
+			Timestamp: "2024-07-29T10:16:41.000Z",
 		}
 		
 		encoded1, _ := framing.EncodeMessage(request)
@@ -297,13 +294,13 @@ func TestMessageFraming_ExtractMessages(t *testing.T) {
 	})
 	
 	t.Run("should handle partial messages", func(t *testing.T) {
-		request := models.NewJanusRequest("test-service", "ping", nil, nil)
+		request := models.NewJanusRequest("ping", nil, nil)
 		response := &models.JanusResponse{
+			ID:        "660e8400-e29b-41d4-a716-446655440005",
 			RequestID: "550e8400-e29b-41d4-a716-446655440000",
-			ChannelID: "test-service",
 			Success:   true,
 			Result:    map[string]interface{}{"pong": true},
-			Timestamp: 1722248201,
+			Timestamp: "2024-07-29T10:16:41.000Z",
 		}
 		
 		encoded1, _ := framing.EncodeMessage(request)
@@ -368,7 +365,7 @@ func TestMessageFraming_CalculateFramedSize(t *testing.T) {
 	framing := protocol.NewMessageFraming()
 	
 	t.Run("should calculate correct framed size", func(t *testing.T) {
-		request := models.NewJanusRequest("test-service", "ping", nil, nil)
+		request := models.NewJanusRequest("ping", nil, nil)
 		
 		size, err := framing.CalculateFramedSize(request)
 		if err != nil {
@@ -390,7 +387,7 @@ func TestMessageFraming_DirectMessage(t *testing.T) {
 	framing := protocol.NewMessageFraming()
 	
 	t.Run("should encode direct message without envelope", func(t *testing.T) {
-		request := models.NewJanusRequest("test-service", "ping", nil, nil)
+		request := models.NewJanusRequest("ping", nil, nil)
 		
 		encoded, err := framing.EncodeDirectMessage(request)
 		if err != nil {
@@ -409,7 +406,7 @@ func TestMessageFraming_DirectMessage(t *testing.T) {
 	})
 	
 	t.Run("should decode direct message without envelope", func(t *testing.T) {
-		originalRequest := models.NewJanusRequest("test-service", "ping", nil, nil)
+		originalRequest := models.NewJanusRequest("ping", nil, nil)
 		
 		encoded, err := framing.EncodeDirectMessage(originalRequest)
 		if err != nil {
@@ -436,7 +433,7 @@ func TestMessageFraming_DirectMessage(t *testing.T) {
 	})
 	
 	t.Run("should roundtrip request through direct encoding", func(t *testing.T) {
-		originalRequest := models.NewJanusRequest("test-service", "ping", nil, nil)
+		originalRequest := models.NewJanusRequest("ping", nil, nil)
 		
 		encoded, err := framing.EncodeDirectMessage(originalRequest)
 		if err != nil {
@@ -461,11 +458,11 @@ func TestMessageFraming_DirectMessage(t *testing.T) {
 	
 	t.Run("should roundtrip response through direct encoding", func(t *testing.T) {
 		originalResponse := &models.JanusResponse{
+			ID:        "660e8400-e29b-41d4-a716-446655440006",
 			RequestID: "550e8400-e29b-41d4-a716-446655440000",
-			ChannelID: "test-service",
 			Success:   true,
 			Result:    map[string]interface{}{"pong": true},
-			Timestamp: 1722248201,
+			Timestamp: "2024-07-29T10:16:41.000Z",
 		}
 		
 		encoded, err := framing.EncodeDirectMessage(originalResponse)
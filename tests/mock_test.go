@@ -0,0 +1,238 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/mock"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/server"
+)
+
+// generatedArgs builds arguments for every declared argument of a request
+func generatedArgs(generator *mock.Generator, request *manifest.RequestManifest) map[string]interface{} {
+	args := make(map[string]interface{}, len(request.Args))
+	for name, arg := range request.Args {
+		args[name] = generator.Argument(arg)
+	}
+	return args
+}
+
+// TestMockGeneratorConformsToManifest validates generated arguments and responses against their definitions
+func TestMockGeneratorConformsToManifest(t *testing.T) {
+	for name, content := range map[string]string{
+		"schema constructs":      schemaConstructsManifest,
+		"collection constraints": collectionConstraintsManifest,
+		"validation errors":      validationErrorsManifest,
+	} {
+		m, err := manifest.ParseJSONString(content)
+		if err != nil {
+			t.Fatalf("%s: failed to parse manifest: %v", name, err)
+		}
+		validator := manifest.NewResponseValidator(m)
+
+		for seed := int64(1); seed <= 20; seed++ {
+			generator := mock.NewGenerator(m, seed)
+			for requestName, request := range m.Requests {
+				if errors := m.ValidateRequestArgsAll(request, generatedArgs(generator, request)); errors != nil {
+					t.Errorf("%s seed %d: generated args for %s are invalid: %v", name, seed, requestName, errors)
+				}
+				if request.Response == nil {
+					continue
+				}
+				response, ok := generator.Response(request.Response).(map[string]interface{})
+				if !ok {
+					t.Errorf("%s seed %d: expected object response for %s", name, seed, requestName)
+					continue
+				}
+				if result := validator.ValidateResponse(response, request.Response); !result.Valid {
+					t.Errorf("%s seed %d: generated response for %s is invalid: %v", name, seed, requestName, result.Errors)
+				}
+			}
+		}
+	}
+
+	t.Log("✅ Generated arguments and responses conform to the manifest")
+}
+
+// TestMockGeneratorPatterns validates strings generated from patterns and formats
+func TestMockGeneratorPatterns(t *testing.T) {
+	generator := mock.NewGenerator(&manifest.Manifest{}, 7)
+	minLength, maxLength := 4, 24
+	patterns := []string{`^[a-z]+$`, `^[A-Z]{3}-\d{4}$`, `^(red|green|blue)$`, `^[^"\s]+@example\.org$`, `^v\d+\.\d+(\.\d+)?$`}
+
+	for _, pattern := range patterns {
+		arg := &manifest.ArgumentManifest{Name: "code", Type: "string", Pattern: pattern, MinLength: &minLength, MaxLength: &maxLength}
+		m := &manifest.Manifest{}
+		for i := 0; i < 20; i++ {
+			value := generator.Argument(arg)
+			if err := m.ValidateRequestArgs(&manifest.RequestManifest{Args: map[string]*manifest.ArgumentManifest{"code": arg}},
+				map[string]interface{}{"code": value}); err != nil {
+				t.Errorf("Pattern %s: generated %q is invalid: %v", pattern, value, err)
+				break
+			}
+		}
+	}
+
+	for _, format := range manifest.SupportedFormats() {
+		arg := &manifest.ArgumentManifest{Name: "value", Type: "string", Format: format}
+		value := generator.Argument(arg)
+		err := (&manifest.Manifest{}).ValidateRequestArgs(&manifest.RequestManifest{Args: map[string]*manifest.ArgumentManifest{"value": arg}},
+			map[string]interface{}{"value": value})
+		if err != nil {
+			t.Errorf("Format %s: generated %q is invalid: %v", format, value, err)
+		}
+	}
+
+	t.Log("✅ Generated strings satisfy patterns, length limits and formats")
+}
+
+// TestMockGeneratorDeterministic validates that equal seeds produce equal responses
+func TestMockGeneratorDeterministic(t *testing.T) {
+	m := parseSchemaConstructsManifest(t)
+	request, _ := m.GetRequest("draw")
+
+	first, _ := json.Marshal(mock.NewGenerator(m, 42).Response(request.Response))
+	second, _ := json.Marshal(mock.NewGenerator(m, 42).Response(request.Response))
+	if string(first) != string(second) {
+		t.Errorf("Expected equal output for equal seeds:\n%s\n%s", first, second)
+	}
+
+	t.Log("✅ Generated responses are deterministic per seed")
+}
+
+const mockScript = `
+latency: 5ms
+requests:
+  place_order:
+    fixtures:
+      - match: {customer: blocked}
+        error: {code: RESOURCE_NOT_FOUND, details: customer is blocked}
+      - match: {customer: fixed}
+        result: {order_id: "A-1"}
+      - match: {customer: slow}
+        latency: 150ms
+        result: {order_id: "S-1"}
+`
+
+// startMockServer starts a mock server for the validation errors manifest
+func startMockServer(t *testing.T, script *mock.Script) string {
+	t.Helper()
+	m, err := manifest.ParseJSONString(validationErrorsManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	scripted, err := mock.New(m, mock.Options{Seed: 1, Script: script})
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	_, socketPath, _ := startTestServer(t, nil, func(srv *server.JanusServer) {
+		if err := scripted.Register(srv); err != nil {
+			t.Fatalf("Failed to register mock: %v", err)
+		}
+	})
+	return socketPath
+}
+
+// TestMockServerFixtures validates scripted results, errors and latency over a socket
+func TestMockServerFixtures(t *testing.T) {
+	script, err := mock.ParseScript([]byte(mockScript))
+	if err != nil {
+		t.Fatalf("Failed to parse script: %v", err)
+	}
+	socketPath := startMockServer(t, script)
+
+	client, err := protocol.New(socketPath, protocol.DefaultJanusClientConfig())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	response, err := client.SendRequest(ctx, "place_order", map[string]interface{}{"customer": "fixed"})
+	if err != nil || !response.Success {
+		t.Fatalf("Expected fixture result, got %v %+v", err, response)
+	}
+	if result, _ := response.Result.(map[string]interface{}); result["order_id"] != "A-1" {
+		t.Errorf("Expected fixture result, got %v", response.Result)
+	}
+
+	response, err = client.SendRequest(ctx, "place_order", map[string]interface{}{"customer": "blocked"})
+	if err != nil || response.Error == nil || response.Error.Code != models.ResourceNotFound ||
+		!strings.Contains(response.Error.Error(), "customer is blocked") {
+		t.Errorf("Expected scripted RESOURCE_NOT_FOUND, got %v %+v", err, response)
+	}
+
+	start := time.Now()
+	if response, err = client.SendRequest(ctx, "place_order", map[string]interface{}{"customer": "slow"}); err != nil || !response.Success {
+		t.Fatalf("Expected slow fixture result, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("Expected fixture latency, request took %v", elapsed)
+	}
+
+	// Calls without a matching fixture get a generated response
+	response, err = client.SendRequest(ctx, "place_order", map[string]interface{}{"customer": "ann"})
+	if err != nil || !response.Success {
+		t.Fatalf("Expected generated response, got %v %+v", err, response)
+	}
+
+	t.Log("✅ Mock server answers with fixtures, scripted errors and latency")
+}
+
+// TestMockErrorInjection validates errorRate failures and argument validation in the handler
+func TestMockErrorInjection(t *testing.T) {
+	m, _ := manifest.ParseJSONString(validationErrorsManifest)
+	script := &mock.Script{Requests: map[string]*mock.RequestScript{
+		"place_order": {ErrorRate: 1, Error: &mock.ScriptedError{Code: "SERVICE_UNAVAILABLE"}},
+	}}
+	mocked, err := mock.New(m, mock.Options{Script: script})
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	handler := mocked.Handler("place_order")
+
+	result := handler.Handle(&models.JanusRequest{Request: "place_order", Args: map[string]interface{}{"customer": "ann"}})
+	if result.Error == nil || result.Error.Code != models.ServiceUnavailable {
+		t.Errorf("Expected injected SERVICE_UNAVAILABLE, got %+v", result)
+	}
+
+	result = handler.Handle(&models.JanusRequest{Request: "place_order", Args: map[string]interface{}{"priority": 9.0}})
+	if result.Error == nil || result.Error.Code != models.InvalidParams {
+		t.Errorf("Expected InvalidParams for invalid arguments, got %+v", result)
+	}
+
+	t.Log("✅ Mock handlers inject errors and validate arguments")
+}
+
+// TestMockScriptValidation validates rejection of scripts that do not fit the manifest
+func TestMockScriptValidation(t *testing.T) {
+	m, _ := manifest.ParseJSONString(validationErrorsManifest)
+	cases := map[string]string{
+		"unknown request":    "requests: {missing: {errorRate: 0.5}}",
+		"unknown error code": "requests: {place_order: {error: {code: NOT_A_CODE}}}",
+		"error rate above 1": "requests: {place_order: {errorRate: 2}}",
+		"result and error":   "requests: {place_order: {fixtures: [{result: {}, error: {code: -32603}}]}}",
+	}
+	for name, content := range cases {
+		script, err := mock.ParseScript([]byte(content))
+		if err != nil {
+			t.Fatalf("%s: failed to parse script: %v", name, err)
+		}
+		if _, err := mock.New(m, mock.Options{Script: script}); err == nil {
+			t.Errorf("%s: expected script to be rejected", name)
+		}
+	}
+
+	if _, err := mock.ParseScript([]byte("latency: soon")); err == nil {
+		t.Error("Expected invalid duration to be rejected")
+	}
+
+	t.Log("✅ Mock scripts are validated against the manifest")
+}
@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/openrpc"
+)
+
+const openRPCTestManifest = `{
+	"version": "1.4.0",
+	"name": "Inventory",
+	"description": "Inventory service",
+	"requests": {
+		"get_item": {
+			"name": "get_item",
+			"description": "Fetch an item",
+			"args": {
+				"sku": {"name": "sku", "type": "string", "description": "Stock keeping unit", "required": true},
+				"warehouse": {"name": "warehouse", "type": "string", "description": "Warehouse code"}
+			},
+			"response": {"type": "object", "description": "The item", "modelRef": "Item"},
+			"errorCodes": ["RESOURCE_NOT_FOUND", "-32005", "VALIDATION_ERROR", "NO_SUCH_CODE"]
+		},
+		"ping_stock": {
+			"name": "ping_stock",
+			"description": "Liveness check"
+		}
+	},
+	"models": {
+		"Item": {
+			"name": "Item",
+			"type": "object",
+			"description": "Inventory item",
+			"properties": {
+				"sku": {"name": "sku", "type": "string", "description": "SKU"},
+				"count": {"name": "count", "type": "integer", "description": "Units in stock"}
+			},
+			"required": ["sku"]
+		}
+	}
+}`
+
+// TestOpenRPCGenerate validates methods, params, results, errors and components
+func TestOpenRPCGenerate(t *testing.T) {
+	m, err := manifest.ParseJSONString(openRPCTestManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	doc, issues, err := openrpc.Generate(m, openrpc.Options{SocketPath: "/tmp/inventory.sock"})
+	if err != nil {
+		t.Fatalf("Failed to generate OpenRPC document: %v", err)
+	}
+
+	if doc.OpenRPC != openrpc.Version || doc.Info.Title != "Inventory" || doc.Info.Version != "1.4.0" {
+		t.Errorf("Unexpected document header: %+v", doc.Info)
+	}
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "unix:///tmp/inventory.sock" {
+		t.Errorf("Unexpected servers: %+v", doc.Servers)
+	}
+	if len(doc.Methods) != 2 || doc.Methods[0].Name != "get_item" {
+		t.Fatalf("Expected methods sorted by name, got %+v", doc.Methods)
+	}
+
+	method := doc.Methods[0]
+	if len(method.Params) != 2 || method.Params[0].Name != "sku" || !method.Params[0].Required {
+		t.Errorf("Unexpected params: %+v", method.Params)
+	}
+	if method.Result.Schema.Ref != "#/components/schemas/Item" {
+		t.Errorf("Expected result to reference components, got %q", method.Result.Schema.Ref)
+	}
+
+	codes := make([]int, 0, len(method.Errors))
+	for _, e := range method.Errors {
+		codes = append(codes, e.Code)
+	}
+	expected := []int{int(models.ResourceNotFound), int(models.ValidationFailed), int(models.ValidationFailed)}
+	if len(codes) != len(expected) || codes[0] != expected[0] || codes[1] != expected[1] || codes[2] != expected[2] {
+		t.Errorf("Expected error codes %v, got %v", expected, codes)
+	}
+	if method.Errors[0].Message != models.ResourceNotFound.Message() {
+		t.Errorf("Expected standard error message, got %q", method.Errors[0].Message)
+	}
+
+	if len(issues) != 1 || !strings.Contains(issues[0].Message, "NO_SUCH_CODE") {
+		t.Errorf("Expected one issue for the unknown error code, got %v", issues)
+	}
+
+	item := doc.Components.Schemas["Item"]
+	if item == nil || item.Properties["count"].Type != "integer" {
+		t.Errorf("Expected Item component schema, got %+v", item)
+	}
+
+	// Methods without a response still carry a result descriptor
+	if doc.Methods[1].Result == nil || doc.Methods[1].Result.Schema == nil {
+		t.Error("Expected result descriptor for method without response")
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal document: %v", err)
+	}
+	if !strings.Contains(string(data), `"paramStructure":"by-name"`) {
+		t.Errorf("Expected by-name params in %s", data)
+	}
+
+	t.Log("✅ OpenRPC document generated from manifest")
+}
+
+// TestParseJSONRPCErrorCode validates name, numeric and legacy lookups
+func TestParseJSONRPCErrorCode(t *testing.T) {
+	cases := map[string]models.JSONRPCErrorCode{
+		"METHOD_NOT_FOUND":          models.MethodNotFound,
+		"manifest_validation_error": models.ManifestValidationError,
+		"-32001":                    models.ServiceUnavailable,
+		"-32601":                    models.MethodNotFound,
+		"-32099":                    models.JSONRPCErrorCode(-32099),
+		"UNKNOWN_REQUEST":           models.MethodNotFound,
+		"INVALID_ARGUMENTS":         models.InvalidParams,
+	}
+	for name, expected := range cases {
+		code, ok := models.ParseJSONRPCErrorCode(name)
+		if !ok || code != expected {
+			t.Errorf("ParseJSONRPCErrorCode(%q) = %v, %v; expected %v", name, code, ok, expected)
+		}
+	}
+
+	if _, ok := models.ParseJSONRPCErrorCode("NOT_A_CODE"); ok {
+		t.Error("Expected unknown name to be rejected")
+	}
+	for _, number := range []string{"42", "-32100", "-32650", "0"} {
+		if _, ok := models.ParseJSONRPCErrorCode(number); ok {
+			t.Errorf("Expected out-of-range number %s to be rejected", number)
+		}
+	}
+
+	for _, code := range models.KnownJSONRPCErrorCodes() {
+		if parsed, ok := models.ParseJSONRPCErrorCode(code.String()); !ok || parsed != code {
+			t.Errorf("Expected %s to round-trip", code)
+		}
+	}
+}
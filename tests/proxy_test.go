@@ -0,0 +1,289 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/proxy"
+	"GoJanus/pkg/traffic"
+	"GoJanus/pkg/wire"
+)
+
+// startProxy starts a proxy and stops it when the test ends
+func startProxy(t *testing.T, config proxy.Config) *proxy.Proxy {
+	t.Helper()
+	if config.ListenPath == "" {
+		config.ListenPath = fmt.Sprintf("/tmp/proxy-test-%d.sock", time.Now().UnixNano())
+	}
+	janusProxy, err := proxy.New(config)
+	if err != nil {
+		t.Fatalf("Failed to create proxy: %v", err)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		janusProxy.StartListening()
+		close(stopped)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	t.Cleanup(func() {
+		janusProxy.Stop()
+		<-stopped
+	})
+	return janusProxy
+}
+
+// TestProxyRelaysAndLogs validates forwarding through the proxy with logging and dumping
+func TestProxyRelaysAndLogs(t *testing.T) {
+	upstreamPath, stop := startGreetingServer(t, "Hello", "")
+	defer stop()
+
+	listenPath := fmt.Sprintf("/tmp/proxy-test-%d.sock", time.Now().UnixNano())
+	dumpPath := filepath.Join(t.TempDir(), "dump.jsonl")
+	recorder, err := traffic.OpenRecorder(dumpPath)
+	if err != nil {
+		t.Fatalf("Failed to open dump: %v", err)
+	}
+	defer recorder.Close()
+
+	var log bytes.Buffer
+	janusProxy := startProxy(t, proxy.Config{
+		ListenPath:   listenPath,
+		UpstreamPath: upstreamPath,
+		Log:          &log,
+		Pretty:       true,
+		Dump:         recorder,
+	})
+
+	client := newTestClient(t, listenPath)
+	ctx := context.Background()
+	response, err := client.SendRequest(ctx, "greet", map[string]interface{}{"name": "Ada"})
+	if err != nil || !response.Success {
+		t.Fatalf("Expected response through the proxy, got %v %+v", err, response)
+	}
+	if result, _ := response.Result.(map[string]interface{}); result["greeting"] != "Hello, Ada" {
+		t.Errorf("Unexpected result through the proxy: %v", response.Result)
+	}
+	response, err = client.SendRequest(ctx, "greet", map[string]interface{}{})
+	if err != nil || response.Error == nil {
+		t.Fatalf("Expected relayed error, got %v %+v", err, response)
+	}
+
+	stats := janusProxy.Stats()
+	if stats.Requests != 2 || stats.Responses != 2 || stats.Dropped != 0 {
+		t.Errorf("Unexpected proxy stats: %+v", stats)
+	}
+	for _, expected := range []string{"→ greet", "← greet", "ok ", "error INVALID_PARAMS (-32602)", `"name": "Ada"`} {
+		if !strings.Contains(log.String(), expected) {
+			t.Errorf("Expected log to contain %q:\n%s", expected, log.String())
+		}
+	}
+
+	records, err := traffic.LoadRecords(dumpPath)
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Expected 2 dumped exchanges, got %d: %v", len(records), err)
+	}
+	if records[0].Request.ReplyTo == nil || *records[0].Request.ReplyTo == janusProxy.ReplyPath() {
+		t.Errorf("Expected the dump to keep the client's reply_to, got %v", records[0].Request.ReplyTo)
+	}
+
+	t.Log("✅ Proxy relays requests and responses, logging and dumping the traffic")
+}
+
+// TestProxyFaultInjection validates latency and loss for selected requests only
+func TestProxyFaultInjection(t *testing.T) {
+	upstreamPath, stop := startGreetingServer(t, "Hello", "")
+	defer stop()
+
+	listenPath := fmt.Sprintf("/tmp/proxy-test-%d.sock", time.Now().UnixNano())
+	janusProxy := startProxy(t, proxy.Config{
+		ListenPath:   listenPath,
+		UpstreamPath: upstreamPath,
+		Requests:     []string{"gree*"},
+		LossRate:     1,
+		Seed:         1,
+	})
+
+	config := protocol.DefaultJanusClientConfig()
+	config.EnableValidation = false
+	config.DatagramTimeout = 300 * time.Millisecond
+	client, err := protocol.New(listenPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	if _, err := client.SendRequest(ctx, "greet", map[string]interface{}{"name": "Ada"}); err == nil {
+		t.Error("Expected the dropped request to time out")
+	}
+	if response, err := client.SendRequest(ctx, "ping", nil); err != nil || !response.Success {
+		t.Errorf("Expected unselected requests to pass, got %v", err)
+	}
+	if stats := janusProxy.Stats(); stats.Dropped != 1 || stats.Requests != 1 || stats.Pending != 0 {
+		t.Errorf("Unexpected proxy stats: %+v", stats)
+	}
+
+	listenPath = fmt.Sprintf("/tmp/proxy-test-%d.sock", time.Now().UnixNano())
+	startProxy(t, proxy.Config{ListenPath: listenPath, UpstreamPath: upstreamPath, Latency: 100 * time.Millisecond})
+	start := time.Now()
+	if _, err := newTestClient(t, listenPath).SendRequest(ctx, "greet", map[string]interface{}{"name": "Ada"}); err != nil {
+		t.Fatalf("Request through delaying proxy failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected latency in both directions, round trip took %v", elapsed)
+	}
+
+	t.Log("✅ Proxy injects loss and latency into selected traffic")
+}
+
+// TestProxySeparatesClientsReusingIDs validates that responses reach the right client when two clients send the same id
+func TestProxySeparatesClientsReusingIDs(t *testing.T) {
+	upstreamPath, _ := startGreetingServer(t, "Hello", "")
+	listenPath := fmt.Sprintf("/tmp/proxy-test-%d.sock", time.Now().UnixNano())
+	janusProxy := startProxy(t, proxy.Config{ListenPath: listenPath, UpstreamPath: upstreamPath})
+
+	names := []string{"Ada", "Grace"}
+	replies := make([]*net.UnixConn, len(names))
+	for i, name := range names {
+		replyPath := fmt.Sprintf("/tmp/proxy-client-%d-%d.sock", time.Now().UnixNano(), i)
+		reply, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: replyPath, Net: "unixgram"})
+		if err != nil {
+			t.Fatalf("Failed to bind reply socket: %v", err)
+		}
+		defer os.Remove(replyPath)
+		defer reply.Close()
+		replies[i] = reply
+
+		request := models.NewJanusRequest("greet", map[string]interface{}{"name": name}, nil)
+		request.ID = "shared-id"
+		request.ReplyTo = &replyPath
+		data, err := wire.EncodeRequest(request)
+		if err != nil {
+			t.Fatalf("Failed to encode request: %v", err)
+		}
+		conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: listenPath, Net: "unixgram"})
+		if err != nil {
+			t.Fatalf("Failed to dial proxy: %v", err)
+		}
+		conn.Write(data)
+		conn.Close()
+	}
+
+	for i, reply := range replies {
+		buffer := make([]byte, 64*1024)
+		reply.SetReadDeadline(time.Now().Add(2 * time.Second))
+		n, err := reply.Read(buffer)
+		if err != nil {
+			t.Fatalf("Client %d got no response: %v", i, err)
+		}
+		response, err := wire.DecodeResponse(buffer[:n])
+		if err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		result, _ := response.Result.(map[string]interface{})
+		if response.RequestID != "shared-id" || result["greeting"] != "Hello, "+names[i] {
+			t.Errorf("Client %d got the wrong response: %s %v", i, response.RequestID, response.Result)
+		}
+	}
+	if stats := janusProxy.Stats(); stats.Responses != 2 || stats.Unmatched != 0 || stats.Pending != 0 {
+		t.Errorf("Unexpected proxy stats: %+v", stats)
+	}
+
+	t.Log("✅ Proxy keeps requests from different clients apart when they share an id")
+}
+
+// TestProxyRelaysCBOR validates that CBOR requests are rewritten and relayed in their encoding
+func TestProxyRelaysCBOR(t *testing.T) {
+	upstreamPath := startEncodingServer(t)
+	var log bytes.Buffer
+	listenPath := fmt.Sprintf("/tmp/proxy-test-%d.sock", time.Now().UnixNano())
+	janusProxy := startProxy(t, proxy.Config{ListenPath: listenPath, UpstreamPath: upstreamPath, Log: &log})
+
+	client := newTestClient(t, listenPath, wire.CBOR)
+	response, err := client.SendRequest(context.Background(), "checksum", map[string]interface{}{"data": []byte{40, 2}})
+	if err != nil || !response.Success {
+		t.Fatalf("Expected a CBOR response through the proxy, got %v %+v", err, response)
+	}
+	if result, _ := response.Result.(map[string]interface{}); result["sum"] != 42.0 {
+		t.Errorf("Unexpected result through the proxy: %v", response.Result)
+	}
+	// The client asks get_info for the server's encodings before its first CBOR request
+	if stats := janusProxy.Stats(); stats.Requests != 2 || stats.Responses != 2 || stats.Invalid != 0 {
+		t.Errorf("Unexpected proxy stats: %+v\n%s", stats, log.String())
+	}
+
+	t.Log("✅ Proxy relays CBOR traffic")
+}
+
+// TestProxyConfigValidation validates rejected proxy configurations
+func TestProxyConfigValidation(t *testing.T) {
+	cases := map[string]proxy.Config{
+		"missing upstream":  {ListenPath: "/tmp/a.sock"},
+		"same socket":       {ListenPath: "/tmp/a.sock", UpstreamPath: "/tmp/a.sock"},
+		"loss above 1":      {ListenPath: "/tmp/a.sock", UpstreamPath: "/tmp/b.sock", LossRate: 1.5},
+		"negative latency":  {ListenPath: "/tmp/a.sock", UpstreamPath: "/tmp/b.sock", Latency: -time.Second},
+		"malformed pattern": {ListenPath: "/tmp/a.sock", UpstreamPath: "/tmp/b.sock", Requests: []string{"[a-"}},
+	}
+	for name, config := range cases {
+		if _, err := proxy.New(config); err == nil {
+			t.Errorf("%s: expected configuration to be rejected", name)
+		}
+	}
+
+	janusProxy, err := proxy.New(proxy.Config{ListenPath: "/tmp/a.sock", UpstreamPath: "/tmp/b.sock"})
+	if err != nil || janusProxy.ReplyPath() != "/tmp/a.sock.reply" {
+		t.Errorf("Expected default reply path, got %v", err)
+	}
+
+	t.Log("✅ Proxy configuration is validated")
+}
+
+// TestProxyExpiresPendingRequests validates that requests without a response are forgotten after PendingTimeout
+func TestProxyExpiresPendingRequests(t *testing.T) {
+	// An upstream that never answers
+	upstreamPath := fmt.Sprintf("/tmp/proxy-silent-test-%d.sock", time.Now().UnixNano())
+	upstream, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: upstreamPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer os.Remove(upstreamPath)
+	defer upstream.Close()
+
+	listenPath := fmt.Sprintf("/tmp/proxy-test-%d.sock", time.Now().UnixNano())
+	janusProxy := startProxy(t, proxy.Config{
+		ListenPath:     listenPath,
+		UpstreamPath:   upstreamPath,
+		PendingTimeout: 400 * time.Millisecond,
+	})
+
+	config := protocol.DefaultJanusClientConfig()
+	config.EnableValidation = false
+	config.DatagramTimeout = 100 * time.Millisecond
+	client, err := protocol.New(listenPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+	if _, err := client.SendRequest(context.Background(), "greet", nil); err == nil {
+		t.Fatal("Expected the request to time out")
+	}
+	if stats := janusProxy.Stats(); stats.Pending != 1 {
+		t.Fatalf("Expected the request to be pending, got %+v", stats)
+	}
+
+	time.Sleep(800 * time.Millisecond)
+	if stats := janusProxy.Stats(); stats.Pending != 0 {
+		t.Errorf("Expected the request to expire after PendingTimeout, got %+v", stats)
+	}
+
+	t.Log("✅ Pending requests expire without further traffic")
+}
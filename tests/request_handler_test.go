@@ -74,7 +74,6 @@ func TestDirectValueHandlers(t *testing.T) {
 			// Create test request
 			testCmd := &models.JanusRequest{
 				ID:        "test-id",
-				ChannelID: "test-channel",
 				Request:   "test-request",
 				Args:      map[string]interface{}{},
 				ReplyTo:   stringPtr("/tmp/test-reply.sock"),
@@ -110,7 +109,6 @@ func TestCustomHandlerTypes(t *testing.T) {
 
 	testCmd := &models.JanusRequest{
 		ID:        "test-id",
-		ChannelID: "test-channel",
 		Request:   "get-user",
 		Args:      map[string]interface{}{},
 		ReplyTo:   stringPtr("/tmp/test-reply.sock"),
@@ -164,7 +162,6 @@ func TestAsyncHandlers(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			testCmd := &models.JanusRequest{
 				ID:        "test-id",
-				ChannelID: "test-channel",
 				Request:   "test-request",
 				Args:      map[string]interface{}{},
 				ReplyTo:   stringPtr("/tmp/test-reply.sock"),
@@ -200,7 +197,6 @@ func TestHandlerErrorHandling(t *testing.T) {
 
 	testCmd := &models.JanusRequest{
 		ID:        "test-id",
-		ChannelID: "test-channel",
 		Request:   "test-request",
 		Args:      map[string]interface{}{},
 		ReplyTo:   stringPtr("/tmp/test-reply.sock"),
@@ -260,7 +256,6 @@ func TestHandlerRegistry(t *testing.T) {
 	// Test execution of retrieved handler
 	testCmd := &models.JanusRequest{
 		ID:        "test-id",
-		ChannelID: "test-channel",
 		Request:   "test-request",
 		Args:      map[string]interface{}{},
 		ReplyTo:   stringPtr("/tmp/test-reply.sock"),
@@ -299,7 +294,6 @@ func TestJSONRPCErrorMapping(t *testing.T) {
 
 	testCmd := &models.JanusRequest{
 		ID:        "test-id",
-		ChannelID: "test-channel",
 		Request:   "test-request",
 		Args:      map[string]interface{}{},
 		ReplyTo:   stringPtr("/tmp/test-reply.sock"),
@@ -341,7 +335,6 @@ func TestHandlerArguments(t *testing.T) {
 
 	testCmd := &models.JanusRequest{
 		ID:        "test-id",
-		ChannelID: "test-channel",
 		Request:   "process-user",
 		Args: map[string]interface{}{
 			"name": "John",
@@ -0,0 +1,396 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"GoJanus/pkg/manifest"
+)
+
+const schemaConstructsManifest = `{
+	"version": "1.0.0",
+	"name": "Shapes",
+	"description": "Schema construct test",
+	"requests": {
+		"draw": {
+			"name": "draw",
+			"description": "Draw a shape",
+			"args": {
+				"shape": {
+					"name": "shape",
+					"description": "Shape to draw",
+					"required": true,
+					"oneOf": [{"modelRef": "Circle"}, {"modelRef": "Square"}],
+					"discriminator": {"propertyName": "kind", "mapping": {"circle": "Circle", "square": "Square"}}
+				},
+				"id": {"name": "id", "type": "string", "description": "Request id", "required": true, "format": "uuid"},
+				"note": {"name": "note", "type": "string", "description": "Optional note", "required": true, "nullable": true},
+				"mode": {"name": "mode", "description": "Fixed mode", "const": "fast"},
+				"size": {
+					"name": "size",
+					"description": "Pixels or a named size",
+					"anyOf": [{"type": "integer", "minimum": 1}, {"type": "string", "enum": ["small", "large"]}]
+				},
+				"level": {
+					"name": "level",
+					"description": "Level between 1 and 10",
+					"allOf": [{"type": "number", "minimum": 1}, {"type": "number", "maximum": 10}]
+				},
+				"origin": {
+					"name": "origin",
+					"description": "Address or host",
+					"oneOf": [{"type": "string", "format": "ipv4"}, {"type": "string", "format": "uri"}]
+				}
+			},
+			"response": {
+				"type": "object",
+				"description": "Drawing",
+				"properties": {
+					"shape": {"name": "shape", "description": "Drawn shape", "required": true, "oneOf": [{"modelRef": "Circle"}, {"modelRef": "Square"}], "discriminator": {"propertyName": "kind", "mapping": {"circle": "Circle", "square": "Square"}}},
+					"created": {"name": "created", "type": "string", "description": "Creation time", "required": true, "format": "date-time"},
+					"owner": {"name": "owner", "type": "string", "description": "Owner email", "required": true, "nullable": true, "format": "email"}
+				}
+			}
+		}
+	},
+	"models": {
+		"Circle": {
+			"name": "Circle",
+			"type": "object",
+			"description": "A circle",
+			"properties": {
+				"kind": {"name": "kind", "type": "string", "description": "Discriminator", "required": true, "const": "circle"},
+				"radius": {"name": "radius", "type": "number", "description": "Radius", "required": true, "minimum": 0}
+			}
+		},
+		"Square": {
+			"name": "Square",
+			"type": "object",
+			"description": "A square",
+			"properties": {
+				"kind": {"name": "kind", "type": "string", "description": "Discriminator", "required": true, "const": "square"},
+				"side": {"name": "side", "type": "number", "description": "Side length", "required": true, "minimum": 0}
+			}
+		}
+	}
+}`
+
+// parseSchemaConstructsManifest parses and validates the shared test manifest
+func parseSchemaConstructsManifest(t *testing.T) *manifest.Manifest {
+	m, err := manifest.ParseJSONString(schemaConstructsManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	return m
+}
+
+// validDrawArgs returns arguments that satisfy every constraint of the draw request
+func validDrawArgs() map[string]interface{} {
+	return map[string]interface{}{
+		"shape":  map[string]interface{}{"kind": "circle", "radius": 2.0},
+		"id":     "123e4567-e89b-12d3-a456-426614174000",
+		"note":   nil,
+		"mode":   "fast",
+		"size":   "small",
+		"level":  5.0,
+		"origin": "10.0.0.1",
+	}
+}
+
+// TestSchemaConstructsArgumentValidation validates composition, nullable, const and format on arguments
+func TestSchemaConstructsArgumentValidation(t *testing.T) {
+	m := parseSchemaConstructsManifest(t)
+	request, _ := m.GetRequest("draw")
+
+	if err := m.ValidateRequestArgs(request, validDrawArgs()); err != nil {
+		t.Fatalf("Expected valid arguments to pass, got %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		arg     string
+		value   interface{}
+		field   string
+		message string
+	}{
+		{"wrong variant fields", "shape", map[string]interface{}{"kind": "square", "radius": 2.0}, "shape.radius", "unknown property"},
+		{"unknown discriminator", "shape", map[string]interface{}{"kind": "triangle"}, "shape.kind", "does not select a oneOf variant"},
+		{"missing discriminator", "shape", map[string]interface{}{"radius": 2.0}, "shape.kind", "discriminator property must be a string"},
+		{"bad uuid", "id", "not-a-uuid", "id", "not a valid uuid"},
+		{"wrong const", "mode", "slow", "mode", "must equal constant fast"},
+		{"no anyOf match", "size", "medium", "size", "anyOf[1]"},
+		{"allOf violation", "level", 11.0, "level", "exceeds maximum"},
+		{"no oneOf match", "origin", "not an address", "origin", "none of the oneOf alternatives"},
+	}
+
+	for _, tc := range cases {
+		args := validDrawArgs()
+		args[tc.arg] = tc.value
+		err := m.ValidateRequestArgs(request, args)
+		validationErr, ok := err.(*manifest.ValidationError)
+		if !ok {
+			t.Errorf("%s: expected *ValidationError, got %v", tc.name, err)
+			continue
+		}
+		if validationErr.Field != tc.field || !strings.Contains(validationErr.Message, tc.message) {
+			t.Errorf("%s: expected %s error containing %q, got %s: %s", tc.name, tc.field, tc.message, validationErr.Field, validationErr.Message)
+		}
+	}
+
+	// Required but not nullable still rejects null
+	args := validDrawArgs()
+	args["id"] = nil
+	if err := m.ValidateRequestArgs(request, args); err == nil {
+		t.Error("Expected null for a non-nullable required argument to be rejected")
+	}
+
+	t.Log("✅ Argument validation enforces oneOf/anyOf/allOf, discriminators, nullable, const and format")
+}
+
+// TestSchemaConstructsResponseValidation validates the same constructs in the ResponseValidator
+func TestSchemaConstructsResponseValidation(t *testing.T) {
+	m := parseSchemaConstructsManifest(t)
+	validator := manifest.NewResponseValidator(m)
+	response := m.Requests["draw"].Response
+
+	valid := map[string]interface{}{
+		"shape":   map[string]interface{}{"kind": "square", "side": 3.0},
+		"created": "2024-01-02T03:04:05Z",
+		"owner":   nil,
+	}
+	if result := validator.ValidateResponse(valid, response); !result.Valid {
+		t.Fatalf("Expected valid response, got %v", result.Errors)
+	}
+
+	invalid := map[string]interface{}{
+		"shape":   map[string]interface{}{"kind": "square", "side": -1.0},
+		"created": "yesterday",
+		"owner":   "Ann <ann@example.com>",
+	}
+	result := validator.ValidateResponse(invalid, response)
+	fields := make(map[string]string)
+	for _, err := range result.Errors {
+		fields[err.Field] = err.Message
+	}
+	if len(result.Errors) != 3 || !strings.Contains(fields["shape.side"], "too small") ||
+		!strings.Contains(fields["created"], "date-time") || !strings.Contains(fields["owner"], "email") {
+		t.Errorf("Unexpected response errors: %v", fields)
+	}
+
+	t.Log("✅ Response validation enforces discriminated variants, nullable and format")
+}
+
+// TestSchemaConstructsAllOfClosedModels validates that allOf accepts the union of closed models' properties
+func TestSchemaConstructsAllOfClosedModels(t *testing.T) {
+	m, err := manifest.ParseJSONString(`{
+		"version": "1.0.0",
+		"name": "Tags",
+		"description": "allOf test",
+		"requests": {
+			"tag": {
+				"name": "tag",
+				"description": "Tag an item",
+				"args": {
+					"item": {"name": "item", "description": "Named and timestamped", "required": true,
+						"allOf": [{"modelRef": "Named"}, {"modelRef": "Timestamped"}]}
+				},
+				"response": {"type": "object", "description": "Tagged item", "properties": {
+					"item": {"name": "item", "description": "Named and timestamped", "required": true,
+						"allOf": [{"modelRef": "Named"}, {"modelRef": "Timestamped"}]}
+				}}
+			}
+		},
+		"models": {
+			"Named": {"name": "Named", "type": "object", "description": "Has a name", "additionalProperties": false,
+				"properties": {"name": {"name": "name", "type": "string", "description": "Name", "required": true}}},
+			"Timestamped": {"name": "Timestamped", "type": "object", "description": "Has a timestamp", "additionalProperties": false,
+				"properties": {"created": {"name": "created", "type": "string", "description": "Creation time", "required": true}}}
+		}
+	}`)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	request, _ := m.GetRequest("tag")
+	validator := manifest.NewResponseValidator(m)
+
+	valid := map[string]interface{}{"name": "a", "created": "today"}
+	if err := m.ValidateRequestArgs(request, map[string]interface{}{"item": valid}); err != nil {
+		t.Errorf("Expected properties declared across allOf members to pass, got %v", err)
+	}
+	if result := validator.ValidateResponse(map[string]interface{}{"item": valid}, request.Response); !result.Valid {
+		t.Errorf("Expected valid allOf response, got %v", result.Errors)
+	}
+
+	extra := map[string]interface{}{"name": "a", "created": "today", "color": "red"}
+	err = m.ValidateRequestArgs(request, map[string]interface{}{"item": extra})
+	validationErr, ok := err.(*manifest.ValidationError)
+	if !ok || validationErr.Field != "item.color" || validationErr.Message != "unknown property" {
+		t.Errorf("Expected item.color to be rejected as unknown, got %v", err)
+	}
+	result := validator.ValidateResponse(map[string]interface{}{"item": extra}, request.Response)
+	if len(result.Errors) != 1 || result.Errors[0].Field != "item.color" {
+		t.Errorf("Expected one response error for item.color, got %v", result.Errors)
+	}
+
+	err = m.ValidateRequestArgs(request, map[string]interface{}{"item": map[string]interface{}{"name": "a"}})
+	if validationErr, ok := err.(*manifest.ValidationError); !ok || validationErr.Field != "item.created" {
+		t.Errorf("Expected missing item.created to be reported, got %v", err)
+	}
+
+	t.Log("✅ allOf of closed models accepts declared properties and rejects the rest")
+}
+
+// TestSchemaConstructsManifestValidation validates definition checks for the new keywords
+func TestSchemaConstructsManifestValidation(t *testing.T) {
+	cases := map[string]string{
+		"unknown format":          `"format": "color", "type": "string"`,
+		"format on number":        `"format": "uuid", "type": "number"`,
+		"discriminator no oneOf":  `"type": "object", "discriminator": {"propertyName": "kind"}`,
+		"variant without model":   `"oneOf": [{"type": "string"}], "discriminator": {"propertyName": "kind"}`,
+		"mapping to non-variant":  `"oneOf": [{"modelRef": "Circle"}], "discriminator": {"propertyName": "kind", "mapping": {"x": "Square"}}`,
+		"const of wrong type":     `"type": "integer", "const": "one"`,
+		"untyped without keyword": `"description": "nothing"`,
+		"invalid alternative":     `"anyOf": [{"type": "set"}]`,
+	}
+
+	for name, arg := range cases {
+		content := strings.Replace(schemaConstructsManifest, `"name": "mode", "description": "Fixed mode", "const": "fast"`,
+			`"name": "mode", `+arg, 1)
+		if _, err := manifest.ParseJSONString(content); err == nil {
+			t.Errorf("%s: expected manifest to be rejected", name)
+		}
+	}
+
+	_, err := manifest.ParseJSONString(strings.Replace(schemaConstructsManifest, `{"type": "string", "enum": ["small", "large"]}`, `{"type": "set"}`, 1))
+	if err == nil || !strings.Contains(err.Error(), "request.draw.size.anyOf[1]") {
+		t.Errorf("Expected error path for the invalid alternative, got %v", err)
+	}
+
+	t.Log("✅ Manifest validation rejects malformed composition, format, const and discriminator definitions")
+}
+
+// TestSchemaConstructsRoundTrip validates JSON and YAML serialization of the new keywords
+func TestSchemaConstructsRoundTrip(t *testing.T) {
+	m := parseSchemaConstructsManifest(t)
+	parser := manifest.NewManifestParser()
+
+	yamlData, err := parser.SerializeToYAML(m)
+	if err != nil {
+		t.Fatalf("SerializeToYAML failed: %v", err)
+	}
+	fromYAML, err := parser.ParseYAML(yamlData)
+	if err != nil {
+		t.Fatalf("Failed to parse serialized YAML: %v\n%s", err, yamlData)
+	}
+
+	jsonData, err := parser.SerializeToJSON(fromYAML)
+	if err != nil {
+		t.Fatalf("SerializeToJSON failed: %v", err)
+	}
+	fromJSON, err := parser.ParseJSON(jsonData)
+	if err != nil {
+		t.Fatalf("Failed to parse serialized JSON: %v", err)
+	}
+
+	original, _ := json.Marshal(m)
+	roundTripped, _ := json.Marshal(fromJSON)
+	if string(original) != string(roundTripped) {
+		t.Errorf("Round trip changed the manifest:\n%s\n%s", original, roundTripped)
+	}
+
+	args := fromJSON.Requests["draw"].Args
+	if args["shape"].Discriminator == nil || args["shape"].Discriminator.Mapping["square"] != "Square" ||
+		!args["note"].Nullable || args["mode"].Const != "fast" || args["id"].Format != "uuid" || len(args["size"].AnyOf) != 2 {
+		t.Error("Expected composition, discriminator, nullable, const and format to survive the round trip")
+	}
+
+	// YAML-decoded manifests validate the same way
+	request, _ := fromYAML.GetRequest("draw")
+	if err := fromYAML.ValidateRequestArgs(request, validDrawArgs()); err != nil {
+		t.Errorf("Expected YAML manifest to accept valid arguments, got %v", err)
+	}
+
+	t.Log("✅ New schema keywords round-trip through JSON and YAML")
+}
+
+// TestSchemaConstructsJSONSchema validates JSON Schema export and import of the new keywords
+func TestSchemaConstructsJSONSchema(t *testing.T) {
+	m := parseSchemaConstructsManifest(t)
+
+	doc, issues := manifest.ToJSONSchema(m)
+	if len(issues) != 0 {
+		t.Errorf("Unexpected export issues: %v", issues)
+	}
+	data, _ := json.Marshal(doc)
+	for _, fragment := range []string{`"type":["string","null"]`, `"const":"fast"`, `"format":"uuid"`, `"discriminator":{"propertyName":"kind"`, `"#/$defs/Circle"`} {
+		if !strings.Contains(string(data), fragment) {
+			t.Errorf("Expected exported schema to contain %s", fragment)
+		}
+	}
+
+	imported, issues, err := manifest.FromJSONSchema(data)
+	if err != nil {
+		t.Fatalf("FromJSONSchema failed: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Unexpected import issues: %v", issues)
+	}
+	args := imported.Requests["draw"].Args
+	if !args["note"].Nullable || args["note"].Type != "string" || len(args["shape"].OneOf) != 2 ||
+		args["shape"].Discriminator.Mapping["circle"] != "Circle" || args["level"].Type != "" {
+		t.Errorf("Unexpected imported arguments: note=%+v shape=%+v", args["note"], args["shape"])
+	}
+
+	// Nullable model references are wrapped in anyOf with a null alternative
+	nullableRef := `{"$schema": "https://json-schema.org/draft/2020-12/schema", "title": "Refs", "x-janus-version": "1.0.0",
+		"$defs": {"Circle": {"type": "object", "properties": {"radius": {"type": "number"}}}},
+		"x-janus-requests": {"show": {"args": {"type": "object", "properties": {
+			"shape": {"description": "Optional shape", "anyOf": [{"$ref": "#/$defs/Circle"}, {"type": "null"}]}}}}}}`
+	imported, issues, err = manifest.FromJSONSchema([]byte(nullableRef))
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("Expected clean import, got %v %v", err, issues)
+	}
+	shape := imported.Requests["show"].Args["shape"]
+	if !shape.Nullable || shape.ModelRef != "Circle" || len(shape.AnyOf) != 0 || shape.Description != "Optional shape" {
+		t.Errorf("Expected nullable Circle reference, got %+v", shape)
+	}
+
+	t.Log("✅ JSON Schema export and import preserve composition, const, format and nullable")
+}
+
+// TestSchemaConstructsLintAndDiff validates lint rules and diff reporting for the new keywords
+func TestSchemaConstructsLintAndDiff(t *testing.T) {
+	m := parseSchemaConstructsManifest(t)
+	if report := manifest.Lint(m, nil); len(report.Issues) != 0 {
+		t.Errorf("Expected a clean lint report, got %v", report.Issues)
+	}
+
+	broken := decodeLintManifest(t, strings.Replace(schemaConstructsManifest, `"mapping": {"circle": "Circle", "square": "Square"}}
+				},`, `"mapping": {"circle": "Circle", "square": "Triangle"}}
+				},`, 1))
+	broken.Requests["draw"].Args["id"].Format = "guid"
+	rules := make(map[string]string)
+	for _, issue := range manifest.Lint(broken, nil).Issues {
+		rules[issue.Rule] = issue.Path
+	}
+	if rules["invalid-discriminator"] != "requests.draw.args.shape.discriminator.mapping.square" || rules["invalid-format"] != "requests.draw.args.id.format" {
+		t.Errorf("Unexpected lint issues: %v", rules)
+	}
+
+	next := parseSchemaConstructsManifest(t)
+	next.Version = "1.1.0"
+	next.Requests["draw"].Args["note"].Nullable = false
+	next.Requests["draw"].Args["size"].AnyOf = append(next.Requests["draw"].Args["size"].AnyOf, &manifest.ArgumentManifest{Type: "boolean"})
+	next.Requests["draw"].Response.Properties["owner"].Format = ""
+
+	kinds := make(map[string]manifest.ChangeSeverity)
+	for _, change := range manifest.Diff(m, next).Changes {
+		kinds[change.Kind] = change.Severity
+	}
+	if kinds["nullable_changed"] != manifest.ChangeBreaking || kinds["alternative_added"] != manifest.ChangeCompatible || kinds["format_changed"] != manifest.ChangeBreaking {
+		t.Errorf("Unexpected diff severities: %v", kinds)
+	}
+
+	t.Log("✅ Lint and diff understand composition, discriminator, nullable and format")
+}
@@ -120,10 +120,10 @@ func TestMultiClientConnectionManagement(t *testing.T) {
 			// Send request
 			cmd := models.JanusRequest{
 				ID:        fmt.Sprintf("test-%d-%d", clientID, time.Now().UnixNano()),
-				ChannelID: "test",
+				Method:    "ping",
 				Request:   "ping",
 				ReplyTo:   &responseSocketPath,
-				Timestamp: float64(time.Now().Unix()),
+				Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
 			}
 			
 			cmdData, _ := json.Marshal(cmd)
@@ -215,10 +215,10 @@ func TestEventDrivenArchitecture(t *testing.T) {
 	// Send ping request (built-in)
 	cmd := models.JanusRequest{
 		ID:        "event-test",
-		ChannelID: "test",
+		Method:    "ping",
 		Request:   "ping",
 		ReplyTo:   &responseSocketPath,
-		Timestamp: float64(time.Now().Unix()),
+		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
 	}
 	
 	cmdData, _ := json.Marshal(cmd)
@@ -334,10 +334,10 @@ func TestConnectionProcessingLoop(t *testing.T) {
 		
 		cmd := models.JanusRequest{
 			ID:        cmdID,
-			ChannelID: "test",
+			Method:    "track_test",
 			Request:   "track_test",
 			ReplyTo:   &responseSocketPath,
-			Timestamp: float64(time.Now().Unix()),
+			Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
 		}
 		
 		cmdData, _ := json.Marshal(cmd)
@@ -407,10 +407,10 @@ func TestErrorResponseGeneration(t *testing.T) {
 	
 	cmd := models.JanusRequest{
 		ID:        "error-test",
-		ChannelID: "test",
+		Method:    "nonexistent_request",
 		Request:   "nonexistent_request",
 		ReplyTo:   &responseSocketPath,
-		Timestamp: float64(time.Now().Unix()),
+		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
 	}
 	
 	cmdData, _ := json.Marshal(cmd)
@@ -485,10 +485,10 @@ func TestClientActivityTracking(t *testing.T) {
 		
 		cmd := models.JanusRequest{
 			ID:        fmt.Sprintf("activity-test-%d", i),
-			ChannelID: "test-client",  // Same channel = same client
+			Method:    "ping",
 			Request:   "ping",
 			ReplyTo:   &responseSocketPath,
-			Timestamp: float64(time.Now().Unix()),
+			Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
 		}
 		
 		cmdData, _ := json.Marshal(cmd)
@@ -545,11 +545,11 @@ func TestRequestExecutionWithTimeout(t *testing.T) {
 	
 	cmd := models.JanusRequest{
 		ID:        "timeout-test",
-		ChannelID: "test",
+		Method:    "slow_request",
 		Request:   "slow_request",
 		ReplyTo:   &responseSocketPath,
 		Timeout:   func() *float64 { v := 1.0; return &v }(), // 1 second timeout
-		Timestamp: float64(time.Now().Unix()),
+		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
 	}
 	
 	startTime := time.Now()
@@ -0,0 +1,206 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/mock"
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/shell"
+)
+
+// TestShellParseArgs validates JSON and key=value argument parsing
+func TestShellParseArgs(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		``:                                {},
+		`{"name": "Ada", "age": 36}`:      {"name": "Ada", "age": 36.0},
+		`name=Ada age=36 active=true`:     {"name": "Ada", "age": 36.0, "active": true},
+		`name="Ada Lovelace" code='42'`:   {"name": "Ada Lovelace", "code": "42"},
+		`tags=[1, "two"] meta={"a": "]"}`: {"tags": []interface{}{1.0, "two"}, "meta": map[string]interface{}{"a": "]"}},
+		`note=null path=/tmp/a\ b`:        {"note": nil, "path": "/tmp/a b"},
+		`quote="say \"hi\""`:              {"quote": `say "hi"`},
+	}
+	for input, expected := range cases {
+		args, err := shell.ParseArgs(input)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", input, err)
+			continue
+		}
+		if !reflect.DeepEqual(args, expected) {
+			t.Errorf("%q: expected %v, got %v", input, expected, args)
+		}
+	}
+
+	for _, input := range []string{`name`, `=value`, `a=1 a=2`, `name="open`, `tags=[1, 2`, `[1]`, `{"a": 1`} {
+		if _, err := shell.ParseArgs(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+
+	t.Log("✅ Shell arguments parse from JSON and key=value pairs")
+}
+
+// TestShellCompletion validates completion of requests, commands, argument keys and values
+func TestShellCompletion(t *testing.T) {
+	m, err := manifest.ParseJSONString(validationErrorsManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	m.Requests["configure"] = &manifest.RequestManifest{Name: "configure", Args: map[string]*manifest.ArgumentManifest{
+		"mode":    {Name: "mode", Type: "string", Enum: []string{"fast", "1", "slow mode"}},
+		"dry_run": {Name: "dry_run", Type: "boolean"},
+	}}
+	completer := shell.NewCompleter(m)
+
+	cases := []struct {
+		line     string
+		expected []string
+		start    int
+	}{
+		{"pl", []string{"place_order "}, 0},
+		{"p", []string{"ping ", "place_order "}, 0},
+		{".h", []string{".help ", ".history "}, 0},
+		{"place_order cu", []string{"customer="}, 12},
+		{"place_order customer=x ", []string{"items=", "priority="}, 23},
+		{".describe pl", []string{"place_order"}, 10},
+		{"configure mode=", []string{`mode=fast `, `mode="1" `, `mode="slow mode" `}, 10},
+		{"configure dry_run=t", []string{"dry_run=true "}, 10},
+		{"configure {\"mo", nil, 10},
+		{"unknown ar", nil, 8},
+	}
+	for _, c := range cases {
+		candidates, start := completer.Complete(c.line)
+		if !reflect.DeepEqual(candidates, c.expected) || start != c.start {
+			t.Errorf("%q: expected %q at %d, got %q at %d", c.line, c.expected, c.start, candidates, start)
+		}
+	}
+
+	t.Log("✅ Completion covers requests, shell commands, argument keys and values")
+}
+
+// TestShellLineEditor validates editing keys, completion and history browsing
+func TestShellLineEditor(t *testing.T) {
+	m, _ := manifest.ParseJSONString(validationErrorsManifest)
+	history := shell.NewHistory(0)
+	history.Add("ping")
+	history.Add("echo message=hi")
+
+	cases := map[string]string{
+		"pl\tcust\tAda\r":          "place_order customer=Ada",
+		"ac\x1b[Db\r":              "abc",
+		"\x1b[A\x1b[A\r":           "ping",
+		"\x1b[A\x1b[A\x1b[B\r":     "echo message=hi",
+		"one two\x17three\r":       "one three",
+		"abc\x01x\x05y\x7f\x7fz\n": "xabz",
+	}
+	for input, expected := range cases {
+		var out bytes.Buffer
+		editor := shell.NewLineEditor(strings.NewReader(input), &out, history, shell.NewCompleter(m))
+		line, err := editor.ReadLine("> ")
+		if err != nil || line != expected {
+			t.Errorf("%q: expected %q, got %q (%v)", input, expected, line, err)
+		}
+	}
+
+	editor := shell.NewLineEditor(strings.NewReader("abc\x03\x04"), io.Discard, nil, nil)
+	if _, err := editor.ReadLine("> "); err != shell.ErrInterrupted {
+		t.Errorf("Expected Ctrl-C to interrupt, got %v", err)
+	}
+	if _, err := editor.ReadLine("> "); err != io.EOF {
+		t.Errorf("Expected Ctrl-D to end input, got %v", err)
+	}
+
+	t.Log("✅ Line editor handles keys, completion and history")
+}
+
+// TestShellExecute validates requests, validation errors and commands against a mock server
+func TestShellExecute(t *testing.T) {
+	script, err := mock.ParseScript([]byte(mockScript))
+	if err != nil {
+		t.Fatalf("Failed to parse script: %v", err)
+	}
+	socketPath := startMockServer(t, script)
+
+	client, err := protocol.New(socketPath, protocol.DefaultJanusClientConfig())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+	m, err := client.LoadManifest()
+	if err != nil || m == nil || !m.HasRequest("place_order") {
+		t.Fatalf("Expected the server manifest, got %v", err)
+	}
+
+	var out bytes.Buffer
+	janusShell := shell.New(client, m, &out, shell.Config{})
+	ctx := context.Background()
+	expectations := []struct {
+		line     string
+		contains []string
+	}{
+		{"place_order customer=fixed", []string{`"order_id": "A-1"`}},
+		{"place_order priority=9 items=[{\"name\": \"X\"}]", []string{"(not sent)", "/customer:", "/priority:", "/items/0/name:"}},
+		{"place_order customer=blocked", []string{"error RESOURCE_NOT_FOUND (-32004)", "customer is blocked"}},
+		{"place_order customer=\"open", []string{"error: unterminated"}},
+		{".describe place_order", []string{"customer: string (required)", "items: array"}},
+		{".requests", []string{"place_order", "ping"}},
+		{".history", []string{"1  place_order customer=fixed"}},
+	}
+	for _, expectation := range expectations {
+		out.Reset()
+		if janusShell.Execute(ctx, expectation.line) {
+			t.Errorf("%q: unexpected exit", expectation.line)
+		}
+		for _, text := range expectation.contains {
+			if !strings.Contains(out.String(), text) {
+				t.Errorf("%q: expected output to contain %q:\n%s", expectation.line, text, out.String())
+			}
+		}
+	}
+	if !janusShell.Execute(ctx, ".exit") {
+		t.Error("Expected .exit to end the shell")
+	}
+
+	out.Reset()
+	if err := janusShell.Run(ctx, strings.NewReader("ping\n.exit\nping\n")); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if strings.Count(out.String(), `"message": "pong"`) != 1 {
+		t.Errorf("Expected one ping before .exit, got:\n%s", out.String())
+	}
+
+	t.Log("✅ Shell sends requests, validates arguments and prints errors with code names")
+}
+
+// TestShellHistoryFile validates history persistence, deduplication and limits
+func TestShellHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	history, err := shell.LoadHistory(path, 3)
+	if err != nil {
+		t.Fatalf("Failed to load missing history file: %v", err)
+	}
+	for _, line := range []string{"a", "b", "b", " ", "c", "d"} {
+		if err := history.Add(line); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if entries := history.Entries(); !reflect.DeepEqual(entries, []string{"b", "c", "d"}) {
+		t.Errorf("Expected the last 3 distinct entries, got %v", entries)
+	}
+
+	reloaded, err := shell.LoadHistory(path, 3)
+	if err != nil {
+		t.Fatalf("Failed to reload history: %v", err)
+	}
+	if entries := reloaded.Entries(); !reflect.DeepEqual(entries, []string{"b", "c", "d"}) {
+		t.Errorf("Expected history to persist, got %v", entries)
+	}
+
+	t.Log("✅ History persists across sessions within its limit")
+}
@@ -22,7 +22,7 @@ func TestRequestValidationWithoutConnection(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createStatelessTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "stateless-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -72,7 +72,7 @@ func TestIndependentRequestExecution(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createStatelessTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "stateless-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -121,23 +121,18 @@ func TestChannelIsolationBetweenClients(t *testing.T) {
 	_ = createMultiChannelManifest() // Load manifest but don't use it - manifest is now fetched dynamically
 	
 	// Create clients for different channels
-	client1, err := protocol.New(testSocketPath1, "channel-1")
+	client1, err := protocol.New(testSocketPath1)
 	if err != nil {
 		t.Fatalf("Failed to create client1: %v", err)
 	}
 	defer client1.Close()
 	
-	client2, err := protocol.New(testSocketPath2, "channel-2")
+	client2, err := protocol.New(testSocketPath2)
 	if err != nil {
 		t.Fatalf("Failed to create client2: %v", err)
 	}
 	defer client2.Close()
 	
-	// Verify channel isolation
-	if client1.ChannelIdentifier() == client2.ChannelIdentifier() {
-		t.Error("Clients should have different channel identifiers")
-	}
-	
 	ctx := context.Background()
 	
 	// Client1 should be able to call channel-1 requests
@@ -178,7 +173,7 @@ func TestArgumentValidationInStatelessMode(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createStatelessTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "stateless-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -244,7 +239,7 @@ func TestMessageSerializationForStatelessOperations(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createStatelessTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "stateless-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -286,27 +281,18 @@ func TestMultiChannelManifestHandling(t *testing.T) {
 	_ = createMultiChannelManifest() // Load manifest but don't use it - manifest is now fetched dynamically
 	
 	// Test creating clients for different channels
-	client1, err := protocol.New(testSocketPath, "channel-1")
+	client1, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client for channel-1: %v", err)
 	}
 	defer client1.Close()
 	
-	client2, err := protocol.New(testSocketPath, "channel-2")
+	client2, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client for channel-2: %v", err)
 	}
 	defer client2.Close()
 	
-	// Verify clients have correct channel identifiers
-	if client1.ChannelIdentifier() != "channel-1" {
-		t.Errorf("Expected channel-1, got %s", client1.ChannelIdentifier())
-	}
-	
-	if client2.ChannelIdentifier() != "channel-2" {
-		t.Errorf("Expected channel-2, got %s", client2.ChannelIdentifier())
-	}
-	
 	// Verify clients have access to the same manifest
 	if client1.Manifest() != client2.Manifest() {
 		t.Error("Clients should share the same manifest reference")
@@ -343,25 +329,20 @@ func TestStatelessRequestUUIDGeneration(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createStatelessTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "stateless-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
-	// Note: SOCK_DGRAM clients are connectionless and don't need explicit cleanup
-	
-	// Verify client was created properly
-	if client.ChannelIdentifier() != "stateless-channel" {
-		t.Errorf("Expected channel ID 'stateless-channel', got %s", client.ChannelIdentifier())
-	}
+	defer client.Close()
 	
 	// Create multiple requests and verify they have different UUIDs
 	args := map[string]interface{}{
 		"test_param": "value",
 	}
 	
-	request1 := models.NewJanusRequest("stateless-channel", "stateless-request", args, nil)
-	request2 := models.NewJanusRequest("stateless-channel", "stateless-request", args, nil)
-	request3 := models.NewJanusRequest("stateless-channel", "stateless-request", args, nil)
+	request1 := models.NewJanusRequest("stateless-request", args, nil)
+	request2 := models.NewJanusRequest("stateless-request", args, nil)
+	request3 := models.NewJanusRequest("stateless-request", args, nil)
 	
 	// Verify UUIDs are different
 	if request1.ID == request2.ID {
@@ -397,7 +378,7 @@ func TestChannelIsolationValidation(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createMultiChannelManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "channel-1")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -428,52 +409,6 @@ func createStatelessTestManifest() *manifest.Manifest {
 		Version:     "1.0.0",
 		Name:        "Stateless Test API",
 		Description: "Manifest for stateless communication testing",
-		Channels: map[string]*manifest.ChannelManifest{
-			"stateless-channel": {
-				Name:        "Stateless Channel",
-				Description: "Channel for stateless testing",
-				Requests: map[string]*manifest.RequestManifest{
-					"stateless-request": {
-						Name:        "Stateless Request",
-						Description: "Request for stateless testing",
-						Args: map[string]*manifest.ArgumentManifest{
-							"test_param": {
-								Name:        "Test Parameter",
-								Type:        "string",
-								Description: "Test parameter",
-								Required:    true,
-							},
-						},
-						Response: &manifest.ResponseManifest{
-							Type:        "object",
-							Description: "Test response",
-						},
-					},
-					"validation-request": {
-						Name:        "Validation Request",
-						Description: "Request for validation testing",
-						Args: map[string]*manifest.ArgumentManifest{
-							"required_param": {
-								Name:        "Required Parameter",
-								Type:        "string",
-								Description: "Required parameter",
-								Required:    true,
-							},
-							"optional_param": {
-								Name:        "Optional Parameter",
-								Type:        "string",
-								Description: "Optional parameter",
-								Required:    false,
-							},
-						},
-						Response: &manifest.ResponseManifest{
-							Type:        "object",
-							Description: "Validation response",
-						},
-					},
-				},
-			},
-		},
 	}
 }
 
@@ -483,51 +418,5 @@ func createMultiChannelManifest() *manifest.Manifest {
 		Version:     "1.0.0",
 		Name:        "Multi-Channel Test API",
 		Description: "Manifest with multiple channels",
-		Channels: map[string]*manifest.ChannelManifest{
-			"channel-1": {
-				Name:        "Channel 1",
-				Description: "First test channel",
-				Requests: map[string]*manifest.RequestManifest{
-					"request-1": {
-						Name:        "Request 1",
-						Description: "First channel request",
-						Args: map[string]*manifest.ArgumentManifest{
-							"param1": {
-								Name:        "Parameter 1",
-								Type:        "string",
-								Description: "First parameter",
-								Required:    true,
-							},
-						},
-						Response: &manifest.ResponseManifest{
-							Type:        "object",
-							Description: "Response from channel 1",
-						},
-					},
-				},
-			},
-			"channel-2": {
-				Name:        "Channel 2",
-				Description: "Second test channel",
-				Requests: map[string]*manifest.RequestManifest{
-					"request-2": {
-						Name:        "Request 2",
-						Description: "Second channel request",
-						Args: map[string]*manifest.ArgumentManifest{
-							"param2": {
-								Name:        "Parameter 2",
-								Type:        "string",
-								Description: "Second parameter",
-								Required:    true,
-							},
-						},
-						Response: &manifest.ResponseManifest{
-							Type:        "object",
-							Description: "Response from channel 2",
-						},
-					},
-				},
-			},
-		},
 	}
 }
\ No newline at end of file
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/server"
+	"GoJanus/pkg/wire"
+)
+
+// startTestServer starts a server on a unique socket after register installs its handlers
+// The returned stop waits for the listener to exit; it also runs on cleanup, so calling it is optional
+func startTestServer(t *testing.T, config *server.ServerConfig, register func(*server.JanusServer)) (*server.JanusServer, string, func()) {
+	t.Helper()
+	if config == nil {
+		config = &server.ServerConfig{}
+	}
+	if config.SocketPath == "" {
+		config.SocketPath = fmt.Sprintf("/tmp/janus-test-%d.sock", time.Now().UnixNano())
+	}
+	config.CleanupOnStart = true
+	config.CleanupOnShutdown = true
+
+	srv := server.NewJanusServer(config)
+	if register != nil {
+		register(srv)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.StartListening()
+		close(stopped)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			srv.Stop()
+			<-stopped
+			os.Remove(config.SocketPath)
+		})
+	}
+	t.Cleanup(stop)
+	return srv, config.SocketPath, stop
+}
+
+// newTestClient creates a client that sends without fetching a manifest, in the given encoding if any
+func newTestClient(t *testing.T, socketPath string, encoding ...wire.Encoding) *protocol.JanusClient {
+	t.Helper()
+	config := protocol.DefaultJanusClientConfig()
+	config.EnableValidation = false
+	if len(encoding) > 0 {
+		config.Encoding = encoding[0]
+	}
+	client, err := protocol.New(socketPath, config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
@@ -23,7 +23,7 @@ func TestRequestTimeoutConfiguration(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createTimeoutTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "timeout-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -77,7 +77,7 @@ func TestTimeoutCallbackMechanisms(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createTimeoutTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "timeout-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -115,7 +115,7 @@ func TestUUIDGeneration(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createTimeoutTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "timeout-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -126,9 +126,9 @@ func TestUUIDGeneration(t *testing.T) {
 		"test_param": "value",
 	}
 	
-	request1 := models.NewJanusRequest("timeout-channel", "timeout-request", args, nil)
-	request2 := models.NewJanusRequest("timeout-channel", "timeout-request", args, nil)
-	request3 := models.NewJanusRequest("timeout-channel", "timeout-request", args, nil)
+	request1 := models.NewJanusRequest("timeout-request", args, nil)
+	request2 := models.NewJanusRequest("timeout-request", args, nil)
+	request3 := models.NewJanusRequest("timeout-request", args, nil)
 	
 	// Verify UUIDs are different
 	if request1.ID == request2.ID {
@@ -168,7 +168,7 @@ func TestMultipleConcurrentTimeouts(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createTimeoutTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "timeout-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -214,7 +214,7 @@ func TestDefaultTimeoutBehavior(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createTimeoutTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "timeout-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -289,7 +289,7 @@ func TestJanusRequestTimeoutFieldSerialization(t *testing.T) {
 	
 	// Test request with timeout
 	timeout := 45.0
-	requestWithTimeout := models.NewJanusRequest("timeout-channel", "timeout-request", args, &timeout)
+	requestWithTimeout := models.NewJanusRequest("timeout-request", args, &timeout)
 	
 	// Serialize to JSON
 	jsonData, err := requestWithTimeout.ToJSON()
@@ -312,7 +312,7 @@ func TestJanusRequestTimeoutFieldSerialization(t *testing.T) {
 	}
 	
 	// Test request without timeout
-	requestWithoutTimeout := models.NewJanusRequest("timeout-channel", "timeout-request", args, nil)
+	requestWithoutTimeout := models.NewJanusRequest("timeout-request", args, nil)
 	
 	// Serialize to JSON
 	jsonData, err = requestWithoutTimeout.ToJSON()
@@ -343,7 +343,7 @@ func TestTimeoutValidation(t *testing.T) {
 	defer os.Remove(testSocketPath)
 	
 	_ = createTimeoutTestManifest() // Load manifest but don't use it - manifest is now fetched dynamically
-	client, err := protocol.New(testSocketPath, "timeout-channel")
+	client, err := protocol.New(testSocketPath)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -459,30 +459,5 @@ func createTimeoutTestManifest() *manifest.Manifest {
 		Version:     "1.0.0",
 		Name:        "Timeout Test API",
 		Description: "Manifest for timeout testing",
-		Channels: map[string]*manifest.ChannelManifest{
-			"timeout-channel": {
-				Name:        "Timeout Channel",
-				Description: "Channel for timeout testing",
-				Requests: map[string]*manifest.RequestManifest{
-					"timeout-request": {
-						Name:        "Timeout Request",
-						Description: "Request for timeout testing",
-						Args: map[string]*manifest.ArgumentManifest{
-							"test_param": {
-								Name:        "Test Parameter",
-								Type:        "string",
-								Description: "Test parameter for timeout request",
-								Required:    true,
-							},
-						},
-						Response: &manifest.ResponseManifest{
-							Type:        "object",
-							Description: "Timeout test response",
-						},
-						ErrorCodes: []string{"REQUEST_TIMEOUT", "CONNECTION_ERROR"},
-					},
-				},
-			},
-		},
 	}
 }
\ No newline at end of file
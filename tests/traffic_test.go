@@ -0,0 +1,176 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/server"
+	"GoJanus/pkg/traffic"
+)
+
+// startGreetingServer serves a "greet" request answered with the given salutation
+func startGreetingServer(t *testing.T, salutation, recordPath string) (string, func()) {
+	t.Helper()
+	_, socketPath, stop := startTestServer(t, &server.ServerConfig{RecordPath: recordPath}, func(srv *server.JanusServer) {
+		srv.RegisterHandler("greet", server.SyncHandler(func(cmd *models.JanusRequest) server.HandlerResult {
+			name, _ := cmd.Args["name"].(string)
+			if name == "" {
+				return server.HandlerResult{Error: models.NewJSONRPCError(models.InvalidParams, "name is required")}
+			}
+			return server.HandlerResult{Value: map[string]interface{}{
+				"greeting":  salutation + ", " + name,
+				"timestamp": time.Now().UnixNano(),
+			}}
+		}))
+	})
+	return socketPath, stop
+}
+
+// recordGreetings captures two successful greetings and one error
+func recordGreetings(t *testing.T) string {
+	t.Helper()
+	capturePath := filepath.Join(t.TempDir(), "capture.jsonl")
+	socketPath, stop := startGreetingServer(t, "Hello", capturePath)
+
+	client := newTestClient(t, socketPath)
+	ctx := context.Background()
+	for _, args := range []map[string]interface{}{{"name": "Ada"}, {"name": "Grace"}, {}} {
+		if _, err := client.SendRequest(ctx, "greet", args); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+	stop()
+	return capturePath
+}
+
+// greetRecords keeps the captured greet exchanges, dropping built-in traffic
+func greetRecords(t *testing.T, path string) []*traffic.Record {
+	t.Helper()
+	records, err := traffic.LoadRecords(path)
+	if err != nil {
+		t.Fatalf("Failed to load capture: %v", err)
+	}
+	var greets []*traffic.Record
+	for _, record := range records {
+		if record.Request.Request == "greet" {
+			greets = append(greets, record)
+		}
+	}
+	return greets
+}
+
+// TestTrafficRecording validates that the server captures request/response pairs with timing
+func TestTrafficRecording(t *testing.T) {
+	records := greetRecords(t, recordGreetings(t))
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 captured greet requests, got %d", len(records))
+	}
+
+	first := records[0]
+	if first.Request.Args["name"] != "Ada" || first.Response == nil || !first.Response.Success {
+		t.Errorf("Unexpected first record: %+v", first)
+	}
+	if first.Response.RequestID != first.Request.ID {
+		t.Errorf("Expected response to correlate with request %s, got %s", first.Request.ID, first.Response.RequestID)
+	}
+	if first.Time.IsZero() || first.DurationMs < 0 {
+		t.Errorf("Expected timing information, got time %v duration %v", first.Time, first.DurationMs)
+	}
+	if failed := records[2].Response; failed == nil || failed.Error == nil || failed.Error.Code != models.InvalidParams {
+		t.Errorf("Expected captured InvalidParams error, got %+v", failed)
+	}
+
+	t.Log("✅ Server records every request/response pair with timing")
+}
+
+// TestTrafficReplay validates replaying a capture against matching and changed servers
+func TestTrafficReplay(t *testing.T) {
+	records := greetRecords(t, recordGreetings(t))
+
+	socketPath, stop := startGreetingServer(t, "Hello", "")
+	report := traffic.Replay(context.Background(), newTestClient(t, socketPath), records, traffic.ReplayOptions{})
+	stop()
+	if !report.OK() || report.Matched != 3 {
+		t.Errorf("Expected identical server to match, got %s: %+v", report.Summary(), report.Results)
+	}
+
+	socketPath, stop = startGreetingServer(t, "Hi", "")
+	defer stop()
+	report = traffic.Replay(context.Background(), newTestClient(t, socketPath), records, traffic.ReplayOptions{})
+	if report.OK() || report.Mismatched != 2 || report.Matched != 1 {
+		t.Fatalf("Expected two mismatches, got %s", report.Summary())
+	}
+	differences := report.Results[0].Differences
+	if len(differences) != 1 || differences[0].Path != "/result/greeting" || differences[0].Expected != "Hello, Ada" {
+		t.Errorf("Expected a single greeting difference, got %+v", differences)
+	}
+
+	t.Log("✅ Replay reports responses that changed and ignores volatile fields")
+}
+
+// TestTrafficDiffIgnore validates the ignore rules for volatile fields
+func TestTrafficDiffIgnore(t *testing.T) {
+	expected := &models.JanusResponse{
+		Success: true, ID: "a", RequestID: "r1", Timestamp: "t1",
+		Result: map[string]interface{}{"id": 1, "items": []interface{}{"x", "y"}, "meta": map[string]interface{}{"timestamp": 1}},
+	}
+	actual := &models.JanusResponse{
+		Success: true, ID: "b", RequestID: "r2", Timestamp: "t2",
+		Result: map[string]interface{}{"id": 2, "items": []interface{}{"x"}, "meta": map[string]interface{}{"timestamp": 2}},
+	}
+
+	differences, err := traffic.DiffResponses(expected, actual, nil)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	var paths []string
+	for _, difference := range differences {
+		paths = append(paths, difference.Path)
+	}
+	if strings.Join(paths, " ") != "/result/id /result/items/1" {
+		t.Errorf("Expected result id and missing item only, got %v", paths)
+	}
+
+	differences, _ = traffic.DiffResponses(expected, actual, []string{"/id", "/request_id", "timestamp", "/result/id", "items"})
+	if len(differences) != 0 {
+		t.Errorf("Expected custom ignores to hide all differences, got %+v", differences)
+	}
+
+	t.Log("✅ Response diffs skip ignored fields by pointer and by name")
+}
+
+// TestTrafficRecordFormat validates the JSONL encoding round trip
+func TestTrafficRecordFormat(t *testing.T) {
+	var buffer bytes.Buffer
+	recorder := traffic.NewRecorder(&buffer)
+	request := models.NewJanusRequest("greet", map[string]interface{}{"name": "Ada"}, nil)
+	response := models.NewSuccessResponse(request.ID, "ok")
+	started := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := recorder.Record(request, response, "client", started, 1500*time.Microsecond); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	if lines := strings.Count(buffer.String(), "\n"); lines != 2 {
+		t.Errorf("Expected one line per record, got %d", lines)
+	}
+	records, err := traffic.ReadRecords(&buffer)
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d: %v", len(records), err)
+	}
+	if records[0].Duration() != 1500*time.Microsecond || records[0].Client != "client" {
+		t.Errorf("Unexpected decoded record: %+v", records[0])
+	}
+
+	if _, err := traffic.ReadRecords(strings.NewReader("{\"time\": \"2024-01-01T00:00:00Z\"}\n")); err == nil {
+		t.Error("Expected records without a request to be rejected")
+	}
+
+	t.Log("✅ Capture records round-trip through JSONL")
+}
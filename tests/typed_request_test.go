@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+	"GoJanus/pkg/server"
+)
+
+type typedGreetArgs struct {
+	Name  string `json:"name"`
+	Times int    `json:"times,omitempty"`
+}
+
+type typedGreeting struct {
+	Message string   `json:"message"`
+	Parts   []string `json:"parts"`
+}
+
+// startTypedTestServer starts a server with typed handlers on a unique socket
+func startTypedTestServer(t *testing.T) string {
+	t.Helper()
+	_, socketPath, _ := startTestServer(t, nil, func(srv *server.JanusServer) {
+		srv.RegisterHandler("greet", server.NewTypedHandler(func(cmd *models.JanusRequest, args typedGreetArgs) (typedGreeting, error) {
+			if args.Name == "" {
+				return typedGreeting{}, models.NewJSONRPCError(models.ValidationFailed, "name is required")
+			}
+			if args.Times < 0 {
+				return typedGreeting{}, fmt.Errorf("greet %s: %w", args.Name, models.NewJSONRPCError(models.ValidationFailed, "times cannot be negative"))
+			}
+			times := args.Times
+			if times == 0 {
+				times = 1
+			}
+			parts := make([]string, times)
+			for i := range parts {
+				parts[i] = "hello " + args.Name
+			}
+			return typedGreeting{Message: parts[0], Parts: parts}, nil
+		}))
+	})
+	return socketPath
+}
+
+// TestTypedCallRoundTrip validates struct encoding and result decoding
+func TestTypedCallRoundTrip(t *testing.T) {
+	socketPath := startTypedTestServer(t)
+
+	client, err := protocol.New(socketPath)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	greeting, err := protocol.Call[typedGreetArgs, typedGreeting](context.Background(), client, "greet", typedGreetArgs{Name: "janus", Times: 2})
+	if err != nil {
+		t.Fatalf("Typed call failed: %v", err)
+	}
+
+	if greeting.Message != "hello janus" || len(greeting.Parts) != 2 {
+		t.Errorf("Unexpected greeting: %+v", greeting)
+	}
+
+	t.Log("✅ Typed call encodes args and decodes result")
+}
+
+// TestTypedCallErrors validates that server errors surface as typed JSON-RPC errors
+func TestTypedCallErrors(t *testing.T) {
+	socketPath := startTypedTestServer(t)
+
+	client, err := protocol.New(socketPath)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+
+	// Handler-reported error
+	_, err = protocol.Call[typedGreetArgs, typedGreeting](ctx, client, "greet", typedGreetArgs{})
+	if rpcErr, ok := models.AsJSONRPCError(err); !ok || rpcErr.Code != models.ValidationFailed {
+		t.Errorf("Expected VALIDATION_FAILED, got %v", err)
+	}
+
+	// Wrapped handler error keeps its code
+	_, err = protocol.Call[typedGreetArgs, typedGreeting](ctx, client, "greet", typedGreetArgs{Name: "janus", Times: -1})
+	if rpcErr, ok := models.AsJSONRPCError(err); !ok || rpcErr.Code != models.ValidationFailed {
+		t.Errorf("Expected VALIDATION_FAILED for wrapped error, got %v", err)
+	}
+
+	// Binding error: wrong argument type
+	_, err = protocol.Call[map[string]interface{}, typedGreeting](ctx, client, "greet", map[string]interface{}{"name": 42})
+	if rpcErr, ok := models.AsJSONRPCError(err); !ok || rpcErr.Code != models.InvalidParams {
+		t.Errorf("Expected INVALID_PARAMS for type mismatch, got %v", err)
+	}
+
+	// Binding error: unknown argument
+	_, err = protocol.Call[map[string]interface{}, typedGreeting](ctx, client, "greet", map[string]interface{}{"name": "x", "extra": true})
+	if rpcErr, ok := models.AsJSONRPCError(err); !ok || rpcErr.Code != models.InvalidParams {
+		t.Errorf("Expected INVALID_PARAMS for unknown argument, got %v", err)
+	}
+
+	// Unknown method
+	_, err = protocol.Call[struct{}, typedGreeting](ctx, client, "missing", struct{}{})
+	if rpcErr, ok := models.AsJSONRPCError(err); !ok || rpcErr.Code != models.MethodNotFound {
+		t.Errorf("Expected METHOD_NOT_FOUND, got %v", err)
+	}
+
+	t.Log("✅ Typed call errors are returned as *models.JSONRPCError")
+}
+
+// TestEncodeArgs validates struct-to-args conversion edge cases
+func TestEncodeArgs(t *testing.T) {
+	args, err := protocol.EncodeArgs(struct{}{})
+	if err != nil || args != nil {
+		t.Errorf("Expected nil args for empty struct, got %v (%v)", args, err)
+	}
+
+	var nilArgs *typedGreetArgs
+	if args, err := protocol.EncodeArgs(nilArgs); err != nil || args != nil {
+		t.Errorf("Expected nil args for nil pointer, got %v (%v)", args, err)
+	}
+
+	if _, err := protocol.EncodeArgs([]string{"a"}); err == nil {
+		t.Error("Expected error for non-object arguments")
+	}
+
+	args, err = protocol.EncodeArgs(typedGreetArgs{Name: "x"})
+	if err != nil || args["name"] != "x" {
+		t.Errorf("Unexpected args: %v (%v)", args, err)
+	}
+	if _, exists := args["times"]; exists {
+		t.Error("Expected omitempty field to be omitted")
+	}
+}
@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"GoJanus/pkg/manifest"
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/protocol"
+)
+
+const validationErrorsManifest = `{
+	"version": "1.0.0",
+	"name": "Orders",
+	"description": "Collect-all validation test",
+	"requests": {
+		"place_order": {
+			"name": "place_order",
+			"description": "Place an order",
+			"args": {
+				"customer": {"name": "customer", "type": "string", "description": "Customer", "required": true},
+				"priority": {"name": "priority", "type": "integer", "description": "Priority", "minimum": 1, "maximum": 5},
+				"items": {
+					"name": "items",
+					"type": "array",
+					"description": "Order lines",
+					"items": {"name": "item", "type": "object", "description": "Line", "modelRef": "Line"}
+				}
+			}
+		}
+	},
+	"models": {
+		"Line": {
+			"name": "Line",
+			"type": "object",
+			"description": "An order line",
+			"required": ["name"],
+			"properties": {
+				"name": {"name": "name", "type": "string", "description": "Product name", "minLength": 2, "pattern": "^[a-z]+$"},
+				"quantity": {"name": "quantity", "type": "integer", "description": "Quantity", "minimum": 1}
+			}
+		}
+	}
+}`
+
+// TestValidateRequestArgsAll validates that every argument error is reported with a JSON pointer
+func TestValidateRequestArgsAll(t *testing.T) {
+	m, err := manifest.ParseJSONString(validationErrorsManifest)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	request, _ := m.GetRequest("place_order")
+
+	args := map[string]interface{}{
+		"priority": 9.0,
+		"coupon":   "FREE",
+		"items": []interface{}{
+			map[string]interface{}{"name": "apple", "quantity": 2.0},
+			map[string]interface{}{"quantity": 0.0},
+			map[string]interface{}{"name": "X", "colour": "red"},
+		},
+	}
+
+	errors := m.ValidateRequestArgsAll(request, args)
+	expected := []struct{ path, constraint string }{
+		{"/coupon", "additionalProperties"},
+		{"/customer", "required"},
+		{"/items/1/name", "required"},
+		{"/items/1/quantity", "minimum"},
+		{"/items/2/colour", "additionalProperties"},
+		{"/items/2/name", "pattern"},
+		{"/items/2/name", "minLength"},
+		{"/priority", "maximum"},
+	}
+	if len(errors) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(expected), len(errors), errors)
+	}
+	for i, want := range expected {
+		if errors[i].Path != want.path || errors[i].Constraint != want.constraint {
+			t.Errorf("Error %d: expected %s (%s), got %s (%s): %s", i, want.path, want.constraint, errors[i].Path, errors[i].Constraint, errors[i].Message)
+		}
+	}
+
+	minimum := errors[3]
+	if minimum.Field != "items[1].quantity" || minimum.Expected != "minimum 1" || minimum.Actual != 0.0 {
+		t.Errorf("Expected field, expected and actual values, got %+v", minimum)
+	}
+
+	if first := m.ValidateRequestArgs(request, args); first == nil || first.Error() != errors[0].Error() {
+		t.Errorf("Expected ValidateRequestArgs to return the first error, got %v", first)
+	}
+	if valid := m.ValidateRequestArgsAll(request, map[string]interface{}{"customer": "ann"}); valid != nil {
+		t.Errorf("Expected no errors for valid arguments, got %v", valid)
+	}
+	if err := m.ValidateRequestArgs(request, map[string]interface{}{"customer": "ann"}); err != nil {
+		t.Errorf("Expected nil error for valid arguments, got %v", err)
+	}
+
+	t.Log("✅ ValidateRequestArgsAll reports every error with path, constraint, expected and actual values")
+}
+
+// TestValidationErrorsJSONRPCError validates packaging of all errors into the error context
+func TestValidationErrorsJSONRPCError(t *testing.T) {
+	m, _ := manifest.ParseJSONString(validationErrorsManifest)
+	request, _ := m.GetRequest("place_order")
+	errors := m.ValidateRequestArgsAll(request, map[string]interface{}{"priority": "high"})
+
+	rpcErr := errors.JSONRPCError()
+	if rpcErr.Code != models.InvalidParams || rpcErr.Data == nil {
+		t.Fatalf("Expected InvalidParams with data, got %+v", rpcErr)
+	}
+
+	// Clients see the list after a JSON round trip
+	data, err := json.Marshal(rpcErr)
+	if err != nil {
+		t.Fatalf("Failed to marshal error: %v", err)
+	}
+	var decoded models.JSONRPCError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal error: %v", err)
+	}
+	list, _ := decoded.Data.Context["errors"].([]interface{})
+	if len(list) != 2 {
+		t.Fatalf("Expected two errors in context, got %v", decoded.Data.Context)
+	}
+	entry, _ := list[1].(map[string]interface{})
+	if entry["path"] != "/priority" || entry["constraint"] != "type" || entry["expected"] != "integer" || entry["actual"] != "high" {
+		t.Errorf("Unexpected error entry: %v", entry)
+	}
+
+	// ValidationErrorsFrom reads both the local and the decoded form
+	if local := manifest.ValidationErrorsFrom(rpcErr); len(local) != 2 || local[1] != errors[1] {
+		t.Errorf("Expected the local error list, got %v", local)
+	}
+	fromWire := manifest.ValidationErrorsFrom(&decoded)
+	if len(fromWire) != 2 || fromWire[1].Path != "/priority" || fromWire[1].Constraint != "type" || fromWire[1].Expected != "integer" || fromWire[1].Actual != "high" {
+		t.Errorf("Unexpected decoded error list: %v", fromWire)
+	}
+	if manifest.ValidationErrorsFrom(models.NewJSONRPCError(models.InvalidParams, "no list")) != nil {
+		t.Error("Expected nil for an error without a validation list")
+	}
+
+	t.Log("✅ Validation errors are packaged into JSONRPCErrorData.Context")
+}
+
+// TestClientReportsAllValidationErrors validates that client-side validation returns every error
+func TestClientReportsAllValidationErrors(t *testing.T) {
+	_, socketPath, _ := startCachingTestServer(t, versionedTestManifest("1.0.0"))
+
+	client, err := protocol.New(socketPath, protocol.DefaultJanusClientConfig())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.SendRequest(context.Background(), "greet", map[string]interface{}{"name": 5, "loud": true})
+	rpcErr, ok := models.AsJSONRPCError(err)
+	if !ok || rpcErr.Code != models.InvalidParams {
+		t.Fatalf("Expected InvalidParams error, got %v", err)
+	}
+	errors := manifest.ValidationErrorsFrom(rpcErr)
+	if len(errors) != 2 || errors[0].Path != "/loud" || errors[1].Path != "/name" {
+		t.Errorf("Expected errors for /loud and /name, got %v", rpcErr.Data.Context["errors"])
+	}
+
+	t.Log("✅ Client validation reports all argument errors at once")
+}
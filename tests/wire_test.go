@@ -0,0 +1,199 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"GoJanus/pkg/models"
+	"GoJanus/pkg/wire"
+)
+
+// wireFixture is an entry of fixtures/wire/index.json
+type wireFixture struct {
+	File        string `json:"file"`
+	Kind        string `json:"kind"`
+	Valid       bool   `json:"valid"`
+	Description string `json:"description"`
+	Code        int    `json:"code"`
+	Field       string `json:"field"`
+}
+
+// TestWireFixtures validates the strict codec against the shared golden fixtures
+func TestWireFixtures(t *testing.T) {
+	dir := filepath.Join("fixtures", "wire")
+	indexData, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture index: %v", err)
+	}
+	var index struct {
+		Fixtures []wireFixture `json:"fixtures"`
+	}
+	if err := json.Unmarshal(indexData, &index); err != nil {
+		t.Fatalf("Failed to parse fixture index: %v", err)
+	}
+	if len(index.Fixtures) == 0 {
+		t.Fatal("Expected fixtures in the index")
+	}
+
+	for _, fixture := range index.Fixtures {
+		data, err := os.ReadFile(filepath.Join(dir, fixture.File))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", fixture.File, err)
+		}
+
+		var decoded interface{}
+		var encode func() ([]byte, error)
+		switch fixture.Kind {
+		case "request":
+			request, decodeErr := wire.DecodeRequest(data)
+			decoded, err = request, decodeErr
+			encode = func() ([]byte, error) { return wire.EncodeRequest(request) }
+		case "response":
+			response, decodeErr := wire.DecodeResponse(data)
+			decoded, err = response, decodeErr
+			encode = func() ([]byte, error) { return wire.EncodeResponse(response) }
+		default:
+			t.Fatalf("%s: unknown kind %q", fixture.File, fixture.Kind)
+		}
+
+		if !fixture.Valid {
+			rpcErr, ok := err.(*models.JSONRPCError)
+			if !ok {
+				t.Errorf("%s (%s): expected a JSON-RPC error, got %v", fixture.File, fixture.Description, err)
+				continue
+			}
+			if int(rpcErr.Code) != fixture.Code {
+				t.Errorf("%s (%s): expected code %d, got %d: %v", fixture.File, fixture.Description, fixture.Code, rpcErr.Code, rpcErr)
+			}
+			if fixture.Field != "" && !strings.Contains(rpcErr.Error(), fixture.Field) {
+				t.Errorf("%s (%s): expected the error to name %q, got %v", fixture.File, fixture.Description, fixture.Field, rpcErr)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s (%s): unexpected error: %v", fixture.File, fixture.Description, err)
+			continue
+		}
+		encoded, err := encode()
+		if err != nil {
+			t.Errorf("%s: failed to encode %+v: %v", fixture.File, decoded, err)
+			continue
+		}
+		if original, roundTrip := wireFields(t, data), wireFields(t, encoded); !reflect.DeepEqual(original, roundTrip) {
+			t.Errorf("%s: round trip changed the message:\n%v\n%v", fixture.File, original, roundTrip)
+		}
+	}
+
+	t.Logf("✅ %d wire fixtures decode as expected", len(index.Fixtures))
+}
+
+// TestWireFixturesCBOR validates that the CBOR form of each fixture passes or fails the same checks
+func TestWireFixturesCBOR(t *testing.T) {
+	dir := filepath.Join("fixtures", "wire")
+	indexData, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture index: %v", err)
+	}
+	var index struct {
+		Fixtures []wireFixture `json:"fixtures"`
+	}
+	if err := json.Unmarshal(indexData, &index); err != nil {
+		t.Fatalf("Failed to parse fixture index: %v", err)
+	}
+
+	checked := 0
+	for _, fixture := range index.Fixtures {
+		data, err := os.ReadFile(filepath.Join(dir, fixture.File))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", fixture.File, err)
+		}
+		var object map[string]interface{}
+		if json.Unmarshal(data, &object) != nil || object == nil {
+			continue // Not representable as a CBOR map
+		}
+		encoded, err := wire.MarshalCBOR(object)
+		if err != nil {
+			t.Fatalf("%s: failed to encode as CBOR: %v", fixture.File, err)
+		}
+
+		if fixture.Kind == "request" {
+			_, err = wire.DecodeRequest(encoded)
+		} else {
+			_, err = wire.DecodeResponse(encoded)
+		}
+		checked++
+		if fixture.Valid {
+			if err != nil {
+				t.Errorf("%s (%s): unexpected CBOR error: %v", fixture.File, fixture.Description, err)
+			}
+			continue
+		}
+		rpcErr, ok := err.(*models.JSONRPCError)
+		if !ok || int(rpcErr.Code) != fixture.Code || (fixture.Field != "" && !strings.Contains(rpcErr.Error(), fixture.Field)) {
+			t.Errorf("%s (%s): expected code %d naming %q in CBOR, got %v", fixture.File, fixture.Description, fixture.Code, fixture.Field, err)
+		}
+	}
+
+	t.Logf("✅ %d wire fixtures decode the same way in CBOR", checked)
+}
+
+// wireFields decodes a message for comparison, dropping null fields since null and absent are equivalent
+func wireFields(t *testing.T, data []byte) map[string]interface{} {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Failed to decode %s: %v", data, err)
+	}
+	for name, value := range fields {
+		if value == nil {
+			delete(fields, name)
+		}
+	}
+	return fields
+}
+
+// TestWireEncoding validates that messages from the constructors encode and invalid ones are refused
+func TestWireEncoding(t *testing.T) {
+	timeout := 2.5
+	request := models.NewJanusRequest("echo", map[string]interface{}{"message": "hi"}, &timeout)
+	data, err := wire.EncodeRequest(request)
+	if err != nil {
+		t.Fatalf("Failed to encode request: %v", err)
+	}
+	decoded, err := wire.DecodeRequest(data)
+	if err != nil {
+		t.Fatalf("Failed to decode request: %v", err)
+	}
+	if decoded.ID != request.ID || decoded.Timestamp != request.Timestamp || *decoded.Timeout != timeout {
+		t.Errorf("Round trip changed the request: %+v", decoded)
+	}
+
+	for _, response := range []*models.JanusResponse{
+		models.NewSuccessResponse(request.ID, map[string]interface{}{"message": "hi"}),
+		models.NewErrorResponse(request.ID, models.NewJSONRPCError(models.MethodNotFound, "Unknown request: echo")),
+	} {
+		data, err := wire.EncodeResponse(response)
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		if _, err := wire.DecodeResponse(data); err != nil {
+			t.Errorf("Failed to decode %s: %v", data, err)
+		}
+	}
+
+	request.Method = "ping"
+	if _, err := wire.EncodeRequest(request); err == nil {
+		t.Error("Expected a request whose method differs from its name to be refused")
+	}
+	contradictory := models.NewSuccessResponse(request.ID, nil)
+	contradictory.Error = models.NewJSONRPCError(models.InternalError, "")
+	if _, err := wire.EncodeResponse(contradictory); err == nil {
+		t.Error("Expected a success response with an error to be refused")
+	}
+
+	t.Log("✅ Wire encoding accepts constructed messages and refuses contradictions")
+}